@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+	"what-to-wear/server/api"
+)
+
+// AccountExportDTO 账户数据导出任务DTO
+type AccountExportDTO struct {
+	ID            uint            `json:"id"`
+	Status        api.JobStatus   `json:"status"`
+	Stage         api.ExportStage `json:"stage"`
+	Progress      int             `json:"progress"`       // 进度百分比 0-100
+	DeleteAccount bool            `json:"delete_account"` // 导出完成后删除账户
+	FileName      string          `json:"file_name,omitempty"`
+	FileSize      int64           `json:"file_size,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	DownloadURL   string          `json:"download_url,omitempty"` // 无需登录的下载链接，过期后失效
+	CreatedAt     time.Time       `json:"created_at"`
+	CompletedAt   *time.Time      `json:"completed_at,omitempty"`
+	ExpiresAt     *time.Time      `json:"expires_at,omitempty"`
+}
+
+// AccountExportManifest 导出压缩包中的清单（manifest.json）
+type AccountExportManifest struct {
+	Version     int                               `json:"version"`
+	UserID      uint                              `json:"user_id"`
+	Username    string                            `json:"username"`
+	GeneratedAt time.Time                         `json:"generated_at"`
+	Files       []AccountExportManifestFile       `json:"files"`
+	Attachments []AccountExportManifestAttachment `json:"attachments"`
+}
+
+// AccountExportManifestFile 压缩包中的数据文件
+type AccountExportManifestFile struct {
+	Path    string `json:"path"`
+	Records int    `json:"records"`
+	Size    int64  `json:"size"`
+	SHA256  string `json:"sha256"`
+}
+
+// AccountExportManifestAttachment 压缩包中的附件文件，读取失败的附件 Included 为 false 并记录原因
+type AccountExportManifestAttachment struct {
+	ID           uint           `json:"id"`
+	EntityType   api.EntityType `json:"entity_type"`
+	EntityID     uint           `json:"entity_id"`
+	OriginalName string         `json:"original_name"`
+	MimeType     string         `json:"mime_type"`
+	Path         string         `json:"path,omitempty"`
+	Size         int64          `json:"size"`
+	SHA256       string         `json:"sha256,omitempty"`
+	Included     bool           `json:"included"`
+	Error        string         `json:"error,omitempty"`
+}
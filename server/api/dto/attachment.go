@@ -11,7 +11,7 @@ type UploadAttachmentDTO struct {
 	File        *multipart.FileHeader `form:"file" binding:"required"`
 	EntityType  api.EntityType        `form:"entity_type" binding:"required"`
 	EntityID    uint                  `form:"entity_id" binding:"required"`
-	UserID      uint                  `form:"user_id" binding:"required"`
+	UserID      uint                  `form:"-"`
 	Description string                `form:"description"`
 	Tags        []string              `form:"tags"`
 	IsPublic    bool                  `form:"is_public"`
@@ -23,12 +23,41 @@ type BatchUploadDTO struct {
 	Files       []*multipart.FileHeader `form:"files" binding:"required"`
 	EntityType  api.EntityType          `form:"entity_type" binding:"required"`
 	EntityID    uint                    `form:"entity_id" binding:"required"`
-	UserID      uint                    `form:"user_id" binding:"required"`
+	UserID      uint                    `form:"-"`
 	Description string                  `form:"description"`
 	Tags        []string                `form:"tags"`
 	IsPublic    bool                    `form:"is_public"`
 }
 
+// CreateUploadSlotDTO 申请直传上传凭证DTO
+type CreateUploadSlotDTO struct {
+	EntityType  api.EntityType `json:"entity_type" binding:"required"`
+	EntityID    uint           `json:"entity_id" binding:"required"`
+	FileName    string         `json:"file_name" binding:"required"`
+	MimeType    string         `json:"mime_type" binding:"required"`
+	FileSize    int64          `json:"file_size" binding:"required,gt=0"` // 文件大小(字节)，上传的文件不能超过
+	Description string         `json:"description"`
+	Tags        []string       `json:"tags"`
+	IsPublic    bool           `json:"is_public"`
+	SortOrder   int            `json:"sort_order"`
+}
+
+// UploadSlotDTO 直传上传凭证DTO，客户端按 Method 和 Headers 将文件上传到 UploadURL 后确认上传
+type UploadSlotDTO struct {
+	ID           uint              `json:"id"`
+	Status       api.UploadStatus  `json:"status"`
+	EntityType   api.EntityType    `json:"entity_type"`
+	EntityID     uint              `json:"entity_id"`
+	ObjectKey    string            `json:"object_key"`
+	MimeType     string            `json:"mime_type"`
+	FileSize     int64             `json:"file_size"`
+	UploadURL    string            `json:"upload_url,omitempty"`
+	Method       string            `json:"method,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty"` // 上传时必须携带的请求头
+	AttachmentID *uint             `json:"attachment_id,omitempty"`
+	ExpiresAt    time.Time         `json:"expires_at"`
+}
+
 // UpdateAttachmentDTO 更新附件DTO
 type UpdateAttachmentDTO struct {
 	Description *string  `json:"description"`
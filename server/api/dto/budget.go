@@ -0,0 +1,78 @@
+package dto
+
+import "time"
+
+// CreateBudgetDTO 创建预算DTO
+type CreateBudgetDTO struct {
+	Name       string  `json:"name" binding:"required,max=50"`
+	Period     string  `json:"period" binding:"required"` // monthly, seasonal
+	CategoryID *uint   `json:"category_id"`               // 为空时为总预算
+	Amount     float64 `json:"amount" binding:"required,gt=0"`
+	Currency   string  `json:"currency"` // 默认为用户本位币
+}
+
+// UpdateBudgetDTO 更新预算DTO
+type UpdateBudgetDTO struct {
+	Name       *string  `json:"name,omitempty" binding:"omitempty,max=50"`
+	Period     *string  `json:"period,omitempty"`
+	CategoryID *uint    `json:"category_id,omitempty"` // 0 表示改为总预算
+	Amount     *float64 `json:"amount,omitempty" binding:"omitempty,gt=0"`
+	Currency   *string  `json:"currency,omitempty"`
+}
+
+// BudgetDTO 预算DTO
+type BudgetDTO struct {
+	ID           uint      `json:"id"`
+	Name         string    `json:"name"`
+	Period       string    `json:"period"`
+	CategoryID   *uint     `json:"category_id"`
+	CategoryName string    `json:"category_name"`
+	Amount       float64   `json:"amount"`
+	Currency     string    `json:"currency"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// BudgetStatusRequestDTO 预算执行情况查询参数
+type BudgetStatusRequestDTO struct {
+	Date *time.Time `form:"date" time_format:"2006-01-02"` // 统计该日期所在的周期，默认今天
+}
+
+// BudgetStatusDTO 预算执行情况，金额均为本位币
+type BudgetStatusDTO struct {
+	BudgetID     uint      `json:"budget_id"`
+	Name         string    `json:"name"`
+	Period       string    `json:"period"`
+	CategoryID   *uint     `json:"category_id"`
+	CategoryName string    `json:"category_name"`
+	PeriodLabel  string    `json:"period_label"`
+	PeriodStart  time.Time `json:"period_start"`
+	PeriodEnd    time.Time `json:"period_end"`
+	Currency     string    `json:"currency"`
+	Amount       float64   `json:"amount"`
+	Spent        float64   `json:"spent"`
+	Remaining    float64   `json:"remaining"`  // 超支时为负数
+	Percentage   float64   `json:"percentage"` // 已用百分比
+	IsExceeded   bool      `json:"is_exceeded"`
+}
+
+// BudgetAlertListDTO 预算提醒查询参数
+type BudgetAlertListDTO struct {
+	Unread bool `form:"unread"` // 只返回未读提醒
+	Limit  int  `form:"limit"`
+}
+
+// BudgetAlertDTO 超出预算提醒DTO
+type BudgetAlertDTO struct {
+	ID               uint      `json:"id"`
+	BudgetID         uint      `json:"budget_id"`
+	BudgetName       string    `json:"budget_name"`
+	PurchaseRecordID uint      `json:"purchase_record_id"`
+	PeriodLabel      string    `json:"period_label"`
+	Currency         string    `json:"currency"`
+	BudgetAmount     float64   `json:"budget_amount"`
+	Spent            float64   `json:"spent"`
+	Exceeded         float64   `json:"exceeded"` // 超出金额
+	IsRead           bool      `json:"is_read"`
+	CreatedAt        time.Time `json:"created_at"`
+}
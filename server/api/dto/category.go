@@ -12,6 +12,7 @@ type CategoryDTO struct {
 	Icon        string    `json:"icon,omitempty"`
 	SortOrder   int       `json:"sort_order"`
 	IsActive    bool      `json:"is_active"`
+	IsCustom    bool      `json:"is_custom"` // 用户自定义分类
 	ItemCount   int64     `json:"item_count,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
@@ -42,6 +43,17 @@ type UpdateCategoryDTO struct {
 	IsActive    *bool   `json:"is_active,omitempty"`
 }
 
+// DeleteCategoryDTO 删除分类参数
+type DeleteCategoryDTO struct {
+	ReassignTo *uint `form:"reassign_to"` // 分类下仍有衣物时必填，衣物将转移到该分类
+}
+
+// ReorderCategoriesDTO 分类排序请求，按数组顺序重新设置同级分类的排序值
+type ReorderCategoriesDTO struct {
+	ParentID    *uint  `json:"parent_id"` // 为空时对根分类排序
+	CategoryIDs []uint `json:"category_ids" binding:"required,min=1"`
+}
+
 // CategoryListRequest 分类列表请求
 type CategoryListRequest struct {
 	ParentID   *uint  `form:"parent_id"`
@@ -75,14 +75,17 @@ type ClothingItemListDTO struct {
 	Status      *api.ClothingStatus `form:"status"`
 	Brand       string              `form:"brand"`
 	Color       string              `form:"color"`
-	Season      string              `form:"season"`
-	Occasion    string              `form:"occasion"`
+	Season      []string            `form:"season"`    // 季节标签名称，可传多个
+	Occasion    []string            `form:"occasion"`  // 场合标签名称，可传多个
+	TagMatch    string              `form:"tag_match"` // 标签、季节、场合多值时的匹配方式：any（默认，满足其一）或 all（全部满足）
 	Material    string              `form:"material"`
 	Condition   string              `form:"condition"`
 	MinPrice    *float32            `form:"min_price"`
 	MaxPrice    *float32            `form:"max_price"`
 	IsFavorite  *bool               `form:"is_favorite"`
 	Search      string              `form:"search"`
+	DateFrom    *time.Time          `form:"date_from" time_format:"2006-01-02"` // 按创建时间筛选
+	DateTo      *time.Time          `form:"date_to" time_format:"2006-01-02"`   // 包含结束日期当天
 	SearchRequest
 }
 
@@ -95,9 +98,57 @@ type ClothingItemListResponseDTO struct {
 	TotalPages int               `json:"total_pages"`
 }
 
+// ClothingSearchDTO 衣物高级搜索参数
+type ClothingSearchDTO struct {
+	Query         string               `form:"q"` // 全文搜索名称、品牌、描述和备注
+	CategoryIDs   []uint               `form:"category_ids"`
+	Brands        []string             `form:"brands"`
+	Colors        []string             `form:"colors"`
+	TagIDs        []uint               `form:"tag_ids"`
+	Conditions    []api.ClothingStatus `form:"conditions"`
+	MinDurability *float64             `form:"min_durability"`
+	MaxDurability *float64             `form:"max_durability"`
+	WornAfter     *time.Time           `form:"worn_after" time_format:"2006-01-02"`  // 最近穿着日期不早于
+	WornBefore    *time.Time           `form:"worn_before" time_format:"2006-01-02"` // 最近穿着日期早于，包含从未穿过的衣物
+	NeverWorn     *bool                `form:"never_worn"`
+	Attributes    map[string]string    `form:"-"`          // 特定属性精确匹配：attrs[sleeve]=长袖
+	AttributeMin  map[string]float64   `form:"-"`          // 特定属性数值下限：attr_min[heel_height]=3
+	AttributeMax  map[string]float64   `form:"-"`          // 特定属性数值上限：attr_max[heel_height]=8
+	SortBy        string               `form:"sort_by"`    // relevance（有搜索词时默认）, created_at, updated_at, name, brand, price, wear_count, durability_score, last_worn_date
+	SortOrder     string               `form:"sort_order"` // asc, desc，默认 desc
+	PaginationRequest
+}
+
+// ClothingSearchResultDTO 衣物搜索结果
+type ClothingSearchResultDTO struct {
+	Items      []ClothingItemSummary `json:"items"`
+	TotalCount int64                 `json:"total_count"`
+	Page       int                   `json:"page"`
+	PageSize   int                   `json:"page_size"`
+	TotalPages int                   `json:"total_pages"`
+	Facets     SearchFacetsDTO       `json:"facets"`
+}
+
+// SearchFacetsDTO 搜索结果的分面统计，基于全部匹配的衣物
+type SearchFacetsDTO struct {
+	Categories []FacetCount `json:"categories"`
+	Brands     []FacetCount `json:"brands"`
+	Colors     []FacetCount `json:"colors"`
+	Tags       []FacetCount `json:"tags"`
+}
+
+// FacetCount 分面取值及数量
+type FacetCount struct {
+	ID    uint   `json:"id,omitempty"` // 分类和标签的ID
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"` // 标签类型
+	Count int64  `json:"count"`
+}
+
 // CreatePurchaseRecordDTO 创建购买记录DTO - 简化版
 type CreatePurchaseRecordDTO struct {
 	Price        float64   `json:"price" binding:"required"`         // 实际购买价格
+	Currency     string    `json:"currency"`                         // 货币代码，默认为用户本位币
 	Store        string    `json:"store"`                            // 商店名称（线上或线下）
 	PurchaseDate time.Time `json:"purchase_date" binding:"required"` // 购买日期
 	Notes        string    `json:"notes"`                            // 备注信息（可包含折扣、原价等信息）
@@ -106,6 +157,7 @@ type CreatePurchaseRecordDTO struct {
 // UpdatePurchaseRecordDTO 更新购买记录DTO - 简化版
 type UpdatePurchaseRecordDTO struct {
 	Price        *float64   `json:"price,omitempty"`         // 实际购买价格
+	Currency     *string    `json:"currency,omitempty"`      // 货币代码
 	Store        *string    `json:"store,omitempty"`         // 商店名称
 	PurchaseDate *time.Time `json:"purchase_date,omitempty"` // 购买日期
 	Notes        *string    `json:"notes,omitempty"`         // 备注信息
@@ -116,6 +168,8 @@ type PurchaseRecordDTO struct {
 	ID             uint      `json:"id"`
 	ClothingItemID uint      `json:"clothing_item_id"`
 	Price          float64   `json:"price"`         // 实际购买价格
+	Currency       string    `json:"currency"`      // 购买货币
+	HomePrice      float64   `json:"home_price"`    // 按购买日汇率折算的本位币价格
 	Store          string    `json:"store"`         // 商店名称
 	PurchaseDate   time.Time `json:"purchase_date"` // 购买日期
 	Notes          string    `json:"notes"`         // 备注信息
@@ -123,10 +177,19 @@ type PurchaseRecordDTO struct {
 	UpdatedAt      time.Time `json:"updated_at"`
 }
 
+// PurchaseRecordListDTO 购买记录查询参数，按商店或日期范围筛选
+type PurchaseRecordListDTO struct {
+	StartDate *time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate   *time.Time `form:"end_date" time_format:"2006-01-02"`
+	Store     string     `form:"store"`
+	Limit     int        `form:"limit"`
+}
+
 // CreateMaintenanceRecordDTO 创建保养记录DTO
 type CreateMaintenanceRecordDTO struct {
 	MaintenanceType     string     `json:"maintenance_type" binding:"required"`
 	Cost                float64    `json:"cost"`
+	Currency            string     `json:"currency"` // 默认为用户本位币
 	MaintenanceDate     time.Time  `json:"maintenance_date" binding:"required"`
 	ServiceProvider     string     `json:"service_provider"`
 	Description         string     `json:"description"`
@@ -138,6 +201,7 @@ type CreateMaintenanceRecordDTO struct {
 type UpdateMaintenanceRecordDTO struct {
 	MaintenanceType     *string    `json:"maintenance_type,omitempty"`
 	Cost                *float64   `json:"cost,omitempty"`
+	Currency            *string    `json:"currency,omitempty"`
 	MaintenanceDate     *time.Time `json:"maintenance_date,omitempty"`
 	ServiceProvider     *string    `json:"service_provider,omitempty"`
 	Description         *string    `json:"description,omitempty"`
@@ -151,6 +215,8 @@ type MaintenanceRecordDTO struct {
 	ClothingItemID      uint       `json:"clothing_item_id"`
 	MaintenanceType     string     `json:"maintenance_type"`
 	Cost                float64    `json:"cost"`
+	Currency            string     `json:"currency"`
+	HomeCost            float64    `json:"home_cost"` // 按保养日汇率折算的本位币费用
 	MaintenanceDate     time.Time  `json:"maintenance_date"`
 	ServiceProvider     string     `json:"service_provider"`
 	Description         string     `json:"description"`
@@ -160,26 +226,49 @@ type MaintenanceRecordDTO struct {
 	UpdatedAt           time.Time  `json:"updated_at"`
 }
 
-// CreateWearRecordDTO 创建穿着记录DTO - 简化版
+// WearFeedbackDTO 穿着反馈：评分、时长、天气和场合
+type WearFeedbackDTO struct {
+	ComfortRating         *int             `json:"comfort_rating,omitempty" binding:"omitempty,min=1,max=5"`
+	StyleRating           *int             `json:"style_rating,omitempty" binding:"omitempty,min=1,max=5"`
+	AppropriatenessRating *int             `json:"appropriateness_rating,omitempty" binding:"omitempty,min=1,max=5"`
+	DurationHours         *float64         `json:"duration_hours,omitempty" binding:"omitempty,gt=0,max=24"`
+	Temperature           *float64         `json:"temperature,omitempty"`
+	WeatherCondition      *api.WeatherType `json:"weather_condition,omitempty"`
+	Occasion              string           `json:"occasion" binding:"max=50"`
+	Location              string           `json:"location" binding:"max=100"`
+}
+
+// CreateWearRecordDTO 创建穿着记录DTO
 type CreateWearRecordDTO struct {
 	WearDate time.Time `json:"wear_date" binding:"required"`
 	Notes    string    `json:"notes"`
+	WearFeedbackDTO
 }
 
-// UpdateWearRecordDTO 更新穿着记录DTO - 简化版
+// UpdateWearRecordDTO 更新穿着记录DTO，为空的字段保持不变
 type UpdateWearRecordDTO struct {
-	WearDate *time.Time `json:"wear_date,omitempty"`
-	Notes    *string    `json:"notes,omitempty"`
+	WearDate              *time.Time       `json:"wear_date,omitempty"`
+	Notes                 *string          `json:"notes,omitempty"`
+	ComfortRating         *int             `json:"comfort_rating,omitempty" binding:"omitempty,min=1,max=5"`
+	StyleRating           *int             `json:"style_rating,omitempty" binding:"omitempty,min=1,max=5"`
+	AppropriatenessRating *int             `json:"appropriateness_rating,omitempty" binding:"omitempty,min=1,max=5"`
+	DurationHours         *float64         `json:"duration_hours,omitempty" binding:"omitempty,gt=0,max=24"`
+	Temperature           *float64         `json:"temperature,omitempty"`
+	WeatherCondition      *api.WeatherType `json:"weather_condition,omitempty"`
+	Occasion              *string          `json:"occasion,omitempty" binding:"omitempty,max=50"`
+	Location              *string          `json:"location,omitempty" binding:"omitempty,max=100"`
 }
 
-// WearRecordDTO 穿着记录DTO - 简化版
+// WearRecordDTO 穿着记录DTO
 type WearRecordDTO struct {
 	ID             uint      `json:"id"`
 	ClothingItemID uint      `json:"clothing_item_id"`
+	OutfitID       *uint     `json:"outfit_id,omitempty"`
 	WearDate       time.Time `json:"wear_date"`
 	Notes          string    `json:"notes"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	WearFeedbackDTO
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // CreateTagDTO 创建标签DTO
@@ -206,9 +295,57 @@ type TagDTO struct {
 	Description string `json:"description"`
 }
 
+// MergeTagsDTO 合并标签DTO
+type MergeTagsDTO struct {
+	SourceIDs []uint `json:"source_ids" binding:"required,min=1"`
+	TargetID  uint   `json:"target_id" binding:"required"`
+}
+
+// BatchItemsDTO 批量操作衣物，AllOrNothing 为 true 时任一衣物失败则全部不生效
+type BatchItemsDTO struct {
+	ItemIDs      []uint `json:"item_ids" binding:"required,min=1,max=200"`
+	AllOrNothing bool   `json:"all_or_nothing"`
+}
+
+// BatchTagsDTO 批量添加或移除标签DTO
+type BatchTagsDTO struct {
+	BatchItemsDTO
+	TagIDs []uint `json:"tag_ids" binding:"required,min=1"`
+}
+
+// BatchCategoryDTO 批量移动分类DTO
+type BatchCategoryDTO struct {
+	BatchItemsDTO
+	CategoryID uint `json:"category_id" binding:"required"`
+}
+
+// BatchConditionDTO 批量修改衣物状态DTO，例如标记为已捐赠或已出售
+type BatchConditionDTO struct {
+	BatchItemsDTO
+	Condition api.ClothingStatus `json:"condition" binding:"required"`
+}
+
+// TagMergeResultDTO 合并标签结果
+type TagMergeResultDTO struct {
+	Target        TagDTO `json:"target"`
+	MergedTagIDs  []uint `json:"merged_tag_ids"`
+	AffectedItems int64  `json:"affected_items"` // 原先使用来源标签的衣物数量
+}
+
+// TagDeleteResultDTO 删除标签结果，Deleted 为 false 时表示标签仍被使用，需确认后删除
+type TagDeleteResultDTO struct {
+	TagID         uint   `json:"tag_id"`
+	Name          string `json:"name"`
+	AffectedItems int64  `json:"affected_items"`
+	Deleted       bool   `json:"deleted"`
+}
+
 // TagStatsItem 标签统计项
 type TagStatsItem struct {
+	TagID      uint    `json:"tag_id"`
 	TagName    string  `json:"tag_name"`
+	Type       string  `json:"type"`
+	IsSystem   bool    `json:"is_system"`
 	Count      int64   `json:"count"`
 	Percentage float64 `json:"percentage"`
 }
@@ -222,6 +359,7 @@ type ClothingStatsDTO struct {
 	ByOccasion    map[string]int64             `json:"by_occasion"`
 	ByBrand       map[string]int64             `json:"by_brand"`
 	ByColor       map[string]int64             `json:"by_color"`
+	Currency      string                       `json:"currency"` // 金额使用的本位币
 	TotalValue    float64                      `json:"total_value"`
 	AveragePrice  float64                      `json:"average_price"`
 	MostWornItems []ClothingItemSummary        `json:"most_worn_items"`
@@ -237,6 +375,29 @@ type MaintenanceStatsDTO struct {
 	LastMaintenance  *time.Time         `json:"last_maintenance"`
 }
 
+// DurabilityPointDTO 耐久度时间线节点DTO
+type DurabilityPointDTO struct {
+	Date        time.Time `json:"date"`
+	EventType   string    `json:"event_type"` // created, wear, maintenance, now
+	RecordID    uint      `json:"record_id,omitempty"`
+	Description string    `json:"description"`
+	TimeDamage  float64   `json:"time_damage"` // 距上一节点的自然磨损
+	Change      float64   `json:"change"`      // 本次事件的影响
+	Score       float64   `json:"score"`       // 事件后的耐久度
+}
+
+// DurabilityTimelineDTO 衣物耐久度时间线DTO
+type DurabilityTimelineDTO struct {
+	ClothingItemID   uint                 `json:"clothing_item_id"`
+	ClothingItemName string               `json:"clothing_item_name"`
+	CurrentScore     float64              `json:"current_score"`
+	WearDamageRate   float64              `json:"wear_damage_rate"` // 分类穿着磨损率
+	MaterialFactor   float64              `json:"material_factor"`  // 材质耐久系数
+	WearCount        int                  `json:"wear_count"`
+	MaintenanceCount int                  `json:"maintenance_count"`
+	Timeline         []DurabilityPointDTO `json:"timeline"`
+}
+
 // WearStatsDTO 穿着统计DTO
 type WearStatsDTO struct {
 	TotalWears      int64                     `json:"total_wears"`
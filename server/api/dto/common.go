@@ -1,11 +1,14 @@
 package dto
 
-import "time"
+import (
+	"mime/multipart"
+	"time"
+)
 
 // PaginationRequest 分页请求
 type PaginationRequest struct {
-	Page     int `form:"page" binding:"min=1" json:"page"`
-	PageSize int `form:"page_size" binding:"min=1,max=100" json:"page_size"`
+	Page     int `form:"page" binding:"omitempty,min=1" json:"page"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100" json:"page_size"`
 }
 
 // SearchRequest 通用搜索请求
@@ -26,26 +29,36 @@ type BulkOperationRequest struct {
 
 // BulkOperationResponse 批量操作响应
 type BulkOperationResponse struct {
-	SuccessCount int      `json:"success_count"`
-	FailureCount int      `json:"failure_count"`
-	Errors       []string `json:"errors,omitempty"`
-	Results      []uint   `json:"results,omitempty"`
+	SuccessCount int              `json:"success_count"`
+	FailureCount int              `json:"failure_count"`
+	Errors       []string         `json:"errors,omitempty"`
+	Results      []uint           `json:"results,omitempty"` // 成功的ID
+	Items        []BulkItemResult `json:"items,omitempty"`   // 逐个ID的处理结果
+	RolledBack   bool             `json:"rolled_back"`       // 全有或全无模式下存在失败，未应用任何变更
+}
+
+// BulkItemResult 批量操作中单个ID的处理结果
+type BulkItemResult struct {
+	ID      uint   `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
 }
 
 // ExportRequest 导出请求
 type ExportRequest struct {
-	Type     string            `json:"type" binding:"required"` // csv, excel, json, pdf
-	Format   string            `json:"format"`                  // detailed, summary
-	Filters  map[string]string `json:"filters,omitempty"`
-	DateFrom *time.Time        `json:"date_from,omitempty"`
-	DateTo   *time.Time        `json:"date_to,omitempty"`
+	Type     string            `json:"type" form:"type" binding:"required"` // csv, excel, json
+	Format   string            `json:"format" form:"format"`                // detailed, summary
+	Filters  map[string]string `json:"filters,omitempty" form:"-"`
+	DateFrom *time.Time        `json:"date_from,omitempty" form:"date_from" time_format:"2006-01-02"`
+	DateTo   *time.Time        `json:"date_to,omitempty" form:"date_to" time_format:"2006-01-02"`
 }
 
 // ImportRequest 导入请求
 type ImportRequest struct {
-	Type        string            `json:"type" binding:"required"` // csv, excel, json
-	FileURL     string            `json:"file_url"`
-	Options     map[string]string `json:"options,omitempty"`
-	DryRun      bool              `json:"dry_run"`
-	SkipErrors  bool              `json:"skip_errors"`
-}
\ No newline at end of file
+	Type       string                `json:"type" form:"type" binding:"required"` // csv, excel, json
+	File       *multipart.FileHeader `json:"-" form:"file"`                       // 上传的文件，与 FileURL 二选一
+	FileURL    string                `json:"file_url" form:"file_url"`            // 文件的下载地址，与 File 二选一
+	Options    map[string]string     `json:"options,omitempty" form:"-"`
+	DryRun     bool                  `json:"dry_run" form:"dry_run"`
+	SkipErrors bool                  `json:"skip_errors" form:"skip_errors"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// CurrencyInfoDTO 货币信息
+type CurrencyInfoDTO struct {
+	HomeCurrency string   `json:"home_currency"` // 用户本位币，统计金额均以该货币表示
+	BaseCurrency string   `json:"base_currency"` // 汇率表的基准货币
+	Currencies   []string `json:"currencies"`    // 支持的货币
+}
+
+// UpdateHomeCurrencyDTO 修改本位币DTO
+type UpdateHomeCurrencyDTO struct {
+	Currency string `json:"currency" binding:"required"`
+}
+
+// ExchangeRatesRequestDTO 汇率查询参数
+type ExchangeRatesRequestDTO struct {
+	Currency string     `form:"currency"`                      // 目标货币，默认为用户本位币
+	Date     *time.Time `form:"date" time_format:"2006-01-02"` // 默认今天
+}
+
+// ExchangeRatesDTO 汇率查询结果
+type ExchangeRatesDTO struct {
+	Currency string             `json:"currency"` // 目标货币
+	Date     time.Time          `json:"date"`
+	Rates    map[string]float64 `json:"rates"` // 1单位货币折合目标货币的数量
+}
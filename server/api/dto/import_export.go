@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+	"what-to-wear/server/api"
+)
+
+// WardrobeExportDTO JSON格式的衣橱导出文件，也可直接用于导入
+type WardrobeExportDTO struct {
+	ExportedAt time.Time         `json:"exported_at"`
+	Items      []WardrobeItemDTO `json:"items"`
+}
+
+// WardrobeItemDTO 导入导出的衣物，分类使用完整路径，标签使用名称
+type WardrobeItemDTO struct {
+	Name        string                   `json:"name"`
+	Category    string                   `json:"category"` // 分类路径，如"上衣 > T恤"，导入时也可只写分类名称
+	Brand       string                   `json:"brand"`
+	Color       string                   `json:"color"`
+	Size        string                   `json:"size"`
+	Material    string                   `json:"material"`
+	Style       string                   `json:"style"`
+	Description string                   `json:"description"`
+	Status      api.ClothingStatus       `json:"status"`
+	IsFavorite  bool                     `json:"is_favorite"`
+	Tags        []string                 `json:"tags"`
+	Purchase    *CreatePurchaseRecordDTO `json:"purchase,omitempty"`
+
+	// 以下字段仅导出，导入时忽略
+	WearCount          int                    `json:"wear_count"`
+	LastWornDate       *time.Time             `json:"last_worn_date,omitempty"`
+	DurabilityScore    float64                `json:"durability_score"`
+	MaintenanceCount   int                    `json:"maintenance_count"`
+	WearRecords        []WearRecordDTO        `json:"wear_records,omitempty"`
+	MaintenanceRecords []MaintenanceRecordDTO `json:"maintenance_records,omitempty"`
+}
+
+// ImportResultDTO 导入结果，包含逐行校验报告
+type ImportResultDTO struct {
+	DryRun        bool                 `json:"dry_run"`
+	TotalRows     int                  `json:"total_rows"`
+	ValidRows     int                  `json:"valid_rows"`
+	ImportedCount int                  `json:"imported_count"`
+	Rows          []ImportRowResultDTO `json:"rows"`
+}
+
+// ImportRowResultDTO 单行导入结果
+type ImportRowResultDTO struct {
+	Row      int      `json:"row"` // CSV/Excel 为表格行号，JSON 为衣物序号（从1开始）
+	Name     string   `json:"name"`
+	Valid    bool     `json:"valid"`
+	Imported bool     `json:"imported"`
+	ItemID   uint     `json:"item_id,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
@@ -95,6 +95,13 @@ type OutfitSummaryDTO struct {
 	LastWornDate *time.Time        `json:"last_worn_date"`
 }
 
+// WearOutfitDTO 穿着穿搭DTO，反馈中的天气、温度、场合和地点为空时沿用穿搭的信息
+type WearOutfitDTO struct {
+	WearDate *time.Time `json:"wear_date"` // 为空时使用当前时间
+	Notes    string     `json:"notes"`
+	WearFeedbackDTO
+}
+
 // RateOutfitDTO 评价穿搭DTO
 type RateOutfitDTO struct {
 	Rating api.OutfitRating `json:"rating" binding:"required,min=1,max=5"`
@@ -110,9 +117,34 @@ type OutfitRecommendationDTO struct {
 	Confidence       float64                     `json:"confidence"`
 	Reason           string                      `json:"reason"`
 	AlternativeItems [][]RecommendedClothingItem `json:"alternative_items"` // 替代选择
+	Status           api.RecommendationStatus    `json:"status"`
+	OutfitID         *uint                       `json:"outfit_id,omitempty"` // 采纳后生成的穿搭ID
+	RejectReason     string                      `json:"reject_reason,omitempty"`
+	RespondedAt      *time.Time                  `json:"responded_at,omitempty"`
 	CreatedAt        time.Time                   `json:"created_at"`
 }
 
+// RecommendationListDTO 推荐历史列表请求DTO
+type RecommendationListDTO struct {
+	Status   *api.RecommendationStatus `form:"status"`
+	Page     int                       `form:"page"`
+	PageSize int                       `form:"page_size"`
+}
+
+// AcceptRecommendationDTO 采纳推荐DTO
+type AcceptRecommendationDTO struct {
+	Alternative int        `json:"alternative" binding:"min=0"` // 0为主推荐，1开始为替代搭配
+	Name        string     `json:"name" binding:"max=100"`
+	Date        *time.Time `json:"date"`
+	Notes       string     `json:"notes"`
+}
+
+// RejectRecommendationDTO 拒绝推荐DTO
+type RejectRecommendationDTO struct {
+	Reason  string `json:"reason" binding:"required,max=500"`
+	ItemIDs []uint `json:"item_ids"` // 不喜欢的具体单品，为空表示整体不满意
+}
+
 // Outfit 穿搭记录
 type Outfit struct {
 	ID          uint             `json:"id"`
@@ -27,6 +27,7 @@ type ActivityItem struct {
 // AnalyticsDTO 分析DTO
 type AnalyticsDTO struct {
 	Period    string                 `json:"period"`
+	Currency  string                 `json:"currency"` // 金额使用的本位币
 	StartDate time.Time              `json:"start_date"`
 	EndDate   time.Time              `json:"end_date"`
 	Metrics   map[string]interface{} `json:"metrics"`
@@ -34,6 +35,20 @@ type AnalyticsDTO struct {
 	Summary   AnalyticsSummary       `json:"summary"`
 }
 
+// AnalyticsRequestDTO 分析查询参数
+type AnalyticsRequestDTO struct {
+	Period    string     `form:"period"` // week, month, quarter, year；指定起止日期时为 custom
+	StartDate *time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate   *time.Time `form:"end_date" time_format:"2006-01-02"`
+	Limit     int        `form:"limit"` // 排行榜数量
+}
+
+// TrendRequestDTO 趋势分析查询参数
+type TrendRequestDTO struct {
+	Granularity string `form:"granularity"` // week, month，默认 month
+	Periods     int    `form:"periods"`     // 统计的已结束周期数，默认6，最多24
+}
+
 // ChartData 图表数据
 type ChartData struct {
 	Type   string                   `json:"type"` // line, bar, pie, doughnut
@@ -55,6 +70,7 @@ type AnalyticsSummary struct {
 
 // TrendAnalysisDTO 趋势分析DTO
 type TrendAnalysisDTO struct {
+	Currency       string       `json:"currency"` // 支出使用的本位币
 	WearTrends     []TrendItem  `json:"wear_trends"`
 	SpendingTrends []TrendItem  `json:"spending_trends"`
 	CategoryTrends []TrendItem  `json:"category_trends"`
@@ -65,6 +81,7 @@ type TrendAnalysisDTO struct {
 // TrendItem 趋势项
 type TrendItem struct {
 	Period    string  `json:"period"`
+	Label     string  `json:"label,omitempty"` // 分类或季节名称
 	Value     float64 `json:"value"`
 	Change    float64 `json:"change"`    // 变化百分比
 	Direction string  `json:"direction"` // up, down, stable
@@ -82,19 +99,38 @@ type Prediction struct {
 // ReportDTO 报告DTO
 type ReportDTO struct {
 	Type      string    `json:"type" binding:"required"` // monthly, yearly, custom
-	StartDate time.Time `json:"start_date"`
-	EndDate   time.Time `json:"end_date"`
-	Format    string    `json:"format"`   // pdf, excel, json
-	Sections  []string  `json:"sections"` // overview, clothing, spending, wear, maintenance
+	StartDate time.Time `json:"start_date"`              // monthly/yearly 时取所在月份或年份，默认上一个完整周期
+	EndDate   time.Time `json:"end_date"`                // 仅 custom 使用
+	Format    string    `json:"format"`                  // pdf, csv, xlsx, json，默认 json
+	Sections  []string  `json:"sections"`                // overview, clothing, spending, wear, maintenance，默认全部
+}
+
+// ReportJobDTO 报告生成任务DTO
+type ReportJobDTO struct {
+	ID          uint             `json:"id"`
+	Type        api.ReportType   `json:"type"`
+	Format      api.ReportFormat `json:"format"`
+	Sections    []string         `json:"sections"`
+	StartDate   time.Time        `json:"start_date"`
+	EndDate     time.Time        `json:"end_date"`
+	Status      api.JobStatus    `json:"status"`
+	FileName    string           `json:"file_name,omitempty"`
+	FileSize    int64            `json:"file_size,omitempty"`
+	Error       string           `json:"error,omitempty"`
+	DownloadURL string           `json:"download_url,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time       `json:"expires_at,omitempty"`
 }
 
-// ReportData 报告数据
+// ReportData 报告数据，只包含选中的章节
 type ReportData struct {
-	Overview    ReportOverview    `json:"overview"`
-	Clothing    ReportClothing    `json:"clothing"`
-	Spending    ReportSpending    `json:"spending"`
-	Wear        ReportWear        `json:"wear"`
-	Maintenance ReportMaintenance `json:"maintenance"`
+	Currency    string             `json:"currency"` // 金额使用的本位币
+	Overview    *ReportOverview    `json:"overview,omitempty"`
+	Clothing    *ReportClothing    `json:"clothing,omitempty"`
+	Spending    *ReportSpending    `json:"spending,omitempty"`
+	Wear        *ReportWear        `json:"wear,omitempty"`
+	Maintenance *ReportMaintenance `json:"maintenance,omitempty"`
 }
 
 // ReportOverview 报告概览
@@ -183,6 +219,14 @@ type ClothingItemSummary struct {
 	PurchasePrice *float64           `json:"purchase_price"`
 }
 
+// CostPerWearItem 单品每次穿着成本
+type CostPerWearItem struct {
+	ClothingItemSummary
+	TotalWears  int64   `json:"total_wears"`  // 截至统计结束日期的穿着次数
+	PeriodWears int64   `json:"period_wears"` // 统计周期内的穿着次数
+	CostPerWear float64 `json:"cost_per_wear"`
+}
+
 // ComfortAnalysisDTO 舒适度分析DTO
 type ComfortAnalysisDTO struct {
 	AverageComfort         float64                     `json:"average_comfort"`
@@ -203,8 +247,15 @@ type MaintenanceReminderDTO struct {
 	Priority            string    `json:"priority"` // low, medium, high, urgent
 }
 
+// MaintenanceRemindersDTO 保养提醒汇总DTO
+type MaintenanceRemindersDTO struct {
+	Overdue  []MaintenanceReminderDTO `json:"overdue"`
+	Upcoming []MaintenanceReminderDTO `json:"upcoming"`
+}
+
 // SpendingStatsDTO 支出统计DTO
 type SpendingStatsDTO struct {
+	Currency          string                `json:"currency"` // 金额使用的本位币
 	TotalSpent        float64               `json:"total_spent"`
 	MonthlySpending   map[string]float64    `json:"monthly_spending"`
 	CategorySpending  map[string]float64    `json:"category_spending"`
@@ -213,4 +264,5 @@ type SpendingStatsDTO struct {
 	CostPerWear       float64               `json:"cost_per_wear"`
 	MostExpensiveItem *ClothingItemSummary  `json:"most_expensive_item"`
 	BestValueItems    []ClothingItemSummary `json:"best_value_items"`
+	Budgets           []BudgetStatusDTO     `json:"budgets"` // 当前周期的预算执行情况
 }
@@ -35,7 +35,7 @@ type LocationInfo struct {
 // CurrentWeather 当前天气
 type CurrentWeather struct {
 	Temperature   float64         `json:"temperature"`
-	FeelsLike     float64         `json:"feels_like"`
+	FeelsLike     *float64        `json:"feels_like,omitempty"`
 	Humidity      int             `json:"humidity"`
 	Pressure      float64         `json:"pressure"`
 	Visibility    float64         `json:"visibility"`
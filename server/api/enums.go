@@ -117,6 +117,24 @@ func (g Gender) IsValid() bool {
 	}
 }
 
+// UserRole 用户角色枚举
+type UserRole string
+
+const (
+	UserRoleUser  UserRole = "user"  // 普通用户
+	UserRoleAdmin UserRole = "admin" // 管理员
+)
+
+// IsValid 检查用户角色是否有效
+func (r UserRole) IsValid() bool {
+	switch r {
+	case UserRoleUser, UserRoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
 // OutfitRating 穿搭评分枚举
 type OutfitRating int
 
@@ -215,6 +233,158 @@ func IsValidItemRole(role string) bool {
 	return false
 }
 
+// RecommendationStatus 穿搭推荐状态枚举
+type RecommendationStatus string
+
+const (
+	RecommendationStatusPending  RecommendationStatus = "pending"  // 待处理
+	RecommendationStatusAccepted RecommendationStatus = "accepted" // 已采纳
+	RecommendationStatusRejected RecommendationStatus = "rejected" // 已拒绝
+)
+
+// IsValid 检查推荐状态是否有效
+func (s RecommendationStatus) IsValid() bool {
+	switch s {
+	case RecommendationStatusPending, RecommendationStatusAccepted, RecommendationStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReportType 报告周期类型枚举
+type ReportType string
+
+const (
+	ReportTypeMonthly ReportType = "monthly" // 月度报告
+	ReportTypeYearly  ReportType = "yearly"  // 年度报告
+	ReportTypeCustom  ReportType = "custom"  // 自定义周期
+)
+
+// IsValid 检查报告类型是否有效
+func (t ReportType) IsValid() bool {
+	switch t {
+	case ReportTypeMonthly, ReportTypeYearly, ReportTypeCustom:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReportFormat 报告格式枚举
+type ReportFormat string
+
+const (
+	ReportFormatJSON ReportFormat = "json" // JSON
+	ReportFormatCSV  ReportFormat = "csv"  // CSV表格
+	ReportFormatPDF  ReportFormat = "pdf"  // PDF文档
+	ReportFormatXLSX ReportFormat = "xlsx" // Excel工作簿
+)
+
+// IsValid 检查报告格式是否有效
+func (f ReportFormat) IsValid() bool {
+	switch f {
+	case ReportFormatJSON, ReportFormatCSV, ReportFormatPDF, ReportFormatXLSX:
+		return true
+	default:
+		return false
+	}
+}
+
+// ExchangeFormat 衣橱导入导出文件格式枚举
+type ExchangeFormat string
+
+const (
+	ExchangeFormatCSV   ExchangeFormat = "csv"   // CSV表格
+	ExchangeFormatExcel ExchangeFormat = "excel" // Excel工作簿（xlsx）
+	ExchangeFormatJSON  ExchangeFormat = "json"  // JSON
+)
+
+// IsValid 检查导入导出格式是否有效
+func (f ExchangeFormat) IsValid() bool {
+	switch f {
+	case ExchangeFormatCSV, ExchangeFormatExcel, ExchangeFormatJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReportSection 报告章节枚举
+type ReportSection string
+
+const (
+	ReportSectionOverview    ReportSection = "overview"    // 概览
+	ReportSectionClothing    ReportSection = "clothing"    // 衣物
+	ReportSectionSpending    ReportSection = "spending"    // 支出
+	ReportSectionWear        ReportSection = "wear"        // 穿着
+	ReportSectionMaintenance ReportSection = "maintenance" // 保养
+)
+
+// AllReportSections 全部报告章节（按输出顺序）
+var AllReportSections = []ReportSection{
+	ReportSectionOverview, ReportSectionClothing, ReportSectionSpending,
+	ReportSectionWear, ReportSectionMaintenance,
+}
+
+// IsValid 检查报告章节是否有效
+func (s ReportSection) IsValid() bool {
+	switch s {
+	case ReportSectionOverview, ReportSectionClothing, ReportSectionSpending,
+		ReportSectionWear, ReportSectionMaintenance:
+		return true
+	default:
+		return false
+	}
+}
+
+// JobStatus 后台任务状态枚举
+type JobStatus string
+
+const (
+	JobStatusPending    JobStatus = "pending"    // 排队中
+	JobStatusProcessing JobStatus = "processing" // 处理中
+	JobStatusCompleted  JobStatus = "completed"  // 已完成
+	JobStatusFailed     JobStatus = "failed"     // 失败
+)
+
+// ExportStage 账户数据导出任务的处理阶段枚举
+type ExportStage string
+
+const (
+	ExportStageQueued      ExportStage = "queued"      // 排队中
+	ExportStageCollecting  ExportStage = "collecting"  // 汇总数据
+	ExportStageAttachments ExportStage = "attachments" // 打包附件
+	ExportStageFinished    ExportStage = "finished"    // 已结束
+)
+
+// UploadStatus 直传上传凭证状态枚举
+type UploadStatus string
+
+const (
+	UploadStatusPending   UploadStatus = "pending"   // 等待上传
+	UploadStatusCompleted UploadStatus = "completed" // 已确认
+	UploadStatusExpired   UploadStatus = "expired"   // 已过期
+)
+
+// BudgetPeriod 预算周期枚举
+type BudgetPeriod string
+
+const (
+	BudgetPeriodMonthly  BudgetPeriod = "monthly"  // 每月
+	BudgetPeriodSeasonal BudgetPeriod = "seasonal" // 每季（3-5月为春季，依此类推）
+)
+
+// IsValid 检查预算周期是否有效
+func (p BudgetPeriod) IsValid() bool {
+	switch p {
+	case BudgetPeriodMonthly, BudgetPeriodSeasonal:
+		return true
+	default:
+		return false
+	}
+}
+
 // 系统标签枚举定义
 // SystemTag 系统标签信息
 type SystemTag struct {
@@ -6,15 +6,18 @@ import (
 	"log"
 	"os"
 
+	"what-to-wear/server/api"
 	"what-to-wear/server/config"
 	"what-to-wear/server/database"
+	"what-to-wear/server/models"
 )
 
 func main() {
 	// 定义命令行参数
 	var (
-		action = flag.String("action", "migrate", "操作类型: migrate, seed, reset, status, drop")
-		seeder = flag.String("seeder", "", "指定要运行的种子数据 (categories, tags)")
+		action   = flag.String("action", "migrate", "操作类型: migrate, seed, reset, status, drop, promote-admin")
+		seeder   = flag.String("seeder", "", "指定要运行的种子数据 (categories, tags)")
+		username = flag.String("username", "", "设为管理员的用户名 (promote-admin)")
 	)
 	flag.Parse()
 
@@ -73,6 +76,19 @@ func main() {
 		}
 		fmt.Println("数据库状态正常!")
 
+	case "promote-admin":
+		if *username == "" {
+			log.Fatal("请通过 -username 指定用户名")
+		}
+		result := db.Model(&models.User{}).Where("username = ?", *username).Update("role", api.UserRoleAdmin)
+		if result.Error != nil {
+			log.Fatalf("设置管理员失败: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			log.Fatalf("用户不存在: %s", *username)
+		}
+		fmt.Printf("用户 %s 已设为管理员，重新登录后生效\n", *username)
+
 	case "drop":
 		fmt.Println("警告: 即将删除所有表!")
 		fmt.Print("确认删除? (y/N): ")
@@ -89,7 +105,7 @@ func main() {
 
 	default:
 		fmt.Printf("未知操作: %s\n", *action)
-		fmt.Println("可用操作: migrate, seed, reset, status, drop")
+		fmt.Println("可用操作: migrate, seed, reset, status, drop, promote-admin")
 		os.Exit(1)
 	}
 
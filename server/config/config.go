@@ -3,15 +3,22 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig   `json:"server"`
-	Database DatabaseConfig `json:"database"`
-	JWT      JWTConfig      `json:"jwt"`
-	OSS      OSSConfig      `json:"oss"`
+	Server        ServerConfig        `json:"server"`
+	Database      DatabaseConfig      `json:"database"`
+	JWT           JWTConfig           `json:"jwt"`
+	OSS           OSSConfig           `json:"oss"`
+	Storage       StorageConfig       `json:"storage"`
+	Weather       WeatherConfig       `json:"weather"`
+	Maintenance   MaintenanceConfig   `json:"maintenance"`
+	Report        ReportConfig        `json:"report"`
+	AccountExport AccountExportConfig `json:"account_export"`
+	Currency      CurrencyConfig      `json:"currency"`
 }
 
 type ServerConfig struct {
@@ -33,6 +40,55 @@ type OSSConfig struct {
 	Expires         int64  `json:"expires"`
 }
 
+type StorageConfig struct {
+	Provider        string `json:"provider"`         // 附件存储提供商: local, oss
+	LocalDir        string `json:"local_dir"`        // 本地存储根目录
+	SigningSecret   string `json:"signing_secret"`   // 本地存储签名链接的密钥，必须单独配置
+	URLExpires      int64  `json:"url_expires"`      // 签名链接有效期(秒)
+	MaxUploadSize   int64  `json:"max_upload_size"`  // 单个文件最大大小(MB)
+	UploadExpires   int64  `json:"upload_expires"`   // 直传上传凭证有效期(秒)，过期未确认的上传会被清理
+	CleanupInterval int    `json:"cleanup_interval"` // 过期上传凭证清理间隔(秒)，0 表示不清理
+}
+
+type WeatherConfig struct {
+	Providers   []string `json:"providers"`    // 按顺序尝试的天气数据源
+	APIKey      string   `json:"api_key"`      // 在线数据源的API Key
+	BaseURL     string   `json:"base_url"`     // 在线数据源地址
+	FixturePath string   `json:"fixture_path"` // 本地数据文件目录
+	CacheTTL    int64    `json:"cache_ttl"`    // 缓存时间(秒)
+	Timeout     int      `json:"timeout"`      // 请求超时(秒)
+}
+
+type MaintenanceConfig struct {
+	ReminderEnabled   bool `json:"reminder_enabled"`    // 是否启用保养提醒调度
+	ReminderInterval  int  `json:"reminder_interval"`   // 扫描间隔(秒)
+	ReminderLeadDays  int  `json:"reminder_lead_days"`  // 提前提醒天数
+	ReminderBatchSize int  `json:"reminder_batch_size"` // 单次扫描最多处理的记录数
+}
+
+type ReportConfig struct {
+	WorkerEnabled     bool   `json:"worker_enabled"`     // 是否启用后台报告生成
+	PollInterval      int    `json:"poll_interval"`      // 任务轮询间隔(秒)
+	BatchSize         int    `json:"batch_size"`         // 单次领取的任务数
+	ProcessingTimeout int    `json:"processing_timeout"` // 处理中任务的超时时间(秒)，超时后重新排队
+	OutputDir         string `json:"output_dir"`         // 报告文件存储目录
+	RetentionDays     int    `json:"retention_days"`     // 报告文件保留天数
+}
+
+type AccountExportConfig struct {
+	WorkerEnabled     bool   `json:"worker_enabled"`     // 是否启用后台账户数据导出
+	PollInterval      int    `json:"poll_interval"`      // 任务轮询间隔(秒)
+	BatchSize         int    `json:"batch_size"`         // 单次领取的任务数
+	ProcessingTimeout int    `json:"processing_timeout"` // 处理中任务的超时时间(秒)，超时后重新排队
+	OutputDir         string `json:"output_dir"`         // 导出压缩包存储目录
+	LinkTTL           int    `json:"link_ttl"`           // 下载链接有效期(秒)
+}
+
+type CurrencyConfig struct {
+	BaseCurrency string `json:"base_currency"` // 汇率表的基准货币，也是新用户的默认本位币
+	RatesPath    string `json:"rates_path"`    // 本地汇率表文件(CSV: date,currency,rate)
+}
+
 func LoadConfig() (*Config, error) {
 	// 加载 .env 文件
 	if err := godotenv.Load(); err != nil {
@@ -64,6 +120,49 @@ func LoadConfig() (*Config, error) {
 			Region:          getEnvWithDefault("OSS_REGION", "cn-hangzhou"),
 			Expires:         getEnvInt64WithDefault("OSS_EXPIRES", 3600),
 		},
+		Storage: StorageConfig{
+			Provider:        getEnvWithDefault("STORAGE_PROVIDER", "local"),
+			LocalDir:        getEnvWithDefault("STORAGE_LOCAL_DIR", "data/uploads"),
+			SigningSecret:   os.Getenv("STORAGE_SIGNING_SECRET"),
+			URLExpires:      getEnvInt64WithDefault("STORAGE_URL_EXPIRES", 3600),
+			MaxUploadSize:   getEnvInt64WithDefault("STORAGE_MAX_UPLOAD_SIZE", 50),
+			UploadExpires:   getEnvInt64WithDefault("STORAGE_UPLOAD_EXPIRES", 900),
+			CleanupInterval: getEnvIntWithDefault("STORAGE_UPLOAD_CLEANUP_INTERVAL", 300),
+		},
+		Weather: WeatherConfig{
+			Providers:   getEnvListWithDefault("WEATHER_PROVIDERS", []string{"local"}),
+			APIKey:      os.Getenv("WEATHER_API_KEY"),
+			BaseURL:     getEnvWithDefault("WEATHER_BASE_URL", "https://api.openweathermap.org"),
+			FixturePath: getEnvWithDefault("WEATHER_FIXTURE_PATH", "fixtures/weather"),
+			CacheTTL:    getEnvInt64WithDefault("WEATHER_CACHE_TTL", 600),
+			Timeout:     getEnvIntWithDefault("WEATHER_TIMEOUT", 10),
+		},
+		Maintenance: MaintenanceConfig{
+			ReminderEnabled:   getEnvBoolWithDefault("MAINTENANCE_REMINDER_ENABLED", true),
+			ReminderInterval:  getEnvIntWithDefault("MAINTENANCE_REMINDER_INTERVAL", 3600),
+			ReminderLeadDays:  getEnvIntWithDefault("MAINTENANCE_REMINDER_LEAD_DAYS", 3),
+			ReminderBatchSize: getEnvIntWithDefault("MAINTENANCE_REMINDER_BATCH_SIZE", 500),
+		},
+		Report: ReportConfig{
+			WorkerEnabled:     getEnvBoolWithDefault("REPORT_WORKER_ENABLED", true),
+			PollInterval:      getEnvIntWithDefault("REPORT_POLL_INTERVAL", 5),
+			BatchSize:         getEnvIntWithDefault("REPORT_BATCH_SIZE", 5),
+			ProcessingTimeout: getEnvIntWithDefault("REPORT_PROCESSING_TIMEOUT", 1800),
+			OutputDir:         getEnvWithDefault("REPORT_OUTPUT_DIR", "data/reports"),
+			RetentionDays:     getEnvIntWithDefault("REPORT_RETENTION_DAYS", 7),
+		},
+		AccountExport: AccountExportConfig{
+			WorkerEnabled:     getEnvBoolWithDefault("ACCOUNT_EXPORT_WORKER_ENABLED", true),
+			PollInterval:      getEnvIntWithDefault("ACCOUNT_EXPORT_POLL_INTERVAL", 10),
+			BatchSize:         getEnvIntWithDefault("ACCOUNT_EXPORT_BATCH_SIZE", 2),
+			ProcessingTimeout: getEnvIntWithDefault("ACCOUNT_EXPORT_PROCESSING_TIMEOUT", 3600),
+			OutputDir:         getEnvWithDefault("ACCOUNT_EXPORT_OUTPUT_DIR", "data/exports"),
+			LinkTTL:           getEnvIntWithDefault("ACCOUNT_EXPORT_LINK_TTL", 172800),
+		},
+		Currency: CurrencyConfig{
+			BaseCurrency: getEnvWithDefault("CURRENCY_BASE", "CNY"),
+			RatesPath:    getEnvWithDefault("EXCHANGE_RATES_PATH", "fixtures/exchange_rates.csv"),
+		},
 	}
 
 	return config, nil
@@ -92,6 +191,35 @@ func getEnvInt64WithDefault(key string, defaultValue int64) int64 {
 	return defaultValue
 }
 
+func getEnvBoolWithDefault(key string, defaultValue bool) bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(key))) {
+	case "true", "1", "yes", "on":
+		return true
+	case "false", "0", "no", "off":
+		return false
+	default:
+		return defaultValue
+	}
+}
+
+func getEnvListWithDefault(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	if len(result) == 0 {
+		return defaultValue
+	}
+	return result
+}
+
 func parseInt(s string) int {
 	var result int
 	fmt.Sscanf(s, "%d", &result)
@@ -13,17 +13,24 @@ import (
 
 // Container 依赖注入容器
 type Container struct {
-	Config                *config.Config
-	DB                    *gorm.DB
+	Config *config.Config
+	DB     *gorm.DB
 	// Repositories
 	UserRepo             repositories.UserRepository
 	OutfitRepo           repositories.OutfitRepository
 	OutfitItemRepo       repositories.OutfitItemRepository
+	RecommendationRepo   repositories.OutfitRecommendationRepository
 	ClothingItemRepo     repositories.ClothingItemRepository
 	ClothingCategoryRepo repositories.ClothingCategoryRepository
 	AttachmentRepo       repositories.AttachmentRepository
 	PurchaseRecordRepo   repositories.PurchaseRecordRepository
 	WearRecordRepo       repositories.WearRecordRepository
+	MaintenanceRepo      repositories.MaintenanceRecordRepository
+	ActivityRepo         repositories.ActivityRepository
+	ReportJobRepo        repositories.ReportJobRepository
+	AccountExportRepo    repositories.AccountExportRepository
+	BudgetRepo           repositories.BudgetRepository
+	UploadSlotRepo       repositories.UploadSlotRepository
 
 	// Services
 	AuthService           services.AuthService
@@ -32,61 +39,246 @@ type Container struct {
 	PurchaseRecordService services.PurchaseRecordService
 	WearRecordService     services.WearRecordService
 	ClothingItemService   services.ClothingItemService
+	RecommendationService services.RecommendationService
+	MaintenanceService    services.MaintenanceService
+	DurabilityService     services.DurabilityService
+	AnalyticsService      services.AnalyticsService
+	TrendService          services.TrendService
+	DashboardService      services.DashboardService
+	ReportService         services.ReportService
+	ImportExportService   services.ImportExportService
+	AccountExportService  services.AccountExportService
+	WeatherService        services.WeatherService
 	OSSService            services.OSSService
+	Storage               services.Storage
+	AttachmentService     services.AttachmentServiceInterface
+	CurrencyService       services.CurrencyService
+	BudgetService         services.BudgetService
+
+	// Schedulers
+	MaintenanceScheduler services.MaintenanceScheduler
+	ReportWorker         services.ReportWorker
+	AccountExportWorker  services.AccountExportWorker
+	UploadCleanupWorker  services.UploadCleanupWorker
 
 	// Controllers
-	AuthController     *controllers.AuthController
-	UserController     *controllers.UserController
-	ClothingController *controllers.ClothingController
-	OSSController      *controllers.OSSController
+	AuthController           *controllers.AuthController
+	UserController           *controllers.UserController
+	ClothingController       *controllers.ClothingController
+	OutfitController         *controllers.OutfitController
+	RecommendationController *controllers.RecommendationController
+	MaintenanceController    *controllers.MaintenanceController
+	PurchaseController       *controllers.PurchaseController
+	CurrencyController       *controllers.CurrencyController
+	BudgetController         *controllers.BudgetController
+	AnalyticsController      *controllers.AnalyticsController
+	DashboardController      *controllers.DashboardController
+	ReportController         *controllers.ReportController
+	ImportExportController   *controllers.ImportExportController
+	AccountExportController  *controllers.AccountExportController
+	WeatherController        *controllers.WeatherController
+	OSSController            *controllers.OSSController
+	AttachmentController     *controllers.AttachmentController
+	StorageController        *controllers.StorageController
 }
 
 // NewContainer 创建容器实例
 func NewContainer(cfg *config.Config, db *gorm.DB) *Container {
 	// 创建 Repositories
+	transactor := repositories.NewTransactor(db)
 	userRepo := repositories.NewUserRepository(db)
 	outfitRepo := repositories.NewOutfitRepository(db)
 	outfitItemRepo := repositories.NewOutfitItemRepository(db)
+	recommendationRepo := repositories.NewOutfitRecommendationRepository(db)
 	clothingItemRepo := repositories.NewClothingItemRepository(db)
 	clothingCategoryRepo := repositories.NewClothingCategoryRepository(db)
 	clothingTagRepository := repositories.NewClothingTagRepository(db)
 	attachmentRepo := repositories.NewAttachmentRepository(db)
 	purchaseRecordRepo := repositories.NewPurchaseRecordRepository(db)
 	wearRecordRepo := repositories.NewWearRecordRepository(db)
+	maintenanceRepo := repositories.NewMaintenanceRecordRepository(db)
+	activityRepo := repositories.NewActivityRepository(db)
+	reportJobRepo := repositories.NewReportJobRepository(db)
+	accountExportRepo := repositories.NewAccountExportRepository(db)
+	budgetRepo := repositories.NewBudgetRepository(db)
+	uploadSlotRepo := repositories.NewUploadSlotRepository(db)
+
+	// 创建 OSS Service（传入 config）
+	ossService, err := services.NewOSSService(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize OSS service: %v", err)
+	}
+
+	// 创建文件存储（提供商由配置决定）
+	storage, err := services.NewStorage(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
 
 	// 创建 Services
+	durabilityService := services.NewDurabilityService(
+		clothingItemRepo,
+		clothingCategoryRepo,
+		wearRecordRepo,
+		maintenanceRepo,
+	)
+	currencyService := services.NewCurrencyService(
+		transactor,
+		cfg,
+		userRepo,
+		clothingItemRepo,
+		purchaseRecordRepo,
+		maintenanceRepo,
+	)
+	budgetService := services.NewBudgetService(
+		budgetRepo,
+		purchaseRecordRepo,
+		clothingItemRepo,
+		clothingCategoryRepo,
+		currencyService,
+	)
 	authService := services.NewAuthService(userRepo)
-	userService := services.NewUserService(userRepo)
+	accountPurger := services.NewAccountPurger(userRepo, attachmentRepo, uploadSlotRepo, reportJobRepo, storage)
+	accountExportService := services.NewAccountExportService(
+		cfg,
+		accountExportRepo,
+		userRepo,
+		clothingItemRepo,
+		clothingCategoryRepo,
+		clothingTagRepository,
+		outfitRepo,
+		outfitItemRepo,
+		wearRecordRepo,
+		purchaseRecordRepo,
+		maintenanceRepo,
+		budgetRepo,
+		attachmentRepo,
+		storage,
+		accountPurger,
+	)
+	userService := services.NewUserService(userRepo, accountExportService, accountPurger)
 	outfitService := services.NewOutfitService(
+		transactor,
 		outfitRepo,
 		outfitItemRepo,
 		clothingItemRepo,
 		clothingCategoryRepo,
 		attachmentRepo,
+		wearRecordRepo,
+		durabilityService,
 	)
 	purchaseRecordService := services.NewPurchaseRecordService(
 		purchaseRecordRepo,
 		clothingItemRepo,
 		clothingCategoryRepo,
+		currencyService,
+		budgetService,
 	)
 	wearRecordService := services.NewWearRecordService(
+		transactor,
 		wearRecordRepo,
 		clothingItemRepo,
+		durabilityService,
 	)
 	clothingItemService := services.NewClothingItemService(
+		transactor,
 		clothingItemRepo,
 		clothingCategoryRepo,
+		clothingTagRepository,
 		attachmentRepo,
 		purchaseRecordRepo,
 		wearRecordRepo,
+		currencyService,
+		budgetService,
 	)
-	clothingCategoryService := services.NewCategoryService(clothingCategoryRepo)
+	clothingCategoryService := services.NewCategoryService(transactor, clothingCategoryRepo)
 	clothingTagService := services.NewClothingTagService(clothingTagRepository)
+	recommendationService := services.NewRecommendationService(
+		recommendationRepo,
+		clothingItemRepo,
+		clothingCategoryRepo,
+		userRepo,
+	)
+	analyticsService := services.NewAnalyticsService(
+		clothingItemRepo,
+		clothingCategoryRepo,
+		wearRecordRepo,
+		currencyService,
+	)
+	trendService := services.NewTrendService(
+		clothingItemRepo,
+		clothingCategoryRepo,
+		wearRecordRepo,
+		purchaseRecordRepo,
+		maintenanceRepo,
+		currencyService,
+	)
+	maintenanceService := services.NewMaintenanceService(transactor, maintenanceRepo, clothingItemRepo, durabilityService, currencyService)
+	dashboardService := services.NewDashboardService(
+		userRepo,
+		activityRepo,
+		clothingItemService,
+		outfitService,
+		purchaseRecordService,
+	)
+	reportService := services.NewReportService(
+		cfg,
+		reportJobRepo,
+		clothingItemRepo,
+		clothingCategoryRepo,
+		wearRecordRepo,
+		purchaseRecordRepo,
+		maintenanceRepo,
+		outfitRepo,
+		maintenanceService,
+		wearRecordService,
+		currencyService,
+	)
 
-	// 创建 OSS Service（传入 config）
-	ossService, err := services.NewOSSService(cfg)
+	attachmentService := services.NewAttachmentService(
+		cfg,
+		attachmentRepo,
+		uploadSlotRepo,
+		clothingItemRepo,
+		outfitRepo,
+		maintenanceRepo,
+		wearRecordRepo,
+		purchaseRecordRepo,
+		storage,
+	)
+
+	importExportService := services.NewImportExportService(
+		clothingItemRepo,
+		clothingTagRepository,
+		purchaseRecordRepo,
+		wearRecordRepo,
+		maintenanceRepo,
+		clothingItemService,
+		clothingCategoryService,
+		currencyService,
+	)
+
+	// 创建保养提醒调度器（由 main 启动）
+	maintenanceScheduler := services.NewMaintenanceScheduler(
+		cfg,
+		maintenanceRepo,
+		clothingItemRepo,
+		services.NewLogReminderNotifier(),
+	)
+
+	// 创建报告生成后台任务（由 main 启动）
+	reportWorker := services.NewReportWorker(cfg, reportJobRepo, reportService)
+
+	// 创建账户数据导出后台任务（由 main 启动）
+	accountExportWorker := services.NewAccountExportWorker(cfg, accountExportRepo, accountExportService)
+
+	// 创建过期直传上传凭证清理后台任务（由 main 启动）
+	uploadCleanupWorker := services.NewUploadCleanupWorker(cfg, attachmentService)
+
+	// 创建天气服务（数据源由配置决定）
+	weatherService, err := services.NewWeatherService(cfg)
 	if err != nil {
-		log.Fatalf("Failed to initialize OSS service: %v", err)
+		log.Fatalf("Failed to initialize weather service: %v", err)
 	}
 
 	// 创建 Controllers
@@ -97,21 +289,45 @@ func NewContainer(cfg *config.Config, db *gorm.DB) *Container {
 		clothingCategoryService,
 		clothingTagService,
 		wearRecordService,
+		durabilityService,
 	)
+	outfitController := controllers.NewOutfitController(outfitService)
+	recommendationController := controllers.NewRecommendationController(recommendationService, weatherService)
+	maintenanceController := controllers.NewMaintenanceController(maintenanceService)
+	purchaseController := controllers.NewPurchaseController(purchaseRecordService)
+	currencyController := controllers.NewCurrencyController(currencyService)
+	budgetController := controllers.NewBudgetController(budgetService)
+	analyticsController := controllers.NewAnalyticsController(analyticsService, trendService, wearRecordService)
+	dashboardController := controllers.NewDashboardController(dashboardService)
+	reportController := controllers.NewReportController(reportService)
+	importExportController := controllers.NewImportExportController(importExportService)
+	accountExportController := controllers.NewAccountExportController(accountExportService)
+	weatherController := controllers.NewWeatherController(weatherService)
 	ossController := controllers.NewOSSController(ossService)
+	attachmentController := controllers.NewAttachmentController(attachmentService)
+	// 仅本地存储需要由服务端提供文件下载
+	localStorage, _ := storage.(services.LocalStorage)
+	storageController := controllers.NewStorageController(localStorage, attachmentService, cfg.Storage.MaxUploadSize*1024*1024)
 
 	return &Container{
-		Config:              cfg,
-		DB:                  db,
+		Config: cfg,
+		DB:     db,
 		// Repositories
 		UserRepo:             userRepo,
 		OutfitRepo:           outfitRepo,
 		OutfitItemRepo:       outfitItemRepo,
+		RecommendationRepo:   recommendationRepo,
 		ClothingItemRepo:     clothingItemRepo,
 		ClothingCategoryRepo: clothingCategoryRepo,
 		AttachmentRepo:       attachmentRepo,
 		PurchaseRecordRepo:   purchaseRecordRepo,
 		WearRecordRepo:       wearRecordRepo,
+		MaintenanceRepo:      maintenanceRepo,
+		ActivityRepo:         activityRepo,
+		ReportJobRepo:        reportJobRepo,
+		AccountExportRepo:    accountExportRepo,
+		BudgetRepo:           budgetRepo,
+		UploadSlotRepo:       uploadSlotRepo,
 
 		// Services
 		AuthService:           authService,
@@ -120,13 +336,47 @@ func NewContainer(cfg *config.Config, db *gorm.DB) *Container {
 		PurchaseRecordService: purchaseRecordService,
 		WearRecordService:     wearRecordService,
 		ClothingItemService:   clothingItemService,
+		RecommendationService: recommendationService,
+		MaintenanceService:    maintenanceService,
+		DurabilityService:     durabilityService,
+		AnalyticsService:      analyticsService,
+		TrendService:          trendService,
+		DashboardService:      dashboardService,
+		ReportService:         reportService,
+		ImportExportService:   importExportService,
+		AccountExportService:  accountExportService,
+		WeatherService:        weatherService,
 		OSSService:            ossService,
+		Storage:               storage,
+		AttachmentService:     attachmentService,
+		CurrencyService:       currencyService,
+		BudgetService:         budgetService,
+
+		// Schedulers
+		MaintenanceScheduler: maintenanceScheduler,
+		ReportWorker:         reportWorker,
+		AccountExportWorker:  accountExportWorker,
+		UploadCleanupWorker:  uploadCleanupWorker,
 
 		// Controllers
-		AuthController:     authController,
-		UserController:     userController,
-		ClothingController: clothingController,
-		OSSController:      ossController,
+		AuthController:           authController,
+		UserController:           userController,
+		ClothingController:       clothingController,
+		OutfitController:         outfitController,
+		RecommendationController: recommendationController,
+		MaintenanceController:    maintenanceController,
+		PurchaseController:       purchaseController,
+		CurrencyController:       currencyController,
+		BudgetController:         budgetController,
+		AnalyticsController:      analyticsController,
+		DashboardController:      dashboardController,
+		ReportController:         reportController,
+		ImportExportController:   importExportController,
+		AccountExportController:  accountExportController,
+		WeatherController:        weatherController,
+		OSSController:            ossController,
+		AttachmentController:     attachmentController,
+		StorageController:        storageController,
 	}
 }
 
@@ -145,6 +395,11 @@ func (c *Container) GetClothingController() *controllers.ClothingController {
 	return c.ClothingController
 }
 
+// GetOutfitController 获取穿搭控制器
+func (c *Container) GetOutfitController() *controllers.OutfitController {
+	return c.OutfitController
+}
+
 // GetOutfitService 获取穿搭服务
 func (c *Container) GetOutfitService() services.OutfitService {
 	return c.OutfitService
@@ -160,7 +415,92 @@ func (c *Container) GetClothingItemService() services.ClothingItemService {
 	return c.ClothingItemService
 }
 
+// GetRecommendationController 获取穿搭推荐控制器
+func (c *Container) GetRecommendationController() *controllers.RecommendationController {
+	return c.RecommendationController
+}
+
+// GetMaintenanceController 获取衣物保养控制器
+func (c *Container) GetMaintenanceController() *controllers.MaintenanceController {
+	return c.MaintenanceController
+}
+
+// GetPurchaseController 获取购买记录控制器
+func (c *Container) GetPurchaseController() *controllers.PurchaseController {
+	return c.PurchaseController
+}
+
+// GetCurrencyController 获取货币控制器
+func (c *Container) GetCurrencyController() *controllers.CurrencyController {
+	return c.CurrencyController
+}
+
+// GetBudgetController 获取预算控制器
+func (c *Container) GetBudgetController() *controllers.BudgetController {
+	return c.BudgetController
+}
+
+// GetAnalyticsController 获取穿着分析控制器
+func (c *Container) GetAnalyticsController() *controllers.AnalyticsController {
+	return c.AnalyticsController
+}
+
+// GetDashboardController 获取仪表板控制器
+func (c *Container) GetDashboardController() *controllers.DashboardController {
+	return c.DashboardController
+}
+
+// GetReportController 获取衣橱报告控制器
+func (c *Container) GetReportController() *controllers.ReportController {
+	return c.ReportController
+}
+
+// GetImportExportController 获取衣橱导入导出控制器
+func (c *Container) GetImportExportController() *controllers.ImportExportController {
+	return c.ImportExportController
+}
+
+// GetAccountExportController 获取账户数据导出控制器
+func (c *Container) GetAccountExportController() *controllers.AccountExportController {
+	return c.AccountExportController
+}
+
+// GetMaintenanceScheduler 获取保养提醒调度器
+func (c *Container) GetMaintenanceScheduler() services.MaintenanceScheduler {
+	return c.MaintenanceScheduler
+}
+
+// GetReportWorker 获取报告生成后台任务
+func (c *Container) GetReportWorker() services.ReportWorker {
+	return c.ReportWorker
+}
+
+// GetAccountExportWorker 获取账户数据导出后台任务
+func (c *Container) GetAccountExportWorker() services.AccountExportWorker {
+	return c.AccountExportWorker
+}
+
+// GetUploadCleanupWorker 获取过期直传上传凭证清理后台任务
+func (c *Container) GetUploadCleanupWorker() services.UploadCleanupWorker {
+	return c.UploadCleanupWorker
+}
+
+// GetWeatherController 获取天气控制器
+func (c *Container) GetWeatherController() *controllers.WeatherController {
+	return c.WeatherController
+}
+
 // GetOSSController 获取OSS控制器
 func (c *Container) GetOSSController() *controllers.OSSController {
 	return c.OSSController
 }
+
+// GetAttachmentController 获取附件控制器
+func (c *Container) GetAttachmentController() *controllers.AttachmentController {
+	return c.AttachmentController
+}
+
+// GetStorageController 获取本地存储文件控制器
+func (c *Container) GetStorageController() *controllers.StorageController {
+	return c.StorageController
+}
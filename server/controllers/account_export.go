@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"net/http"
+	"what-to-wear/server/api"
+	"what-to-wear/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountExportController 账户数据导出控制器
+type AccountExportController struct {
+	accountExportService services.AccountExportService
+}
+
+// NewAccountExportController 创建账户数据导出控制器实例
+func NewAccountExportController(accountExportService services.AccountExportService) *AccountExportController {
+	return &AccountExportController{
+		accountExportService: accountExportService,
+	}
+}
+
+// CreateExport 创建账户数据导出任务，压缩包生成后通过下载链接获取
+func (ac *AccountExportController) CreateExport(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	export, err := ac.accountExportService.CreateExport(c.Request.Context(), userID, false)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, api.Success(export, "数据导出任务已创建"))
+}
+
+// GetExports 获取导出任务列表
+func (ac *AccountExportController) GetExports(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	limit := parseIntQuery(c, "limit", 20)
+	if limit < 1 || limit > 100 {
+		c.JSON(http.StatusBadRequest, api.BadRequest("数量必须在1-100之间"))
+		return
+	}
+
+	exports, err := ac.accountExportService.GetExports(c.Request.Context(), userID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(exports, "获取导出任务列表成功"))
+}
+
+// GetExport 获取导出任务状态和进度
+func (ac *AccountExportController) GetExport(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	exportID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	export, err := ac.accountExportService.GetExport(c.Request.Context(), userID, exportID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(export, "获取导出任务成功"))
+}
+
+// DownloadExport 通过下载链接下载导出的压缩包，无需登录，链接过期后失效
+func (ac *AccountExportController) DownloadExport(c *gin.Context) {
+	export, err := ac.accountExportService.GetExportFile(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.FileAttachment(export.FilePath, export.FileName)
+}
+
+// DeleteExport 删除导出任务
+func (ac *AccountExportController) DeleteExport(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	exportID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := ac.accountExportService.DeleteExport(c.Request.Context(), userID, exportID); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(nil, "导出任务删除成功"))
+}
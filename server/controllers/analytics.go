@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"net/http"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsController 穿着分析控制器
+type AnalyticsController struct {
+	analyticsService  services.AnalyticsService
+	trendService      services.TrendService
+	wearRecordService services.WearRecordService
+}
+
+// NewAnalyticsController 创建穿着分析控制器实例
+func NewAnalyticsController(
+	analyticsService services.AnalyticsService,
+	trendService services.TrendService,
+	wearRecordService services.WearRecordService,
+) *AnalyticsController {
+	return &AnalyticsController{
+		analyticsService:  analyticsService,
+		trendService:      trendService,
+		wearRecordService: wearRecordService,
+	}
+}
+
+// GetWearFrequency 穿着频率分析
+func (ac *AnalyticsController) GetWearFrequency(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AnalyticsRequestDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("查询参数错误: "+err.Error()))
+		return
+	}
+
+	analytics, err := ac.analyticsService.GetWearFrequency(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(analytics, "获取穿着频率分析成功"))
+}
+
+// GetCostPerWear 每次穿着成本分析
+func (ac *AnalyticsController) GetCostPerWear(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AnalyticsRequestDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("查询参数错误: "+err.Error()))
+		return
+	}
+
+	analytics, err := ac.analyticsService.GetCostPerWear(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(analytics, "获取穿着成本分析成功"))
+}
+
+// GetTrends 趋势分析和预测
+func (ac *AnalyticsController) GetTrends(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.TrendRequestDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("查询参数错误: "+err.Error()))
+		return
+	}
+
+	trends, err := ac.trendService.GetTrends(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(trends, "获取趋势分析成功"))
+}
+
+// GetComfortAnalysis 舒适度分析
+func (ac *AnalyticsController) GetComfortAnalysis(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	analysis, err := ac.wearRecordService.GetComfortAnalysis(userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(analysis, "获取舒适度分析成功"))
+}
@@ -44,7 +44,7 @@ func (ac *AttachmentController) UploadAttachment(c *gin.Context) {
 
 	response, err := ac.attachmentService.UploadAttachment(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(api.StatusInternalServerError, api.InternalError(err.Error()))
+		handleServiceError(c, err)
 		return
 	}
 
@@ -53,6 +53,11 @@ func (ac *AttachmentController) UploadAttachment(c *gin.Context) {
 
 // GetAttachmentsByEntity 获取指定实体的附件列表
 func (ac *AttachmentController) GetAttachmentsByEntity(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
 	entityTypeStr := c.Param("entity_type")
 	entityType := api.EntityType(entityTypeStr)
 	if !entityType.IsValid() {
@@ -65,9 +70,9 @@ func (ac *AttachmentController) GetAttachmentsByEntity(c *gin.Context) {
 		return
 	}
 
-	attachments, err := ac.attachmentService.GetAttachmentsByEntity(c.Request.Context(), entityType, entityID)
+	attachments, err := ac.attachmentService.GetAttachmentsByEntity(c.Request.Context(), userID, entityType, entityID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		handleServiceError(c, err)
 		return
 	}
 
@@ -88,7 +93,7 @@ func (ac *AttachmentController) DeleteAttachment(c *gin.Context) {
 
 	err := ac.attachmentService.DeleteAttachment(c.Request.Context(), attachmentID, userID)
 	if err != nil {
-		c.JSON(api.StatusInternalServerError, api.InternalError(err.Error()))
+		handleServiceError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, api.Success(nil, "附件删除成功"))
@@ -96,15 +101,20 @@ func (ac *AttachmentController) DeleteAttachment(c *gin.Context) {
 
 // GetAttachmentInfo 获取附件详细信息
 func (ac *AttachmentController) GetAttachmentInfo(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
 	attachmentID, ok := parseUintParamRequired(c, "id")
 	if !ok {
 		return
 	}
 
 	// 获取基本附件信息
-	attachment, err := ac.attachmentService.GetAttachment(c.Request.Context(), attachmentID)
+	attachment, err := ac.attachmentService.GetAttachment(c.Request.Context(), attachmentID, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		handleServiceError(c, err)
 		return
 	}
 
@@ -131,13 +141,56 @@ func (ac *AttachmentController) UpdateAttachmentInfo(c *gin.Context) {
 
 	attachment, err := ac.attachmentService.UpdateAttachment(c.Request.Context(), attachmentID, userID, &req)
 	if err != nil {
-		c.JSON(api.StatusInternalServerError, api.InternalError(err.Error()))
+		handleServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, api.Success(attachment, "附件信息更新成功"))
 }
 
+// CreateUploadSlot 申请直传上传凭证，客户端凭返回的链接直接上传到存储
+func (ac *AttachmentController) CreateUploadSlot(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateUploadSlotDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	slot, err := ac.attachmentService.CreateUploadSlot(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, api.Success(slot, "上传凭证申请成功"))
+}
+
+// CompleteUpload 确认直传上传完成并创建附件
+func (ac *AttachmentController) CompleteUpload(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	slotID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	attachment, err := ac.attachmentService.CompleteUpload(c.Request.Context(), userID, slotID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(attachment, "附件上传成功"))
+}
+
 // BatchDeleteAttachments 批量删除附件
 func (ac *AttachmentController) BatchDeleteAttachments(c *gin.Context) {
 	userID, ok := getUserIDRequired(c)
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BudgetController 预算控制器
+type BudgetController struct {
+	budgetService services.BudgetService
+}
+
+// NewBudgetController 创建预算控制器实例
+func NewBudgetController(budgetService services.BudgetService) *BudgetController {
+	return &BudgetController{
+		budgetService: budgetService,
+	}
+}
+
+// CreateBudget 创建预算
+func (bc *BudgetController) CreateBudget(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateBudgetDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	budget, err := bc.budgetService.CreateBudget(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, api.Success(budget, "预算创建成功"))
+}
+
+// GetBudgets 获取预算列表
+func (bc *BudgetController) GetBudgets(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	budgets, err := bc.budgetService.GetBudgets(c.Request.Context(), userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(budgets, "获取预算成功"))
+}
+
+// GetBudget 获取预算详情
+func (bc *BudgetController) GetBudget(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	budgetID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	budget, err := bc.budgetService.GetBudget(c.Request.Context(), userID, budgetID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(budget, "获取预算成功"))
+}
+
+// UpdateBudget 更新预算
+func (bc *BudgetController) UpdateBudget(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	budgetID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateBudgetDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	budget, err := bc.budgetService.UpdateBudget(c.Request.Context(), userID, budgetID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(budget, "预算更新成功"))
+}
+
+// DeleteBudget 删除预算
+func (bc *BudgetController) DeleteBudget(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	budgetID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := bc.budgetService.DeleteBudget(c.Request.Context(), userID, budgetID); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(nil, "预算删除成功"))
+}
+
+// GetStatus 获取预算执行情况
+func (bc *BudgetController) GetStatus(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.BudgetStatusRequestDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("查询参数错误: "+err.Error()))
+		return
+	}
+
+	date := time.Now()
+	if req.Date != nil {
+		date = *req.Date
+	}
+
+	status, err := bc.budgetService.GetBudgetStatus(c.Request.Context(), userID, date)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(status, "获取预算执行情况成功"))
+}
+
+// GetAlerts 获取超出预算提醒
+func (bc *BudgetController) GetAlerts(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.BudgetAlertListDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("查询参数错误: "+err.Error()))
+		return
+	}
+
+	alerts, err := bc.budgetService.GetAlerts(c.Request.Context(), userID, req.Unread, req.Limit)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(alerts, "获取预算提醒成功"))
+}
+
+// MarkAlertRead 标记预算提醒为已读
+func (bc *BudgetController) MarkAlertRead(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	alertID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := bc.budgetService.MarkAlertRead(c.Request.Context(), userID, alertID); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(nil, "预算提醒已读"))
+}
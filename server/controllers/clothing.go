@@ -16,6 +16,7 @@ type ClothingController struct {
 	categoryService   services.ClothingCategoryService
 	tagService        services.ClothingTagService
 	wearRecordService services.WearRecordService
+	durabilityService services.DurabilityService
 }
 
 // NewClothingController 创建衣物控制器
@@ -24,12 +25,14 @@ func NewClothingController(
 	categoryService services.ClothingCategoryService,
 	tagService services.ClothingTagService,
 	wearRecordService services.WearRecordService,
+	durabilityService services.DurabilityService,
 ) *ClothingController {
 	return &ClothingController{
 		clothingService:   clothingService,
 		categoryService:   categoryService,
 		tagService:        tagService,
 		wearRecordService: wearRecordService,
+		durabilityService: durabilityService,
 	}
 }
 
@@ -54,7 +57,7 @@ func (cc *ClothingController) CreateClothingItem(c *gin.Context) {
 
 	item, err := cc.clothingService.CreateClothingItem(c.Request.Context(), userID, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		handleServiceError(c, err)
 		return
 	}
 
@@ -111,16 +114,72 @@ func (cc *ClothingController) GetClothingItems(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, api.BadRequest("无效的衣物状态"))
 		return
 	}
+	if req.TagMatch != "" && req.TagMatch != "any" && req.TagMatch != "all" {
+		c.JSON(http.StatusBadRequest, api.BadRequest("无效的标签匹配方式"))
+		return
+	}
+	if req.DateFrom != nil && req.DateTo != nil && req.DateFrom.After(*req.DateTo) {
+		c.JSON(http.StatusBadRequest, api.BadRequest("开始日期不能晚于结束日期"))
+		return
+	}
+	// 通用筛选：filters[key]=value
+	if len(req.Filters) == 0 {
+		req.Filters = c.QueryMap("filters")
+	}
 
 	items, total, err := cc.clothingService.GetClothingItems(c.Request.Context(), userID, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		handleServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, api.SuccessWithPage(items, int64(total), req.Page, req.PageSize, "获取衣物列表成功"))
 }
 
+// SearchClothingItems 高级搜索衣物
+func (cc *ClothingController) SearchClothingItems(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ClothingSearchDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("查询参数错误: "+err.Error()))
+		return
+	}
+	validatePagination(&req.Page, &req.PageSize)
+
+	if req.SortBy != "" && !isValidClothingSearchSortBy(req.SortBy) {
+		c.JSON(http.StatusBadRequest, api.BadRequest("无效的排序字段"))
+		return
+	}
+	if req.SortOrder != "" && !isValidSortOrder(req.SortOrder) {
+		c.JSON(http.StatusBadRequest, api.BadRequest("无效的排序方向"))
+		return
+	}
+
+	// 特定属性筛选：attrs[key]=value, attr_min[key]=number, attr_max[key]=number
+	req.Attributes = c.QueryMap("attrs")
+	var err error
+	if req.AttributeMin, err = parseFloatQueryMap(c, "attr_min"); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest(err.Error()))
+		return
+	}
+	if req.AttributeMax, err = parseFloatQueryMap(c, "attr_max"); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := cc.clothingService.SearchClothingItems(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(result, "搜索衣物成功"))
+}
+
 // UpdateClothingItem 更新衣物
 func (cc *ClothingController) UpdateClothingItem(c *gin.Context) {
 	userID, ok := getUserIDRequired(c)
@@ -147,7 +206,7 @@ func (cc *ClothingController) UpdateClothingItem(c *gin.Context) {
 
 	item, err := cc.clothingService.UpdateClothingItem(c.Request.Context(), userID, itemID, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		handleServiceError(c, err)
 		return
 	}
 
@@ -198,40 +257,114 @@ func (cc *ClothingController) BatchDeleteClothingItems(c *gin.Context) {
 		return
 	}
 
-	type BatchDeleteRequest struct {
-		ItemIDs []uint `json:"item_ids" binding:"required,min=1"`
+	var req dto.BatchItemsDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	result, err := cc.clothingService.BatchDeleteClothingItems(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(result, "批量删除完成"))
+}
+
+// BatchAddTags 批量为衣物添加标签
+func (cc *ClothingController) BatchAddTags(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.BatchTagsDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	result, err := cc.clothingService.BatchAddTags(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(result, "批量添加标签完成"))
+}
+
+// BatchRemoveTags 批量移除衣物标签
+func (cc *ClothingController) BatchRemoveTags(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.BatchTagsDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	result, err := cc.clothingService.BatchRemoveTags(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
 	}
 
-	var req BatchDeleteRequest
+	c.JSON(http.StatusOK, api.Success(result, "批量移除标签完成"))
+}
+
+// BatchMoveCategory 批量移动衣物分类
+func (cc *ClothingController) BatchMoveCategory(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.BatchCategoryDTO
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
 		return
 	}
 
-	// 逐个删除衣物
-	successCount := 0
-	var deleteErrors []string
+	result, err := cc.clothingService.BatchMoveCategory(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
 
-	for _, itemID := range req.ItemIDs {
-		if err := cc.clothingService.DeleteClothingItem(c.Request.Context(), userID, itemID); err != nil {
-			deleteErrors = append(deleteErrors, fmt.Sprintf("删除衣物 %d 失败: %v", itemID, err))
-		} else {
-			successCount++
-		}
+	c.JSON(http.StatusOK, api.Success(result, "批量移动分类完成"))
+}
+
+// BatchUpdateCondition 批量修改衣物状态
+func (cc *ClothingController) BatchUpdateCondition(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
 	}
 
-	result := map[string]interface{}{
-		"success_count": successCount,
-		"failure_count": len(req.ItemIDs) - successCount,
-		"errors":        deleteErrors,
+	var req dto.BatchConditionDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
 	}
 
-	c.JSON(http.StatusOK, api.Success(result, "批量删除完成"))
+	result, err := cc.clothingService.BatchUpdateCondition(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(result, "批量修改状态完成"))
 }
 
-// GetCategories 获取分类列表
+// GetCategories 获取分类列表，登录用户同时获取自己的自定义分类
 func (cc *ClothingController) GetCategories(c *gin.Context) {
-	categories, err := cc.categoryService.GetAllCategories(c.Request.Context())
+	userID := getUserID(c)
+
+	categories, err := cc.categoryService.GetAllCategories(c.Request.Context(), userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
 		return
@@ -240,9 +373,11 @@ func (cc *ClothingController) GetCategories(c *gin.Context) {
 	c.JSON(http.StatusOK, api.Success(categories, "获取分类列表成功"))
 }
 
-// GetCategoryTree 获取分类树
+// GetCategoryTree 获取分类树，登录用户的自定义分类合并在树中
 func (cc *ClothingController) GetCategoryTree(c *gin.Context) {
-	tree, err := cc.categoryService.GetCategoryTree(c.Request.Context())
+	userID := getUserID(c)
+
+	tree, err := cc.categoryService.GetCategoryTree(c.Request.Context(), userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
 		return
@@ -251,24 +386,66 @@ func (cc *ClothingController) GetCategoryTree(c *gin.Context) {
 	c.JSON(http.StatusOK, api.Success(tree, "获取分类树成功"))
 }
 
-// CreateCategory 创建分类
+// CreateCategory 创建系统分类（管理员）
 func (cc *ClothingController) CreateCategory(c *gin.Context) {
+	cc.createCategory(c, nil)
+}
+
+// UpdateCategory 更新系统分类（管理员）
+func (cc *ClothingController) UpdateCategory(c *gin.Context) {
+	cc.updateCategory(c, nil)
+}
+
+// DeleteCategory 删除系统分类（管理员）
+func (cc *ClothingController) DeleteCategory(c *gin.Context) {
+	cc.deleteCategory(c, nil)
+}
+
+// CreateCustomCategory 创建自定义分类
+func (cc *ClothingController) CreateCustomCategory(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+	cc.createCategory(c, &userID)
+}
+
+// UpdateCustomCategory 更新自定义分类
+func (cc *ClothingController) UpdateCustomCategory(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+	cc.updateCategory(c, &userID)
+}
+
+// DeleteCustomCategory 删除自定义分类
+func (cc *ClothingController) DeleteCustomCategory(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+	cc.deleteCategory(c, &userID)
+}
+
+// createCategory 创建分类，ownerID 为空时创建系统分类
+func (cc *ClothingController) createCategory(c *gin.Context, ownerID *uint) {
 	var req dto.CreateCategoryDTO
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
 		return
 	}
 
-	category, err := cc.categoryService.CreateCategory(c.Request.Context(), &req)
+	category, err := cc.categoryService.CreateCategory(c.Request.Context(), ownerID, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		handleServiceError(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, api.Success(category, "分类创建成功"))
 }
 
-// UpdateCategory 更新分类
-func (cc *ClothingController) UpdateCategory(c *gin.Context) {
+// updateCategory 更新分类
+func (cc *ClothingController) updateCategory(c *gin.Context, ownerID *uint) {
 	categoryID, ok := parseUintParamRequired(c, "id")
 	if !ok {
 		return
@@ -280,15 +457,53 @@ func (cc *ClothingController) UpdateCategory(c *gin.Context) {
 		return
 	}
 
-	category, err := cc.categoryService.UpdateCategory(c.Request.Context(), categoryID, &req)
+	category, err := cc.categoryService.UpdateCategory(c.Request.Context(), ownerID, categoryID, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		handleServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, api.Success(category, "分类更新成功"))
 }
 
+// deleteCategory 删除分类
+func (cc *ClothingController) deleteCategory(c *gin.Context, ownerID *uint) {
+	categoryID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.DeleteCategoryDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("查询参数错误: "+err.Error()))
+		return
+	}
+
+	if err := cc.categoryService.DeleteCategory(c.Request.Context(), ownerID, categoryID, req.ReassignTo); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(nil, "分类删除成功"))
+}
+
+// ReorderCategories 调整同级分类顺序
+func (cc *ClothingController) ReorderCategories(c *gin.Context) {
+	var req dto.ReorderCategoriesDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	categories, err := cc.categoryService.ReorderCategories(c.Request.Context(), &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(categories, "分类排序更新成功"))
+}
+
 // GetTags 获取标签列表
 func (cc *ClothingController) GetTags(c *gin.Context) {
 	userID := getUserID(c)
@@ -343,7 +558,7 @@ func (cc *ClothingController) CreateTag(c *gin.Context) {
 
 	tag, err := cc.tagService.CreateTag(c.Request.Context(), userID, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		handleServiceError(c, err)
 		return
 	}
 
@@ -370,13 +585,77 @@ func (cc *ClothingController) UpdateTag(c *gin.Context) {
 
 	tag, err := cc.tagService.UpdateTag(c.Request.Context(), userID, tagID, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		handleServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, api.Success(tag, "标签更新成功"))
 }
 
+// DeleteTag 删除标签，标签仍被衣物使用时需传 confirm=true
+func (cc *ClothingController) DeleteTag(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	tagID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	confirm := parseBoolQuery(c, "confirm", false)
+	result, err := cc.tagService.DeleteTag(c.Request.Context(), userID, tagID, confirm)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	if !result.Deleted {
+		c.JSON(http.StatusOK, api.Success(result, fmt.Sprintf("标签正被 %d 件衣物使用，确认后将从这些衣物上移除", result.AffectedItems)))
+		return
+	}
+	c.JSON(http.StatusOK, api.Success(result, "标签删除成功"))
+}
+
+// MergeTags 合并标签
+func (cc *ClothingController) MergeTags(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.MergeTagsDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	result, err := cc.tagService.MergeTags(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(result, "标签合并成功"))
+}
+
+// GetTagStats 获取标签使用统计
+func (cc *ClothingController) GetTagStats(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	stats, err := cc.tagService.GetTagStats(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(stats, "获取标签统计成功"))
+}
+
 // GetSystemTagEnumsByType 根据类型获取系统标签枚举（从内存）
 func (cc *ClothingController) GetSystemTagEnumsByType(c *gin.Context) {
 	tagType := c.Param("type")
@@ -409,6 +688,15 @@ func (cc *ClothingController) GetAllSystemTagEnums(c *gin.Context) {
 
 // RecordWear 记录穿着
 func (cc *ClothingController) RecordWear(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	itemID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
 
 	var req dto.CreateWearRecordDTO
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -416,5 +704,32 @@ func (cc *ClothingController) RecordWear(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, api.Success(nil, "穿着记录添加成功"))
+	record, err := cc.wearRecordService.CreateWearRecord(userID, itemID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, api.Success(record, "穿着记录添加成功"))
+}
+
+// GetDurabilityTimeline 获取衣物耐久度时间线
+func (cc *ClothingController) GetDurabilityTimeline(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	itemID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	timeline, err := cc.durabilityService.GetDurabilityTimeline(c.Request.Context(), userID, itemID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(timeline, "获取耐久度时间线成功"))
 }
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CurrencyController 货币控制器
+type CurrencyController struct {
+	currencyService services.CurrencyService
+}
+
+// NewCurrencyController 创建货币控制器实例
+func NewCurrencyController(currencyService services.CurrencyService) *CurrencyController {
+	return &CurrencyController{
+		currencyService: currencyService,
+	}
+}
+
+// GetCurrencies 获取本位币和支持的货币
+func (cc *CurrencyController) GetCurrencies(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	info, err := cc.currencyService.GetCurrencyInfo(c.Request.Context(), userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(info, "获取货币信息成功"))
+}
+
+// GetRates 获取指定日期的汇率
+func (cc *CurrencyController) GetRates(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ExchangeRatesRequestDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("查询参数错误: "+err.Error()))
+		return
+	}
+
+	date := time.Now()
+	if req.Date != nil {
+		date = *req.Date
+	}
+
+	rates, err := cc.currencyService.GetRates(c.Request.Context(), userID, req.Currency, date)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(rates, "获取汇率成功"))
+}
+
+// UpdateHomeCurrency 修改本位币，已有的购买价格和保养费用会重新折算
+func (cc *CurrencyController) UpdateHomeCurrency(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateHomeCurrencyDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	info, err := cc.currencyService.ChangeHomeCurrency(c.Request.Context(), userID, req.Currency)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(info, "本位币修改成功"))
+}
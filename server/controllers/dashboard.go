@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"net/http"
+	"what-to-wear/server/api"
+	"what-to-wear/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DashboardController 仪表板控制器
+type DashboardController struct {
+	dashboardService services.DashboardService
+}
+
+// NewDashboardController 创建仪表板控制器实例
+func NewDashboardController(dashboardService services.DashboardService) *DashboardController {
+	return &DashboardController{
+		dashboardService: dashboardService,
+	}
+}
+
+// GetDashboard 获取仪表板数据
+func (dc *DashboardController) GetDashboard(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	activityLimit := parseIntQuery(c, "activity_limit", 20)
+	if activityLimit < 1 || activityLimit > 100 {
+		c.JSON(http.StatusBadRequest, api.BadRequest("活动数量必须在1-100之间"))
+		return
+	}
+
+	dashboard, err := dc.dashboardService.GetDashboard(c.Request.Context(), userID, activityLimit)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(dashboard, "获取仪表板数据成功"))
+}
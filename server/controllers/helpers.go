@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	stderrors "errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"what-to-wear/server/api"
@@ -120,7 +122,7 @@ func isValidSortOrder(sortOrder string) bool {
 
 // isValidClothingSortBy 验证衣物排序字段
 func isValidClothingSortBy(sortBy string) bool {
-	validFields := []string{"name", "price", "wear_count", "durability_score", "created_at", "updated_at", "brand", "color"}
+	validFields := []string{"name", "price", "wear_count", "durability_score", "created_at", "updated_at", "brand", "color", "last_worn_date"}
 	for _, field := range validFields {
 		if sortBy == field {
 			return true
@@ -128,3 +130,76 @@ func isValidClothingSortBy(sortBy string) bool {
 	}
 	return false
 }
+
+// parseOptionalFloatQuery 解析可选的数字查询参数，未提供时返回 nil
+func parseOptionalFloatQuery(c *gin.Context, key string) (*float64, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	number, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s 必须为数字", key)
+	}
+	return &number, nil
+}
+
+// parseFloatQueryMap 解析 key[name]=number 形式的查询参数
+func parseFloatQueryMap(c *gin.Context, key string) (map[string]float64, error) {
+	raw := c.QueryMap(key)
+	result := make(map[string]float64, len(raw))
+	for name, value := range raw {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%s] 必须为数字", key, name)
+		}
+		result[name] = number
+	}
+	return result, nil
+}
+
+// isValidClothingSearchSortBy 验证衣物搜索排序字段
+func isValidClothingSearchSortBy(sortBy string) bool {
+	validFields := []string{"relevance", "name", "price", "wear_count", "durability_score", "created_at", "updated_at", "brand", "color", "last_worn_date"}
+	for _, field := range validFields {
+		if sortBy == field {
+			return true
+		}
+	}
+	return false
+}
+
+// handleServiceError 根据服务层错误类型返回对应的错误响应
+func handleServiceError(c *gin.Context, err error) {
+	var apiErr *errors.APIError
+	if stderrors.As(err, &apiErr) {
+		c.JSON(apiErr.Code, api.Error(apiErr.Code, apiErr.Error()))
+		return
+	}
+	c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+}
+
+// isValidOutfitSortBy 验证穿搭排序字段
+func isValidOutfitSortBy(sortBy string) bool {
+	validFields := []string{"date", "name", "rating", "temperature", "created_at", "updated_at"}
+	for _, field := range validFields {
+		if sortBy == field {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidSeason 验证季节（支持中文标签名和英文）
+func isValidSeason(season string) bool {
+	validSeasons := []string{
+		api.SeasonSpring, api.SeasonSummer, api.SeasonAutumn, api.SeasonWinter,
+		"spring", "summer", "autumn", "winter",
+	}
+	for _, valid := range validSeasons {
+		if season == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"net/http"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportExportController 衣橱导入导出控制器
+type ImportExportController struct {
+	importExportService services.ImportExportService
+}
+
+// NewImportExportController 创建衣橱导入导出控制器实例
+func NewImportExportController(importExportService services.ImportExportService) *ImportExportController {
+	return &ImportExportController{
+		importExportService: importExportService,
+	}
+}
+
+// ExportWardrobe 导出衣橱文件（csv、excel、json）
+func (ic *ImportExportController) ExportWardrobe(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+	req.Filters = c.QueryMap("filters")
+
+	file, err := ic.importExportService.ExportWardrobe(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+file.FileName+`"`)
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// ImportWardrobe 导入衣橱文件，返回逐行校验报告
+// 支持 multipart 上传文件，也支持通过 JSON 或表单中的 file_url 指定文件地址
+func (ic *ImportExportController) ImportWardrobe(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+	// JSON 请求的选项在请求体中，表单请求的选项为 options[key]=value
+	if len(req.Options) == 0 {
+		req.Options = c.PostFormMap("options")
+	}
+
+	result, err := ic.importExportService.ImportWardrobe(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	message := "导入完成"
+	if req.DryRun {
+		message = "导入校验完成"
+	}
+	c.JSON(http.StatusOK, api.Success(result, message))
+}
@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"net/http"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenanceController 衣物保养控制器
+type MaintenanceController struct {
+	maintenanceService services.MaintenanceService
+}
+
+// NewMaintenanceController 创建衣物保养控制器实例
+func NewMaintenanceController(maintenanceService services.MaintenanceService) *MaintenanceController {
+	return &MaintenanceController{
+		maintenanceService: maintenanceService,
+	}
+}
+
+// CreateRecord 为衣物添加保养记录
+func (mc *MaintenanceController) CreateRecord(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	itemID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateMaintenanceRecordDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	record, err := mc.maintenanceService.CreateMaintenanceRecord(c.Request.Context(), userID, itemID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, api.Success(record, "保养记录创建成功"))
+}
+
+// GetItemRecords 获取衣物的保养记录
+func (mc *MaintenanceController) GetItemRecords(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	itemID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	records, err := mc.maintenanceService.GetItemMaintenanceRecords(c.Request.Context(), userID, itemID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(records, "获取保养记录成功"))
+}
+
+// GetRecords 获取用户的保养记录列表
+func (mc *MaintenanceController) GetRecords(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	limit := parseIntQuery(c, "limit", 50)
+	records, err := mc.maintenanceService.GetMaintenanceRecords(c.Request.Context(), userID, limit)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(records, "获取保养记录成功"))
+}
+
+// GetRecord 获取保养记录详情
+func (mc *MaintenanceController) GetRecord(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	recordID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	record, err := mc.maintenanceService.GetMaintenanceRecord(c.Request.Context(), userID, recordID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(record, "获取保养记录成功"))
+}
+
+// UpdateRecord 更新保养记录
+func (mc *MaintenanceController) UpdateRecord(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	recordID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateMaintenanceRecordDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	record, err := mc.maintenanceService.UpdateMaintenanceRecord(c.Request.Context(), userID, recordID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(record, "保养记录更新成功"))
+}
+
+// DeleteRecord 删除保养记录
+func (mc *MaintenanceController) DeleteRecord(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	recordID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := mc.maintenanceService.DeleteMaintenanceRecord(c.Request.Context(), userID, recordID); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(nil, "保养记录删除成功"))
+}
+
+// GetReminders 获取保养提醒（已过期和指定天数内即将到期）
+func (mc *MaintenanceController) GetReminders(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	days := parseIntQuery(c, "days", 7)
+	if days < 1 || days > 365 {
+		c.JSON(http.StatusBadRequest, api.BadRequest("天数必须在1-365之间"))
+		return
+	}
+
+	overdue, err := mc.maintenanceService.GetOverdueMaintenance(c.Request.Context(), userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	upcoming, err := mc.maintenanceService.GetUpcomingMaintenance(c.Request.Context(), userID, days)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(dto.MaintenanceRemindersDTO{
+		Overdue:  overdue,
+		Upcoming: upcoming,
+	}, "获取保养提醒成功"))
+}
+
+// GetCostByType 获取按类型分组的保养费用
+func (mc *MaintenanceController) GetCostByType(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	costs, err := mc.maintenanceService.GetMaintenanceCostByType(c.Request.Context(), userID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(costs, "获取保养费用统计成功"))
+}
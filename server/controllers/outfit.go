@@ -0,0 +1,246 @@
+package controllers
+
+import (
+	"net/http"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OutfitController 穿搭控制器
+type OutfitController struct {
+	outfitService services.OutfitService
+}
+
+// NewOutfitController 创建穿搭控制器实例
+func NewOutfitController(outfitService services.OutfitService) *OutfitController {
+	return &OutfitController{
+		outfitService: outfitService,
+	}
+}
+
+// CreateOutfit 创建穿搭记录
+func (oc *OutfitController) CreateOutfit(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateOutfitDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+	if req.Weather != nil && !req.Weather.IsValid() {
+		c.JSON(http.StatusBadRequest, api.BadRequest("无效的天气类型"))
+		return
+	}
+
+	outfit, err := oc.outfitService.CreateOutfit(userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, api.Success(outfit, "穿搭创建成功"))
+}
+
+// GetOutfits 获取穿搭列表
+func (oc *OutfitController) GetOutfits(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.OutfitListDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("查询参数错误: "+err.Error()))
+		return
+	}
+	// 验证并设置默认值
+	validatePagination(&req.Page, &req.PageSize)
+
+	// 验证筛选参数
+	if req.Weather != nil && !req.Weather.IsValid() {
+		c.JSON(http.StatusBadRequest, api.BadRequest("无效的天气类型"))
+		return
+	}
+	if req.Rating != nil && !req.Rating.IsValid() {
+		c.JSON(http.StatusBadRequest, api.BadRequest("评分必须在1-5之间"))
+		return
+	}
+	if req.Season != "" && !isValidSeason(req.Season) {
+		c.JSON(http.StatusBadRequest, api.BadRequest("无效的季节"))
+		return
+	}
+	if req.DateFrom != nil && req.DateTo != nil && req.DateFrom.After(*req.DateTo) {
+		c.JSON(http.StatusBadRequest, api.BadRequest("开始日期不能晚于结束日期"))
+		return
+	}
+
+	// 验证排序参数
+	if req.SortBy != "" && !isValidOutfitSortBy(req.SortBy) {
+		c.JSON(http.StatusBadRequest, api.BadRequest("无效的排序字段"))
+		return
+	}
+	if req.SortOrder != "" && !isValidSortOrder(req.SortOrder) {
+		c.JSON(http.StatusBadRequest, api.BadRequest("无效的排序方向"))
+		return
+	}
+
+	outfits, total, err := oc.outfitService.ListOutfits(userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.SuccessWithPage(outfits, total, req.Page, req.PageSize, "获取穿搭列表成功"))
+}
+
+// GetOutfit 获取穿搭详情
+func (oc *OutfitController) GetOutfit(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	outfitID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	outfit, err := oc.outfitService.GetOutfit(userID, outfitID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(outfit, "获取穿搭详情成功"))
+}
+
+// UpdateOutfit 更新穿搭记录
+func (oc *OutfitController) UpdateOutfit(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	outfitID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateOutfitDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	outfit, err := oc.outfitService.UpdateOutfit(userID, outfitID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(outfit, "穿搭更新成功"))
+}
+
+// DeleteOutfit 删除穿搭记录
+func (oc *OutfitController) DeleteOutfit(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	outfitID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := oc.outfitService.DeleteOutfit(userID, outfitID); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(nil, "穿搭删除成功"))
+}
+
+// RateOutfit 评价穿搭
+func (oc *OutfitController) RateOutfit(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	outfitID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.RateOutfitDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	if err := oc.outfitService.RateOutfit(userID, outfitID, int(req.Rating), req.Notes); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(nil, "穿搭评价成功"))
+}
+
+// WearOutfit 穿着穿搭（为穿搭中的每件衣物记录一次穿着）
+func (oc *OutfitController) WearOutfit(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	outfitID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	// 请求体可为空，默认今天穿着
+	var req dto.WearOutfitDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+			return
+		}
+	}
+
+	outfit, err := oc.outfitService.WearOutfit(userID, outfitID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, api.Success(outfit, "穿着记录添加成功"))
+}
+
+// GetOutfitWears 获取穿搭的穿着记录
+func (oc *OutfitController) GetOutfitWears(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	outfitID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	limit := parseIntQuery(c, "limit", 100)
+	records, err := oc.outfitService.GetOutfitWears(userID, outfitID, limit)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(records, "获取穿着记录成功"))
+}
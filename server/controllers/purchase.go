@@ -0,0 +1,244 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PurchaseController 购买记录控制器
+type PurchaseController struct {
+	purchaseRecordService services.PurchaseRecordService
+}
+
+// NewPurchaseController 创建购买记录控制器实例
+func NewPurchaseController(purchaseRecordService services.PurchaseRecordService) *PurchaseController {
+	return &PurchaseController{
+		purchaseRecordService: purchaseRecordService,
+	}
+}
+
+// CreateRecord 为衣物添加购买记录
+func (pc *PurchaseController) CreateRecord(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	itemID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.CreatePurchaseRecordDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	record, err := pc.purchaseRecordService.CreatePurchaseRecord(c.Request.Context(), userID, itemID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, api.Success(record, "购买记录创建成功"))
+}
+
+// GetItemRecord 获取衣物的购买记录
+func (pc *PurchaseController) GetItemRecord(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	itemID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	record, err := pc.purchaseRecordService.GetItemPurchaseRecord(c.Request.Context(), userID, itemID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(record, "获取购买记录成功"))
+}
+
+// GetRecords 获取购买记录列表，可按商店或日期范围筛选
+func (pc *PurchaseController) GetRecords(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.PurchaseRecordListDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("查询参数错误: "+err.Error()))
+		return
+	}
+
+	hasDateRange := req.StartDate != nil || req.EndDate != nil
+	if req.Store != "" && hasDateRange {
+		c.JSON(http.StatusBadRequest, api.BadRequest("商店和日期范围不能同时指定"))
+		return
+	}
+
+	var (
+		records []dto.PurchaseRecordDTO
+		err     error
+	)
+	switch {
+	case req.Store != "":
+		records, err = pc.purchaseRecordService.GetPurchasesByStore(c.Request.Context(), userID, req.Store)
+	case hasDateRange:
+		var startDate time.Time
+		endDate := time.Now()
+		if req.StartDate != nil {
+			startDate = *req.StartDate
+		}
+		if req.EndDate != nil {
+			endDate = *req.EndDate
+		}
+		records, err = pc.purchaseRecordService.GetPurchasesByDateRange(c.Request.Context(), userID, startDate, endDate)
+	default:
+		limit := req.Limit
+		if limit == 0 {
+			limit = 50
+		}
+		records, err = pc.purchaseRecordService.GetPurchaseRecords(c.Request.Context(), userID, limit)
+	}
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(records, "获取购买记录成功"))
+}
+
+// GetRecord 获取购买记录详情
+func (pc *PurchaseController) GetRecord(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	recordID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	record, err := pc.purchaseRecordService.GetPurchaseRecord(c.Request.Context(), userID, recordID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(record, "获取购买记录成功"))
+}
+
+// UpdateRecord 更新购买记录
+func (pc *PurchaseController) UpdateRecord(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	recordID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdatePurchaseRecordDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	record, err := pc.purchaseRecordService.UpdatePurchaseRecord(c.Request.Context(), userID, recordID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(record, "购买记录更新成功"))
+}
+
+// DeleteRecord 删除购买记录
+func (pc *PurchaseController) DeleteRecord(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	recordID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := pc.purchaseRecordService.DeletePurchaseRecord(c.Request.Context(), userID, recordID); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(nil, "购买记录删除成功"))
+}
+
+// GetStats 获取支出统计
+func (pc *PurchaseController) GetStats(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	stats, err := pc.purchaseRecordService.GetSpendingStats(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(stats, "获取支出统计成功"))
+}
+
+// GetMonthlySpending 获取指定年份的月度支出
+func (pc *PurchaseController) GetMonthlySpending(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	year := parseIntQuery(c, "year", time.Now().Year())
+	if year < 1900 || year > 9999 {
+		c.JSON(http.StatusBadRequest, api.BadRequest("无效的年份"))
+		return
+	}
+
+	spending, err := pc.purchaseRecordService.GetSpendingByMonth(c.Request.Context(), userID, year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(spending, "获取月度支出成功"))
+}
+
+// GetCategorySpending 获取按分类分组的支出
+func (pc *PurchaseController) GetCategorySpending(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	spending, err := pc.purchaseRecordService.GetSpendingByCategory(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(spending, "获取分类支出成功"))
+}
@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecommendationController 穿搭推荐控制器
+type RecommendationController struct {
+	recommendationService services.RecommendationService
+	weatherService        services.WeatherService
+}
+
+// NewRecommendationController 创建穿搭推荐控制器实例
+func NewRecommendationController(
+	recommendationService services.RecommendationService,
+	weatherService services.WeatherService,
+) *RecommendationController {
+	return &RecommendationController{
+		recommendationService: recommendationService,
+		weatherService:        weatherService,
+	}
+}
+
+// GetRecommendations 根据天气获取穿搭推荐（直接传入天气或按城市查询）
+func (rc *RecommendationController) GetRecommendations(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	req := dto.ClothingRecommendationRequest{
+		Occasion: c.Query("occasion"),
+		Style:    c.Query("style"),
+		UserID:   userID,
+	}
+
+	if c.Query("temperature") != "" {
+		// 直接传入天气条件
+		condition := api.WeatherType(c.Query("condition"))
+		if !condition.IsValid() {
+			c.JSON(http.StatusBadRequest, api.BadRequest("无效的天气类型"))
+			return
+		}
+		current := dto.CurrentWeather{Condition: condition}
+		temperature, err := parseOptionalFloatQuery(c, "temperature")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, api.BadRequest(err.Error()))
+			return
+		}
+		current.Temperature = *temperature
+		if current.FeelsLike, err = parseOptionalFloatQuery(c, "feels_like"); err != nil {
+			c.JSON(http.StatusBadRequest, api.BadRequest(err.Error()))
+			return
+		}
+		windSpeed, err := parseOptionalFloatQuery(c, "wind_speed")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, api.BadRequest(err.Error()))
+			return
+		}
+		if windSpeed != nil {
+			current.WindSpeed = *windSpeed
+		}
+		if humidity := c.Query("humidity"); humidity != "" {
+			if current.Humidity, err = strconv.Atoi(humidity); err != nil {
+				c.JSON(http.StatusBadRequest, api.BadRequest("humidity 必须为整数"))
+				return
+			}
+		}
+		req.Weather = dto.WeatherResponse{Current: current}
+	} else {
+		// 根据城市或经纬度查询实时天气
+		weatherReq := dto.WeatherRequest{City: c.Query("city")}
+		latitude, err := parseOptionalFloatQuery(c, "latitude")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, api.BadRequest(err.Error()))
+			return
+		}
+		longitude, err := parseOptionalFloatQuery(c, "longitude")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, api.BadRequest(err.Error()))
+			return
+		}
+		if latitude != nil {
+			weatherReq.Latitude = *latitude
+		}
+		if longitude != nil {
+			weatherReq.Longitude = *longitude
+		}
+		if weatherReq.City == "" && weatherReq.Latitude == 0 && weatherReq.Longitude == 0 {
+			c.JSON(http.StatusBadRequest, api.BadRequest("请提供 temperature 和 condition，或 city / latitude,longitude"))
+			return
+		}
+		weather, err := rc.weatherService.GetWeather(c.Request.Context(), &weatherReq)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		req.Weather = *weather
+	}
+
+	if genderStr := c.Query("gender"); genderStr != "" {
+		gender := api.Gender(genderStr)
+		if !gender.IsValid() {
+			c.JSON(http.StatusBadRequest, api.BadRequest("无效的性别类型"))
+			return
+		}
+		req.Gender = &gender
+	}
+
+	recommendation, err := rc.recommendationService.GenerateRecommendation(c.Request.Context(), &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(recommendation, "获取穿搭推荐成功"))
+}
+
+// GetRecommendationHistory 获取推荐历史
+func (rc *RecommendationController) GetRecommendationHistory(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RecommendationListDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("查询参数错误: "+err.Error()))
+		return
+	}
+	validatePagination(&req.Page, &req.PageSize)
+	if req.Status != nil && !req.Status.IsValid() {
+		c.JSON(http.StatusBadRequest, api.BadRequest("无效的推荐状态"))
+		return
+	}
+
+	recommendations, total, err := rc.recommendationService.GetRecommendationHistory(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.SuccessWithPage(recommendations, total, req.Page, req.PageSize, "获取推荐历史成功"))
+}
+
+// GetRecommendation 获取单条推荐详情
+func (rc *RecommendationController) GetRecommendation(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+	recommendationID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	recommendation, err := rc.recommendationService.GetRecommendation(c.Request.Context(), userID, recommendationID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(recommendation, "获取推荐详情成功"))
+}
+
+// AcceptRecommendation 采纳推荐
+func (rc *RecommendationController) AcceptRecommendation(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+	recommendationID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	// 请求体可为空，默认采纳主推荐
+	var req dto.AcceptRecommendationDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+			return
+		}
+	}
+
+	recommendation, err := rc.recommendationService.AcceptRecommendation(c.Request.Context(), userID, recommendationID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(recommendation, "已采纳推荐并生成穿搭"))
+}
+
+// RejectRecommendation 拒绝推荐
+func (rc *RecommendationController) RejectRecommendation(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+	recommendationID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.RejectRecommendationDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	recommendation, err := rc.recommendationService.RejectRecommendation(c.Request.Context(), userID, recommendationID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(recommendation, "已拒绝推荐"))
+}
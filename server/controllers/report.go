@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"net/http"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportController 衣橱报告控制器
+type ReportController struct {
+	reportService services.ReportService
+}
+
+// NewReportController 创建衣橱报告控制器实例
+func NewReportController(reportService services.ReportService) *ReportController {
+	return &ReportController{
+		reportService: reportService,
+	}
+}
+
+// CreateReport 创建报告生成任务，文件生成后通过下载链接获取
+func (rc *ReportController) CreateReport(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReportDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	job, err := rc.reportService.CreateReport(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, api.Success(job, "报告任务已创建"))
+}
+
+// PreviewReport 同步获取报告数据
+func (rc *ReportController) PreviewReport(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReportDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	data, err := rc.reportService.GetReportData(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(data, "获取报告数据成功"))
+}
+
+// GetReports 获取报告任务列表
+func (rc *ReportController) GetReports(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	limit := parseIntQuery(c, "limit", 20)
+	if limit < 1 || limit > 100 {
+		c.JSON(http.StatusBadRequest, api.BadRequest("数量必须在1-100之间"))
+		return
+	}
+
+	jobs, err := rc.reportService.GetReports(c.Request.Context(), userID, limit)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(jobs, "获取报告列表成功"))
+}
+
+// GetReport 获取报告任务状态
+func (rc *ReportController) GetReport(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	jobID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	job, err := rc.reportService.GetReport(c.Request.Context(), userID, jobID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(job, "获取报告成功"))
+}
+
+// DownloadReport 下载报告文件
+func (rc *ReportController) DownloadReport(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	jobID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	job, err := rc.reportService.GetReportFile(c.Request.Context(), userID, jobID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.FileAttachment(job.FilePath, job.FileName)
+}
+
+// DeleteReport 删除报告
+func (rc *ReportController) DeleteReport(c *gin.Context) {
+	userID, ok := getUserIDRequired(c)
+	if !ok {
+		return
+	}
+
+	jobID, ok := parseUintParamRequired(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := rc.reportService.DeleteReport(c.Request.Context(), userID, jobID); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(nil, "报告删除成功"))
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	stderrors "errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"what-to-wear/server/api"
+	"what-to-wear/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StorageController 本地存储文件控制器
+type StorageController struct {
+	localStorage      services.LocalStorage
+	attachmentService services.AttachmentServiceInterface
+	maxUploadSize     int64
+}
+
+// NewStorageController 创建本地存储文件控制器实例，未使用本地存储时 localStorage 为 nil，maxUploadSize 单位为字节
+func NewStorageController(localStorage services.LocalStorage, attachmentService services.AttachmentServiceInterface, maxUploadSize int64) *StorageController {
+	return &StorageController{
+		localStorage:      localStorage,
+		attachmentService: attachmentService,
+		maxUploadSize:     maxUploadSize,
+	}
+}
+
+// ServeFile 通过签名链接下载本地存储的文件，无需登录，链接过期后失效
+func (sc *StorageController) ServeFile(c *gin.Context) {
+	if sc.localStorage == nil {
+		c.JSON(http.StatusNotFound, api.NotFound("文件不存在"))
+		return
+	}
+
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("无效的链接参数"))
+		return
+	}
+
+	filePath, err := sc.localStorage.Resolve(strings.TrimPrefix(c.Param("key"), "/"), expires, c.Query("signature"))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.File(filePath)
+}
+
+// UploadFile 通过签名链接直传文件到本地存储，无需登录，链接过期后失效
+func (sc *StorageController) UploadFile(c *gin.Context) {
+	if sc.localStorage == nil {
+		c.JSON(http.StatusNotFound, api.NotFound("文件不存在"))
+		return
+	}
+
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("无效的链接参数"))
+		return
+	}
+
+	contentType := c.GetHeader("Content-Type")
+	key, err := sc.localStorage.ResolveUpload(strings.TrimPrefix(c.Param("key"), "/"), contentType, expires, c.Query("signature"))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	// 上传大小不能超过申请凭证时声明的大小
+	limit, err := sc.attachmentService.GetPendingUploadSize(c.Request.Context(), key)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	if sc.maxUploadSize > 0 && sc.maxUploadSize < limit {
+		limit = sc.maxUploadSize
+	}
+	if c.Request.ContentLength > limit {
+		c.JSON(http.StatusRequestEntityTooLarge, api.Error(http.StatusRequestEntityTooLarge, "文件大小超过申请的大小"))
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+	if err := sc.localStorage.Put(c.Request.Context(), key, body, c.Request.ContentLength, contentType); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if stderrors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, api.Error(http.StatusRequestEntityTooLarge, "文件大小超过申请的大小"))
+			return
+		}
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(nil, "文件上传成功"))
+}
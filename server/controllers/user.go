@@ -111,9 +111,14 @@ func (uc *UserController) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	err = uc.userService.DeleteUser(c.Request.Context(), uint(targetUserID))
+	// export=true 时先导出账户数据，导出完成后再删除账户
+	export, err := uc.userService.DeleteUser(c.Request.Context(), uint(targetUserID), parseBoolQuery(c, "export", false))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, api.InternalError(err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+	if export != nil {
+		c.JSON(http.StatusAccepted, api.Success(export, "数据导出任务已创建，导出完成后将删除账户，请通过下载链接获取导出的数据"))
 		return
 	}
 
@@ -215,13 +220,3 @@ func (uc *UserController) GetPreferences(c *gin.Context) {
 
 	c.JSON(http.StatusOK, api.Success(preferences, "获取偏好设置成功"))
 }
-
-// ExportData 导出用户数据
-func (uc *UserController) ExportData(c *gin.Context) {
-	userID := getUserID(c)
-	if userID == 0 {
-		c.JSON(http.StatusUnauthorized, api.Unauthorized("未授权访问"))
-		return
-	}
-	c.JSON(http.StatusOK, api.Success(gin.H{"message": "数据导出请求已提交，将通过邮件发送下载链接"}, "数据导出请求成功"))
-}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"net/http"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WeatherController 天气控制器
+type WeatherController struct {
+	weatherService services.WeatherService
+}
+
+// NewWeatherController 创建天气控制器实例
+func NewWeatherController(weatherService services.WeatherService) *WeatherController {
+	return &WeatherController{
+		weatherService: weatherService,
+	}
+}
+
+// GetWeather 获取天气（城市或经纬度）
+func (wc *WeatherController) GetWeather(c *gin.Context) {
+	var req dto.WeatherRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请求参数错误: "+err.Error()))
+		return
+	}
+
+	if req.City == "" && req.Latitude == 0 && req.Longitude == 0 {
+		c.JSON(http.StatusBadRequest, api.BadRequest("请提供城市或经纬度"))
+		return
+	}
+	if !services.IsValidWeatherUnits(req.Units) {
+		c.JSON(http.StatusBadRequest, api.BadRequest("无效的单位，可选值: metric, imperial, kelvin"))
+		return
+	}
+
+	weather, err := wc.weatherService.GetWeather(c.Request.Context(), &req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Success(weather, "获取天气成功"))
+}
@@ -83,7 +83,7 @@ func SeedCategories(db *gorm.DB) error {
 	for _, seed := range categorySeeds {
 		if seed.ParentName == "" { // 一级分类
 			var existingCategory models.ClothingCategory
-			err := db.Where("name = ? AND parent_id IS NULL", seed.Name).First(&existingCategory).Error
+			err := db.Where("name = ? AND parent_id IS NULL AND user_id IS NULL", seed.Name).First(&existingCategory).Error
 
 			if err == gorm.ErrRecordNotFound {
 				newCategory := models.ClothingCategory{
@@ -113,7 +113,7 @@ func SeedCategories(db *gorm.DB) error {
 			}
 
 			var existingCategory models.ClothingCategory
-			err := db.Where("name = ? AND parent_id = ?", seed.Name, parentID).First(&existingCategory).Error
+			err := db.Where("name = ? AND parent_id = ? AND user_id IS NULL", seed.Name, parentID).First(&existingCategory).Error
 
 			if err == gorm.ErrRecordNotFound {
 				newCategory := models.ClothingCategory{
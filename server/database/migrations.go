@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"gorm.io/gorm"
 	"what-to-wear/server/models"
+	"what-to-wear/server/utils"
 )
 
 // AutoMigrate 自动迁移数据库表结构
@@ -19,20 +20,106 @@ func AutoMigrate(db *gorm.DB) error {
 		&models.ClothingItem{},
 		&models.Outfit{},
 		&models.OutfitItem{},
+		&models.OutfitRecommendation{},
 		&models.WearRecord{},
 		&models.MaintenanceRecord{},
 		&models.PurchaseRecord{},
 		&models.Attachment{},
+		&models.UploadSlot{},
+		&models.ReportJob{},
+		&models.AccountExport{},
+		&models.Budget{},
+		&models.BudgetAlert{},
 	)
 
 	if err != nil {
 		return fmt.Errorf("数据库迁移失败: %v", err)
 	}
 
+	if err := backfillHomeAmounts(db); err != nil {
+		return err
+	}
+	if err := backfillWearEventIDs(db); err != nil {
+		return err
+	}
+
+	if err := migrateClothingSearch(db); err != nil {
+		return err
+	}
+	if err := migrateCategoryOwnership(db); err != nil {
+		return err
+	}
+
 	fmt.Println("数据库迁移完成")
 	return nil
 }
 
+// backfillHomeAmounts 为引入多币种之前的记录补齐本位币金额（历史记录均为默认货币）
+func backfillHomeAmounts(db *gorm.DB) error {
+	statements := []string{
+		"UPDATE purchase_records SET home_price = price WHERE home_price = 0 AND price <> 0",
+		"UPDATE maintenance_records SET home_cost = cost WHERE home_cost = 0 AND cost <> 0",
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("补齐本位币金额失败: %v", err)
+		}
+	}
+	return nil
+}
+
+// backfillWearEventIDs 为引入穿着事件ID之前的记录补齐事件ID
+// 穿搭的一次穿着按穿搭、穿着时间和创建时间归为同一事件，单品的穿着记录各自为一个事件
+func backfillWearEventIDs(db *gorm.DB) error {
+	err := db.Exec(`UPDATE wear_records SET wear_event_id = md5(
+		COALESCE('outfit-' || outfit_id::text, 'record-' || id::text) || ':' || wear_date::text || ':' || created_at::text
+	) WHERE wear_event_id IS NULL OR wear_event_id = ''`).Error
+	if err != nil {
+		return fmt.Errorf("补齐穿着事件ID失败: %v", err)
+	}
+	return nil
+}
+
+// migrateClothingSearch 为衣物全文搜索分词建立 GIN 索引，并补齐已有衣物的分词
+func migrateClothingSearch(db *gorm.DB) error {
+	err := db.Exec("CREATE INDEX IF NOT EXISTS idx_clothing_items_search ON clothing_items USING GIN (to_tsvector('simple', COALESCE(search_tokens, '')))").Error
+	if err != nil {
+		return fmt.Errorf("创建衣物搜索索引失败: %v", err)
+	}
+
+	var items []models.ClothingItem
+	err = db.Where("search_tokens IS NULL OR search_tokens = ''").
+		FindInBatches(&items, 200, func(tx *gorm.DB, batch int) error {
+			for _, item := range items {
+				tokens := utils.SearchTokens(item.Name, item.Brand, item.Description, item.Notes)
+				if err := db.Model(&models.ClothingItem{}).Where("id = ?", item.ID).
+					UpdateColumn("search_tokens", tokens).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return fmt.Errorf("补齐衣物搜索分词失败: %v", err)
+	}
+	return nil
+}
+
+// migrateCategoryOwnership 分类名称改为按所有者唯一：系统分类之间、同一用户的自定义分类之间不重名
+func migrateCategoryOwnership(db *gorm.DB) error {
+	statements := []string{
+		"DROP INDEX IF EXISTS idx_clothing_categories_name",
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_clothing_categories_system_name ON clothing_categories (name) WHERE user_id IS NULL AND deleted_at IS NULL",
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_clothing_categories_user_name ON clothing_categories (user_id, name) WHERE user_id IS NOT NULL AND deleted_at IS NULL",
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("迁移分类名称索引失败: %v", err)
+		}
+	}
+	return nil
+}
+
 // MigrateSpecificModels 迁移指定的模型
 func MigrateSpecificModels(db *gorm.DB, models ...interface{}) error {
 	fmt.Printf("开始迁移指定模型 (%d个)...\n", len(models))
@@ -52,8 +139,14 @@ func DropAllTables(db *gorm.DB) error {
 
 	// 按依赖关系逆序删除表
 	tables := []interface{}{
+		&models.BudgetAlert{},
+		&models.Budget{},
+		&models.AccountExport{},
+		&models.ReportJob{},
+		&models.UploadSlot{},
 		&models.Attachment{},
 		&models.PurchaseRecord{},
+		&models.OutfitRecommendation{},
 		&models.MaintenanceRecord{},
 		&models.WearRecord{},
 		&models.OutfitItem{},
@@ -85,10 +178,16 @@ func CheckMigrationStatus(db *gorm.DB) error {
 		&models.ClothingItem{},
 		&models.Outfit{},
 		&models.OutfitItem{},
+		&models.OutfitRecommendation{},
 		&models.WearRecord{},
 		&models.MaintenanceRecord{},
 		&models.PurchaseRecord{},
 		&models.Attachment{},
+		&models.UploadSlot{},
+		&models.ReportJob{},
+		&models.AccountExport{},
+		&models.Budget{},
+		&models.BudgetAlert{},
 	}
 
 	for _, model := range models {
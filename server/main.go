@@ -17,6 +17,14 @@ func main() {
 	log := logger.GetLogger()
 	log.Info("Starting What-to-Wear server")
 
+	// 加载应用配置
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal("Failed to load config", logger.Fields{
+			"error": err.Error(),
+		})
+	}
+
 	// 初始化数据库（连接 + 迁移 + 种子数据）
 	if err := database.Initialize(); err != nil {
 		log.Fatal("Database initialization failed", logger.Fields{
@@ -26,9 +34,29 @@ func main() {
 	log.Info("Database initialized successfully")
 
 	// 创建依赖注入容器
-	appContainer := container.NewContainer(database.GetDB())
+	appContainer := container.NewContainer(cfg, database.GetDB())
 	log.Info("Dependency injection container initialized")
 
+	// 启动保养提醒调度器
+	maintenanceScheduler := appContainer.GetMaintenanceScheduler()
+	maintenanceScheduler.Start()
+	defer maintenanceScheduler.Stop()
+
+	// 启动报告生成后台任务
+	reportWorker := appContainer.GetReportWorker()
+	reportWorker.Start()
+	defer reportWorker.Stop()
+
+	// 启动账户数据导出后台任务
+	accountExportWorker := appContainer.GetAccountExportWorker()
+	accountExportWorker.Start()
+	defer accountExportWorker.Stop()
+
+	// 启动过期直传上传凭证清理后台任务
+	uploadCleanupWorker := appContainer.GetUploadCleanupWorker()
+	uploadCleanupWorker.Start()
+	defer uploadCleanupWorker.Stop()
+
 	// 创建Gin引擎
 	r := gin.New() // 使用gin.New()而不是gin.Default()来避免默认日志
 
@@ -37,6 +37,19 @@ func AuthMiddleware() gin.HandlerFunc {
 		// 将用户信息存储到上下文中
 		c.Set("user_id", claims.UserID)
 		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+		c.Next()
+	}
+}
+
+// AdminMiddleware 管理员权限中间件，需在 AuthMiddleware 之后使用
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.UserRole(c.GetString("role")) != api.UserRoleAdmin {
+			c.JSON(http.StatusForbidden, api.Forbidden("需要管理员权限"))
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
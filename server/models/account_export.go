@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"what-to-wear/server/api"
+
+	"gorm.io/gorm"
+)
+
+// AccountExport 账户数据导出任务模型，压缩包通过带令牌的链接下载，账户删除后仍可在有效期内下载
+type AccountExport struct {
+	gorm.Model
+	UserID        uint            `json:"user_id" gorm:"not null;index"`
+	Status        api.JobStatus   `json:"status" gorm:"not null;default:pending;index"`
+	Stage         api.ExportStage `json:"stage" gorm:"not null;default:queued"`
+	Progress      int             `json:"progress" gorm:"not null;default:0"`  // 进度百分比 0-100
+	DeleteAccount bool            `json:"delete_account" gorm:"default:false"` // 导出完成后删除账户
+	DownloadToken string          `json:"-" gorm:"uniqueIndex;not null"`       // 下载链接令牌
+	FileName      string          `json:"file_name"`                           // 下载时的文件名
+	FilePath      string          `json:"-"`                                   // 压缩包的存储路径
+	FileSize      int64           `json:"file_size"`                           // 文件大小(字节)
+	Error         string          `json:"error"`                               // 失败原因
+	CompletedAt   *time.Time      `json:"completed_at"`                        // 完成时间
+	ExpiresAt     *time.Time      `json:"expires_at" gorm:"index"`             // 下载链接过期时间
+}
+
+// TableName 指定表名
+func (AccountExport) TableName() string {
+	return "account_exports"
+}
+
+// IsDownloadable 压缩包是否可下载
+func (e *AccountExport) IsDownloadable(now time.Time) bool {
+	if e.Status != api.JobStatusCompleted || e.FilePath == "" {
+		return false
+	}
+	return e.ExpiresAt == nil || now.Before(*e.ExpiresAt)
+}
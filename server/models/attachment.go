@@ -38,9 +38,9 @@ type Attachment struct {
 	Thumbnail *string `json:"thumbnail"` // 缩略图URL
 
 	// 元数据
-	Description string            `json:"description"`               // 描述
-	Tags        []string          `json:"tags" gorm:"type:json"`     // 标签
-	Metadata    map[string]string `json:"metadata" gorm:"type:json"` // 额外元数据
+	Description string            `json:"description"`                               // 描述
+	Tags        []string          `json:"tags" gorm:"type:json;serializer:json"`     // 标签
+	Metadata    map[string]string `json:"metadata" gorm:"type:json;serializer:json"` // 额外元数据
 
 	// 状态信息
 	IsActive  bool `json:"is_active" gorm:"default:true"`  // 是否激活
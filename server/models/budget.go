@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"what-to-wear/server/api"
+
+	"gorm.io/gorm"
+)
+
+// Budget 服装预算模型，未指定分类时为总预算
+type Budget struct {
+	gorm.Model
+	UserID     uint             `json:"user_id" gorm:"not null;index"`
+	Name       string           `json:"name" gorm:"not null"`
+	Period     api.BudgetPeriod `json:"period" gorm:"not null"`
+	CategoryID *uint            `json:"category_id" gorm:"index"`
+	Amount     float64          `json:"amount" gorm:"type:decimal(12,2);not null"`
+	Currency   string           `json:"currency" gorm:"type:varchar(3);not null;default:'CNY'"` // 预算金额的货币，统计时按当日汇率折算为本位币
+}
+
+// TableName 指定表名
+func (Budget) TableName() string {
+	return "budgets"
+}
+
+// BudgetAlert 超出预算提醒，同一预算每个周期只提醒一次
+type BudgetAlert struct {
+	gorm.Model
+	UserID           uint      `json:"user_id" gorm:"not null;index"`
+	BudgetID         uint      `json:"budget_id" gorm:"not null;uniqueIndex:idx_budget_alert_period"`
+	PeriodStart      time.Time `json:"period_start" gorm:"not null;uniqueIndex:idx_budget_alert_period"`
+	PurchaseRecordID uint      `json:"purchase_record_id"` // 导致超出预算的购买记录
+	BudgetName       string    `json:"budget_name"`
+	PeriodLabel      string    `json:"period_label"`
+	Currency         string    `json:"currency" gorm:"type:varchar(3)"` // 以下金额使用的本位币
+	BudgetAmount     float64   `json:"budget_amount" gorm:"type:decimal(12,2)"`
+	Spent            float64   `json:"spent" gorm:"type:decimal(12,2)"`
+	IsRead           bool      `json:"is_read" gorm:"default:false;index"`
+}
+
+// TableName 指定表名
+func (BudgetAlert) TableName() string {
+	return "budget_alerts"
+}
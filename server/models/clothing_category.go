@@ -4,10 +4,12 @@ import (
 	"gorm.io/gorm"
 )
 
-// ClothingCategory 衣物分类模型
+// ClothingCategory 衣物分类模型，UserID 为空时为系统分类，否则为该用户的自定义分类。
+// 名称在系统分类之间、同一用户的自定义分类之间唯一（见迁移中的部分唯一索引）
 type ClothingCategory struct {
 	gorm.Model
-	Name        string `json:"name" gorm:"not null;uniqueIndex"`
+	UserID      *uint  `json:"user_id" gorm:"index"`
+	Name        string `json:"name" gorm:"not null"`
 	Description string `json:"description"`
 	ParentID    *uint  `json:"parent_id" gorm:"index"`
 	Icon        string `json:"icon"`
@@ -29,3 +31,13 @@ func (c *ClothingCategory) IsRootCategory() bool {
 func (c *ClothingCategory) HasParent() bool {
 	return c.ParentID != nil
 }
+
+// IsCustom 检查是否为用户自定义分类
+func (c *ClothingCategory) IsCustom() bool {
+	return c.UserID != nil
+}
+
+// IsVisibleTo 系统分类对所有用户可见，自定义分类只对创建者可见
+func (c *ClothingCategory) IsVisibleTo(userID uint) bool {
+	return c.UserID == nil || *c.UserID == userID
+}
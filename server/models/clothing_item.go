@@ -2,9 +2,11 @@ package models
 
 import (
 	"math"
+	"sort"
 	"time"
 
 	"what-to-wear/server/api"
+	"what-to-wear/server/utils"
 
 	"gorm.io/gorm"
 )
@@ -36,6 +38,15 @@ type SpecificAttributes struct {
 	Diameter float64 `json:"diameter,omitempty"` // 直径 (cm)
 }
 
+// 可用于搜索筛选的特定属性（JSON 键名）
+var (
+	SpecificTextAttributes    = []string{"sleeve", "neckline", "pattern", "thickness", "fit", "length", "rise", "leg", "shoe_type", "closure"}
+	SpecificNumericAttributes = []string{"inseam", "heel_height", "width", "diameter"}
+)
+
+// ClothingFilterFields 衣物列表通用筛选（filters[key]=value）支持的字段，按值精确匹配
+var ClothingFilterFields = []string{"brand", "color", "size", "material", "style", "condition"}
+
 // ClothingItem 衣物资产模型
 type ClothingItem struct {
 	gorm.Model
@@ -54,10 +65,11 @@ type ClothingItem struct {
 	WearCount          int                `json:"wear_count" gorm:"default:0"`
 	DurabilityScore    float64            `json:"durability_score" gorm:"default:100.0"`
 	LastWornDate       *time.Time         `json:"last_worn_date"`
-	SpecificAttributes SpecificAttributes `json:"specific_attributes" gorm:"type:json"`
+	SpecificAttributes SpecificAttributes `json:"specific_attributes" gorm:"type:json;serializer:json"`
 	Notes              string             `json:"notes"`
 	IsActive           bool               `json:"is_active" gorm:"default:true"`
 	IsFavorite         bool               `json:"is_favorite" gorm:"default:false"`
+	SearchTokens       string             `json:"-" gorm:"type:text"` // 名称、品牌、描述和备注的全文搜索分词
 }
 
 // TableName 指定表名
@@ -65,65 +77,115 @@ func (ClothingItem) TableName() string {
 	return "clothing_items"
 }
 
-// CalculateDurability 计算耐久度
-func (c *ClothingItem) CalculateDurability() float64 {
-	baseScore := 100.0
-
-	// 根据穿着次数计算磨损
-	wearDamage := float64(c.WearCount) * c.getWearDamageRate()
+// BeforeSave 保存前更新全文搜索分词
+func (c *ClothingItem) BeforeSave(tx *gorm.DB) error {
+	c.SearchTokens = utils.SearchTokens(c.Name, c.Brand, c.Description, c.Notes)
+	return nil
+}
 
-	// 根据时间计算自然磨损
-	timeDamage := c.getTimeDamage()
+// 耐久度计算参数
+const (
+	maxDurabilityScore         = 100.0
+	yearlyTimeDamage           = 5.0 // 每年自然磨损分数
+	DefaultWearDamageRate      = 1.0 // 默认每次穿着磨损分数
+	DurabilityEventCreated     = "created"
+	DurabilityEventWear        = "wear"
+	DurabilityEventMaintenance = "maintenance"
+	DurabilityEventNow         = "now"
+)
 
-	// 根据保养记录计算加分
-	maintenanceBonus := c.getMaintenanceBonus()
+// DurabilityPoint 耐久度时间线节点
+type DurabilityPoint struct {
+	Date       time.Time
+	EventType  string  // created, wear, maintenance, now
+	RecordID   uint    // 对应的穿着或保养记录ID
+	TimeDamage float64 // 距上一节点的自然磨损
+	Change     float64 // 本次事件对耐久度的影响（不含自然磨损）
+	Score      float64 // 事件发生后的耐久度
+}
 
-	// 根据材质计算耐久系数
-	materialFactor := c.getMaterialDurabilityFactor()
+// CalculateDurability 按时间顺序回放穿着和保养记录计算耐久度时间线
+// wearDamageRate 为分类穿着磨损率，由服务层根据分类提供；最后一个节点即当前耐久度
+func (c *ClothingItem) CalculateDurability(wearDamageRate float64, wears []WearRecord, maintenances []MaintenanceRecord, now time.Time) []DurabilityPoint {
+	type event struct {
+		date        time.Time
+		wear        *WearRecord
+		maintenance *MaintenanceRecord
+	}
 
-	durability := (baseScore - wearDamage - timeDamage + maintenanceBonus) * materialFactor
+	events := make([]event, 0, len(wears)+len(maintenances))
+	for i := range wears {
+		events = append(events, event{date: wears[i].WearDate, wear: &wears[i]})
+	}
+	for i := range maintenances {
+		events = append(events, event{date: maintenances[i].MaintenanceDate, maintenance: &maintenances[i]})
+	}
+	// 同一时间先计算穿着磨损再计算保养恢复
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].date.Equal(events[j].date) {
+			return events[i].wear != nil && events[j].wear == nil
+		}
+		return events[i].date.Before(events[j].date)
+	})
+
+	// 起点为购买日期，没有时使用创建时间，早于起点的记录会提前起点
+	start := c.CreatedAt
+	if c.PurchaseDate != nil {
+		start = *c.PurchaseDate
+	}
+	if len(events) > 0 && events[0].date.Before(start) {
+		start = events[0].date
+	}
 
-	// 确保耐久度在0-100之间
-	if durability < 0 {
-		durability = 0
-	} else if durability > 100 {
-		durability = 100
+	materialFactor := c.MaterialDurabilityFactor()
+	score := maxDurabilityScore
+	last := start
+	points := make([]DurabilityPoint, 0, len(events)+2)
+	points = append(points, DurabilityPoint{Date: start, EventType: DurabilityEventCreated, Score: score})
+
+	advance := func(date time.Time, eventType string, recordID uint, change float64) {
+		timeDamage := 0.0
+		if date.After(last) {
+			timeDamage = date.Sub(last).Hours() / 24 / 365 * yearlyTimeDamage / materialFactor
+			last = date
+		}
+		score = clampDurability(score - timeDamage + change)
+		points = append(points, DurabilityPoint{
+			Date:       date,
+			EventType:  eventType,
+			RecordID:   recordID,
+			TimeDamage: math.Round(timeDamage*100) / 100,
+			Change:     math.Round(change*100) / 100,
+			Score:      math.Round(score*100) / 100,
+		})
 	}
 
-	return math.Round(durability*100) / 100
-}
+	for _, e := range events {
+		if e.wear != nil {
+			advance(e.date, DurabilityEventWear, e.wear.ID, -wearDamageRate/materialFactor)
+		} else {
+			effect := e.maintenance.GetMaintenanceEffect() + e.maintenance.GetConditionEffect()
+			advance(e.date, DurabilityEventMaintenance, e.maintenance.ID, effect)
+		}
+	}
+	advance(now, DurabilityEventNow, 0, 0)
 
-// getWearDamageRate 获取穿着磨损率
-func (c *ClothingItem) getWearDamageRate() float64 {
-	// 由于移除了Category关联对象，这里使用默认磨损率
-	// 具体的分类相关逻辑可以在服务层通过CategoryID查询分类信息后处理
-	return 1.0 // 默认磨损率
+	return points
 }
 
-// getTimeDamage 获取时间磨损
-func (c *ClothingItem) getTimeDamage() float64 {
-	if c.PurchaseDate == nil {
+// clampDurability 确保耐久度在0-100之间
+func clampDurability(score float64) float64 {
+	if score < 0 {
 		return 0
 	}
-
-	// 计算购买至今的天数
-	daysSincePurchase := time.Since(*c.PurchaseDate).Hours() / 24
-
-	// 每年自然磨损5分
-	yearsSincePurchase := daysSincePurchase / 365
-	return yearsSincePurchase * 5.0
-}
-
-// getMaintenanceBonus 获取保养加分
-func (c *ClothingItem) getMaintenanceBonus() float64 {
-	// 这里简化处理，实际应该从数据库查询保养记录
-	// 每次保养可以恢复2-5分耐久度
-	// 由于移除了直接关联，这里返回默认值，实际使用时需要传入保养记录数量
-	return 0.0
+	if score > maxDurabilityScore {
+		return maxDurabilityScore
+	}
+	return score
 }
 
-// getMaterialDurabilityFactor 获取材质耐久系数
-func (c *ClothingItem) getMaterialDurabilityFactor() float64 {
+// MaterialDurabilityFactor 获取材质耐久系数，系数越高磨损越慢
+func (c *ClothingItem) MaterialDurabilityFactor() float64 {
 	materialFactors := map[string]float64{
 		"真皮":   1.2,
 		"羊毛":   1.1,
@@ -140,32 +202,6 @@ func (c *ClothingItem) getMaterialDurabilityFactor() float64 {
 	return 1.0 // 默认系数
 }
 
-// UpdateDurability 更新耐久度
-func (c *ClothingItem) UpdateDurability(db *gorm.DB) error {
-	c.DurabilityScore = c.CalculateDurability()
-	return db.Model(c).Update("durability_score", c.DurabilityScore).Error
-}
-
-// IncrementWearCount 增加穿着次数
-func (c *ClothingItem) IncrementWearCount(db *gorm.DB) error {
-	c.WearCount++
-	now := time.Now()
-	c.LastWornDate = &now
-
-	// 更新穿着次数和最后穿着时间
-	err := db.Model(c).Updates(map[string]interface{}{
-		"wear_count":     c.WearCount,
-		"last_worn_date": c.LastWornDate,
-	}).Error
-
-	if err != nil {
-		return err
-	}
-
-	// 重新计算耐久度
-	return c.UpdateDurability(db)
-}
-
 // GetCostPerWear 获取每次穿着成本
 func (c *ClothingItem) GetCostPerWear() float64 {
 	if c.WearCount == 0 {
@@ -13,6 +13,8 @@ type MaintenanceRecord struct {
 	ClothingItemID      uint                `json:"clothing_item_id" gorm:"not null;index"`
 	MaintenanceType     api.MaintenanceType `json:"maintenance_type" gorm:"not null"`
 	Cost                float64             `json:"cost" gorm:"type:decimal(10,2);default:0"`
+	Currency            string              `json:"currency" gorm:"type:varchar(3);not null;default:'CNY'"`
+	HomeCost            float64             `json:"home_cost" gorm:"type:decimal(12,2);not null;default:0"` // 按保养日汇率折算的本位币费用
 	MaintenanceDate     time.Time           `json:"maintenance_date" gorm:"not null"`
 	ServiceProvider     string              `json:"service_provider"`                     // 服务提供商（如干洗店名称）
 	ServiceLocation     string              `json:"service_location"`                     // 服务地点
@@ -20,10 +22,12 @@ type MaintenanceRecord struct {
 	AfterCondition      *api.ClothingStatus `json:"after_condition"`                      // 保养后状态
 	EffectivenessScore  int                 `json:"effectiveness_score" gorm:"default:0"` // 保养效果评分 (1-10)
 	Notes               string              `json:"notes"`
-	Images              []string            `json:"images" gorm:"type:json"`            // 保养前后对比图
-	NextMaintenanceDate *time.Time          `json:"next_maintenance_date"`              // 下次保养建议时间
-	IsScheduled         bool                `json:"is_scheduled" gorm:"default:false"`  // 是否为计划保养
-	ReminderSent        bool                `json:"reminder_sent" gorm:"default:false"` // 是否已发送提醒
+	Images              []string            `json:"images" gorm:"type:json;serializer:json"` // 保养前后对比图
+	NextMaintenanceDate *time.Time          `json:"next_maintenance_date"`                   // 下次保养建议时间
+	IsScheduled         bool                `json:"is_scheduled" gorm:"default:false"`       // 是否为计划保养
+	ReminderSent        bool                `json:"reminder_sent" gorm:"default:false"`      // 是否已发送提醒
+	ReminderAttempts    int                 `json:"-" gorm:"default:0"`                      // 提醒发送失败次数
+	ReminderRetryAt     *time.Time          `json:"-" gorm:"index"`                          // 提醒发送失败后的下次重试时间
 }
 
 // TableName 指定表名
@@ -78,14 +82,34 @@ func (m *MaintenanceRecord) GetMaintenanceEffect() float64 {
 	return effect
 }
 
-// BeforeCreate GORM钩子：创建前自动计算下次保养时间
+// GetConditionEffect 获取保养前后状态变化对耐久度的影响
+// 保养后变为损坏时扣分，从损坏状态修复时额外加分
+func (m *MaintenanceRecord) GetConditionEffect() float64 {
+	if m.BeforeCondition == nil || m.AfterCondition == nil || *m.BeforeCondition == *m.AfterCondition {
+		return 0
+	}
+
+	switch {
+	case *m.AfterCondition == api.ClothingStatusDamaged:
+		return -20.0
+	case *m.BeforeCondition == api.ClothingStatusDamaged:
+		return 10.0
+	default:
+		return 0
+	}
+}
+
+// BeforeCreate GORM钩子：未指定下次保养时间时自动计算
 func (m *MaintenanceRecord) BeforeCreate(tx *gorm.DB) error {
-	m.CalculateNextMaintenanceDate()
+	if m.NextMaintenanceDate == nil {
+		m.CalculateNextMaintenanceDate()
+	}
 	return nil
 }
 
-// BeforeUpdate GORM钩子：更新前重新计算下次保养时间
-func (m *MaintenanceRecord) BeforeUpdate(tx *gorm.DB) error {
-	m.CalculateNextMaintenanceDate()
-	return nil
+// ResetReminder 下次保养日期变化后重置提醒状态
+func (m *MaintenanceRecord) ResetReminder() {
+	m.ReminderSent = false
+	m.ReminderAttempts = 0
+	m.ReminderRetryAt = nil
 }
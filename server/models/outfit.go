@@ -21,19 +21,38 @@ type Outfit struct {
 	Rating      *api.OutfitRating `json:"rating"`
 	RatingNotes string            `json:"rating_notes"`
 	Notes       string            `json:"notes"`
-	Tags        []string          `json:"tags" gorm:"type:json"`
+	Tags        []string          `json:"tags" gorm:"type:json;serializer:json"`
 	IsPublic    bool              `json:"is_public" gorm:"default:false"`
 }
 
 // OutfitRecommendation 穿搭推荐模型
 type OutfitRecommendation struct {
 	gorm.Model
-	UserID           uint             `json:"user_id" gorm:"not null;index"`
-	RecommendedItems []uint           `json:"recommended_items" gorm:"type:json"` // 推荐的衣物ID列表
-	Weather          *api.WeatherType `json:"weather"`
-	Temperature      *float64         `json:"temperature"`
-	Occasion         string           `json:"occasion"`
-	Confidence       float64          `json:"confidence"`                         // 推荐置信度
-	Reason           string           `json:"reason"`                             // 推荐理由
-	AlternativeItems [][]uint         `json:"alternative_items" gorm:"type:json"` // 替代选择的衣物ID列表
+	UserID           uint                     `json:"user_id" gorm:"not null;index"`
+	RecommendedItems []uint                   `json:"recommended_items" gorm:"type:json;serializer:json"` // 推荐的衣物ID列表
+	Weather          *api.WeatherType         `json:"weather"`
+	Temperature      *float64                 `json:"temperature"`
+	Occasion         string                   `json:"occasion"`
+	Confidence       float64                  `json:"confidence"`                                         // 推荐置信度
+	Reason           string                   `json:"reason"`                                             // 推荐理由
+	AlternativeItems [][]uint                 `json:"alternative_items" gorm:"type:json;serializer:json"` // 替代选择的衣物ID列表
+	ItemLayers       []RecommendedItemLayer   `json:"item_layers" gorm:"type:json;serializer:json"`       // 推荐衣物的角色和层次
+	Status           api.RecommendationStatus `json:"status" gorm:"default:pending;index"`
+	OutfitID         *uint                    `json:"outfit_id" gorm:"index"`                          // 采纳后生成的穿搭ID
+	AcceptedItems    []uint                   `json:"accepted_items" gorm:"type:json;serializer:json"` // 实际采纳的衣物ID
+	RejectReason     string                   `json:"reject_reason"`                                   // 拒绝原因
+	RejectedItems    []uint                   `json:"rejected_items" gorm:"type:json;serializer:json"` // 用户明确不喜欢的衣物ID
+	RespondedAt      *time.Time               `json:"responded_at"`
+}
+
+// RecommendedItemLayer 推荐衣物的角色和层次
+type RecommendedItemLayer struct {
+	ClothingItemID uint         `json:"clothing_item_id"`
+	Role           api.ItemRole `json:"role"`
+	Layer          int          `json:"layer"`
+}
+
+// IsPending 是否待处理
+func (r *OutfitRecommendation) IsPending() bool {
+	return r.Status == "" || r.Status == api.RecommendationStatusPending
 }
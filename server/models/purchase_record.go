@@ -11,7 +11,9 @@ type PurchaseRecord struct {
 	gorm.Model
 	ClothingItemID uint      `json:"clothing_item_id" gorm:"not null;uniqueIndex"`
 	Price          float64   `json:"price" gorm:"type:decimal(10,2);not null"` // 实际购买价格
-	Store          string    `json:"store"`                                    // 商店名称（线上或线下）
+	Currency       string    `json:"currency" gorm:"type:varchar(3);not null;default:'CNY'"`
+	HomePrice      float64   `json:"home_price" gorm:"type:decimal(12,2);not null;default:0"` // 按购买日汇率折算的本位币价格
+	Store          string    `json:"store"`                                                   // 商店名称（线上或线下）
 	PurchaseDate   time.Time `json:"purchase_date" gorm:"not null"`
 	Notes          string    `json:"notes"` // 备注信息（可包含折扣、原价等信息）
 }
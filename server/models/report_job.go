@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"what-to-wear/server/api"
+
+	"gorm.io/gorm"
+)
+
+// ReportJob 报告生成任务模型
+type ReportJob struct {
+	gorm.Model
+	UserID      uint             `json:"user_id" gorm:"not null;index"`
+	Type        api.ReportType   `json:"type" gorm:"not null"`
+	Format      api.ReportFormat `json:"format" gorm:"not null"`
+	Sections    []string         `json:"sections" gorm:"type:json;serializer:json"`
+	StartDate   time.Time        `json:"start_date" gorm:"not null"`
+	EndDate     time.Time        `json:"end_date" gorm:"not null"`
+	Status      api.JobStatus    `json:"status" gorm:"not null;default:pending;index"`
+	FileName    string           `json:"file_name"`               // 下载时的文件名
+	FilePath    string           `json:"-"`                       // 生成文件的存储路径
+	FileSize    int64            `json:"file_size"`               // 文件大小(字节)
+	Error       string           `json:"error"`                   // 失败原因
+	CompletedAt *time.Time       `json:"completed_at"`            // 完成时间
+	ExpiresAt   *time.Time       `json:"expires_at" gorm:"index"` // 文件过期时间
+}
+
+// TableName 指定表名
+func (ReportJob) TableName() string {
+	return "report_jobs"
+}
+
+// IsDownloadable 报告文件是否可下载
+func (j *ReportJob) IsDownloadable(now time.Time) bool {
+	if j.Status != api.JobStatusCompleted || j.FilePath == "" {
+		return false
+	}
+	return j.ExpiresAt == nil || now.Before(*j.ExpiresAt)
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"what-to-wear/server/api"
+
+	"gorm.io/gorm"
+)
+
+// UploadSlot 直传上传凭证，客户端凭预签名链接直接上传到存储，确认后创建附件记录
+type UploadSlot struct {
+	gorm.Model
+	UserID          uint             `json:"user_id" gorm:"not null;index"`
+	EntityType      api.EntityType   `json:"entity_type" gorm:"not null"`                  // 关联实体类型
+	EntityID        uint             `json:"entity_id" gorm:"not null"`                    // 关联实体ID
+	Status          api.UploadStatus `json:"status" gorm:"not null;default:pending;index"` // 凭证状态
+	StorageProvider string           `json:"storage_provider" gorm:"not null"`             // 存储提供商
+	BucketName      string           `json:"bucket_name"`                                  // 存储桶名称
+	ObjectKey       string           `json:"object_key" gorm:"uniqueIndex;not null"`       // 对象键
+	OriginalName    string           `json:"original_name" gorm:"not null"`                // 原始文件名
+	FileName        string           `json:"file_name" gorm:"not null"`                    // 存储文件名
+	MimeType        string           `json:"mime_type" gorm:"not null"`                    // 声明的MIME类型
+	FileSize        int64            `json:"file_size" gorm:"not null"`                    // 声明的文件大小(字节)，上传的文件不能超过
+	Description     string           `json:"description"`                                  // 附件描述
+	Tags            []string         `json:"tags" gorm:"type:json;serializer:json"`        // 附件标签
+	IsPublic        bool             `json:"is_public" gorm:"default:false"`               // 附件是否公开
+	SortOrder       int              `json:"sort_order" gorm:"default:0"`                  // 附件排序
+	AttachmentID    *uint            `json:"attachment_id"`                                // 确认后创建的附件ID
+	ExpiresAt       time.Time        `json:"expires_at" gorm:"not null;index"`             // 上传凭证过期时间
+	CompletedAt     *time.Time       `json:"completed_at"`                                 // 确认时间
+}
+
+// TableName 指定表名
+func (UploadSlot) TableName() string {
+	return "upload_slots"
+}
+
+// IsExpired 上传凭证是否已过期
+func (s *UploadSlot) IsExpired(now time.Time) bool {
+	return s.Status == api.UploadStatusExpired || (s.Status == api.UploadStatusPending && !now.Before(s.ExpiresAt))
+}
@@ -9,14 +9,16 @@ import (
 
 type User struct {
 	gorm.Model
-	Username  string      `json:"username" gorm:"uniqueIndex;not null" binding:"required"`
-	Password  string      `json:"password" gorm:"not null" binding:"required"`
-	Email     string      `json:"email" gorm:"uniqueIndex;not null" binding:"required,email"`
-	Nickname  string      `json:"nickname" gorm:"not null"`
-	Gender    api.Gender  `json:"gender" gorm:"type:varchar(10)"`
-	BirthDate *time.Time  `json:"birth_date"`
-	Height    *int        `json:"height"`
-	Weight    *int        `json:"weight"`
+	Username     string       `json:"username" gorm:"uniqueIndex;not null" binding:"required"`
+	Password     string       `json:"password" gorm:"not null" binding:"required"`
+	Email        string       `json:"email" gorm:"uniqueIndex;not null" binding:"required,email"`
+	Nickname     string       `json:"nickname" gorm:"not null"`
+	Gender       api.Gender   `json:"gender" gorm:"type:varchar(10)"`
+	BirthDate    *time.Time   `json:"birth_date"`
+	Height       *int         `json:"height"`
+	Weight       *int         `json:"weight"`
+	HomeCurrency string       `json:"home_currency" gorm:"type:varchar(3);not null;default:'CNY'"` // 本位币，统计金额均折算为该货币
+	Role         api.UserRole `json:"role" gorm:"type:varchar(20);not null;default:'user'"`
 }
 
 // TableName 指定表名
@@ -3,15 +3,27 @@ package models
 import (
 	"time"
 
+	"what-to-wear/server/api"
+
 	"gorm.io/gorm"
 )
 
-// WearRecord 穿着记录模型 - 简化版
+// WearRecord 穿着记录模型
 type WearRecord struct {
 	gorm.Model
-	ClothingItemID uint      `json:"clothing_item_id" gorm:"not null;index"`
-	WearDate       time.Time `json:"wear_date" gorm:"not null"`
-	Notes          string    `json:"notes"` // 备注信息（可包含场合、天气、评分等）
+	ClothingItemID        uint             `json:"clothing_item_id" gorm:"not null;index"`
+	OutfitID              *uint            `json:"outfit_id" gorm:"index"`             // 通过穿搭记录的穿着，关联穿搭ID
+	WearEventID           string           `json:"wear_event_id" gorm:"size:36;index"` // 同一次穿着的记录共享的事件ID
+	WearDate              time.Time        `json:"wear_date" gorm:"not null"`
+	ComfortRating         *int             `json:"comfort_rating"`         // 舒适度评分 1-5
+	StyleRating           *int             `json:"style_rating"`           // 风格评分 1-5
+	AppropriatenessRating *int             `json:"appropriateness_rating"` // 场合得体度评分 1-5
+	DurationHours         *float64         `json:"duration_hours"`         // 穿着时长（小时）
+	Temperature           *float64         `json:"temperature"`
+	WeatherCondition      *api.WeatherType `json:"weather_condition" gorm:"index"`
+	Occasion              string           `json:"occasion" gorm:"index"`
+	Location              string           `json:"location"`
+	Notes                 string           `json:"notes"`
 }
 
 // TableName 指定表名
@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AccountExportRepository 账户数据导出任务仓库接口
+type AccountExportRepository interface {
+	// 基础CRUD操作
+	Create(ctx context.Context, export *models.AccountExport) error
+	GetByID(ctx context.Context, id uint) (*models.AccountExport, error)
+	GetByUserID(ctx context.Context, userID uint, limit int) ([]models.AccountExport, error)
+	GetByToken(ctx context.Context, token string) (*models.AccountExport, error)
+	Update(ctx context.Context, export *models.AccountExport) error
+	Delete(ctx context.Context, id uint) error
+
+	// 更新任务的处理阶段和进度
+	UpdateProgress(ctx context.Context, id uint, stage api.ExportStage, progress int) error
+	// 用户是否有排队中或处理中的任务
+	HasActive(ctx context.Context, userID uint) (bool, error)
+
+	// 任务调度
+	// 领取排队中的任务并标记为处理中，多实例部署时已被锁定的任务会被跳过
+	ClaimPending(ctx context.Context, limit int) ([]models.AccountExport, error)
+	// 将长时间未更新进度的处理中任务重新放回队列
+	RequeueStale(ctx context.Context, before time.Time) (int64, error)
+	// 获取下载链接已过期的任务
+	GetExpired(ctx context.Context, before time.Time, limit int) ([]models.AccountExport, error)
+}
+
+// accountExportRepository 账户数据导出任务仓库实现
+type accountExportRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountExportRepository 创建账户数据导出任务仓库实例
+func NewAccountExportRepository(db *gorm.DB) AccountExportRepository {
+	return &accountExportRepository{db: db}
+}
+
+// Create 创建导出任务
+func (r *accountExportRepository) Create(ctx context.Context, export *models.AccountExport) error {
+	return r.db.WithContext(ctx).Create(export).Error
+}
+
+// GetByID 根据ID获取导出任务
+func (r *accountExportRepository) GetByID(ctx context.Context, id uint) (*models.AccountExport, error) {
+	var export models.AccountExport
+	err := r.db.WithContext(ctx).First(&export, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// GetByUserID 获取用户的导出任务，按创建时间倒序
+func (r *accountExportRepository) GetByUserID(ctx context.Context, userID uint, limit int) ([]models.AccountExport, error) {
+	var exports []models.AccountExport
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&exports).Error
+	return exports, err
+}
+
+// GetByToken 根据下载令牌获取导出任务
+func (r *accountExportRepository) GetByToken(ctx context.Context, token string) (*models.AccountExport, error) {
+	var export models.AccountExport
+	err := r.db.WithContext(ctx).Where("download_token = ?", token).First(&export).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// Update 更新导出任务
+func (r *accountExportRepository) Update(ctx context.Context, export *models.AccountExport) error {
+	return r.db.WithContext(ctx).Save(export).Error
+}
+
+// Delete 删除导出任务
+func (r *accountExportRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.AccountExport{}, id).Error
+}
+
+// UpdateProgress 更新处理阶段和进度，同时刷新更新时间以免被当作超时任务重新排队
+func (r *accountExportRepository) UpdateProgress(ctx context.Context, id uint, stage api.ExportStage, progress int) error {
+	return r.db.WithContext(ctx).Model(&models.AccountExport{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"stage": stage, "progress": progress}).Error
+}
+
+// HasActive 检查用户是否有未结束的导出任务
+func (r *accountExportRepository) HasActive(ctx context.Context, userID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.AccountExport{}).
+		Where("user_id = ? AND status IN ?", userID, []api.JobStatus{api.JobStatusPending, api.JobStatusProcessing}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ClaimPending 领取排队中的任务
+func (r *accountExportRepository) ClaimPending(ctx context.Context, limit int) ([]models.AccountExport, error) {
+	var exports []models.AccountExport
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", api.JobStatusPending).
+			Order("created_at ASC")
+		if limit > 0 {
+			query = query.Limit(limit)
+		}
+		if err := query.Find(&exports).Error; err != nil {
+			return err
+		}
+		if len(exports) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(exports))
+		for i := range exports {
+			ids = append(ids, exports[i].ID)
+			exports[i].Status = api.JobStatusProcessing
+		}
+		return tx.Model(&models.AccountExport{}).
+			Where("id IN ?", ids).
+			Update("status", api.JobStatusProcessing).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+// RequeueStale 重新排队超时的处理中任务
+func (r *accountExportRepository) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.AccountExport{}).
+		Where("status = ? AND updated_at < ?", api.JobStatusProcessing, before).
+		Updates(map[string]interface{}{"status": api.JobStatusPending, "stage": api.ExportStageQueued, "progress": 0})
+	return result.RowsAffected, result.Error
+}
+
+// GetExpired 获取已过期的任务
+func (r *accountExportRepository) GetExpired(ctx context.Context, before time.Time, limit int) ([]models.AccountExport, error) {
+	var exports []models.AccountExport
+	query := r.db.WithContext(ctx).
+		Where("expires_at IS NOT NULL AND expires_at < ?", before).
+		Order("expires_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&exports).Error
+	return exports, err
+}
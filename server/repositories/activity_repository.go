@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"what-to-wear/server/api"
+
+	"gorm.io/gorm"
+)
+
+// ActivityRecord 用户活动记录
+type ActivityRecord struct {
+	ID        uint
+	Type      api.EntityType
+	Name      string // 衣物或穿搭名称
+	Detail    string // 品牌、场合、商店、保养类型或备注
+	ItemCount int    // 穿搭穿着时涉及的单品数量
+	CreatedAt time.Time
+}
+
+// recentActivityQuery 合并各类记录的最近活动，每个子查询先各自截取前N条
+// 通过穿搭记录的穿着会为每件单品生成一条记录，这里按穿搭和穿着时间合并为一条活动
+const recentActivityQuery = `
+(SELECT id, 'clothing_item' AS type, name, brand AS detail, 0 AS item_count, created_at
+	FROM clothing_items
+	WHERE user_id = @user AND deleted_at IS NULL
+	ORDER BY created_at DESC LIMIT @limit)
+UNION ALL
+(SELECT id, 'outfit' AS type, name, occasion AS detail, 0 AS item_count, created_at
+	FROM outfits
+	WHERE user_id = @user AND deleted_at IS NULL
+	ORDER BY created_at DESC LIMIT @limit)
+UNION ALL
+(SELECT p.id, 'purchase' AS type, c.name, p.store AS detail, 0 AS item_count, p.created_at
+	FROM purchase_records p JOIN clothing_items c ON c.id = p.clothing_item_id
+	WHERE c.user_id = @user AND p.deleted_at IS NULL AND c.deleted_at IS NULL
+	ORDER BY p.created_at DESC LIMIT @limit)
+UNION ALL
+(SELECT m.id, 'maintenance' AS type, c.name, m.maintenance_type AS detail, 0 AS item_count, m.created_at
+	FROM maintenance_records m JOIN clothing_items c ON c.id = m.clothing_item_id
+	WHERE c.user_id = @user AND m.deleted_at IS NULL AND c.deleted_at IS NULL
+	ORDER BY m.created_at DESC LIMIT @limit)
+UNION ALL
+(SELECT w.id, 'wear_record' AS type, c.name, w.notes AS detail, 0 AS item_count, w.created_at
+	FROM wear_records w JOIN clothing_items c ON c.id = w.clothing_item_id
+	WHERE c.user_id = @user AND w.outfit_id IS NULL AND w.deleted_at IS NULL AND c.deleted_at IS NULL
+	ORDER BY w.created_at DESC LIMIT @limit)
+UNION ALL
+(SELECT MIN(w.id) AS id, 'wear_record' AS type, o.name, o.occasion AS detail, COUNT(*) AS item_count, MAX(w.created_at) AS created_at
+	FROM wear_records w JOIN outfits o ON o.id = w.outfit_id
+	WHERE o.user_id = @user AND w.deleted_at IS NULL AND o.deleted_at IS NULL
+	GROUP BY o.id, o.name, o.occasion, w.wear_date
+	ORDER BY MAX(w.created_at) DESC LIMIT @limit)
+ORDER BY created_at DESC, id DESC
+LIMIT @limit`
+
+// ActivityRepository 用户活动仓库接口
+type ActivityRepository interface {
+	// 获取衣物、穿搭、购买、保养和穿着记录合并后按时间倒序的最近活动
+	GetRecentActivity(ctx context.Context, userID uint, limit int) ([]ActivityRecord, error)
+}
+
+// activityRepository 用户活动仓库实现
+type activityRepository struct {
+	db *gorm.DB
+}
+
+// NewActivityRepository 创建用户活动仓库实例
+func NewActivityRepository(db *gorm.DB) ActivityRepository {
+	return &activityRepository{db: db}
+}
+
+// GetRecentActivity 在一次查询中获取最近活动
+func (r *activityRepository) GetRecentActivity(ctx context.Context, userID uint, limit int) ([]ActivityRecord, error) {
+	var records []ActivityRecord
+	err := r.db.WithContext(ctx).Raw(recentActivityQuery, map[string]interface{}{
+		"user":  userID,
+		"limit": limit,
+	}).Scan(&records).Error
+	return records, err
+}
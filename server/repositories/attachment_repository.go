@@ -17,7 +17,10 @@ type AttachmentRepository interface {
 
 	// 查询操作
 	GetByEntityID(ctx context.Context, entityType api.EntityType, entityID uint) ([]models.Attachment, error)
+	GetByEntityIDs(ctx context.Context, entityType api.EntityType, entityIDs []uint) ([]models.Attachment, error)
 	GetByUserID(ctx context.Context, userID uint, limit int) ([]models.Attachment, error)
+	// 获取用户的所有附件，包括已停用的附件
+	GetAllByUserID(ctx context.Context, userID uint) ([]models.Attachment, error)
 	GetByType(ctx context.Context, attachmentType api.AttachmentType, limit int) ([]models.Attachment, error)
 
 	// 统计操作
@@ -69,6 +72,18 @@ func (r *attachmentRepository) GetByEntityID(ctx context.Context, entityType api
 	return attachments, err
 }
 
+// GetByEntityIDs 批量获取多个实体的附件
+func (r *attachmentRepository) GetByEntityIDs(ctx context.Context, entityType api.EntityType, entityIDs []uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	if len(entityIDs) == 0 {
+		return attachments, nil
+	}
+	err := r.db.WithContext(ctx).Where("entity_type = ? AND entity_id IN ? AND is_active = ?", entityType, entityIDs, true).
+		Order("sort_order ASC, created_at ASC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
 // GetByUserID 根据用户ID获取附件
 func (r *attachmentRepository) GetByUserID(ctx context.Context, userID uint, limit int) ([]models.Attachment, error) {
 	var attachments []models.Attachment
@@ -83,6 +98,13 @@ func (r *attachmentRepository) GetByUserID(ctx context.Context, userID uint, lim
 	return attachments, err
 }
 
+// GetAllByUserID 获取用户的所有附件
+func (r *attachmentRepository) GetAllByUserID(ctx context.Context, userID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	err := r.db.WithContext(ctx).Unscoped().Where("user_id = ?", userID).Find(&attachments).Error
+	return attachments, err
+}
+
 // GetByType 根据附件类型获取附件
 func (r *attachmentRepository) GetByType(ctx context.Context, attachmentType api.AttachmentType, limit int) ([]models.Attachment, error) {
 	var attachments []models.Attachment
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"what-to-wear/server/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BudgetRepository 预算仓库接口
+type BudgetRepository interface {
+	// 基础CRUD操作
+	Create(ctx context.Context, budget *models.Budget) error
+	GetByID(ctx context.Context, id uint) (*models.Budget, error)
+	GetByUserID(ctx context.Context, userID uint) ([]models.Budget, error)
+	Update(ctx context.Context, budget *models.Budget) error
+	Delete(ctx context.Context, id uint) error
+
+	// 超出预算提醒
+	// 创建提醒，同一预算同一周期已有提醒时忽略并返回 false
+	CreateAlert(ctx context.Context, alert *models.BudgetAlert) (bool, error)
+	GetAlertByID(ctx context.Context, id uint) (*models.BudgetAlert, error)
+	GetAlerts(ctx context.Context, userID uint, unreadOnly bool, limit int) ([]models.BudgetAlert, error)
+	MarkAlertRead(ctx context.Context, id uint) error
+}
+
+// budgetRepository 预算仓库实现
+type budgetRepository struct {
+	db *gorm.DB
+}
+
+// NewBudgetRepository 创建预算仓库实例
+func NewBudgetRepository(db *gorm.DB) BudgetRepository {
+	return &budgetRepository{db: db}
+}
+
+// Create 创建预算
+func (r *budgetRepository) Create(ctx context.Context, budget *models.Budget) error {
+	return r.db.WithContext(ctx).Create(budget).Error
+}
+
+// GetByID 根据ID获取预算
+func (r *budgetRepository) GetByID(ctx context.Context, id uint) (*models.Budget, error) {
+	var budget models.Budget
+	err := r.db.WithContext(ctx).First(&budget, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &budget, nil
+}
+
+// GetByUserID 获取用户的全部预算，按创建时间排序
+func (r *budgetRepository) GetByUserID(ctx context.Context, userID uint) ([]models.Budget, error) {
+	var budgets []models.Budget
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at ASC").Find(&budgets).Error
+	return budgets, err
+}
+
+// Update 更新预算
+func (r *budgetRepository) Update(ctx context.Context, budget *models.Budget) error {
+	return r.db.WithContext(ctx).Save(budget).Error
+}
+
+// Delete 删除预算
+func (r *budgetRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.Budget{}, id).Error
+}
+
+// CreateAlert 创建超出预算提醒
+func (r *budgetRepository) CreateAlert(ctx context.Context, alert *models.BudgetAlert) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(alert)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetAlertByID 根据ID获取提醒
+func (r *budgetRepository) GetAlertByID(ctx context.Context, id uint) (*models.BudgetAlert, error) {
+	var alert models.BudgetAlert
+	err := r.db.WithContext(ctx).First(&alert, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// GetAlerts 获取用户的提醒，按创建时间倒序
+func (r *budgetRepository) GetAlerts(ctx context.Context, userID uint, unreadOnly bool, limit int) ([]models.BudgetAlert, error) {
+	var alerts []models.BudgetAlert
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("is_read = ?", false)
+	}
+	query = query.Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&alerts).Error
+	return alerts, err
+}
+
+// MarkAlertRead 标记提醒为已读
+func (r *budgetRepository) MarkAlertRead(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&models.BudgetAlert{}).
+		Where("id = ?", id).
+		Update("is_read", true).Error
+}
@@ -12,17 +12,31 @@ type ClothingCategoryRepository interface {
 	// 基础CRUD操作
 	Create(ctx context.Context, category *models.ClothingCategory) error
 	GetByID(ctx context.Context, id uint) (*models.ClothingCategory, error)
-	GetAll(ctx context.Context) ([]models.ClothingCategory, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]models.ClothingCategory, error)
 	Update(ctx context.Context, category *models.ClothingCategory) error
 	Delete(ctx context.Context, id uint) error
 
-	// 层级查询
-	GetRootCategories(ctx context.Context) ([]models.ClothingCategory, error)
-	GetChildCategories(ctx context.Context, parentID uint) ([]models.ClothingCategory, error)
-	GetCategoryTree(ctx context.Context) ([]models.ClothingCategory, error)
+	// 用户可见的分类（系统分类和该用户的自定义分类），userID 为 0 时只有系统分类
+	GetAll(ctx context.Context, userID uint) ([]models.ClothingCategory, error)
+	GetRootCategories(ctx context.Context, userID uint) ([]models.ClothingCategory, error)
+	GetChildCategories(ctx context.Context, parentID, userID uint) ([]models.ClothingCategory, error)
+	GetCategoryTree(ctx context.Context, userID uint) ([]models.ClothingCategory, error)
+	// 可见分类中是否已有同名分类
+	NameExists(ctx context.Context, name string, userID, excludeID uint) (bool, error)
 
 	// 统计
 	GetCategoryItemCount(ctx context.Context, categoryID uint) (int64, error)
+	// 分类下的全部衣物数量，包括已停用的衣物
+	CountAllItems(ctx context.Context, categoryID uint) (int64, error)
+	// 所有用户的子分类数量
+	CountChildren(ctx context.Context, parentID uint) (int64, error)
+
+	// 管理
+	ReassignItems(ctx context.Context, fromID, toID uint) (int64, error)
+	UpdateSortOrders(ctx context.Context, categoryIDs []uint) error
+
+	// 返回使用指定事务的仓库
+	WithTx(tx *gorm.DB) ClothingCategoryRepository
 }
 
 // clothingCategoryRepository 衣物分类仓库实现
@@ -35,6 +49,11 @@ func NewClothingCategoryRepository(db *gorm.DB) ClothingCategoryRepository {
 	return &clothingCategoryRepository{db: db}
 }
 
+// WithTx 返回使用指定事务的仓库
+func (r *clothingCategoryRepository) WithTx(tx *gorm.DB) ClothingCategoryRepository {
+	return &clothingCategoryRepository{db: tx}
+}
+
 // Create 创建分类
 func (r *clothingCategoryRepository) Create(ctx context.Context, category *models.ClothingCategory) error {
 	return r.db.WithContext(ctx).Create(category).Error
@@ -50,10 +69,20 @@ func (r *clothingCategoryRepository) GetByID(ctx context.Context, id uint) (*mod
 	return &category, nil
 }
 
-// GetAll 获取所有分类
-func (r *clothingCategoryRepository) GetAll(ctx context.Context) ([]models.ClothingCategory, error) {
+// GetByIDs 根据ID批量获取分类
+func (r *clothingCategoryRepository) GetByIDs(ctx context.Context, ids []uint) ([]models.ClothingCategory, error) {
 	var categories []models.ClothingCategory
-	err := r.db.WithContext(ctx).Where("is_active = ?", true).
+	if len(ids) == 0 {
+		return categories, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&categories).Error
+	return categories, err
+}
+
+// GetAll 获取用户可见的所有分类
+func (r *clothingCategoryRepository) GetAll(ctx context.Context, userID uint) ([]models.ClothingCategory, error) {
+	var categories []models.ClothingCategory
+	err := r.db.WithContext(ctx).Scopes(visibleCategories(userID)).Where("is_active = ?", true).
 		Order("sort_order ASC, name ASC").
 		Find(&categories).Error
 	return categories, err
@@ -72,27 +101,27 @@ func (r *clothingCategoryRepository) Delete(ctx context.Context, id uint) error
 }
 
 // GetRootCategories 获取根分类
-func (r *clothingCategoryRepository) GetRootCategories(ctx context.Context) ([]models.ClothingCategory, error) {
+func (r *clothingCategoryRepository) GetRootCategories(ctx context.Context, userID uint) ([]models.ClothingCategory, error) {
 	var categories []models.ClothingCategory
-	err := r.db.WithContext(ctx).Where("parent_id IS NULL AND is_active = ?", true).
+	err := r.db.WithContext(ctx).Scopes(visibleCategories(userID)).Where("parent_id IS NULL AND is_active = ?", true).
 		Order("sort_order ASC, name ASC").
 		Find(&categories).Error
 	return categories, err
 }
 
 // GetChildCategories 获取子分类
-func (r *clothingCategoryRepository) GetChildCategories(ctx context.Context, parentID uint) ([]models.ClothingCategory, error) {
+func (r *clothingCategoryRepository) GetChildCategories(ctx context.Context, parentID, userID uint) ([]models.ClothingCategory, error) {
 	var categories []models.ClothingCategory
-	err := r.db.WithContext(ctx).Where("parent_id = ? AND is_active = ?", parentID, true).
+	err := r.db.WithContext(ctx).Scopes(visibleCategories(userID)).Where("parent_id = ? AND is_active = ?", parentID, true).
 		Order("sort_order ASC, name ASC").
 		Find(&categories).Error
 	return categories, err
 }
 
 // GetCategoryTree 获取分类树
-func (r *clothingCategoryRepository) GetCategoryTree(ctx context.Context) ([]models.ClothingCategory, error) {
+func (r *clothingCategoryRepository) GetCategoryTree(ctx context.Context, userID uint) ([]models.ClothingCategory, error) {
 	var categories []models.ClothingCategory
-	err := r.db.WithContext(ctx).Where("is_active = ?", true).
+	err := r.db.WithContext(ctx).Scopes(visibleCategories(userID)).Where("is_active = ?", true).
 		Order("COALESCE(parent_id, 0) ASC, sort_order ASC, name ASC").
 		Find(&categories).Error
 	return categories, err
@@ -101,8 +130,66 @@ func (r *clothingCategoryRepository) GetCategoryTree(ctx context.Context) ([]mod
 // GetCategoryItemCount 获取分类下的衣物数量
 func (r *clothingCategoryRepository) GetCategoryItemCount(ctx context.Context, categoryID uint) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Where(&models.ClothingItem{}).
+	err := r.db.WithContext(ctx).Model(&models.ClothingItem{}).
 		Where("category_id = ? AND is_active = ?", categoryID, true).
 		Count(&count).Error
 	return count, err
 }
+
+// CountAllItems 获取分类下的全部衣物数量，包括已停用的衣物
+func (r *clothingCategoryRepository) CountAllItems(ctx context.Context, categoryID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.ClothingItem{}).
+		Where("category_id = ?", categoryID).
+		Count(&count).Error
+	return count, err
+}
+
+// NameExists 检查用户可见的启用分类中是否已有同名分类
+func (r *clothingCategoryRepository) NameExists(ctx context.Context, name string, userID, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.ClothingCategory{}).
+		Scopes(visibleCategories(userID)).
+		Where("name = ? AND is_active = ? AND id <> ?", name, true, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CountChildren 获取子分类数量，包含所有用户的自定义子分类
+func (r *clothingCategoryRepository) CountChildren(ctx context.Context, parentID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.ClothingCategory{}).
+		Where("parent_id = ? AND is_active = ?", parentID, true).
+		Count(&count).Error
+	return count, err
+}
+
+// visibleCategories 系统分类和指定用户的自定义分类
+func visibleCategories(userID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id IS NULL OR user_id = ?", userID)
+	}
+}
+
+// ReassignItems 将分类下的所有衣物改到另一分类，返回改动的衣物数量
+func (r *clothingCategoryRepository) ReassignItems(ctx context.Context, fromID, toID uint) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.ClothingItem{}).
+		Where("category_id = ?", fromID).
+		Update("category_id", toID)
+	return result.RowsAffected, result.Error
+}
+
+// UpdateSortOrders 按给定顺序设置分类的排序值
+func (r *clothingCategoryRepository) UpdateSortOrders(ctx context.Context, categoryIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, id := range categoryIDs {
+			err := tx.Model(&models.ClothingCategory{}).
+				Where("id = ?", id).
+				Update("sort_order", i).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -3,11 +3,14 @@ package repositories
 import (
 	"context"
 	"fmt"
-	"strings"
+	"slices"
+	"what-to-wear/server/api"
 	"what-to-wear/server/api/dto"
 	"what-to-wear/server/models"
+	"what-to-wear/server/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ClothingItemRepository 衣物仓库接口
@@ -15,10 +18,16 @@ type ClothingItemRepository interface {
 	// 基础CRUD操作
 	Create(ctx context.Context, item *models.ClothingItem) error
 	GetByID(ctx context.Context, id uint) (*models.ClothingItem, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]models.ClothingItem, error)
 	GetByUserID(ctx context.Context, userID uint, req *dto.ClothingItemListDTO) ([]models.ClothingItem, int64, error)
 	Update(ctx context.Context, item *models.ClothingItem) error
 	Delete(ctx context.Context, id uint) error
 
+	// 批量操作：每个方法作为一个整体生效或失败
+	BatchUpdate(ctx context.Context, itemIDs []uint, updates map[string]interface{}) error
+	BatchAddTags(ctx context.Context, itemIDs, tagIDs []uint) error
+	BatchRemoveTags(ctx context.Context, itemIDs, tagIDs []uint) error
+
 	// 高级查询
 	GetByCategory(ctx context.Context, userID, categoryID uint, limit int) ([]models.ClothingItem, error)
 	GetByTags(ctx context.Context, userID uint, tagIDs []uint, limit int) ([]models.ClothingItem, error)
@@ -26,23 +35,30 @@ type ClothingItemRepository interface {
 	GetRecentlyAdded(ctx context.Context, userID uint, limit int) ([]models.ClothingItem, error)
 	GetMostWorn(ctx context.Context, userID uint, limit int) ([]models.ClothingItem, error)
 	GetLeastWorn(ctx context.Context, userID uint, limit int) ([]models.ClothingItem, error)
+	GetAllActive(ctx context.Context, userID uint) ([]models.ClothingItem, error)
+	GetAllByUserID(ctx context.Context, userID uint) ([]models.ClothingItem, error)
 
 	// 统计查询
 	GetCategoryStats(ctx context.Context, userID uint) ([]dto.CategoryStatsItem, error)
 	GetBrandStats(ctx context.Context, userID uint) ([]dto.BrandStatsItem, error)
 	GetColorStats(ctx context.Context, userID uint) ([]dto.ColorStatsItem, error)
 
-	// 搜索
-	Search(ctx context.Context, userID uint, query string, limit int) ([]models.ClothingItem, error)
+	// 搜索：全文检索与筛选，分面统计基于同样的筛选条件
+	Search(ctx context.Context, userID uint, req *dto.ClothingSearchDTO) ([]models.ClothingItem, int64, error)
+	GetSearchFacets(ctx context.Context, userID uint, req *dto.ClothingSearchDTO) (*dto.SearchFacetsDTO, error)
 
 	// 标签关联
 	AddTags(ctx context.Context, itemID uint, tagIDs []uint) error
 	RemoveTags(ctx context.Context, itemID uint, tagIDs []uint) error
 	GetItemTags(ctx context.Context, itemID uint) ([]models.ClothingTag, error)
+	GetTagsByItemIDs(ctx context.Context, itemIDs []uint) (map[uint][]models.ClothingTag, error)
 
 	// 穿着记录
 	IncrementWearCount(ctx context.Context, itemID uint) error
 	UpdateDurability(ctx context.Context, itemID uint, score float64) error
+
+	// 返回使用指定事务的仓库
+	WithTx(tx *gorm.DB) ClothingItemRepository
 }
 
 // clothingItemRepository 衣物仓库实现
@@ -55,6 +71,11 @@ func NewClothingItemRepository(db *gorm.DB) ClothingItemRepository {
 	return &clothingItemRepository{db: db}
 }
 
+// WithTx 返回使用指定事务的仓库
+func (r *clothingItemRepository) WithTx(tx *gorm.DB) ClothingItemRepository {
+	return &clothingItemRepository{db: tx}
+}
+
 // Create 创建衣物
 func (r *clothingItemRepository) Create(ctx context.Context, item *models.ClothingItem) error {
 	return r.db.Create(item).Error
@@ -74,61 +95,88 @@ func (r *clothingItemRepository) GetByID(ctx context.Context, id uint) (*models.
 func (r *clothingItemRepository) GetByUserID(ctx context.Context, userID uint, req *dto.ClothingItemListDTO) ([]models.ClothingItem, int64, error) {
 	var items []models.ClothingItem
 	var total int64
-	
-	query := r.db.WithContext(ctx).Model(&models.ClothingItem{}).Where("user_id = ? AND is_active = ?", userID, true)
+
+	query := r.db.WithContext(ctx).Model(&models.ClothingItem{}).Where("clothing_items.user_id = ? AND clothing_items.is_active = ?", userID, true)
 
 	// 应用过滤条件
-	if req.CategoryIDs != nil && len(req.CategoryIDs) > 0 {
-		query = query.Where("category_id IN ?", req.CategoryIDs)
+	if len(req.CategoryIDs) > 0 {
+		query = query.Where("clothing_items.category_id IN ?", req.CategoryIDs)
 	}
 	if req.Color != "" {
-		query = query.Where("color LIKE ?", "%"+req.Color+"%")
+		query = query.Where("clothing_items.color LIKE ?", "%"+req.Color+"%")
 	}
 	if req.Brand != "" {
-		query = query.Where("brand LIKE ?", "%"+req.Brand+"%")
+		query = query.Where("clothing_items.brand LIKE ?", "%"+req.Brand+"%")
 	}
 	if req.Material != "" {
-		query = query.Where("material LIKE ?", "%"+req.Material+"%")
+		query = query.Where("clothing_items.material LIKE ?", "%"+req.Material+"%")
 	}
 	if req.Condition != "" {
-		query = query.Where("condition = ?", req.Condition)
+		query = query.Where("clothing_items.condition = ?", req.Condition)
+	}
+	if req.Status != nil {
+		query = query.Where("clothing_items.condition = ?", *req.Status)
 	}
 	if req.MinPrice != nil {
-		query = query.Where("price >= ?", req.MinPrice)
+		query = query.Where("clothing_items.price >= ?", req.MinPrice)
 	}
 	if req.MaxPrice != nil {
-		query = query.Where("price <= ?", req.MaxPrice)
+		query = query.Where("clothing_items.price <= ?", req.MaxPrice)
 	}
 	if req.IsFavorite != nil {
-		query = query.Where("is_favorite = ?", *req.IsFavorite)
+		query = query.Where("clothing_items.is_favorite = ?", *req.IsFavorite)
 	}
 	if req.Search != "" {
 		searchTerm := "%" + req.Search + "%"
-		query = query.Where("name LIKE ? OR brand LIKE ? OR notes LIKE ?", searchTerm, searchTerm, searchTerm)
+		query = query.Where("(clothing_items.name LIKE ? OR clothing_items.brand LIKE ? OR clothing_items.notes LIKE ?)", searchTerm, searchTerm, searchTerm)
+	}
+	if req.DateFrom != nil {
+		query = query.Where("clothing_items.created_at >= ?", *req.DateFrom)
+	}
+	if req.DateTo != nil {
+		query = query.Where("clothing_items.created_at < ?", req.DateTo.AddDate(0, 0, 1))
+	}
+	for key, value := range req.Filters {
+		if slices.Contains(models.ClothingFilterFields, key) {
+			query = query.Where(fmt.Sprintf("clothing_items.%s = ?", key), value)
+		}
 	}
 
-	// 标签过滤
-	if len(req.TagIDs) > 0 {		
-		query = query.Joins("JOIN clothing_item_tags ON clothing_items.id = clothing_item_tags.clothing_item_id").
-			Where("clothing_item_tags.clothing_tag_id IN ?", req.TagIDs)
+	// 标签过滤：季节和场合通过对应类型的标签名称匹配
+	matchAll := req.TagMatch == "all"
+	if len(req.TagIDs) > 0 {
+		tagged := r.db.Model(&models.ClothingItemTag{}).
+			Select("clothing_item_tags.clothing_item_id").
+			Where("clothing_item_tags.clothing_tag_id IN ?", req.TagIDs).
+			Group("clothing_item_tags.clothing_item_id")
+		if matchAll {
+			tagged = tagged.Having("COUNT(DISTINCT clothing_item_tags.clothing_tag_id) = ?", countDistinct(req.TagIDs))
+		}
+		query = query.Where("clothing_items.id IN (?)", tagged)
+	}
+	if len(req.Season) > 0 {
+		query = query.Where("clothing_items.id IN (?)", r.itemsWithTagNames(api.TagTypeSeason, req.Season, matchAll))
+	}
+	if len(req.Occasion) > 0 {
+		query = query.Where("clothing_items.id IN (?)", r.itemsWithTagNames(api.TagTypeOccasion, req.Occasion, matchAll))
 	}
 
 	// 获取总数
-	err := query.WithContext(ctx).Count(&total).Error
+	err := query.Count(&total).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
-	// 应用排序
-	orderBy := "created_at DESC"
-	if req.SortBy != "" {
-		direction := "ASC"
-		if req.SortOrder == "desc" {
-			direction = "DESC"
-		}
-		orderBy = fmt.Sprintf("%s %s", req.SortBy, direction)
+	// 应用排序，排序字段只接受白名单内的列
+	direction := "ASC"
+	if req.SortOrder == "desc" {
+		direction = "DESC"
+	}
+	if column, exists := clothingSortColumns[req.SortBy]; exists {
+		query = query.Order(fmt.Sprintf("%s %s NULLS LAST", column, direction))
 	}
-	query = query.Order(orderBy)
+	// 按ID兜底排序，保证分页读取时顺序稳定
+	query = query.Order("clothing_items.created_at DESC").Order("clothing_items.id DESC")
 
 	// 应用分页
 	offset := (req.Page - 1) * req.PageSize
@@ -137,6 +185,28 @@ func (r *clothingItemRepository) GetByUserID(ctx context.Context, userID uint, r
 	return items, total, err
 }
 
+// itemsWithTagNames 拥有指定类型标签的衣物ID子查询，matchAll 时要求拥有全部标签
+func (r *clothingItemRepository) itemsWithTagNames(tagType api.TagType, names []string, matchAll bool) *gorm.DB {
+	query := r.db.Model(&models.ClothingItemTag{}).
+		Select("clothing_item_tags.clothing_item_id").
+		Joins("JOIN clothing_tags ON clothing_tags.id = clothing_item_tags.clothing_tag_id AND clothing_tags.deleted_at IS NULL").
+		Where("clothing_tags.type = ? AND clothing_tags.name IN ?", tagType, names).
+		Group("clothing_item_tags.clothing_item_id")
+	if matchAll {
+		query = query.Having("COUNT(DISTINCT clothing_tags.name) = ?", countDistinct(names))
+	}
+	return query
+}
+
+// countDistinct 统计不重复取值的数量
+func countDistinct[T comparable](values []T) int {
+	seen := make(map[T]bool, len(values))
+	for _, value := range values {
+		seen[value] = true
+	}
+	return len(seen)
+}
+
 // Update 更新衣物
 func (r *clothingItemRepository) Update(ctx context.Context, item *models.ClothingItem) error {
 	return r.db.WithContext(ctx).Save(item).Error
@@ -149,6 +219,55 @@ func (r *clothingItemRepository) Delete(ctx context.Context, id uint) error {
 		Update("is_active", false).Error
 }
 
+// BatchUpdate 批量更新衣物字段
+func (r *clothingItemRepository) BatchUpdate(ctx context.Context, itemIDs []uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&models.ClothingItem{}).
+		Where("id IN ?", itemIDs).
+		Updates(updates).Error
+}
+
+// BatchAddTags 为多件衣物添加标签，已存在的关联保持不变
+func (r *clothingItemRepository) BatchAddTags(ctx context.Context, itemIDs, tagIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []models.ClothingItemTag
+		err := tx.Where("clothing_item_id IN ? AND clothing_tag_id IN ?", itemIDs, tagIDs).
+			Find(&existing).Error
+		if err != nil {
+			return err
+		}
+
+		type itemTagKey struct{ itemID, tagID uint }
+		linked := make(map[itemTagKey]bool, len(existing))
+		for _, itemTag := range existing {
+			linked[itemTagKey{itemTag.ClothingItemID, itemTag.ClothingTagID}] = true
+		}
+
+		var itemTags []models.ClothingItemTag
+		for _, itemID := range itemIDs {
+			for _, tagID := range tagIDs {
+				if linked[itemTagKey{itemID, tagID}] {
+					continue
+				}
+				linked[itemTagKey{itemID, tagID}] = true
+				itemTags = append(itemTags, models.ClothingItemTag{
+					ClothingItemID: itemID,
+					ClothingTagID:  tagID,
+				})
+			}
+		}
+		if len(itemTags) == 0 {
+			return nil
+		}
+		return tx.Create(&itemTags).Error
+	})
+}
+
+// BatchRemoveTags 移除多件衣物的标签
+func (r *clothingItemRepository) BatchRemoveTags(ctx context.Context, itemIDs, tagIDs []uint) error {
+	return r.db.WithContext(ctx).Where("clothing_item_id IN ? AND clothing_tag_id IN ?", itemIDs, tagIDs).
+		Delete(&models.ClothingItemTag{}).Error
+}
+
 // GetByCategory 根据分类获取衣物
 func (r *clothingItemRepository) GetByCategory(ctx context.Context, userID, categoryID uint, limit int) ([]models.ClothingItem, error) {
 	var items []models.ClothingItem
@@ -235,6 +354,36 @@ func (r *clothingItemRepository) GetLeastWorn(ctx context.Context, userID uint,
 	return items, err
 }
 
+// GetByIDs 根据ID列表批量获取衣物
+func (r *clothingItemRepository) GetByIDs(ctx context.Context, ids []uint) ([]models.ClothingItem, error) {
+	var items []models.ClothingItem
+	if len(ids) == 0 {
+		return items, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&items).Error
+	return items, err
+}
+
+// GetAllActive 获取用户所有在用衣物（不分页）
+func (r *clothingItemRepository) GetAllActive(ctx context.Context, userID uint) ([]models.ClothingItem, error) {
+	var items []models.ClothingItem
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND is_active = ? AND condition = ?", userID, true, api.ClothingStatusActive).
+		Order("created_at DESC").
+		Find(&items).Error
+	return items, err
+}
+
+// GetAllByUserID 获取用户的全部衣物（包含闲置、已出售等状态）
+func (r *clothingItemRepository) GetAllByUserID(ctx context.Context, userID uint) ([]models.ClothingItem, error) {
+	var items []models.ClothingItem
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&items).Error
+	return items, err
+}
+
 // GetCategoryStats 获取分类统计
 func (r *clothingItemRepository) GetCategoryStats(ctx context.Context, userID uint) ([]dto.CategoryStatsItem, error) {
 	var stats []dto.CategoryStatsItem
@@ -277,22 +426,171 @@ func (r *clothingItemRepository) GetColorStats(ctx context.Context, userID uint)
 	return stats, err
 }
 
-// Search 搜索衣物
-func (r *clothingItemRepository) Search(ctx context.Context, userID uint, query string, limit int) ([]models.ClothingItem, error) {
+// clothingSortColumns 衣物列表和搜索结果可用的排序字段
+var clothingSortColumns = map[string]string{
+	"created_at":       "clothing_items.created_at",
+	"updated_at":       "clothing_items.updated_at",
+	"name":             "clothing_items.name",
+	"brand":            "clothing_items.brand",
+	"color":            "clothing_items.color",
+	"price":            "clothing_items.price",
+	"wear_count":       "clothing_items.wear_count",
+	"durability_score": "clothing_items.durability_score",
+	"last_worn_date":   "clothing_items.last_worn_date",
+}
+
+// clothingSearchVector 与 idx_clothing_items_search 索引一致的全文检索向量
+const clothingSearchVector = "to_tsvector('simple', COALESCE(clothing_items.search_tokens, ''))"
+
+// Search 搜索衣物，有搜索词时默认按相关度排序
+func (r *clothingItemRepository) Search(ctx context.Context, userID uint, req *dto.ClothingSearchDTO) ([]models.ClothingItem, int64, error) {
 	var items []models.ClothingItem
-	searchTerm := "%" + strings.ToLower(query) + "%"
+	var total int64
 
-	dbQuery := r.db.WithContext(ctx).Where("user_id = ? AND is_active = ?", userID, true).
-		Where("LOWER(name) LIKE ? OR LOWER(brand) LIKE ? OR LOWER(color) LIKE ? OR LOWER(material) LIKE ? OR LOWER(notes) LIKE ?",
-			searchTerm, searchTerm, searchTerm, searchTerm, searchTerm).
-		Order("created_at DESC")
+	tsQuery := utils.SearchTSQuery(req.Query)
+	base := func() *gorm.DB {
+		return r.db.WithContext(ctx).Model(&models.ClothingItem{}).Scopes(r.searchScope(userID, req, tsQuery))
+	}
 
-	if limit > 0 {
-		dbQuery = dbQuery.Limit(limit)
+	if err := base().Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
 
-	err := dbQuery.WithContext(ctx).Find(&items).Error
-	return items, err
+	query := base()
+	direction := "DESC"
+	if req.SortOrder == "asc" {
+		direction = "ASC"
+	}
+	sortBy := req.SortBy
+	if sortBy == "" && tsQuery != "" {
+		sortBy = "relevance"
+	}
+	switch column, exists := clothingSortColumns[sortBy]; {
+	case sortBy == "relevance" && tsQuery != "":
+		query = query.Order(clause.Expr{
+			SQL:  "ts_rank(" + clothingSearchVector + ", to_tsquery('simple', ?)) " + direction,
+			Vars: []interface{}{tsQuery},
+		})
+	case exists:
+		query = query.Order(fmt.Sprintf("%s %s NULLS LAST", column, direction))
+	}
+	query = query.Order("clothing_items.created_at DESC")
+
+	offset := (req.Page - 1) * req.PageSize
+	err := query.Offset(offset).Limit(req.PageSize).Find(&items).Error
+	return items, total, err
+}
+
+// GetSearchFacets 统计匹配衣物的分类、品牌、颜色和标签分布
+func (r *clothingItemRepository) GetSearchFacets(ctx context.Context, userID uint, req *dto.ClothingSearchDTO) (*dto.SearchFacetsDTO, error) {
+	scope := r.searchScope(userID, req, utils.SearchTSQuery(req.Query))
+	base := func() *gorm.DB {
+		return r.db.WithContext(ctx).Model(&models.ClothingItem{}).Scopes(scope)
+	}
+	facets := &dto.SearchFacetsDTO{}
+
+	err := base().
+		Select("clothing_items.category_id as id, clothing_categories.name as value, COUNT(*) as count").
+		Joins("JOIN clothing_categories ON clothing_categories.id = clothing_items.category_id").
+		Group("clothing_items.category_id, clothing_categories.name").
+		Order("count DESC").
+		Scan(&facets.Categories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = base().
+		Select("clothing_items.brand as value, COUNT(*) as count").
+		Where("clothing_items.brand <> ''").
+		Group("clothing_items.brand").
+		Order("count DESC").
+		Scan(&facets.Brands).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = base().
+		Select("clothing_items.color as value, COUNT(*) as count").
+		Where("clothing_items.color <> ''").
+		Group("clothing_items.color").
+		Order("count DESC").
+		Scan(&facets.Colors).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = base().
+		Select("clothing_tags.id as id, clothing_tags.name as value, clothing_tags.type as type, COUNT(*) as count").
+		Joins("JOIN clothing_item_tags ON clothing_item_tags.clothing_item_id = clothing_items.id AND clothing_item_tags.deleted_at IS NULL").
+		Joins("JOIN clothing_tags ON clothing_tags.id = clothing_item_tags.clothing_tag_id AND clothing_tags.deleted_at IS NULL").
+		Group("clothing_tags.id, clothing_tags.name, clothing_tags.type").
+		Order("count DESC").
+		Scan(&facets.Tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// searchScope 搜索的筛选条件，列名均带表名以便与分面统计的关联查询共用
+func (r *clothingItemRepository) searchScope(userID uint, req *dto.ClothingSearchDTO, tsQuery string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("clothing_items.user_id = ? AND clothing_items.is_active = ?", userID, true)
+
+		if tsQuery != "" {
+			db = db.Where(clothingSearchVector+" @@ to_tsquery('simple', ?)", tsQuery)
+		}
+		if len(req.CategoryIDs) > 0 {
+			db = db.Where("clothing_items.category_id IN ?", req.CategoryIDs)
+		}
+		if len(req.Brands) > 0 {
+			db = db.Where("clothing_items.brand IN ?", req.Brands)
+		}
+		if len(req.Colors) > 0 {
+			db = db.Where("clothing_items.color IN ?", req.Colors)
+		}
+		if len(req.Conditions) > 0 {
+			db = db.Where("clothing_items.condition IN ?", req.Conditions)
+		}
+		if len(req.TagIDs) > 0 {
+			tagged := r.db.Model(&models.ClothingItemTag{}).
+				Select("clothing_item_id").
+				Where("clothing_tag_id IN ?", req.TagIDs)
+			db = db.Where("clothing_items.id IN (?)", tagged)
+		}
+		if req.MinDurability != nil {
+			db = db.Where("clothing_items.durability_score >= ?", *req.MinDurability)
+		}
+		if req.MaxDurability != nil {
+			db = db.Where("clothing_items.durability_score <= ?", *req.MaxDurability)
+		}
+		if req.WornAfter != nil {
+			db = db.Where("clothing_items.last_worn_date >= ?", *req.WornAfter)
+		}
+		if req.WornBefore != nil {
+			db = db.Where("(clothing_items.last_worn_date IS NULL OR clothing_items.last_worn_date < ?)", *req.WornBefore)
+		}
+		if req.NeverWorn != nil {
+			if *req.NeverWorn {
+				db = db.Where("clothing_items.last_worn_date IS NULL")
+			} else {
+				db = db.Where("clothing_items.last_worn_date IS NOT NULL")
+			}
+		}
+
+		// 特定属性存储为 JSON，键名已在服务层校验
+		for key, value := range req.Attributes {
+			db = db.Where("clothing_items.specific_attributes->>? = ?", key, value)
+		}
+		for key, value := range req.AttributeMin {
+			db = db.Where("(clothing_items.specific_attributes->>?)::numeric >= ?", key, value)
+		}
+		for key, value := range req.AttributeMax {
+			db = db.Where("(clothing_items.specific_attributes->>?)::numeric <= ?", key, value)
+		}
+		return db
+	}
 }
 
 // AddTags 为衣物添加标签
@@ -334,6 +632,32 @@ func (r *clothingItemRepository) GetItemTags(ctx context.Context, itemID uint) (
 	return tags, err
 }
 
+// GetTagsByItemIDs 批量获取衣物的标签，按衣物ID分组
+func (r *clothingItemRepository) GetTagsByItemIDs(ctx context.Context, itemIDs []uint) (map[uint][]models.ClothingTag, error) {
+	result := make(map[uint][]models.ClothingTag)
+	if len(itemIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		models.ClothingTag
+		ClothingItemID uint
+	}
+	err := r.db.WithContext(ctx).Model(&models.ClothingTag{}).
+		Select("clothing_tags.*, clothing_item_tags.clothing_item_id").
+		Joins("JOIN clothing_item_tags ON clothing_tags.id = clothing_item_tags.clothing_tag_id").
+		Where("clothing_item_tags.clothing_item_id IN ? AND clothing_item_tags.deleted_at IS NULL", itemIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.ClothingItemID] = append(result[row.ClothingItemID], row.ClothingTag)
+	}
+	return result, nil
+}
+
 // IncrementWearCount 增加穿着次数
 func (r *clothingItemRepository) IncrementWearCount(ctx context.Context, itemID uint) error {
 	return r.db.WithContext(ctx).Model(&models.ClothingItem{}).
@@ -27,6 +27,9 @@ type ClothingTagRepository interface {
 	GetTagItemCount(ctx context.Context, tagID uint) (int64, error)
 	GetPopularTags(ctx context.Context, userID uint, limit int) ([]models.ClothingTag, error)
 	GetTagUsageStats(ctx context.Context, userID uint) (map[uint]int64, error)
+
+	// 合并：将来源标签的衣物关联改为目标标签并停用来源标签，返回受影响的衣物数量
+	MergeTags(ctx context.Context, sourceIDs []uint, targetID uint) (int64, error)
 }
 
 // clothingTagRepository 衣物标签仓库实现
@@ -77,11 +80,16 @@ func (r *clothingTagRepository) Update(ctx context.Context, tag *models.Clothing
 	return r.db.WithContext(ctx).Save(tag).Error
 }
 
-// Delete 删除标签
+// Delete 删除标签（停用标签并移除衣物关联）
 func (r *clothingTagRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Model(&models.ClothingTag{}).
-		Where("id = ?", id).
-		Update("is_active", false).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("clothing_tag_id = ?", id).Delete(&models.ClothingItemTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.ClothingTag{}).
+			Where("id = ?", id).
+			Update("is_active", false).Error
+	})
 }
 
 // GetByType 根据类型获取标签
@@ -207,3 +215,38 @@ func (r *clothingTagRepository) GetTagUsageStats(ctx context.Context, userID uin
 
 	return statsMap, nil
 }
+
+// MergeTags 合并标签，已拥有目标标签的衣物只移除来源标签关联
+func (r *clothingTagRepository) MergeTags(ctx context.Context, sourceIDs []uint, targetID uint) (int64, error) {
+	var affected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.ClothingItemTag{}).
+			Where("clothing_tag_id IN ?", sourceIDs).
+			Distinct("clothing_item_id").
+			Count(&affected).Error
+		if err != nil {
+			return err
+		}
+
+		// 逐个来源标签改指向，避免同一衣物的多个来源标签产生重复关联
+		for _, sourceID := range sourceIDs {
+			tagged := tx.Model(&models.ClothingItemTag{}).
+				Select("clothing_item_id").
+				Where("clothing_tag_id = ?", targetID)
+			err := tx.Model(&models.ClothingItemTag{}).
+				Where("clothing_tag_id = ? AND clothing_item_id NOT IN (?)", sourceID, tagged).
+				Update("clothing_tag_id", targetID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("clothing_tag_id IN ?", sourceIDs).Delete(&models.ClothingItemTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.ClothingTag{}).
+			Where("id IN ?", sourceIDs).
+			Update("is_active", false).Error
+	})
+	return affected, err
+}
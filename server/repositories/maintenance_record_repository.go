@@ -17,22 +17,42 @@ type MaintenanceRecordRepository interface {
 	GetByClothingItemID(ctx context.Context, clothingItemID uint) ([]models.MaintenanceRecord, error)
 	Update(ctx context.Context, record *models.MaintenanceRecord) error
 	Delete(ctx context.Context, id uint) error
+	// 批量更新本位币费用（修改本位币后重新折算）
+	UpdateHomeCosts(ctx context.Context, records []models.MaintenanceRecord) error
 
 	// 查询
 	GetByUserID(ctx context.Context, userID uint, limit int) ([]models.MaintenanceRecord, error)
 	GetByType(ctx context.Context, userID uint, maintenanceType api.MaintenanceType) ([]models.MaintenanceRecord, error)
 	GetUpcoming(ctx context.Context, userID uint, days int) ([]models.MaintenanceRecord, error)
 	GetOverdue(ctx context.Context, userID uint) ([]models.MaintenanceRecord, error)
+	// 获取到期且尚未发送提醒的记录，跳过重试时间未到的记录
+	GetDueReminders(ctx context.Context, before, now time.Time, limit int) ([]models.MaintenanceRecord, error)
+	GetMaintenanceByDateRange(ctx context.Context, userID uint, startDate, endDate time.Time) ([]models.MaintenanceRecord, error)
 
 	// 统计
 	GetMaintenanceCost(ctx context.Context, userID uint) (float64, error)
 	GetMaintenanceFrequency(ctx context.Context, userID uint) (map[string]int64, error)
 	GetMaintenanceCostByType(ctx context.Context, userID uint) (map[string]float64, error)
+	// 获取每件衣物的保养次数
+	GetMaintenanceCountByItem(ctx context.Context, userID uint) (map[uint]int64, error)
 
 	// 提醒管理
 	MarkReminderSent(ctx context.Context, recordID uint) error
+	// 提醒发送失败时增加失败次数并设置下次重试时间
+	DeferReminder(ctx context.Context, recordID uint, retryAt time.Time) error
+
+	// 返回使用指定事务的仓库
+	WithTx(tx *gorm.DB) MaintenanceRecordRepository
 }
 
+// latestMaintenanceCondition 仅保留同一衣物同一保养类型的最新记录，旧记录的提醒已被新保养取代
+const latestMaintenanceCondition = `NOT EXISTS (
+	SELECT 1 FROM maintenance_records AS newer
+	WHERE newer.clothing_item_id = maintenance_records.clothing_item_id
+	AND newer.maintenance_type = maintenance_records.maintenance_type
+	AND newer.maintenance_date > maintenance_records.maintenance_date
+	AND newer.deleted_at IS NULL)`
+
 // maintenanceRecordRepository 保养记录仓库实现
 type maintenanceRecordRepository struct {
 	db *gorm.DB
@@ -43,6 +63,11 @@ func NewMaintenanceRecordRepository(db *gorm.DB) MaintenanceRecordRepository {
 	return &maintenanceRecordRepository{db: db}
 }
 
+// WithTx 返回使用指定事务的仓库
+func (r *maintenanceRecordRepository) WithTx(tx *gorm.DB) MaintenanceRecordRepository {
+	return &maintenanceRecordRepository{db: tx}
+}
+
 // Create 创建保养记录
 func (r *maintenanceRecordRepository) Create(ctx context.Context, record *models.MaintenanceRecord) error {
 	return r.db.WithContext(ctx).Create(record).Error
@@ -77,6 +102,21 @@ func (r *maintenanceRecordRepository) Delete(ctx context.Context, id uint) error
 	return r.db.WithContext(ctx).Delete(&models.MaintenanceRecord{}, id).Error
 }
 
+// UpdateHomeCosts 批量更新本位币费用
+func (r *maintenanceRecordRepository) UpdateHomeCosts(ctx context.Context, records []models.MaintenanceRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range records {
+			if err := tx.Model(&records[i]).Update("home_cost", records[i].HomeCost).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // GetByUserID 根据用户ID获取保养记录
 func (r *maintenanceRecordRepository) GetByUserID(ctx context.Context, userID uint, limit int) ([]models.MaintenanceRecord, error) {
 	var records []models.MaintenanceRecord
@@ -105,11 +145,13 @@ func (r *maintenanceRecordRepository) GetByType(ctx context.Context, userID uint
 // GetUpcoming 获取即将到期的保养提醒
 func (r *maintenanceRecordRepository) GetUpcoming(ctx context.Context, userID uint, days int) ([]models.MaintenanceRecord, error) {
 	var records []models.MaintenanceRecord
-	futureDate := time.Now().AddDate(0, 0, days)
+	now := time.Now()
+	futureDate := now.AddDate(0, 0, days)
 
 	err := r.db.WithContext(ctx).Joins("JOIN clothing_items ON maintenance_records.clothing_item_id = clothing_items.id").
-		Where("clothing_items.user_id = ? AND maintenance_records.next_maintenance_date <= ? AND maintenance_records.reminder_sent = ?",
-			userID, futureDate, false).
+		Where("clothing_items.user_id = ? AND clothing_items.deleted_at IS NULL", userID).
+		Where("maintenance_records.next_maintenance_date >= ? AND maintenance_records.next_maintenance_date <= ?", now, futureDate).
+		Where(latestMaintenanceCondition).
 		Order("maintenance_records.next_maintenance_date ASC").
 		Find(&records).Error
 
@@ -122,20 +164,40 @@ func (r *maintenanceRecordRepository) GetOverdue(ctx context.Context, userID uin
 	now := time.Now()
 
 	err := r.db.WithContext(ctx).Joins("JOIN clothing_items ON maintenance_records.clothing_item_id = clothing_items.id").
-		Where("clothing_items.user_id = ? AND maintenance_records.next_maintenance_date < ?", userID, now).
+		Where("clothing_items.user_id = ? AND clothing_items.deleted_at IS NULL", userID).
+		Where("maintenance_records.next_maintenance_date < ?", now).
+		Where(latestMaintenanceCondition).
 		Order("maintenance_records.next_maintenance_date ASC").
 		Find(&records).Error
 
 	return records, err
 }
 
+// GetDueReminders 获取所有用户中到期且尚未发送提醒的保养记录
+func (r *maintenanceRecordRepository) GetDueReminders(ctx context.Context, before, now time.Time, limit int) ([]models.MaintenanceRecord, error) {
+	var records []models.MaintenanceRecord
+	query := r.db.WithContext(ctx).Joins("JOIN clothing_items ON maintenance_records.clothing_item_id = clothing_items.id").
+		Where("clothing_items.deleted_at IS NULL").
+		Where("maintenance_records.reminder_sent = ? AND maintenance_records.next_maintenance_date <= ?", false, before).
+		Where("maintenance_records.reminder_retry_at IS NULL OR maintenance_records.reminder_retry_at <= ?", now).
+		Where(latestMaintenanceCondition).
+		Order("maintenance_records.next_maintenance_date ASC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&records).Error
+	return records, err
+}
+
 // GetMaintenanceCost 获取用户保养总费用
 func (r *maintenanceRecordRepository) GetMaintenanceCost(ctx context.Context, userID uint) (float64, error) {
 	var totalCost float64
 	err := r.db.WithContext(ctx).Model(&models.MaintenanceRecord{}).
 		Joins("JOIN clothing_items ON maintenance_records.clothing_item_id = clothing_items.id").
 		Where("clothing_items.user_id = ?", userID).
-		Select("COALESCE(SUM(maintenance_records.cost), 0)").
+		Select("COALESCE(SUM(maintenance_records.home_cost), 0)").
 		Scan(&totalCost).Error
 	return totalCost, err
 }
@@ -174,7 +236,7 @@ func (r *maintenanceRecordRepository) GetMaintenanceCostByType(ctx context.Conte
 	}
 
 	err := r.db.WithContext(ctx).Model(&models.MaintenanceRecord{}).
-		Select("maintenance_type, COALESCE(SUM(cost), 0) as total_cost").
+		Select("maintenance_records.maintenance_type, COALESCE(SUM(maintenance_records.home_cost), 0) as total_cost").
 		Joins("JOIN clothing_items ON maintenance_records.clothing_item_id = clothing_items.id").
 		Where("clothing_items.user_id = ?", userID).
 		Group("maintenance_type").
@@ -192,6 +254,32 @@ func (r *maintenanceRecordRepository) GetMaintenanceCostByType(ctx context.Conte
 	return costMap, nil
 }
 
+// GetMaintenanceCountByItem 获取按衣物分组的保养次数
+func (r *maintenanceRecordRepository) GetMaintenanceCountByItem(ctx context.Context, userID uint) (map[uint]int64, error) {
+	var results []struct {
+		ClothingItemID uint  `json:"clothing_item_id"`
+		Count          int64 `json:"count"`
+	}
+
+	err := r.db.WithContext(ctx).Model(&models.MaintenanceRecord{}).
+		Select("maintenance_records.clothing_item_id, COUNT(*) as count").
+		Joins("JOIN clothing_items ON maintenance_records.clothing_item_id = clothing_items.id").
+		Where("clothing_items.user_id = ?", userID).
+		Group("maintenance_records.clothing_item_id").
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	countMap := make(map[uint]int64)
+	for _, result := range results {
+		countMap[result.ClothingItemID] = result.Count
+	}
+
+	return countMap, nil
+}
+
 // GetAverageEffectiveness 获取平均保养效果评分
 func (r *maintenanceRecordRepository) GetAverageEffectiveness(ctx context.Context, userID uint) (float64, error) {
 	var avgEffectiveness float64
@@ -221,6 +309,16 @@ func (r *maintenanceRecordRepository) MarkReminderSent(ctx context.Context, reco
 		Update("reminder_sent", true).Error
 }
 
+// DeferReminder 推迟提醒重试
+func (r *maintenanceRecordRepository) DeferReminder(ctx context.Context, recordID uint, retryAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.MaintenanceRecord{}).
+		Where("id = ?", recordID).
+		Updates(map[string]interface{}{
+			"reminder_attempts": gorm.Expr("reminder_attempts + 1"),
+			"reminder_retry_at": retryAt,
+		}).Error
+}
+
 // GetMaintenanceStats 获取保养统计信息
 func (r *maintenanceRecordRepository) GetMaintenanceStats(ctx context.Context, userID uint) (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
@@ -17,6 +17,7 @@ type OutfitItemRepository interface {
 
 	// 查询操作
 	GetByOutfitID(ctx context.Context, outfitID uint) ([]models.OutfitItem, error)
+	GetByOutfitIDs(ctx context.Context, outfitIDs []uint) ([]models.OutfitItem, error)
 	GetByClothingItemID(ctx context.Context, clothingItemID uint) ([]models.OutfitItem, error)
 	GetByRole(ctx context.Context, outfitID uint, role string) ([]models.OutfitItem, error)
 
@@ -27,7 +28,11 @@ type OutfitItemRepository interface {
 
 	// 统计操作
 	GetItemUsageCount(ctx context.Context, clothingItemID uint) (int64, error)
+	GetItemCountsByOutfitIDs(ctx context.Context, outfitIDs []uint) (map[uint]int64, error)
 	GetPopularItems(ctx context.Context, userID uint, limit int) ([]models.ClothingItem, error)
+
+	// 返回使用指定事务的仓库
+	WithTx(tx *gorm.DB) OutfitItemRepository
 }
 
 // outfitItemRepository 穿搭单品仓库实现
@@ -40,6 +45,11 @@ func NewOutfitItemRepository(db *gorm.DB) OutfitItemRepository {
 	return &outfitItemRepository{db: db}
 }
 
+// WithTx 返回使用指定事务的仓库
+func (r *outfitItemRepository) WithTx(tx *gorm.DB) OutfitItemRepository {
+	return &outfitItemRepository{db: tx}
+}
+
 // Create 创建穿搭单品
 func (r *outfitItemRepository) Create(ctx context.Context, item *models.OutfitItem) error {
 	return r.db.WithContext(ctx).Create(item).Error
@@ -74,6 +84,18 @@ func (r *outfitItemRepository) GetByOutfitID(ctx context.Context, outfitID uint)
 	return items, err
 }
 
+// GetByOutfitIDs 批量获取多个穿搭的单品
+func (r *outfitItemRepository) GetByOutfitIDs(ctx context.Context, outfitIDs []uint) ([]models.OutfitItem, error) {
+	var items []models.OutfitItem
+	if len(outfitIDs) == 0 {
+		return items, nil
+	}
+	err := r.db.WithContext(ctx).Where("outfit_id IN ?", outfitIDs).
+		Order("outfit_id ASC, layer_order ASC, created_at ASC").
+		Find(&items).Error
+	return items, err
+}
+
 // GetByClothingItemID 根据衣物ID获取相关穿搭单品
 func (r *outfitItemRepository) GetByClothingItemID(ctx context.Context, clothingItemID uint) ([]models.OutfitItem, error) {
 	var items []models.OutfitItem
@@ -132,6 +154,32 @@ func (r *outfitItemRepository) GetItemUsageCount(ctx context.Context, clothingIt
 	return count, err
 }
 
+// GetItemCountsByOutfitIDs 批量获取穿搭的单品数量
+func (r *outfitItemRepository) GetItemCountsByOutfitIDs(ctx context.Context, outfitIDs []uint) (map[uint]int64, error) {
+	result := make(map[uint]int64, len(outfitIDs))
+	if len(outfitIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		OutfitID uint
+		Count    int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.OutfitItem{}).
+		Select("outfit_id, COUNT(*) as count").
+		Where("outfit_id IN ?", outfitIDs).
+		Group("outfit_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.OutfitID] = row.Count
+	}
+	return result, nil
+}
+
 // GetPopularItems 获取热门衣物（按穿搭使用频率排序）
 func (r *outfitItemRepository) GetPopularItems(ctx context.Context, userID uint, limit int) ([]models.ClothingItem, error) {
 	var items []models.ClothingItem
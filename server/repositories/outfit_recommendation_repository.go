@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/models"
+
+	"gorm.io/gorm"
+)
+
+// OutfitRecommendationRepository 穿搭推荐数据访问接口
+type OutfitRecommendationRepository interface {
+	// 基础操作
+	Create(ctx context.Context, recommendation *models.OutfitRecommendation) error
+	GetByID(ctx context.Context, id uint) (*models.OutfitRecommendation, error)
+	Update(ctx context.Context, recommendation *models.OutfitRecommendation) error
+
+	// 查询操作
+	GetByUserID(ctx context.Context, userID uint, status *api.RecommendationStatus, limit, offset int) ([]models.OutfitRecommendation, int64, error)
+	GetSince(ctx context.Context, userID uint, since time.Time) ([]models.OutfitRecommendation, error)
+
+	// 采纳推荐：在同一事务中创建穿搭及单品并更新推荐状态
+	Accept(ctx context.Context, recommendation *models.OutfitRecommendation, outfit *models.Outfit, items []models.OutfitItem) error
+	// 拒绝推荐：仅当推荐仍为待处理状态时更新，已处理时返回 gorm.ErrRecordNotFound
+	Reject(ctx context.Context, recommendation *models.OutfitRecommendation) error
+}
+
+// outfitRecommendationRepository 穿搭推荐仓库实现
+type outfitRecommendationRepository struct {
+	db *gorm.DB
+}
+
+// NewOutfitRecommendationRepository 创建穿搭推荐仓库实例
+func NewOutfitRecommendationRepository(db *gorm.DB) OutfitRecommendationRepository {
+	return &outfitRecommendationRepository{db: db}
+}
+
+// Create 创建推荐记录
+func (r *outfitRecommendationRepository) Create(ctx context.Context, recommendation *models.OutfitRecommendation) error {
+	return r.db.WithContext(ctx).Create(recommendation).Error
+}
+
+// GetByID 根据ID获取推荐记录
+func (r *outfitRecommendationRepository) GetByID(ctx context.Context, id uint) (*models.OutfitRecommendation, error) {
+	var recommendation models.OutfitRecommendation
+	err := r.db.WithContext(ctx).First(&recommendation, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &recommendation, nil
+}
+
+// Update 更新推荐记录
+func (r *outfitRecommendationRepository) Update(ctx context.Context, recommendation *models.OutfitRecommendation) error {
+	return r.db.WithContext(ctx).Save(recommendation).Error
+}
+
+// GetByUserID 分页获取用户的推荐历史
+func (r *outfitRecommendationRepository) GetByUserID(ctx context.Context, userID uint, status *api.RecommendationStatus, limit, offset int) ([]models.OutfitRecommendation, int64, error) {
+	var recommendations []models.OutfitRecommendation
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.OutfitRecommendation{}).Where("user_id = ?", userID)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.Order("created_at DESC").Find(&recommendations).Error
+	return recommendations, total, err
+}
+
+// GetSince 获取指定时间之后的推荐记录
+func (r *outfitRecommendationRepository) GetSince(ctx context.Context, userID uint, since time.Time) ([]models.OutfitRecommendation, error) {
+	var recommendations []models.OutfitRecommendation
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Order("created_at DESC").
+		Find(&recommendations).Error
+	return recommendations, err
+}
+
+// Accept 采纳推荐
+func (r *outfitRecommendationRepository) Accept(ctx context.Context, recommendation *models.OutfitRecommendation, outfit *models.Outfit, items []models.OutfitItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(outfit).Error; err != nil {
+			return err
+		}
+
+		for i := range items {
+			items[i].OutfitID = outfit.ID
+		}
+		if len(items) > 0 {
+			if err := tx.Create(&items).Error; err != nil {
+				return err
+			}
+		}
+
+		// 仅当推荐仍为待处理状态时更新，防止重复采纳
+		now := time.Now()
+		updates := models.OutfitRecommendation{
+			Status:        api.RecommendationStatusAccepted,
+			OutfitID:      &outfit.ID,
+			AcceptedItems: recommendation.AcceptedItems,
+			RespondedAt:   &now,
+		}
+		result := tx.Model(&models.OutfitRecommendation{}).
+			Where("id = ? AND status = ?", recommendation.ID, api.RecommendationStatusPending).
+			Select("status", "outfit_id", "accepted_items", "responded_at").
+			Updates(&updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		recommendation.Status = updates.Status
+		recommendation.OutfitID = updates.OutfitID
+		recommendation.RespondedAt = updates.RespondedAt
+		return nil
+	})
+}
+
+// Reject 拒绝推荐，记录原因和不喜欢的衣物
+func (r *outfitRecommendationRepository) Reject(ctx context.Context, recommendation *models.OutfitRecommendation) error {
+	// 仅当推荐仍为待处理状态时更新，防止与采纳并发时互相覆盖
+	result := r.db.WithContext(ctx).Model(&models.OutfitRecommendation{}).
+		Where("id = ? AND status = ?", recommendation.ID, api.RecommendationStatusPending).
+		Select("status", "reject_reason", "rejected_items", "responded_at").
+		Updates(recommendation)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -2,7 +2,10 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
 	"what-to-wear/server/models"
 
 	"gorm.io/gorm"
@@ -16,6 +19,12 @@ type OutfitRepository interface {
 	// 根据用户ID获取穿搭历史
 	GetByUserID(ctx context.Context, userID uint, limit, offset int) ([]*models.Outfit, error)
 
+	// 按条件分页查询穿搭记录
+	List(ctx context.Context, userID uint, req *dto.OutfitListDTO) ([]*models.Outfit, int64, error)
+
+	// 获取用户穿搭总数
+	GetTotalCount(ctx context.Context, userID uint) (int64, error)
+
 	// 根据ID获取穿搭记录
 	GetByID(ctx context.Context, id uint) (*models.Outfit, error)
 
@@ -24,6 +33,9 @@ type OutfitRepository interface {
 
 	// 删除穿搭记录
 	Delete(ctx context.Context, id uint) error
+
+	// 返回使用指定事务的仓库
+	WithTx(tx *gorm.DB) OutfitRepository
 }
 
 // outfitRepository 穿搭仓库实现
@@ -36,6 +48,11 @@ func NewOutfitRepository(db *gorm.DB) OutfitRepository {
 	return &outfitRepository{db: db}
 }
 
+// WithTx 返回使用指定事务的仓库
+func (r *outfitRepository) WithTx(tx *gorm.DB) OutfitRepository {
+	return &outfitRepository{db: tx}
+}
+
 // Create 创建穿搭记录
 func (r *outfitRepository) Create(ctx context.Context, outfit *models.Outfit) error {
 	return r.db.WithContext(ctx).Create(outfit).Error
@@ -58,6 +75,80 @@ func (r *outfitRepository) GetByUserID(ctx context.Context, userID uint, limit,
 	return outfits, err
 }
 
+// seasonMonths 季节对应的月份
+var seasonMonths = map[string][]int{
+	api.SeasonSpring: {3, 4, 5},
+	api.SeasonSummer: {6, 7, 8},
+	api.SeasonAutumn: {9, 10, 11},
+	api.SeasonWinter: {12, 1, 2},
+	"spring":         {3, 4, 5},
+	"summer":         {6, 7, 8},
+	"autumn":         {9, 10, 11},
+	"winter":         {12, 1, 2},
+}
+
+// List 按条件分页查询穿搭记录
+func (r *outfitRepository) List(ctx context.Context, userID uint, req *dto.OutfitListDTO) ([]*models.Outfit, int64, error) {
+	var outfits []*models.Outfit
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Outfit{}).Where("user_id = ?", userID)
+
+	// 应用过滤条件
+	if req.Weather != nil {
+		query = query.Where("weather = ?", *req.Weather)
+	}
+	if req.Occasion != "" {
+		query = query.Where("occasion = ?", req.Occasion)
+	}
+	if req.Season != "" {
+		months, ok := seasonMonths[req.Season]
+		if !ok {
+			return nil, 0, fmt.Errorf("无效的季节: %s", req.Season)
+		}
+		query = query.Where("EXTRACT(MONTH FROM date) IN ?", months)
+	}
+	if req.DateFrom != nil {
+		query = query.Where("date >= ?", *req.DateFrom)
+	}
+	if req.DateTo != nil {
+		query = query.Where("date <= ?", *req.DateTo)
+	}
+	if req.Rating != nil {
+		// 评分不低于指定值
+		query = query.Where("rating >= ?", *req.Rating)
+	}
+	if req.IsPublic != nil {
+		query = query.Where("is_public = ?", *req.IsPublic)
+	}
+	if req.Query != "" {
+		searchTerm := "%" + req.Query + "%"
+		query = query.Where("name LIKE ? OR notes LIKE ? OR location LIKE ?", searchTerm, searchTerm, searchTerm)
+	}
+
+	// 获取总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 应用排序，排序字段由控制器校验
+	orderBy := "date DESC, created_at DESC"
+	if req.SortBy != "" {
+		direction := "ASC"
+		if req.SortOrder == "desc" {
+			direction = "DESC"
+		}
+		orderBy = fmt.Sprintf("%s %s", req.SortBy, direction)
+	}
+	query = query.Order(orderBy)
+
+	// 应用分页
+	offset := (req.Page - 1) * req.PageSize
+	err := query.Offset(offset).Limit(req.PageSize).Find(&outfits).Error
+
+	return outfits, total, err
+}
+
 // GetByID 根据ID获取穿搭记录
 func (r *outfitRepository) GetByID(ctx context.Context, id uint) (*models.Outfit, error) {
 	var outfit models.Outfit
@@ -10,12 +10,14 @@ import (
 
 // PurchaseRecordRepository 购买记录仓库接口
 type PurchaseRecordRepository interface {
-	// 基础CRUD操作
+	// 基础CRUD操作，创建、更新和删除时在同一事务中同步衣物的价格和购买日期
 	Create(ctx context.Context, record *models.PurchaseRecord) error
 	GetByID(ctx context.Context, id uint) (*models.PurchaseRecord, error)
 	GetByClothingItemID(ctx context.Context, clothingItemID uint) (*models.PurchaseRecord, error)
 	Update(ctx context.Context, record *models.PurchaseRecord) error
 	Delete(ctx context.Context, id uint) error
+	// 批量更新本位币价格（修改本位币后重新折算）
+	UpdateHomePrices(ctx context.Context, records []models.PurchaseRecord) error
 
 	// 查询
 	GetByUserID(ctx context.Context, userID uint, limit int) ([]models.PurchaseRecord, error)
@@ -28,6 +30,12 @@ type PurchaseRecordRepository interface {
 	GetSpentByCategory(ctx context.Context, userID uint) (map[string]float64, error)
 	GetAverageItemPrice(ctx context.Context, userID uint) (float64, error)
 	GetSpentByStore(ctx context.Context, userID uint) (map[string]float64, error)
+	GetSpentByBrand(ctx context.Context, userID uint) (map[string]float64, error)
+	// 时间范围 [start, end) 内按分类ID分组的消费
+	GetSpentByCategoryInRange(ctx context.Context, userID uint, start, end time.Time) (map[uint]float64, error)
+
+	// 返回使用指定事务的仓库
+	WithTx(tx *gorm.DB) PurchaseRecordRepository
 }
 
 // purchaseRecordRepository 购买记录仓库实现
@@ -40,9 +48,19 @@ func NewPurchaseRecordRepository(db *gorm.DB) PurchaseRecordRepository {
 	return &purchaseRecordRepository{db: db}
 }
 
-// Create 创建购买记录
+// WithTx 返回使用指定事务的仓库
+func (r *purchaseRecordRepository) WithTx(tx *gorm.DB) PurchaseRecordRepository {
+	return &purchaseRecordRepository{db: tx}
+}
+
+// Create 创建购买记录并同步衣物的价格和购买日期
 func (r *purchaseRecordRepository) Create(ctx context.Context, record *models.PurchaseRecord) error {
-	return r.db.WithContext(ctx).Create(record).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+		return syncItemPurchase(tx, record.ClothingItemID, record.HomePrice, &record.PurchaseDate)
+	})
 }
 
 // GetByID 根据ID获取购买记录
@@ -65,14 +83,58 @@ func (r *purchaseRecordRepository) GetByClothingItemID(ctx context.Context, clot
 	return &record, nil
 }
 
-// Update 更新购买记录
+// Update 更新购买记录并同步衣物的价格和购买日期
 func (r *purchaseRecordRepository) Update(ctx context.Context, record *models.PurchaseRecord) error {
-	return r.db.WithContext(ctx).Save(record).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(record).Error; err != nil {
+			return err
+		}
+		return syncItemPurchase(tx, record.ClothingItemID, record.HomePrice, &record.PurchaseDate)
+	})
 }
 
-// Delete 删除购买记录
+// Delete 删除购买记录并清空衣物的价格和购买日期
 func (r *purchaseRecordRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&models.PurchaseRecord{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var record models.PurchaseRecord
+		if err := tx.First(&record, id).Error; err != nil {
+			return err
+		}
+		// 每件衣物只有一条购买记录（唯一索引），直接物理删除以便重新录入
+		if err := tx.Unscoped().Delete(&record).Error; err != nil {
+			return err
+		}
+		return syncItemPurchase(tx, record.ClothingItemID, 0, nil)
+	})
+}
+
+// UpdateHomePrices 批量更新本位币价格并同步衣物价格
+func (r *purchaseRecordRepository) UpdateHomePrices(ctx context.Context, records []models.PurchaseRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range records {
+			record := &records[i]
+			if err := tx.Model(record).Update("home_price", record.HomePrice).Error; err != nil {
+				return err
+			}
+			if err := syncItemPurchase(tx, record.ClothingItemID, record.HomePrice, &record.PurchaseDate); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// syncItemPurchase 将本位币购买价格和日期写回衣物，衣物价格和购买日期始终与购买记录保持一致
+func syncItemPurchase(tx *gorm.DB, clothingItemID uint, price float64, purchaseDate *time.Time) error {
+	return tx.Model(&models.ClothingItem{}).
+		Where("id = ?", clothingItemID).
+		Updates(map[string]interface{}{
+			"price":         price,
+			"purchase_date": purchaseDate,
+		}).Error
 }
 
 // GetByUserID 根据用户ID获取购买记录
@@ -117,7 +179,7 @@ func (r *purchaseRecordRepository) GetTotalSpent(ctx context.Context, userID uin
 	err := r.db.WithContext(ctx).Model(&models.PurchaseRecord{}).
 		Joins("JOIN clothing_items ON purchase_records.clothing_item_id = clothing_items.id").
 		Where("clothing_items.user_id = ?", userID).
-		Select("COALESCE(SUM(purchase_records.price), 0)").
+		Select("COALESCE(SUM(purchase_records.home_price), 0)").
 		Scan(&totalSpent).Error
 	return totalSpent, err
 }
@@ -130,10 +192,10 @@ func (r *purchaseRecordRepository) GetSpentByMonth(ctx context.Context, userID u
 	}
 
 	err := r.db.WithContext(ctx).Model(&models.PurchaseRecord{}).
-		Select("DATE_FORMAT(purchase_date, '%Y-%m') as month, COALESCE(SUM(price), 0) as total").
+		Select("TO_CHAR(purchase_records.purchase_date, 'YYYY-MM') as month, COALESCE(SUM(purchase_records.home_price), 0) as total").
 		Joins("JOIN clothing_items ON purchase_records.clothing_item_id = clothing_items.id").
-		Where("clothing_items.user_id = ? AND YEAR(purchase_records.purchase_date) = ?", userID, year).
-		Group("DATE_FORMAT(purchase_date, '%Y-%m')").
+		Where("clothing_items.user_id = ? AND EXTRACT(YEAR FROM purchase_records.purchase_date) = ?", userID, year).
+		Group("TO_CHAR(purchase_records.purchase_date, 'YYYY-MM')").
 		Order("month").
 		Scan(&results).Error
 
@@ -157,7 +219,7 @@ func (r *purchaseRecordRepository) GetSpentByCategory(ctx context.Context, userI
 	}
 
 	err := r.db.WithContext(ctx).Model(&models.PurchaseRecord{}).
-		Select("clothing_categories.name as category_name, COALESCE(SUM(purchase_records.price), 0) as total").
+		Select("clothing_categories.name as category_name, COALESCE(SUM(purchase_records.home_price), 0) as total").
 		Joins("JOIN clothing_items ON purchase_records.clothing_item_id = clothing_items.id").
 		Joins("JOIN clothing_categories ON clothing_items.category_id = clothing_categories.id").
 		Where("clothing_items.user_id = ?", userID).
@@ -185,7 +247,7 @@ func (r *purchaseRecordRepository) GetSpentByStore(ctx context.Context, userID u
 	}
 
 	err := r.db.WithContext(ctx).Model(&models.PurchaseRecord{}).
-		Select("purchase_records.store as store_name, COALESCE(SUM(purchase_records.price), 0) as total").
+		Select("purchase_records.store as store_name, COALESCE(SUM(purchase_records.home_price), 0) as total").
 		Joins("JOIN clothing_items ON purchase_records.clothing_item_id = clothing_items.id").
 		Where("clothing_items.user_id = ? AND purchase_records.store != ''", userID).
 		Group("purchase_records.store").
@@ -206,13 +268,66 @@ func (r *purchaseRecordRepository) GetSpentByStore(ctx context.Context, userID u
 	return storeSpent, nil
 }
 
+// GetSpentByBrand 获取按品牌分组的消费统计
+func (r *purchaseRecordRepository) GetSpentByBrand(ctx context.Context, userID uint) (map[string]float64, error) {
+	var results []struct {
+		Brand string  `json:"brand"`
+		Total float64 `json:"total"`
+	}
+
+	err := r.db.WithContext(ctx).Model(&models.PurchaseRecord{}).
+		Select("clothing_items.brand as brand, COALESCE(SUM(purchase_records.home_price), 0) as total").
+		Joins("JOIN clothing_items ON purchase_records.clothing_item_id = clothing_items.id").
+		Where("clothing_items.user_id = ? AND clothing_items.brand != ''", userID).
+		Group("clothing_items.brand").
+		Order("total DESC").
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	brandSpent := make(map[string]float64)
+	for _, result := range results {
+		brandSpent[result.Brand] = result.Total
+	}
+
+	return brandSpent, nil
+}
+
+// GetSpentByCategoryInRange 获取时间范围内按分类ID分组的消费统计
+func (r *purchaseRecordRepository) GetSpentByCategoryInRange(ctx context.Context, userID uint, start, end time.Time) (map[uint]float64, error) {
+	var results []struct {
+		CategoryID uint
+		Total      float64
+	}
+
+	err := r.db.WithContext(ctx).Model(&models.PurchaseRecord{}).
+		Select("clothing_items.category_id as category_id, COALESCE(SUM(purchase_records.home_price), 0) as total").
+		Joins("JOIN clothing_items ON purchase_records.clothing_item_id = clothing_items.id").
+		Where("clothing_items.user_id = ? AND purchase_records.purchase_date >= ? AND purchase_records.purchase_date < ?", userID, start, end).
+		Group("clothing_items.category_id").
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	categorySpent := make(map[uint]float64)
+	for _, result := range results {
+		categorySpent[result.CategoryID] = result.Total
+	}
+
+	return categorySpent, nil
+}
+
 // GetAverageItemPrice 获取平均商品价格
 func (r *purchaseRecordRepository) GetAverageItemPrice(ctx context.Context, userID uint) (float64, error) {
 	var avgPrice float64
 	err := r.db.WithContext(ctx).Model(&models.PurchaseRecord{}).
 		Joins("JOIN clothing_items ON purchase_records.clothing_item_id = clothing_items.id").
 		Where("clothing_items.user_id = ?", userID).
-		Select("COALESCE(AVG(purchase_records.price), 0)").
+		Select("COALESCE(AVG(purchase_records.home_price), 0)").
 		Scan(&avgPrice).Error
 	return avgPrice, err
 }
@@ -222,7 +337,7 @@ func (r *purchaseRecordRepository) GetMostExpensiveItem(ctx context.Context, use
 	var record models.PurchaseRecord
 	err := r.db.WithContext(ctx).Joins("JOIN clothing_items ON purchase_records.clothing_item_id = clothing_items.id").
 		Where("clothing_items.user_id = ?", userID).
-		Order("purchase_records.price DESC").
+		Order("purchase_records.home_price DESC").
 		First(&record).Error
 	if err != nil {
 		return nil, err
@@ -349,7 +464,7 @@ func (r *purchaseRecordRepository) GetPurchaseStats(ctx context.Context, userID
 	err = r.db.WithContext(ctx).Model(&models.PurchaseRecord{}).
 		Joins("JOIN clothing_items ON purchase_records.clothing_item_id = clothing_items.id").
 		Where("clothing_items.user_id = ? AND purchase_records.purchase_date BETWEEN ? AND ?", userID, startOfMonth, endOfMonth).
-		Select("COALESCE(SUM(purchase_records.price), 0)").
+		Select("COALESCE(SUM(purchase_records.home_price), 0)").
 		Scan(&monthlySpent).Error
 	if err != nil {
 		return nil, err
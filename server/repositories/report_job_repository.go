@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReportJobRepository 报告任务仓库接口
+type ReportJobRepository interface {
+	// 基础CRUD操作
+	Create(ctx context.Context, job *models.ReportJob) error
+	GetByID(ctx context.Context, id uint) (*models.ReportJob, error)
+	GetByUserID(ctx context.Context, userID uint, limit int) ([]models.ReportJob, error)
+	Update(ctx context.Context, job *models.ReportJob) error
+	Delete(ctx context.Context, id uint) error
+
+	// 任务调度
+	// 领取排队中的任务并标记为处理中，多实例部署时已被锁定的任务会被跳过
+	ClaimPending(ctx context.Context, limit int) ([]models.ReportJob, error)
+	// 将长时间未完成的处理中任务重新放回队列
+	RequeueStale(ctx context.Context, before time.Time) (int64, error)
+	// 获取文件已过期的任务
+	GetExpired(ctx context.Context, before time.Time, limit int) ([]models.ReportJob, error)
+}
+
+// reportJobRepository 报告任务仓库实现
+type reportJobRepository struct {
+	db *gorm.DB
+}
+
+// NewReportJobRepository 创建报告任务仓库实例
+func NewReportJobRepository(db *gorm.DB) ReportJobRepository {
+	return &reportJobRepository{db: db}
+}
+
+// Create 创建报告任务
+func (r *reportJobRepository) Create(ctx context.Context, job *models.ReportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+// GetByID 根据ID获取报告任务
+func (r *reportJobRepository) GetByID(ctx context.Context, id uint) (*models.ReportJob, error) {
+	var job models.ReportJob
+	err := r.db.WithContext(ctx).First(&job, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetByUserID 获取用户的报告任务，按创建时间倒序
+func (r *reportJobRepository) GetByUserID(ctx context.Context, userID uint, limit int) ([]models.ReportJob, error) {
+	var jobs []models.ReportJob
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&jobs).Error
+	return jobs, err
+}
+
+// Update 更新报告任务
+func (r *reportJobRepository) Update(ctx context.Context, job *models.ReportJob) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+// Delete 删除报告任务
+func (r *reportJobRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.ReportJob{}, id).Error
+}
+
+// ClaimPending 领取排队中的任务
+func (r *reportJobRepository) ClaimPending(ctx context.Context, limit int) ([]models.ReportJob, error) {
+	var jobs []models.ReportJob
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", api.JobStatusPending).
+			Order("created_at ASC")
+		if limit > 0 {
+			query = query.Limit(limit)
+		}
+		if err := query.Find(&jobs).Error; err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(jobs))
+		for i := range jobs {
+			ids = append(ids, jobs[i].ID)
+			jobs[i].Status = api.JobStatusProcessing
+		}
+		return tx.Model(&models.ReportJob{}).
+			Where("id IN ?", ids).
+			Update("status", api.JobStatusProcessing).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// RequeueStale 重新排队超时的处理中任务
+func (r *reportJobRepository) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.ReportJob{}).
+		Where("status = ? AND updated_at < ?", api.JobStatusProcessing, before).
+		Update("status", api.JobStatusPending)
+	return result.RowsAffected, result.Error
+}
+
+// GetExpired 获取已过期的任务
+func (r *reportJobRepository) GetExpired(ctx context.Context, before time.Time, limit int) ([]models.ReportJob, error) {
+	var jobs []models.ReportJob
+	query := r.db.WithContext(ctx).
+		Where("expires_at IS NOT NULL AND expires_at < ?", before).
+		Order("expires_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&jobs).Error
+	return jobs, err
+}
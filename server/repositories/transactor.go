@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Transactor 数据库事务执行器，配合各仓库的 WithTx 在同一事务中执行多个仓库操作
+type Transactor interface {
+	// 在事务中执行 fn，fn 返回错误时回滚
+	Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+}
+
+// transactor 数据库事务执行器实现
+type transactor struct {
+	db *gorm.DB
+}
+
+// NewTransactor 创建数据库事务执行器实例
+func NewTransactor(db *gorm.DB) Transactor {
+	return &transactor{db: db}
+}
+
+// Transaction 在事务中执行 fn
+func (t *transactor) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return t.db.WithContext(ctx).Transaction(fn)
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/models"
+
+	"gorm.io/gorm"
+)
+
+// UploadSlotRepository 直传上传凭证仓库接口
+type UploadSlotRepository interface {
+	// 基础CRUD操作
+	Create(ctx context.Context, slot *models.UploadSlot) error
+	GetByID(ctx context.Context, id uint) (*models.UploadSlot, error)
+	GetByObjectKey(ctx context.Context, objectKey string) (*models.UploadSlot, error)
+
+	// 确认上传：将等待上传的凭证标记为已确认并创建附件记录，凭证已不在等待状态时返回 gorm.ErrRecordNotFound
+	Complete(ctx context.Context, slot *models.UploadSlot, attachment *models.Attachment) error
+	// 将等待上传的凭证标记为已过期，返回凭证是否被更新
+	MarkExpired(ctx context.Context, id uint) (bool, error)
+	// 获取已过期但仍在等待上传的凭证
+	GetExpired(ctx context.Context, before time.Time, limit int) ([]models.UploadSlot, error)
+	// 获取用户仍在等待上传的凭证
+	GetPendingByUserID(ctx context.Context, userID uint) ([]models.UploadSlot, error)
+}
+
+// uploadSlotRepository 直传上传凭证仓库实现
+type uploadSlotRepository struct {
+	db *gorm.DB
+}
+
+// NewUploadSlotRepository 创建直传上传凭证仓库实例
+func NewUploadSlotRepository(db *gorm.DB) UploadSlotRepository {
+	return &uploadSlotRepository{db: db}
+}
+
+// Create 创建上传凭证
+func (r *uploadSlotRepository) Create(ctx context.Context, slot *models.UploadSlot) error {
+	return r.db.WithContext(ctx).Create(slot).Error
+}
+
+// GetByID 根据ID获取上传凭证
+func (r *uploadSlotRepository) GetByID(ctx context.Context, id uint) (*models.UploadSlot, error) {
+	var slot models.UploadSlot
+	err := r.db.WithContext(ctx).First(&slot, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+// GetByObjectKey 根据对象键获取上传凭证
+func (r *uploadSlotRepository) GetByObjectKey(ctx context.Context, objectKey string) (*models.UploadSlot, error) {
+	var slot models.UploadSlot
+	err := r.db.WithContext(ctx).Where("object_key = ?", objectKey).First(&slot).Error
+	if err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+// Complete 在同一事务中确认凭证并创建附件，并发确认时只有一次成功
+func (r *uploadSlotRepository) Complete(ctx context.Context, slot *models.UploadSlot, attachment *models.Attachment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attachment).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		result := tx.Model(&models.UploadSlot{}).
+			Where("id = ? AND status = ?", slot.ID, api.UploadStatusPending).
+			Updates(map[string]interface{}{
+				"status":        api.UploadStatusCompleted,
+				"attachment_id": attachment.ID,
+				"completed_at":  now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		slot.Status = api.UploadStatusCompleted
+		slot.AttachmentID = &attachment.ID
+		slot.CompletedAt = &now
+		return nil
+	})
+}
+
+// MarkExpired 将凭证标记为已过期
+func (r *uploadSlotRepository) MarkExpired(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.UploadSlot{}).
+		Where("id = ? AND status = ?", id, api.UploadStatusPending).
+		Update("status", api.UploadStatusExpired)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetExpired 获取已过期的等待上传凭证
+func (r *uploadSlotRepository) GetExpired(ctx context.Context, before time.Time, limit int) ([]models.UploadSlot, error) {
+	var slots []models.UploadSlot
+	query := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", api.UploadStatusPending, before).
+		Order("expires_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&slots).Error
+	return slots, err
+}
+
+// GetPendingByUserID 获取用户等待上传的凭证
+func (r *uploadSlotRepository) GetPendingByUserID(ctx context.Context, userID uint) ([]models.UploadSlot, error) {
+	var slots []models.UploadSlot
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, api.UploadStatusPending).
+		Find(&slots).Error
+	return slots, err
+}
@@ -29,11 +29,17 @@ type UserRepository interface {
 	// 删除用户
 	Delete(ctx context.Context, id uint) error
 
+	// 彻底删除用户及其名下的所有数据（账户数据导出任务除外），不删除存储中的文件
+	Purge(ctx context.Context, id uint) error
+
 	// 检查用户名是否存在
 	ExistsByUsername(ctx context.Context, username string) (bool, error)
 
 	// 检查邮箱是否存在
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
+
+	// 返回使用指定事务的仓库
+	WithTx(tx *gorm.DB) UserRepository
 }
 
 // userRepository 用户仓储实现
@@ -46,6 +52,11 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db: db}
 }
 
+// WithTx 返回使用指定事务的仓库
+func (r *userRepository) WithTx(tx *gorm.DB) UserRepository {
+	return &userRepository{db: tx}
+}
+
 // Create 创建用户
 func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	log := logger.GetLogger()
@@ -117,6 +128,46 @@ func (r *userRepository) Delete(ctx context.Context, id uint) error {
 	return nil
 }
 
+// Purge 在事务中物理删除用户及其衣物、穿搭、记录、标签、分类、预算、附件和报告任务
+// 账户数据导出任务保留到下载链接过期，以便删除账户后仍能下载导出的数据
+func (r *userRepository) Purge(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		items := tx.Unscoped().Model(&models.ClothingItem{}).Select("id").Where("user_id = ?", id)
+		outfits := tx.Unscoped().Model(&models.Outfit{}).Select("id").Where("user_id = ?", id)
+		tags := tx.Unscoped().Model(&models.ClothingTag{}).Select("id").Where("user_id = ?", id)
+
+		// 先删除依赖衣物、穿搭和标签的记录，最后删除用户
+		steps := []struct {
+			model interface{}
+			query string
+			args  []interface{}
+		}{
+			{&models.ClothingItemTag{}, "clothing_item_id IN (?) OR clothing_tag_id IN (?)", []interface{}{items, tags}},
+			{&models.OutfitItem{}, "outfit_id IN (?) OR clothing_item_id IN (?)", []interface{}{outfits, items}},
+			{&models.WearRecord{}, "clothing_item_id IN (?)", []interface{}{items}},
+			{&models.MaintenanceRecord{}, "clothing_item_id IN (?)", []interface{}{items}},
+			{&models.PurchaseRecord{}, "clothing_item_id IN (?)", []interface{}{items}},
+			{&models.OutfitRecommendation{}, "user_id = ?", []interface{}{id}},
+			{&models.Outfit{}, "user_id = ?", []interface{}{id}},
+			{&models.ClothingItem{}, "user_id = ?", []interface{}{id}},
+			{&models.ClothingTag{}, "user_id = ?", []interface{}{id}},
+			{&models.ClothingCategory{}, "user_id = ?", []interface{}{id}},
+			{&models.BudgetAlert{}, "user_id = ?", []interface{}{id}},
+			{&models.Budget{}, "user_id = ?", []interface{}{id}},
+			{&models.UploadSlot{}, "user_id = ?", []interface{}{id}},
+			{&models.Attachment{}, "user_id = ?", []interface{}{id}},
+			{&models.ReportJob{}, "user_id = ?", []interface{}{id}},
+			{&models.User{}, "id = ?", []interface{}{id}},
+		}
+		for _, step := range steps {
+			if err := tx.Unscoped().Where(step.query, step.args...).Delete(step.model).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // ExistsByUsername 检查用户名是否存在
 func (r *userRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
 	var count int64
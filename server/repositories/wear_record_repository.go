@@ -3,8 +3,10 @@ package repositories
 import (
 	"context"
 	"time"
+	"what-to-wear/server/api"
 	"what-to-wear/server/models"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -17,16 +19,44 @@ type WearRecordRepository interface {
 	Update(ctx context.Context, record *models.WearRecord) error
 	Delete(ctx context.Context, id uint) error
 
+	// 记录穿着：在同一事务中创建穿着记录并更新衣物的穿着次数和最后穿着时间
+	RecordWears(ctx context.Context, records []models.WearRecord) error
+
 	// 查询
 	GetByUserID(ctx context.Context, userID uint, limit int) ([]models.WearRecord, error)
 	GetByDateRange(ctx context.Context, userID uint, startDate, endDate string) ([]models.WearRecord, error)
 	GetByOccasion(ctx context.Context, userID uint, occasion string) ([]models.WearRecord, error)
 	GetByWeather(ctx context.Context, userID uint, weather string) ([]models.WearRecord, error)
+	GetByOutfitID(ctx context.Context, outfitID uint, limit int) ([]models.WearRecord, error)
 
 	// 统计
 	GetWearStats(ctx context.Context, clothingItemID uint) (map[string]interface{}, error)
 	GetWearFrequency(ctx context.Context, userID uint) (map[string]int64, error)
 	GetComfortRatings(ctx context.Context, userID uint) (map[uint]float64, error)
+	GetWeatherStats(ctx context.Context, userID uint) (map[string]int64, error)
+	GetRatingAverages(ctx context.Context, userID uint) (*WearRatingAverages, error)
+	GetComfortByCategory(ctx context.Context, userID uint) (map[string]float64, error)
+	GetComfortByWeather(ctx context.Context, userID uint) (map[api.WeatherType]float64, error)
+	GetOutfitWearSummary(ctx context.Context, outfitID uint) (int64, *time.Time, error)
+	GetOutfitWearSummaries(ctx context.Context, outfitIDs []uint) (map[uint]OutfitWearSummary, error)
+	GetItemWearCountsBefore(ctx context.Context, userID uint, before time.Time) (map[uint]int64, error)
+
+	// 返回使用指定事务的仓库
+	WithTx(tx *gorm.DB) WearRecordRepository
+}
+
+// OutfitWearSummary 穿搭穿着汇总
+type OutfitWearSummary struct {
+	OutfitID     uint
+	WearCount    int64
+	LastWornDate *time.Time
+}
+
+// WearRatingAverages 穿着评分均值，未评分的记录不参与计算
+type WearRatingAverages struct {
+	AvgComfort         float64
+	AvgStyle           float64
+	AvgAppropriateness float64
 }
 
 // wearRecordRepository 穿着记录仓库实现
@@ -39,6 +69,11 @@ func NewWearRecordRepository(db *gorm.DB) WearRecordRepository {
 	return &wearRecordRepository{db: db}
 }
 
+// WithTx 返回使用指定事务的仓库
+func (r *wearRecordRepository) WithTx(tx *gorm.DB) WearRecordRepository {
+	return &wearRecordRepository{db: tx}
+}
+
 // Create 创建穿着记录
 func (r *wearRecordRepository) Create(ctx context.Context, record *models.WearRecord) error {
 	return r.db.WithContext(ctx).Create(record).Error
@@ -78,6 +113,62 @@ func (r *wearRecordRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.WearRecord{}, id).Error
 }
 
+// RecordWears 记录一次穿着，同一批记录使用同一个穿着事件ID，穿着次数在数据库中原子递增，并发记录不会丢失计数
+func (r *wearRecordRepository) RecordWears(ctx context.Context, records []models.WearRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	eventID := uuid.New().String()
+	for i := range records {
+		records[i].WearEventID = eventID
+	}
+
+	// 按衣物汇总本次新增的穿着次数和最晚穿着时间
+	type itemWears struct {
+		count    int
+		lastWorn time.Time
+	}
+	wears := make(map[uint]*itemWears, len(records))
+	itemIDs := make([]uint, 0, len(records))
+	for _, record := range records {
+		summary, exists := wears[record.ClothingItemID]
+		if !exists {
+			summary = &itemWears{lastWorn: record.WearDate}
+			wears[record.ClothingItemID] = summary
+			itemIDs = append(itemIDs, record.ClothingItemID)
+		}
+		summary.count++
+		if record.WearDate.After(summary.lastWorn) {
+			summary.lastWorn = record.WearDate
+		}
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&records).Error; err != nil {
+			return err
+		}
+
+		for _, itemID := range itemIDs {
+			summary := wears[itemID]
+			// GREATEST 忽略 NULL，首次穿着时直接使用本次穿着时间
+			result := tx.Model(&models.ClothingItem{}).
+				Where("id = ?", itemID).
+				Updates(map[string]interface{}{
+					"wear_count":     gorm.Expr("wear_count + ?", summary.count),
+					"last_worn_date": gorm.Expr("GREATEST(last_worn_date, ?)", summary.lastWorn),
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+		return nil
+	})
+}
+
 // GetByUserID 根据用户ID获取穿着记录
 func (r *wearRecordRepository) GetByUserID(ctx context.Context, userID uint, limit int) ([]models.WearRecord, error) {
 	var records []models.WearRecord
@@ -123,6 +214,62 @@ func (r *wearRecordRepository) GetByWeather(ctx context.Context, userID uint, we
 	return records, err
 }
 
+// GetByOutfitID 根据穿搭ID获取穿着记录
+func (r *wearRecordRepository) GetByOutfitID(ctx context.Context, outfitID uint, limit int) ([]models.WearRecord, error) {
+	var records []models.WearRecord
+	query := r.db.WithContext(ctx).Where("outfit_id = ?", outfitID).
+		Order("wear_date DESC, clothing_item_id ASC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&records).Error
+	return records, err
+}
+
+// GetOutfitWearSummary 获取穿搭的穿着次数和最后穿着时间
+// 一次穿着会为每件单品生成一条记录，这些记录共享同一个穿着事件ID，因此按事件ID去重计数
+func (r *wearRecordRepository) GetOutfitWearSummary(ctx context.Context, outfitID uint) (int64, *time.Time, error) {
+	var summary struct {
+		WearCount    int64
+		LastWornDate *time.Time
+	}
+
+	err := r.db.WithContext(ctx).Model(&models.WearRecord{}).
+		Select("COUNT(DISTINCT wear_event_id) as wear_count, MAX(wear_date) as last_worn_date").
+		Where("outfit_id = ?", outfitID).
+		Scan(&summary).Error
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return summary.WearCount, summary.LastWornDate, nil
+}
+
+// GetOutfitWearSummaries 批量获取穿搭的穿着次数和最后穿着时间
+func (r *wearRecordRepository) GetOutfitWearSummaries(ctx context.Context, outfitIDs []uint) (map[uint]OutfitWearSummary, error) {
+	result := make(map[uint]OutfitWearSummary, len(outfitIDs))
+	if len(outfitIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []OutfitWearSummary
+	err := r.db.WithContext(ctx).Model(&models.WearRecord{}).
+		Select("outfit_id, COUNT(DISTINCT wear_event_id) as wear_count, MAX(wear_date) as last_worn_date").
+		Where("outfit_id IN ?", outfitIDs).
+		Group("outfit_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.OutfitID] = row
+	}
+	return result, nil
+}
+
 // GetWearStats 获取衣物穿着统计
 func (r *wearRecordRepository) GetWearStats(ctx context.Context, clothingItemID uint) (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
@@ -130,7 +277,7 @@ func (r *wearRecordRepository) GetWearStats(ctx context.Context, clothingItemID
 	// 基本统计
 	var basicStats struct {
 		TotalWears    int64      `json:"total_wears"`
-		TotalHours    int64      `json:"total_hours"`
+		TotalHours    float64    `json:"total_hours"`
 		AvgComfort    float64    `json:"avg_comfort"`
 		AvgStyle      float64    `json:"avg_style"`
 		LastWornDate  *time.Time `json:"last_worn_date"`
@@ -201,6 +348,32 @@ func (r *wearRecordRepository) GetWearFrequency(ctx context.Context, userID uint
 	return frequencyMap, nil
 }
 
+// GetItemWearCountsBefore 统计用户每件衣物在指定时间之前的穿着次数
+func (r *wearRecordRepository) GetItemWearCountsBefore(ctx context.Context, userID uint, before time.Time) (map[uint]int64, error) {
+	var results []struct {
+		ClothingItemID uint
+		Count          int64
+	}
+
+	err := r.db.WithContext(ctx).Model(&models.WearRecord{}).
+		Select("wear_records.clothing_item_id, COUNT(*) as count").
+		Joins("JOIN clothing_items ON wear_records.clothing_item_id = clothing_items.id").
+		Where("clothing_items.user_id = ? AND wear_records.wear_date <= ?", userID, before).
+		Group("wear_records.clothing_item_id").
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int64, len(results))
+	for _, result := range results {
+		counts[result.ClothingItemID] = result.Count
+	}
+
+	return counts, nil
+}
+
 // GetComfortRatings 获取舒适度评分统计
 func (r *wearRecordRepository) GetComfortRatings(ctx context.Context, userID uint) (map[uint]float64, error) {
 	var results []struct {
@@ -227,6 +400,71 @@ func (r *wearRecordRepository) GetComfortRatings(ctx context.Context, userID uin
 	return comfortMap, nil
 }
 
+// GetRatingAverages 获取用户穿着记录的各项评分均值
+func (r *wearRecordRepository) GetRatingAverages(ctx context.Context, userID uint) (*WearRatingAverages, error) {
+	var averages WearRatingAverages
+	err := r.db.WithContext(ctx).Model(&models.WearRecord{}).
+		Select("COALESCE(AVG(wear_records.comfort_rating), 0) as avg_comfort, "+
+			"COALESCE(AVG(wear_records.style_rating), 0) as avg_style, "+
+			"COALESCE(AVG(wear_records.appropriateness_rating), 0) as avg_appropriateness").
+		Joins("JOIN clothing_items ON wear_records.clothing_item_id = clothing_items.id").
+		Where("clothing_items.user_id = ?", userID).
+		Scan(&averages).Error
+	if err != nil {
+		return nil, err
+	}
+	return &averages, nil
+}
+
+// GetComfortByCategory 获取各分类的平均舒适度
+func (r *wearRecordRepository) GetComfortByCategory(ctx context.Context, userID uint) (map[string]float64, error) {
+	var results []struct {
+		CategoryName string
+		AvgComfort   float64
+	}
+
+	err := r.db.WithContext(ctx).Model(&models.WearRecord{}).
+		Select("clothing_categories.name as category_name, AVG(wear_records.comfort_rating) as avg_comfort").
+		Joins("JOIN clothing_items ON wear_records.clothing_item_id = clothing_items.id").
+		Joins("JOIN clothing_categories ON clothing_items.category_id = clothing_categories.id").
+		Where("clothing_items.user_id = ? AND wear_records.comfort_rating IS NOT NULL", userID).
+		Group("clothing_categories.name").
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	comfortMap := make(map[string]float64, len(results))
+	for _, result := range results {
+		comfortMap[result.CategoryName] = result.AvgComfort
+	}
+	return comfortMap, nil
+}
+
+// GetComfortByWeather 获取各天气下的平均舒适度
+func (r *wearRecordRepository) GetComfortByWeather(ctx context.Context, userID uint) (map[api.WeatherType]float64, error) {
+	var results []struct {
+		WeatherCondition api.WeatherType
+		AvgComfort       float64
+	}
+
+	err := r.db.WithContext(ctx).Model(&models.WearRecord{}).
+		Select("wear_records.weather_condition, AVG(wear_records.comfort_rating) as avg_comfort").
+		Joins("JOIN clothing_items ON wear_records.clothing_item_id = clothing_items.id").
+		Where("clothing_items.user_id = ? AND wear_records.comfort_rating IS NOT NULL AND wear_records.weather_condition IS NOT NULL", userID).
+		Group("wear_records.weather_condition").
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	comfortMap := make(map[api.WeatherType]float64, len(results))
+	for _, result := range results {
+		comfortMap[result.WeatherCondition] = result.AvgComfort
+	}
+	return comfortMap, nil
+}
+
 // GetWeatherStats 获取天气统计
 func (r *wearRecordRepository) GetWeatherStats(ctx context.Context, userID uint) (map[string]int64, error) {
 	var results []struct {
@@ -376,8 +614,8 @@ func (r *wearRecordRepository) GetWearsByTemperatureRange(ctx context.Context, u
 }
 
 // GetTotalWearTime 获取用户总穿着时间
-func (r *wearRecordRepository) GetTotalWearTime(ctx context.Context, userID uint) (int64, error) {
-	var totalHours int64
+func (r *wearRecordRepository) GetTotalWearTime(ctx context.Context, userID uint) (float64, error) {
+	var totalHours float64
 	err := r.db.WithContext(ctx).Model(&models.WearRecord{}).
 		Joins("JOIN clothing_items ON wear_records.clothing_item_id = clothing_items.id").
 		Where("clothing_items.user_id = ?", userID).
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"what-to-wear/server/controllers"
+	"what-to-wear/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupAccountExportRoutes 设置账户数据导出路由
+func setupAccountExportRoutes(api *gin.RouterGroup, accountExportController *controllers.AccountExportController) {
+	// 下载链接凭令牌访问，账户删除后仍可在有效期内下载
+	api.GET("/account/export-files/:token", accountExportController.DownloadExport)
+
+	exports := api.Group("/account/exports")
+	exports.Use(middleware.AuthMiddleware())
+	{
+		exports.POST("", accountExportController.CreateExport)
+		exports.GET("", accountExportController.GetExports)
+		exports.GET("/:id", accountExportController.GetExport)
+		exports.DELETE("/:id", accountExportController.DeleteExport)
+	}
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"what-to-wear/server/controllers"
+	"what-to-wear/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupAttachmentRoutes 设置附件路由
+func setupAttachmentRoutes(api *gin.RouterGroup, attachmentController *controllers.AttachmentController) {
+	attachments := api.Group("/attachments")
+	attachments.Use(middleware.AuthMiddleware())
+	{
+		attachments.POST("", attachmentController.UploadAttachment)
+		attachments.POST("/batch-delete", attachmentController.BatchDeleteAttachments)
+		attachments.POST("/uploads", attachmentController.CreateUploadSlot)
+		attachments.POST("/uploads/:id/complete", attachmentController.CompleteUpload)
+		attachments.GET("/entity/:entity_type/:entity_id", attachmentController.GetAttachmentsByEntity)
+		attachments.GET("/:id", attachmentController.GetAttachmentInfo)
+		attachments.PUT("/:id", attachmentController.UpdateAttachmentInfo)
+		attachments.DELETE("/:id", attachmentController.DeleteAttachment)
+	}
+}
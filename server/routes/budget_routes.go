@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"what-to-wear/server/controllers"
+	"what-to-wear/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupBudgetRoutes 设置预算路由
+func setupBudgetRoutes(api *gin.RouterGroup, budgetController *controllers.BudgetController) {
+	budgets := api.Group("/budgets")
+	budgets.Use(middleware.AuthMiddleware())
+	{
+		budgets.POST("", budgetController.CreateBudget)
+		budgets.GET("", budgetController.GetBudgets)
+		// 执行情况：?date=2025-01-15，默认当前周期
+		budgets.GET("/status", budgetController.GetStatus)
+		// 超出预算提醒：?unread=true&limit=50
+		budgets.GET("/alerts", budgetController.GetAlerts)
+		budgets.PUT("/alerts/:id/read", budgetController.MarkAlertRead)
+		budgets.GET("/:id", budgetController.GetBudget)
+		budgets.PUT("/:id", budgetController.UpdateBudget)
+		budgets.DELETE("/:id", budgetController.DeleteBudget)
+	}
+}
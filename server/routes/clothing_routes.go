@@ -26,9 +26,15 @@ func SetupClothingRoutes(router *gin.RouterGroup, clothingController *controller
 		// 穿着记录
 		clothingAPI.POST("/items/:id/wear", clothingController.RecordWear)
 
+		// 耐久度
+		clothingAPI.GET("/items/:id/durability", clothingController.GetDurabilityTimeline)
+
 		// 分类管理
 		clothingAPI.GET("/categories", clothingController.GetCategories)
 		clothingAPI.GET("/categories/tree", clothingController.GetCategoryTree)
+		clothingAPI.POST("/categories/custom", clothingController.CreateCustomCategory)
+		clothingAPI.PUT("/categories/custom/:id", clothingController.UpdateCustomCategory)
+		clothingAPI.DELETE("/categories/custom/:id", clothingController.DeleteCustomCategory)
 
 		// 标签管理
 		clothingAPI.GET("/tags", clothingController.GetTags)
@@ -49,116 +55,102 @@ func SetupClothingRoutes(router *gin.RouterGroup, clothingController *controller
 }
 
 // 扩展路由配置，包含更多功能
-func SetupExtendedClothingRoutes(router *gin.Engine, clothingController *controllers.ClothingController) {
-	clothingAPI := router.Group("/api/clothing")
+func SetupExtendedClothingRoutes(
+	router *gin.RouterGroup,
+	clothingController *controllers.ClothingController,
+	recommendationController *controllers.RecommendationController,
+	maintenanceController *controllers.MaintenanceController,
+	analyticsController *controllers.AnalyticsController,
+	purchaseController *controllers.PurchaseController,
+	importExportController *controllers.ImportExportController,
+) {
+	clothingAPI := router.Group("/clothing")
 	clothingAPI.Use(middleware.AuthMiddleware())
 	{
 		// 高级搜索和筛选
-		clothingAPI.GET("/search", func(c *gin.Context) {
-			c.JSON(200, gin.H{"message": "搜索功能待实现"})
-		})
+		clothingAPI.GET("/search", clothingController.SearchClothingItems)
 
 		// 推荐系统
-		clothingAPI.GET("/recommendations", func(c *gin.Context) {
-			c.JSON(200, gin.H{"message": "推荐功能待实现"})
-		})
+		recommendationGroup := clothingAPI.Group("/recommendations")
+		{
+			recommendationGroup.GET("", recommendationController.GetRecommendations)
+			recommendationGroup.GET("/history", recommendationController.GetRecommendationHistory)
+			recommendationGroup.GET("/:id", recommendationController.GetRecommendation)
+			recommendationGroup.POST("/:id/accept", recommendationController.AcceptRecommendation)
+			recommendationGroup.POST("/:id/reject", recommendationController.RejectRecommendation)
+		}
 
 		// 衣物保养
 		maintenanceGroup := clothingAPI.Group("/maintenance")
 		{
-			maintenanceGroup.POST("/items/:id/records", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "保养记录功能待实现"})
-			})
-			maintenanceGroup.GET("/items/:id/records", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "保养记录功能待实现"})
-			})
-			maintenanceGroup.GET("/reminders", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "保养提醒功能待实现"})
-			})
+			maintenanceGroup.POST("/items/:id/records", maintenanceController.CreateRecord)
+			maintenanceGroup.GET("/items/:id/records", maintenanceController.GetItemRecords)
+			maintenanceGroup.GET("/records", maintenanceController.GetRecords)
+			maintenanceGroup.GET("/records/:id", maintenanceController.GetRecord)
+			maintenanceGroup.PUT("/records/:id", maintenanceController.UpdateRecord)
+			maintenanceGroup.DELETE("/records/:id", maintenanceController.DeleteRecord)
+			maintenanceGroup.GET("/reminders", maintenanceController.GetReminders)
+			maintenanceGroup.GET("/costs", maintenanceController.GetCostByType)
 		}
 
 		// 穿着分析
 		analyticsGroup := clothingAPI.Group("/analytics")
 		{
-			analyticsGroup.GET("/wear-frequency", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "穿着频率分析待实现"})
-			})
-			analyticsGroup.GET("/comfort-analysis", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "舒适度分析待实现"})
-			})
-			analyticsGroup.GET("/cost-per-wear", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "成本分析待实现"})
-			})
+			analyticsGroup.GET("/wear-frequency", analyticsController.GetWearFrequency)
+			analyticsGroup.GET("/comfort-analysis", analyticsController.GetComfortAnalysis)
+			analyticsGroup.GET("/cost-per-wear", analyticsController.GetCostPerWear)
+			analyticsGroup.GET("/trends", analyticsController.GetTrends)
 		}
 
 		// 购买记录
 		purchaseGroup := clothingAPI.Group("/purchases")
 		{
-			purchaseGroup.POST("/items/:id/records", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "购买记录功能待实现"})
-			})
-			purchaseGroup.GET("/records", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "购买记录功能待实现"})
-			})
-			purchaseGroup.GET("/stats", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "购买统计功能待实现"})
-			})
+			purchaseGroup.POST("/items/:id/records", purchaseController.CreateRecord)
+			purchaseGroup.GET("/items/:id/records", purchaseController.GetItemRecord)
+			purchaseGroup.GET("/records", purchaseController.GetRecords)
+			purchaseGroup.GET("/records/:id", purchaseController.GetRecord)
+			purchaseGroup.PUT("/records/:id", purchaseController.UpdateRecord)
+			purchaseGroup.DELETE("/records/:id", purchaseController.DeleteRecord)
+			purchaseGroup.GET("/stats", purchaseController.GetStats)
+			purchaseGroup.GET("/stats/monthly", purchaseController.GetMonthlySpending)
+			purchaseGroup.GET("/stats/categories", purchaseController.GetCategorySpending)
 		}
 
 		// 标签管理（用户自定义标签）
 		tagGroup := clothingAPI.Group("/tags")
 		{
-			tagGroup.POST("", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "创建标签功能待实现"})
-			})
-			tagGroup.PUT("/:id", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "更新标签功能待实现"})
-			})
-			tagGroup.DELETE("/:id", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "删除标签功能待实现"})
-			})
+			tagGroup.POST("", clothingController.CreateTag)
+			tagGroup.GET("/stats", clothingController.GetTagStats)
+			tagGroup.POST("/merge", clothingController.MergeTags)
+			tagGroup.PUT("/:id", clothingController.UpdateTag)
+			tagGroup.DELETE("/:id", clothingController.DeleteTag)
 		}
 
 		// 分类管理（管理员功能）
 		categoryGroup := clothingAPI.Group("/categories")
+		categoryGroup.Use(middleware.AdminMiddleware())
 		{
-			categoryGroup.POST("", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "创建分类功能待实现"})
-			})
-			categoryGroup.PUT("/:id", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "更新分类功能待实现"})
-			})
-			categoryGroup.DELETE("/:id", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "删除分类功能待实现"})
-			})
+			categoryGroup.POST("", clothingController.CreateCategory)
+			categoryGroup.PUT("/order", clothingController.ReorderCategories)
+			categoryGroup.PUT("/:id", clothingController.UpdateCategory)
+			categoryGroup.DELETE("/:id", clothingController.DeleteCategory)
 		}
 
 		// 批量操作
 		batchGroup := clothingAPI.Group("/batch")
 		{
-			batchGroup.POST("/items/tags", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "批量标签功能待实现"})
-			})
-			batchGroup.DELETE("/items/tags", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "批量标签功能待实现"})
-			})
-			batchGroup.PUT("/items/category", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "批量分类功能待实现"})
-			})
-			batchGroup.DELETE("/items", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "批量删除功能待实现"})
-			})
+			batchGroup.POST("/items/tags", clothingController.BatchAddTags)
+			batchGroup.DELETE("/items/tags", clothingController.BatchRemoveTags)
+			batchGroup.PUT("/items/category", clothingController.BatchMoveCategory)
+			batchGroup.PUT("/items/condition", clothingController.BatchUpdateCondition)
+			batchGroup.DELETE("/items", clothingController.BatchDeleteClothingItems)
 		}
 
 		// 导入导出
 		importExportGroup := clothingAPI.Group("/import-export")
 		{
-			importExportGroup.POST("/import", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "导入功能待实现"})
-			})
-			importExportGroup.GET("/export", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "导出功能待实现"})
-			})
+			importExportGroup.POST("/import", importExportController.ImportWardrobe)
+			importExportGroup.GET("/export", importExportController.ExportWardrobe)
 		}
 	}
 }
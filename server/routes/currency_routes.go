@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"what-to-wear/server/controllers"
+	"what-to-wear/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupCurrencyRoutes 设置货币与汇率路由
+func setupCurrencyRoutes(api *gin.RouterGroup, currencyController *controllers.CurrencyController) {
+	currencies := api.Group("/currencies")
+	currencies.Use(middleware.AuthMiddleware())
+	{
+		currencies.GET("", currencyController.GetCurrencies)
+		// 汇率：?currency=CNY&date=2025-01-01，默认用户本位币和今天
+		currencies.GET("/rates", currencyController.GetRates)
+		currencies.PUT("/home", currencyController.UpdateHomeCurrency)
+	}
+}
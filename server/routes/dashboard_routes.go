@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"what-to-wear/server/controllers"
+	"what-to-wear/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupDashboardRoutes 设置仪表板路由
+func setupDashboardRoutes(api *gin.RouterGroup, dashboardController *controllers.DashboardController) {
+	dashboard := api.Group("/dashboard")
+	dashboard.Use(middleware.AuthMiddleware())
+	{
+		dashboard.GET("", dashboardController.GetDashboard)
+	}
+}
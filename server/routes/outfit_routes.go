@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"what-to-wear/server/controllers"
+	"what-to-wear/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupOutfitRoutes 设置穿搭相关路由
+func setupOutfitRoutes(api *gin.RouterGroup, outfitController *controllers.OutfitController) {
+	outfits := api.Group("/outfits")
+	outfits.Use(middleware.AuthMiddleware())
+	{
+		outfits.POST("", outfitController.CreateOutfit)
+		outfits.GET("", outfitController.GetOutfits)
+		outfits.GET("/:id", outfitController.GetOutfit)
+		outfits.PUT("/:id", outfitController.UpdateOutfit)
+		outfits.DELETE("/:id", outfitController.DeleteOutfit)
+		outfits.POST("/:id/rate", outfitController.RateOutfit)
+
+		// 穿着记录
+		outfits.POST("/:id/wear", outfitController.WearOutfit)
+		outfits.GET("/:id/wears", outfitController.GetOutfitWears)
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"what-to-wear/server/controllers"
+	"what-to-wear/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupReportRoutes 设置衣橱报告路由
+func setupReportRoutes(api *gin.RouterGroup, reportController *controllers.ReportController) {
+	reports := api.Group("/reports")
+	reports.Use(middleware.AuthMiddleware())
+	{
+		reports.POST("", reportController.CreateReport)
+		reports.POST("/preview", reportController.PreviewReport)
+		reports.GET("", reportController.GetReports)
+		reports.GET("/:id", reportController.GetReport)
+		reports.GET("/:id/download", reportController.DownloadReport)
+		reports.DELETE("/:id", reportController.DeleteReport)
+	}
+}
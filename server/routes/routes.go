@@ -23,6 +23,9 @@ func setupPublicRoutes(api *gin.RouterGroup, container *container.Container) {
 	// 认证相关路由
 	setupAuthRoutes(api, container.GetAuthController())
 
+	// 本地存储文件路由
+	setupStorageRoutes(api, container.GetStorageController())
+
 	// 其他公开路由
 	setupPublicAPIRoutes(api)
 }
@@ -35,6 +38,39 @@ func setupProtectedRoutes(api *gin.RouterGroup, container *container.Container)
 
 		// 衣服相关路由
 		SetupClothingRoutes(api, container.GetClothingController())
+		SetupExtendedClothingRoutes(
+			api,
+			container.GetClothingController(),
+			container.GetRecommendationController(),
+			container.GetMaintenanceController(),
+			container.GetAnalyticsController(),
+			container.GetPurchaseController(),
+			container.GetImportExportController(),
+		)
+
+		// 货币与汇率路由
+		setupCurrencyRoutes(api, container.GetCurrencyController())
+
+		// 预算路由
+		setupBudgetRoutes(api, container.GetBudgetController())
+
+		// 穿搭相关路由
+		setupOutfitRoutes(api, container.GetOutfitController())
+
+		// 仪表板路由
+		setupDashboardRoutes(api, container.GetDashboardController())
+
+		// 衣橱报告路由
+		setupReportRoutes(api, container.GetReportController())
+
+		// 附件路由
+		setupAttachmentRoutes(api, container.GetAttachmentController())
+
+		// 账户数据导出路由
+		setupAccountExportRoutes(api, container.GetAccountExportController())
+
+		// 天气相关路由
+		setupWeatherRoutes(api, container.GetWeatherController())
 
 		// OSS相关路由
 		setupOSSRoutes(api, container.GetOSSController())
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"what-to-wear/server/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupStorageRoutes 设置本地存储文件路由
+func setupStorageRoutes(api *gin.RouterGroup, storageController *controllers.StorageController) {
+	// 签名链接凭签名访问，无需登录，PUT 用于直传上传
+	api.GET("/files/*key", storageController.ServeFile)
+	api.PUT("/files/*key", storageController.UploadFile)
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"what-to-wear/server/controllers"
+	"what-to-wear/server/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -9,6 +10,7 @@ import (
 // setupUserRoutes 设置用户相关路由
 func setupUserRoutes(protected *gin.RouterGroup, userController *controllers.UserController) {
 	user := protected.Group("/user")
+	user.Use(middleware.AuthMiddleware())
 	{
 		user.GET("/profile", userController.GetProfile)
 		user.PUT("/profile", userController.UpdateProfile)
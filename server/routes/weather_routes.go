@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"what-to-wear/server/controllers"
+	"what-to-wear/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupWeatherRoutes 设置天气相关路由
+func setupWeatherRoutes(api *gin.RouterGroup, weatherController *controllers.WeatherController) {
+	weather := api.Group("/weather")
+	weather.Use(middleware.AuthMiddleware())
+	{
+		// 获取天气：?city=beijing 或 ?latitude=39.9&longitude=116.4，可选 units
+		weather.GET("", weatherController.GetWeather)
+	}
+}
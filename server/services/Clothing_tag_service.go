@@ -2,11 +2,15 @@ package services
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
 	"what-to-wear/server/api"
 	"what-to-wear/server/api/dto"
 	"what-to-wear/server/api/errors"
 	"what-to-wear/server/models"
 	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
 )
 
 // ClothingTagService 衣物标签服务接口
@@ -16,7 +20,10 @@ type ClothingTagService interface {
 	GetTag(ctx context.Context, tagID uint) (*dto.TagDTO, error)
 	GetAllTags(ctx context.Context, userID uint) ([]dto.TagDTO, error)
 	UpdateTag(ctx context.Context, userID, tagID uint, req *dto.UpdateTagDTO) (*dto.TagDTO, error)
-	DeleteTag(ctx context.Context, userID, tagID uint) error
+	// 删除标签：仍有衣物使用且未确认时不删除，只返回受影响的衣物数量
+	DeleteTag(ctx context.Context, userID, tagID uint, confirm bool) (*dto.TagDeleteResultDTO, error)
+	// 合并标签：来源标签的衣物改用目标标签，来源标签随后停用
+	MergeTags(ctx context.Context, userID uint, req *dto.MergeTagsDTO) (*dto.TagMergeResultDTO, error)
 
 	// 按类型查询
 	GetTagsByType(ctx context.Context, tagType api.TagType, userID *uint) ([]dto.TagDTO, error)
@@ -92,22 +99,11 @@ func (s *clothingTagService) GetAllTags(ctx context.Context, userID uint) ([]dto
 
 // UpdateTag 更新标签
 func (s *clothingTagService) UpdateTag(ctx context.Context, userID, tagID uint, req *dto.UpdateTagDTO) (*dto.TagDTO, error) {
-	// 先获取标签
-	tag, err := s.tagRepo.GetByID(ctx, tagID)
+	tag, err := s.getOwnedTag(ctx, userID, tagID)
 	if err != nil {
 		return nil, err
 	}
 
-	// 检查权限：只有标签创建者或系统管理员可以更新标签
-	if tag.UserID != nil && *tag.UserID != userID && !tag.IsSystem {
-		return nil, errors.ErrForbidden("permission denied")
-	}
-
-	// 系统标签不允许更新
-	if tag.IsSystem {
-		return nil, errors.ErrForbidden("system tags cannot be updated")
-	}
-
 	// 更新字段
 	if req.Name != nil {
 		tag.Name = *req.Name
@@ -118,32 +114,101 @@ func (s *clothingTagService) UpdateTag(ctx context.Context, userID, tagID uint,
 
 	// 调用仓库层更新
 	if err := s.tagRepo.Update(ctx, tag); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("更新标签失败: %w", err)
 	}
 
 	return s.convertToDTO(tag), nil
 }
 
 // DeleteTag 删除标签
-func (s *clothingTagService) DeleteTag(ctx context.Context, userID, tagID uint) error {
-	// 先获取标签
-	tag, err := s.tagRepo.GetByID(ctx, tagID)
+func (s *clothingTagService) DeleteTag(ctx context.Context, userID, tagID uint, confirm bool) (*dto.TagDeleteResultDTO, error) {
+	tag, err := s.getOwnedTag(ctx, userID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := s.tagRepo.GetTagItemCount(ctx, tagID)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("统计标签使用情况失败: %w", err)
 	}
 
-	// 检查权限：只有标签创建者或系统管理员可以删除标签
-	if tag.UserID != nil && *tag.UserID != userID && !tag.IsSystem {
-		return errors.ErrForbidden("permission denied")
+	result := &dto.TagDeleteResultDTO{
+		TagID:         tag.ID,
+		Name:          tag.Name,
+		AffectedItems: affected,
+	}
+	if affected > 0 && !confirm {
+		return result, nil
 	}
 
-	// 系统标签不允许删除
-	if tag.IsSystem {
-		return errors.ErrForbidden("system tags cannot be deleted")
+	// 调用仓库层删除（停用标签并移除衣物关联）
+	if err := s.tagRepo.Delete(ctx, tagID); err != nil {
+		return nil, fmt.Errorf("删除标签失败: %w", err)
+	}
+	result.Deleted = true
+	return result, nil
+}
+
+// MergeTags 合并标签，来源标签须为用户自己的标签，目标标签可以是系统标签
+func (s *clothingTagService) MergeTags(ctx context.Context, userID uint, req *dto.MergeTagsDTO) (*dto.TagMergeResultDTO, error) {
+	target, err := s.tagRepo.GetByID(ctx, req.TargetID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("目标标签不存在")
+		}
+		return nil, fmt.Errorf("获取标签失败: %w", err)
+	}
+	if !target.IsActive || (!target.IsSystem && (target.UserID == nil || *target.UserID != userID)) {
+		return nil, errors.ErrNotFound("目标标签不存在")
+	}
+
+	sourceIDs := make([]uint, 0, len(req.SourceIDs))
+	seen := make(map[uint]bool, len(req.SourceIDs))
+	for _, sourceID := range req.SourceIDs {
+		if sourceID == req.TargetID {
+			return nil, errors.ErrInvalidRequest("来源标签不能包含目标标签")
+		}
+		if seen[sourceID] {
+			continue
+		}
+		seen[sourceID] = true
+		if _, err := s.getOwnedTag(ctx, userID, sourceID); err != nil {
+			return nil, err
+		}
+		sourceIDs = append(sourceIDs, sourceID)
+	}
+
+	affected, err := s.tagRepo.MergeTags(ctx, sourceIDs, target.ID)
+	if err != nil {
+		return nil, fmt.Errorf("合并标签失败: %w", err)
 	}
 
-	// 调用仓库层删除（软删除）
-	return s.tagRepo.Delete(ctx, tagID)
+	return &dto.TagMergeResultDTO{
+		Target:        *s.convertToDTO(target),
+		MergedTagIDs:  sourceIDs,
+		AffectedItems: affected,
+	}, nil
+}
+
+// getOwnedTag 获取当前用户创建的可用标签，系统标签和他人标签不允许修改
+func (s *clothingTagService) getOwnedTag(ctx context.Context, userID, tagID uint) (*models.ClothingTag, error) {
+	tag, err := s.tagRepo.GetByID(ctx, tagID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("标签不存在")
+		}
+		return nil, fmt.Errorf("获取标签失败: %w", err)
+	}
+	if !tag.IsActive {
+		return nil, errors.ErrNotFound("标签不存在")
+	}
+	if tag.IsSystem {
+		return nil, errors.ErrForbidden("系统标签不允许修改")
+	}
+	if tag.UserID == nil || *tag.UserID != userID {
+		return nil, errors.ErrForbidden("无权操作该标签")
+	}
+	return tag, nil
 }
 
 // GetTagsByType 根据类型获取标签
@@ -216,7 +281,10 @@ func (s *clothingTagService) GetTagStats(ctx context.Context, userID uint) ([]dt
 		}
 
 		result = append(result, dto.TagStatsItem{
+			TagID:      tag.ID,
 			TagName:    tag.Name,
+			Type:       string(tag.Type),
+			IsSystem:   tag.IsSystem,
 			Count:      count,
 			Percentage: percentage,
 		})
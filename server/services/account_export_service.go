@@ -0,0 +1,629 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
+	"what-to-wear/server/config"
+	"what-to-wear/server/models"
+	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
+)
+
+const (
+	accountExportManifestVersion   = 1
+	accountExportCleanupBatchSize  = 100
+	defaultAccountExportListLimit  = 20
+	accountExportDownloadURLFormat = "/api/account/export-files/%s"
+
+	// 进度百分比：汇总数据完成后为 collected，附件打包占 collected 到 packed 之间
+	accountExportProgressCollected = 30
+	accountExportProgressPacked    = 95
+)
+
+// AccountExportService 账户数据导出服务接口
+type AccountExportService interface {
+	// 导出任务管理，deleteAccount 为 true 时导出完成后删除账户
+	CreateExport(ctx context.Context, userID uint, deleteAccount bool) (*dto.AccountExportDTO, error)
+	GetExports(ctx context.Context, userID uint, limit int) ([]dto.AccountExportDTO, error)
+	GetExport(ctx context.Context, userID, exportID uint) (*dto.AccountExportDTO, error)
+	// 根据下载令牌获取可下载的导出任务，返回的任务包含文件路径
+	GetExportFile(ctx context.Context, token string) (*models.AccountExport, error)
+	DeleteExport(ctx context.Context, userID, exportID uint) error
+
+	// 生成任务对应的压缩包（由后台任务调用）
+	ProcessExport(ctx context.Context, export *models.AccountExport) error
+	// 删除下载链接过期的压缩包和任务，返回删除数量
+	CleanupExpired(ctx context.Context) (int, error)
+}
+
+// accountExportService 账户数据导出服务实现
+type accountExportService struct {
+	accountExportRepo    repositories.AccountExportRepository
+	userRepo             repositories.UserRepository
+	clothingItemRepo     repositories.ClothingItemRepository
+	clothingCategoryRepo repositories.ClothingCategoryRepository
+	clothingTagRepo      repositories.ClothingTagRepository
+	outfitRepo           repositories.OutfitRepository
+	outfitItemRepo       repositories.OutfitItemRepository
+	wearRecordRepo       repositories.WearRecordRepository
+	purchaseRecordRepo   repositories.PurchaseRecordRepository
+	maintenanceRepo      repositories.MaintenanceRecordRepository
+	budgetRepo           repositories.BudgetRepository
+	attachmentRepo       repositories.AttachmentRepository
+	storage              Storage
+	accountPurger        AccountPurger
+	outputDir            string
+	linkTTL              time.Duration
+}
+
+// NewAccountExportService 创建账户数据导出服务实例
+func NewAccountExportService(
+	cfg *config.Config,
+	accountExportRepo repositories.AccountExportRepository,
+	userRepo repositories.UserRepository,
+	clothingItemRepo repositories.ClothingItemRepository,
+	clothingCategoryRepo repositories.ClothingCategoryRepository,
+	clothingTagRepo repositories.ClothingTagRepository,
+	outfitRepo repositories.OutfitRepository,
+	outfitItemRepo repositories.OutfitItemRepository,
+	wearRecordRepo repositories.WearRecordRepository,
+	purchaseRecordRepo repositories.PurchaseRecordRepository,
+	maintenanceRepo repositories.MaintenanceRecordRepository,
+	budgetRepo repositories.BudgetRepository,
+	attachmentRepo repositories.AttachmentRepository,
+	storage Storage,
+	accountPurger AccountPurger,
+) AccountExportService {
+	return &accountExportService{
+		accountExportRepo:    accountExportRepo,
+		userRepo:             userRepo,
+		clothingItemRepo:     clothingItemRepo,
+		clothingCategoryRepo: clothingCategoryRepo,
+		clothingTagRepo:      clothingTagRepo,
+		outfitRepo:           outfitRepo,
+		outfitItemRepo:       outfitItemRepo,
+		wearRecordRepo:       wearRecordRepo,
+		purchaseRecordRepo:   purchaseRecordRepo,
+		maintenanceRepo:      maintenanceRepo,
+		budgetRepo:           budgetRepo,
+		attachmentRepo:       attachmentRepo,
+		storage:              storage,
+		accountPurger:        accountPurger,
+		outputDir:            cfg.AccountExport.OutputDir,
+		linkTTL:              time.Duration(cfg.AccountExport.LinkTTL) * time.Second,
+	}
+}
+
+// accountExportItem 导出的衣物，附带关联的标签ID
+type accountExportItem struct {
+	models.ClothingItem
+	TagIDs []uint `json:"tag_ids"`
+}
+
+// accountExportOutfit 导出的穿搭，附带穿搭中的衣物
+type accountExportOutfit struct {
+	*models.Outfit
+	Items []models.OutfitItem `json:"items"`
+}
+
+// accountExportEntry 压缩包中的一个数据文件
+type accountExportEntry struct {
+	path    string
+	records int
+	value   interface{}
+}
+
+// CreateExport 创建账户数据导出任务，同一用户同时只能有一个未完成的任务
+func (s *accountExportService) CreateExport(ctx context.Context, userID uint, deleteAccount bool) (*dto.AccountExportDTO, error) {
+	active, err := s.accountExportRepo.HasActive(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("检查导出任务失败: %w", err)
+	}
+	if active {
+		return nil, errors.ErrConflict("已有正在进行的数据导出任务")
+	}
+
+	token, err := newDownloadToken()
+	if err != nil {
+		return nil, fmt.Errorf("生成下载令牌失败: %w", err)
+	}
+	export := &models.AccountExport{
+		UserID:        userID,
+		Status:        api.JobStatusPending,
+		Stage:         api.ExportStageQueued,
+		DeleteAccount: deleteAccount,
+		DownloadToken: token,
+	}
+	if err := s.accountExportRepo.Create(ctx, export); err != nil {
+		return nil, fmt.Errorf("创建导出任务失败: %w", err)
+	}
+
+	result := toAccountExportDTO(export, time.Now())
+	// 导出完成后账户会被删除，无法再登录查询任务，创建时即返回下载链接，导出完成前访问该链接会提示尚未完成
+	if deleteAccount {
+		result.DownloadURL = accountExportDownloadURL(export.DownloadToken)
+	}
+	return result, nil
+}
+
+// GetExports 获取用户的导出任务列表
+func (s *accountExportService) GetExports(ctx context.Context, userID uint, limit int) ([]dto.AccountExportDTO, error) {
+	if limit <= 0 {
+		limit = defaultAccountExportListLimit
+	}
+	exports, err := s.accountExportRepo.GetByUserID(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("获取导出任务列表失败: %w", err)
+	}
+
+	now := time.Now()
+	result := make([]dto.AccountExportDTO, 0, len(exports))
+	for i := range exports {
+		result = append(result, *toAccountExportDTO(&exports[i], now))
+	}
+	return result, nil
+}
+
+// GetExport 获取导出任务详情和进度
+func (s *accountExportService) GetExport(ctx context.Context, userID, exportID uint) (*dto.AccountExportDTO, error) {
+	export, err := s.getOwnedExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, err
+	}
+	return toAccountExportDTO(export, time.Now()), nil
+}
+
+// GetExportFile 根据下载令牌获取可下载的导出任务
+func (s *accountExportService) GetExportFile(ctx context.Context, token string) (*models.AccountExport, error) {
+	export, err := s.accountExportRepo.GetByToken(ctx, token)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("下载链接无效")
+		}
+		return nil, fmt.Errorf("获取导出任务失败: %w", err)
+	}
+
+	switch {
+	case export.Status == api.JobStatusFailed:
+		return nil, errors.ErrConflict("数据导出失败", export.Error)
+	case export.Status != api.JobStatusCompleted:
+		return nil, errors.ErrConflict("数据导出尚未完成")
+	case !export.IsDownloadable(time.Now()):
+		return nil, errors.ErrNotFound("下载链接已过期")
+	}
+	if _, err := os.Stat(export.FilePath); err != nil {
+		return nil, errors.ErrNotFound("导出文件不存在")
+	}
+	return export, nil
+}
+
+// DeleteExport 删除导出任务及其压缩包
+func (s *accountExportService) DeleteExport(ctx context.Context, userID, exportID uint) error {
+	export, err := s.getOwnedExport(ctx, userID, exportID)
+	if err != nil {
+		return err
+	}
+	if export.Status == api.JobStatusProcessing {
+		return errors.ErrConflict("数据正在导出中，请稍后再删除")
+	}
+
+	if err := removeReportFile(export.FilePath); err != nil {
+		return fmt.Errorf("删除导出文件失败: %w", err)
+	}
+	if err := s.accountExportRepo.Delete(ctx, export.ID); err != nil {
+		return fmt.Errorf("删除导出任务失败: %w", err)
+	}
+	return nil
+}
+
+// ProcessExport 生成压缩包并更新任务状态，任务要求删除账户时在导出成功后删除
+func (s *accountExportService) ProcessExport(ctx context.Context, export *models.AccountExport) error {
+	if err := s.generateArchive(ctx, export); err != nil {
+		export.Status = api.JobStatusFailed
+		export.Stage = api.ExportStageFinished
+		export.Error = err.Error()
+		if updateErr := s.accountExportRepo.Update(ctx, export); updateErr != nil {
+			return fmt.Errorf("更新导出任务失败: %w", updateErr)
+		}
+		return err
+	}
+
+	now := time.Now()
+	export.Status = api.JobStatusCompleted
+	export.Stage = api.ExportStageFinished
+	export.Progress = 100
+	export.Error = ""
+	export.CompletedAt = &now
+	if s.linkTTL > 0 {
+		expiresAt := now.Add(s.linkTTL)
+		export.ExpiresAt = &expiresAt
+	}
+	if err := s.accountExportRepo.Update(ctx, export); err != nil {
+		return fmt.Errorf("更新导出任务失败: %w", err)
+	}
+
+	if export.DeleteAccount {
+		if err := s.accountPurger.Purge(ctx, export.UserID); err != nil {
+			// 数据已可下载，仅记录删除失败的原因，用户可重新发起删除
+			export.Error = "数据已导出，但删除账户失败: " + err.Error()
+			if updateErr := s.accountExportRepo.Update(ctx, export); updateErr != nil {
+				return fmt.Errorf("更新导出任务失败: %w", updateErr)
+			}
+			return fmt.Errorf("删除账户失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// CleanupExpired 删除过期的导出压缩包
+func (s *accountExportService) CleanupExpired(ctx context.Context) (int, error) {
+	exports, err := s.accountExportRepo.GetExpired(ctx, time.Now(), accountExportCleanupBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("获取过期导出任务失败: %w", err)
+	}
+
+	removed := 0
+	for _, export := range exports {
+		if err := removeReportFile(export.FilePath); err != nil {
+			return removed, fmt.Errorf("删除导出文件失败: %w", err)
+		}
+		if err := s.accountExportRepo.Delete(ctx, export.ID); err != nil {
+			return removed, fmt.Errorf("删除导出任务失败: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// generateArchive 汇总用户数据和附件，写入 ZIP 压缩包
+func (s *accountExportService) generateArchive(ctx context.Context, export *models.AccountExport) error {
+	user, err := s.userRepo.GetByID(ctx, export.UserID)
+	if err != nil {
+		return fmt.Errorf("获取用户失败: %w", err)
+	}
+	s.reportProgress(ctx, export, api.ExportStageCollecting, 0)
+
+	entries, err := s.collectEntries(ctx, user)
+	if err != nil {
+		return err
+	}
+	attachments, err := s.attachmentRepo.GetByUserID(ctx, user.ID, 0)
+	if err != nil {
+		return fmt.Errorf("获取附件失败: %w", err)
+	}
+	entries = append(entries, accountExportEntry{path: "attachments.json", records: len(attachments), value: attachments})
+	s.reportProgress(ctx, export, api.ExportStageAttachments, accountExportProgressCollected)
+
+	now := time.Now()
+	fileName := fmt.Sprintf("account-export-%d-%s.zip", user.ID, now.Format("20060102150405"))
+	dir := filepath.Join(s.outputDir, fmt.Sprintf("%d", user.ID))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("创建导出目录失败: %w", err)
+	}
+	filePath := filepath.Join(dir, fmt.Sprintf("%d-%s", export.ID, fileName))
+
+	// 先写入临时文件，完整生成后再重命名，避免下载到不完整的压缩包
+	tmpPath := filePath + ".tmp"
+	size, err := s.writeArchive(ctx, export, tmpPath, user, entries, attachments, now)
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("保存导出文件失败: %w", err)
+	}
+
+	export.FileName = fileName
+	export.FilePath = filePath
+	export.FileSize = size
+	return nil
+}
+
+// collectEntries 获取用户名下的所有数据，每类数据对应压缩包中的一个 JSON 文件
+func (s *accountExportService) collectEntries(ctx context.Context, user *models.User) ([]accountExportEntry, error) {
+	profile := *user
+	profile.Password = ""
+
+	items, err := s.clothingItemRepo.GetAllByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("获取衣物失败: %w", err)
+	}
+	itemIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+	itemTags, err := s.clothingItemRepo.GetTagsByItemIDs(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取衣物标签失败: %w", err)
+	}
+	exportItems := make([]accountExportItem, 0, len(items))
+	for _, item := range items {
+		tagIDs := make([]uint, 0, len(itemTags[item.ID]))
+		for _, tag := range itemTags[item.ID] {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+		exportItems = append(exportItems, accountExportItem{ClothingItem: item, TagIDs: tagIDs})
+	}
+
+	outfits, err := s.outfitRepo.GetByUserID(ctx, user.ID, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("获取穿搭失败: %w", err)
+	}
+	exportOutfits := make([]accountExportOutfit, 0, len(outfits))
+	for _, outfit := range outfits {
+		outfitItems, err := s.outfitItemRepo.GetByOutfitID(ctx, outfit.ID)
+		if err != nil {
+			return nil, fmt.Errorf("获取穿搭衣物失败: %w", err)
+		}
+		exportOutfits = append(exportOutfits, accountExportOutfit{Outfit: outfit, Items: outfitItems})
+	}
+
+	wears, err := s.wearRecordRepo.GetByUserID(ctx, user.ID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("获取穿着记录失败: %w", err)
+	}
+	purchases, err := s.purchaseRecordRepo.GetByUserID(ctx, user.ID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("获取购买记录失败: %w", err)
+	}
+	maintenances, err := s.maintenanceRepo.GetByUserID(ctx, user.ID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("获取保养记录失败: %w", err)
+	}
+	tags, err := s.clothingTagRepo.GetUserTags(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("获取自定义标签失败: %w", err)
+	}
+	categories, err := s.clothingCategoryRepo.GetAll(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("获取分类失败: %w", err)
+	}
+	customCategories := make([]models.ClothingCategory, 0)
+	for _, category := range categories {
+		if category.IsCustom() {
+			customCategories = append(customCategories, category)
+		}
+	}
+	budgets, err := s.budgetRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("获取预算失败: %w", err)
+	}
+
+	return []accountExportEntry{
+		{path: "profile.json", records: 1, value: profile},
+		{path: "clothing_items.json", records: len(exportItems), value: exportItems},
+		{path: "outfits.json", records: len(exportOutfits), value: exportOutfits},
+		{path: "wear_records.json", records: len(wears), value: wears},
+		{path: "purchase_records.json", records: len(purchases), value: purchases},
+		{path: "maintenance_records.json", records: len(maintenances), value: maintenances},
+		{path: "tags.json", records: len(tags), value: tags},
+		{path: "categories.json", records: len(customCategories), value: customCategories},
+		{path: "budgets.json", records: len(budgets), value: budgets},
+	}, nil
+}
+
+// writeArchive 写入数据文件、附件和清单，返回压缩包大小
+func (s *accountExportService) writeArchive(
+	ctx context.Context,
+	export *models.AccountExport,
+	filePath string,
+	user *models.User,
+	entries []accountExportEntry,
+	attachments []models.Attachment,
+	generatedAt time.Time,
+) (int64, error) {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return 0, fmt.Errorf("创建导出文件失败: %w", err)
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+	manifest := dto.AccountExportManifest{
+		Version:     accountExportManifestVersion,
+		UserID:      user.ID,
+		Username:    user.Username,
+		GeneratedAt: generatedAt,
+		Files:       make([]dto.AccountExportManifestFile, 0, len(entries)),
+		Attachments: make([]dto.AccountExportManifestAttachment, 0, len(attachments)),
+	}
+
+	for _, entry := range entries {
+		content, err := json.MarshalIndent(entry.value, "", "  ")
+		if err != nil {
+			return 0, fmt.Errorf("生成 %s 失败: %w", entry.path, err)
+		}
+		if err := writeArchiveFile(archive, entry.path, content); err != nil {
+			return 0, err
+		}
+		sum := sha256.Sum256(content)
+		manifest.Files = append(manifest.Files, dto.AccountExportManifestFile{
+			Path:    entry.path,
+			Records: entry.records,
+			Size:    int64(len(content)),
+			SHA256:  hex.EncodeToString(sum[:]),
+		})
+	}
+
+	for i := range attachments {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		item, err := s.writeAttachment(ctx, archive, &attachments[i])
+		if err != nil {
+			return 0, err
+		}
+		manifest.Attachments = append(manifest.Attachments, item)
+
+		progress := accountExportProgressCollected +
+			(accountExportProgressPacked-accountExportProgressCollected)*(i+1)/len(attachments)
+		s.reportProgress(ctx, export, api.ExportStageAttachments, progress)
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("生成清单失败: %w", err)
+	}
+	if err := writeArchiveFile(archive, "manifest.json", content); err != nil {
+		return 0, err
+	}
+	if err := archive.Close(); err != nil {
+		return 0, fmt.Errorf("写入导出文件失败: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("读取导出文件失败: %w", err)
+	}
+	return info.Size(), nil
+}
+
+// writeAttachment 将附件原文件写入压缩包的 attachments 目录
+// 附件无法读取时记录在清单中并继续导出，写入压缩包中途失败则整个导出失败
+func (s *accountExportService) writeAttachment(ctx context.Context, archive *zip.Writer, attachment *models.Attachment) (dto.AccountExportManifestAttachment, error) {
+	item := dto.AccountExportManifestAttachment{
+		ID:           attachment.ID,
+		EntityType:   attachment.EntityType,
+		EntityID:     attachment.EntityID,
+		OriginalName: attachment.OriginalName,
+		MimeType:     attachment.MimeType,
+	}
+
+	reader, err := s.openAttachment(ctx, attachment)
+	if err != nil {
+		// 不在清单中暴露服务器路径等存储细节
+		item.Error = "读取附件失败"
+		if stderrors.Is(err, ErrStorageObjectNotFound) {
+			item.Error = "附件文件不存在"
+		}
+		return item, nil
+	}
+	defer reader.Close()
+
+	item.Path = fmt.Sprintf("attachments/%d-%s", attachment.ID, archiveFileName(attachment.OriginalName))
+	// 图片和视频已经过压缩，直接存储
+	writer, err := archive.CreateHeader(&zip.FileHeader{Name: item.Path, Method: zip.Store, Modified: attachment.CreatedAt})
+	if err != nil {
+		return item, fmt.Errorf("写入附件失败: %w", err)
+	}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(writer, hash), reader)
+	if err != nil {
+		return item, fmt.Errorf("读取附件 %d 失败: %w", attachment.ID, err)
+	}
+
+	item.Size = size
+	item.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	item.Included = true
+	return item, nil
+}
+
+// openAttachment 从附件所在的存储中读取原文件
+func (s *accountExportService) openAttachment(ctx context.Context, attachment *models.Attachment) (io.ReadCloser, error) {
+	if attachment.StorageProvider != s.storage.Name() {
+		return nil, fmt.Errorf("附件存储提供商 %s 与当前存储 %s 不一致", attachment.StorageProvider, s.storage.Name())
+	}
+	key := attachment.ObjectKey
+	if key == "" {
+		key = attachment.FilePath
+	}
+	return s.storage.Get(ctx, key)
+}
+
+// reportProgress 更新任务进度，进度更新失败不影响导出
+func (s *accountExportService) reportProgress(ctx context.Context, export *models.AccountExport, stage api.ExportStage, progress int) {
+	if export.Stage == stage && export.Progress == progress {
+		return
+	}
+	export.Stage = stage
+	export.Progress = progress
+	s.accountExportRepo.UpdateProgress(ctx, export.ID, stage, progress)
+}
+
+// getOwnedExport 获取属于用户的导出任务
+func (s *accountExportService) getOwnedExport(ctx context.Context, userID, exportID uint) (*models.AccountExport, error) {
+	export, err := s.accountExportRepo.GetByID(ctx, exportID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("导出任务不存在")
+		}
+		return nil, fmt.Errorf("获取导出任务失败: %w", err)
+	}
+	if export.UserID != userID {
+		return nil, errors.ErrNotFound("导出任务不存在")
+	}
+	return export, nil
+}
+
+// toAccountExportDTO 将导出任务转换为DTO
+func toAccountExportDTO(export *models.AccountExport, now time.Time) *dto.AccountExportDTO {
+	result := &dto.AccountExportDTO{
+		ID:            export.ID,
+		Status:        export.Status,
+		Stage:         export.Stage,
+		Progress:      export.Progress,
+		DeleteAccount: export.DeleteAccount,
+		FileName:      export.FileName,
+		FileSize:      export.FileSize,
+		Error:         export.Error,
+		CreatedAt:     export.CreatedAt,
+		CompletedAt:   export.CompletedAt,
+		ExpiresAt:     export.ExpiresAt,
+	}
+	if export.IsDownloadable(now) {
+		result.DownloadURL = accountExportDownloadURL(export.DownloadToken)
+	}
+	return result
+}
+
+// accountExportDownloadURL 根据下载令牌生成下载链接
+func accountExportDownloadURL(token string) string {
+	return fmt.Sprintf(accountExportDownloadURLFormat, token)
+}
+
+// writeArchiveFile 向压缩包写入一个文件
+func writeArchiveFile(archive *zip.Writer, name string, content []byte) error {
+	writer, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", name, err)
+	}
+	if _, err := writer.Write(content); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", name, err)
+	}
+	return nil
+}
+
+// archiveFileName 去掉原始文件名中的目录部分，避免压缩包内出现路径穿越
+func archiveFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" || name == ".." {
+		return "file"
+	}
+	return name
+}
+
+// newDownloadToken 生成随机的下载令牌
+func newDownloadToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
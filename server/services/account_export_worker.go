@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"what-to-wear/server/config"
+	"what-to-wear/server/logger"
+	"what-to-wear/server/repositories"
+)
+
+// AccountExportWorker 账户数据导出后台任务接口
+type AccountExportWorker interface {
+	// 启动后台处理，未启用或重复调用时无效
+	Start()
+	// 停止后台处理并等待当前任务结束
+	Stop()
+	// 处理一批排队中的任务并清理过期压缩包，返回处理的任务数量
+	RunOnce(ctx context.Context) (int, error)
+}
+
+// accountExportWorker 账户数据导出后台任务实现
+type accountExportWorker struct {
+	accountExportRepo    repositories.AccountExportRepository
+	accountExportService AccountExportService
+	enabled              bool
+	interval             time.Duration
+	batchSize            int
+	processingTimeout    time.Duration
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewAccountExportWorker 创建账户数据导出后台任务实例
+func NewAccountExportWorker(
+	cfg *config.Config,
+	accountExportRepo repositories.AccountExportRepository,
+	accountExportService AccountExportService,
+) AccountExportWorker {
+	return &accountExportWorker{
+		accountExportRepo:    accountExportRepo,
+		accountExportService: accountExportService,
+		enabled:              cfg.AccountExport.WorkerEnabled,
+		interval:             time.Duration(cfg.AccountExport.PollInterval) * time.Second,
+		batchSize:            cfg.AccountExport.BatchSize,
+		processingTimeout:    time.Duration(cfg.AccountExport.ProcessingTimeout) * time.Second,
+	}
+}
+
+// Start 启动后台处理
+func (w *accountExportWorker) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.enabled || w.cancel != nil || w.interval <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go w.loop(ctx, w.done)
+	logger.GetLogger().Info("Account export worker started", logger.Fields{
+		"interval":   w.interval.String(),
+		"batch_size": w.batchSize,
+	})
+}
+
+// Stop 停止后台处理
+func (w *accountExportWorker) Stop() {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// loop 按间隔轮询任务，启动时立即执行一次
+func (w *accountExportWorker) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			logger.GetLogger().ErrorWithErr(err, "Account export worker run failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 处理一批排队中的任务
+func (w *accountExportWorker) RunOnce(ctx context.Context) (int, error) {
+	log := logger.GetLogger()
+
+	// 进程异常退出时遗留的处理中任务重新排队
+	if w.processingTimeout > 0 {
+		requeued, err := w.accountExportRepo.RequeueStale(ctx, time.Now().Add(-w.processingTimeout))
+		if err != nil {
+			return 0, fmt.Errorf("重新排队超时导出任务失败: %w", err)
+		}
+		if requeued > 0 {
+			log.Warn("Requeued stale account exports", logger.Fields{"count": requeued})
+		}
+	}
+
+	exports, err := w.accountExportRepo.ClaimPending(ctx, w.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("领取导出任务失败: %w", err)
+	}
+
+	processed := 0
+	for i := range exports {
+		if ctx.Err() != nil {
+			break
+		}
+		export := &exports[i]
+		if err := w.accountExportService.ProcessExport(ctx, export); err != nil {
+			log.WarnWithErr(err, "Failed to export account data", logger.Fields{
+				"export_id": export.ID,
+				"user_id":   export.UserID,
+			})
+			continue
+		}
+		processed++
+		log.Info("Account data exported", logger.Fields{
+			"export_id":      export.ID,
+			"user_id":        export.UserID,
+			"file_size":      export.FileSize,
+			"delete_account": export.DeleteAccount,
+		})
+	}
+
+	if removed, err := w.accountExportService.CleanupExpired(ctx); err != nil {
+		return processed, err
+	} else if removed > 0 {
+		log.Info("Expired account exports removed", logger.Fields{"count": removed})
+	}
+
+	return processed, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"what-to-wear/server/logger"
+	"what-to-wear/server/repositories"
+)
+
+// AccountPurger 账户彻底删除接口
+type AccountPurger interface {
+	// 先删除附件、未确认的上传文件和报告文件，再删除用户及其名下的所有数据
+	Purge(ctx context.Context, userID uint) error
+}
+
+// accountPurger 账户彻底删除实现
+type accountPurger struct {
+	userRepo       repositories.UserRepository
+	attachmentRepo repositories.AttachmentRepository
+	uploadSlotRepo repositories.UploadSlotRepository
+	reportJobRepo  repositories.ReportJobRepository
+	storage        Storage
+}
+
+// NewAccountPurger 创建账户彻底删除实例
+func NewAccountPurger(
+	userRepo repositories.UserRepository,
+	attachmentRepo repositories.AttachmentRepository,
+	uploadSlotRepo repositories.UploadSlotRepository,
+	reportJobRepo repositories.ReportJobRepository,
+	storage Storage,
+) AccountPurger {
+	return &accountPurger{
+		userRepo:       userRepo,
+		attachmentRepo: attachmentRepo,
+		uploadSlotRepo: uploadSlotRepo,
+		reportJobRepo:  reportJobRepo,
+		storage:        storage,
+	}
+}
+
+// Purge 删除存储中的文件后再删除数据库记录，文件删除失败时不删除记录，以便重试时仍能找到文件
+func (p *accountPurger) Purge(ctx context.Context, userID uint) error {
+	attachments, err := p.attachmentRepo.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("获取附件失败: %w", err)
+	}
+	for _, attachment := range attachments {
+		key := attachment.ObjectKey
+		if key == "" {
+			key = attachment.FilePath
+		}
+		if err := p.deleteObject(ctx, attachment.StorageProvider, key); err != nil {
+			return fmt.Errorf("删除附件 %d 失败: %w", attachment.ID, err)
+		}
+	}
+
+	slots, err := p.uploadSlotRepo.GetPendingByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("获取上传凭证失败: %w", err)
+	}
+	for _, slot := range slots {
+		if err := p.deleteObject(ctx, slot.StorageProvider, slot.ObjectKey); err != nil {
+			return fmt.Errorf("删除未确认的上传文件失败: %w", err)
+		}
+	}
+
+	jobs, err := p.reportJobRepo.GetByUserID(ctx, userID, 0)
+	if err != nil {
+		return fmt.Errorf("获取报告任务失败: %w", err)
+	}
+	for _, job := range jobs {
+		if err := removeReportFile(job.FilePath); err != nil {
+			return fmt.Errorf("删除报告文件失败: %w", err)
+		}
+	}
+
+	if err := p.userRepo.Purge(ctx, userID); err != nil {
+		return fmt.Errorf("删除用户数据失败: %w", err)
+	}
+	return nil
+}
+
+// deleteObject 删除当前存储中的对象，其他存储提供商的对象无法访问，仅记录日志
+func (p *accountPurger) deleteObject(ctx context.Context, provider, key string) error {
+	if key == "" {
+		return nil
+	}
+	if provider != p.storage.Name() {
+		logger.GetLogger().Warn("Skip deleting object from another storage provider", logger.Fields{
+			"provider":   provider,
+			"object_key": key,
+		})
+		return nil
+	}
+	return p.storage.Delete(ctx, key)
+}
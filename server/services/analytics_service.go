@@ -0,0 +1,534 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
+	"what-to-wear/server/models"
+	"what-to-wear/server/repositories"
+)
+
+const (
+	defaultAnalyticsPeriod = "month"
+	defaultAnalyticsLimit  = 10
+	maxAnalyticsLimit      = 50
+	analyticsTimeLayout    = "2006-01-02 15:04:05"
+	unknownAnalyticsLabel  = "未知"
+)
+
+// AnalyticsService 穿着分析服务接口
+type AnalyticsService interface {
+	// 穿着频率分析：按时间、分类、品牌、颜色统计穿着次数
+	GetWearFrequency(ctx context.Context, userID uint, req *dto.AnalyticsRequestDTO) (*dto.AnalyticsDTO, error)
+	// 每次穿着成本分析：按分类、品牌、颜色统计成本
+	GetCostPerWear(ctx context.Context, userID uint, req *dto.AnalyticsRequestDTO) (*dto.AnalyticsDTO, error)
+}
+
+// analyticsService 穿着分析服务实现
+type analyticsService struct {
+	clothingItemRepo     repositories.ClothingItemRepository
+	clothingCategoryRepo repositories.ClothingCategoryRepository
+	wearRecordRepo       repositories.WearRecordRepository
+	currencyService      CurrencyService
+}
+
+// NewAnalyticsService 创建穿着分析服务实例
+func NewAnalyticsService(
+	clothingItemRepo repositories.ClothingItemRepository,
+	clothingCategoryRepo repositories.ClothingCategoryRepository,
+	wearRecordRepo repositories.WearRecordRepository,
+	currencyService CurrencyService,
+) AnalyticsService {
+	return &analyticsService{
+		clothingItemRepo:     clothingItemRepo,
+		clothingCategoryRepo: clothingCategoryRepo,
+		wearRecordRepo:       wearRecordRepo,
+		currencyService:      currencyService,
+	}
+}
+
+// analyticsPeriod 分析统计周期
+type analyticsPeriod struct {
+	name  string
+	start time.Time
+	end   time.Time
+}
+
+// itemAnalytics 单件衣物的分析数据
+type itemAnalytics struct {
+	item         models.ClothingItem
+	categoryName string
+	periodWears  int64 // 周期内穿着次数
+	totalWears   int64 // 截至周期结束的穿着次数
+}
+
+// analyticsGroup 分组统计结果
+type analyticsGroup struct {
+	label       string
+	items       int
+	periodWears int64
+	totalWears  int64
+	cost        float64
+}
+
+// GetWearFrequency 穿着频率分析
+func (s *analyticsService) GetWearFrequency(ctx context.Context, userID uint, req *dto.AnalyticsRequestDTO) (*dto.AnalyticsDTO, error) {
+	period, err := resolveAnalyticsPeriod(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	items, records, err := loadItemAnalytics(ctx, s.clothingItemRepo, s.clothingCategoryRepo, s.wearRecordRepo, userID, period)
+	if err != nil {
+		return nil, err
+	}
+	limit := analyticsLimit(req.Limit)
+
+	var totalWears int64
+	itemsWorn := 0
+	for _, a := range items {
+		totalWears += a.periodWears
+		if a.periodWears > 0 {
+			itemsWorn++
+		}
+	}
+
+	byCategory := groupItemAnalytics(items, func(a itemAnalytics) string { return a.categoryName })
+	byBrand := groupItemAnalytics(items, func(a itemAnalytics) string { return a.item.Brand })
+	byColor := groupItemAnalytics(items, func(a itemAnalytics) string { return a.item.Color })
+	for _, groups := range [][]analyticsGroup{byCategory, byBrand, byColor} {
+		sortGroups(groups, func(g analyticsGroup) float64 { return float64(g.periodWears) })
+	}
+
+	// 最常穿与周期内未穿的衣物
+	sorted := make([]itemAnalytics, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].periodWears > sorted[j].periodWears })
+	mostWorn := make([]dto.CostPerWearItem, 0, limit)
+	unworn := make([]dto.CostPerWearItem, 0, limit)
+	for _, a := range sorted {
+		if a.periodWears > 0 && len(mostWorn) < limit {
+			mostWorn = append(mostWorn, buildCostPerWearItem(a))
+		}
+	}
+	for i := len(sorted) - 1; i >= 0 && len(unworn) < limit; i-- {
+		if sorted[i].periodWears == 0 {
+			unworn = append(unworn, buildCostPerWearItem(sorted[i]))
+		}
+	}
+
+	weeks := period.end.Sub(period.start).Hours() / 24 / 7
+	metrics := map[string]interface{}{
+		"total_wears":            totalWears,
+		"items_worn":             itemsWorn,
+		"unworn_items":           len(items) - itemsWorn,
+		"average_wears_per_item": roundTo(safeDivide(float64(totalWears), float64(len(items))), 2),
+		"wears_per_week":         roundTo(safeDivide(float64(totalWears), weeks), 2),
+		"most_worn_items":        mostWorn,
+		"unworn_item_list":       unworn,
+	}
+
+	wearValue := func(g analyticsGroup) map[string]interface{} {
+		return map[string]interface{}{
+			"value":   g.periodWears,
+			"items":   g.items,
+			"average": roundTo(safeDivide(float64(g.periodWears), float64(g.items)), 2),
+		}
+	}
+	charts := []dto.ChartData{
+		buildWearTrendChart(records, period),
+		buildGroupChart("bar", "按分类穿着次数", byCategory, wearValue),
+		buildGroupChart("pie", "按品牌穿着次数", byBrand, wearValue),
+		buildGroupChart("pie", "按颜色穿着次数", byColor, wearValue),
+	}
+
+	currency, err := s.currencyService.GetHomeCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AnalyticsDTO{
+		Period:    period.name,
+		Currency:  currency,
+		StartDate: period.start,
+		EndDate:   period.end,
+		Metrics:   metrics,
+		Charts:    charts,
+		Summary:   buildAnalyticsSummary(items, totalWears),
+	}, nil
+}
+
+// GetCostPerWear 每次穿着成本分析
+func (s *analyticsService) GetCostPerWear(ctx context.Context, userID uint, req *dto.AnalyticsRequestDTO) (*dto.AnalyticsDTO, error) {
+	period, err := resolveAnalyticsPeriod(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	items, _, err := loadItemAnalytics(ctx, s.clothingItemRepo, s.clothingCategoryRepo, s.wearRecordRepo, userID, period)
+	if err != nil {
+		return nil, err
+	}
+	limit := analyticsLimit(req.Limit)
+
+	// 只统计有价格的衣物
+	priced := make([]itemAnalytics, 0, len(items))
+	var totalCost float64
+	var totalWears, periodWears int64
+	for _, a := range items {
+		if a.item.Price <= 0 {
+			continue
+		}
+		priced = append(priced, a)
+		totalCost += a.item.Price
+		totalWears += a.totalWears
+		periodWears += a.periodWears
+	}
+
+	costPerWear := make([]dto.CostPerWearItem, 0, len(priced))
+	for _, a := range priced {
+		costPerWear = append(costPerWear, buildCostPerWearItem(a))
+	}
+	sort.SliceStable(costPerWear, func(i, j int) bool { return costPerWear[i].CostPerWear < costPerWear[j].CostPerWear })
+
+	// 性价比最高：已穿着且单次成本最低；性价比最低：单次成本最高（包括从未穿过的）
+	bestValue := make([]dto.CostPerWearItem, 0, limit)
+	for _, item := range costPerWear {
+		if item.TotalWears > 0 && len(bestValue) < limit {
+			bestValue = append(bestValue, item)
+		}
+	}
+	worstValue := make([]dto.CostPerWearItem, 0, limit)
+	for i := len(costPerWear) - 1; i >= 0 && len(worstValue) < limit; i-- {
+		worstValue = append(worstValue, costPerWear[i])
+	}
+
+	byCategory := groupItemAnalytics(priced, func(a itemAnalytics) string { return a.categoryName })
+	byBrand := groupItemAnalytics(priced, func(a itemAnalytics) string { return a.item.Brand })
+	byColor := groupItemAnalytics(priced, func(a itemAnalytics) string { return a.item.Color })
+	for _, groups := range [][]analyticsGroup{byCategory, byBrand, byColor} {
+		sortGroups(groups, groupCostPerWear)
+	}
+
+	metrics := map[string]interface{}{
+		"total_cost":            roundTo(totalCost, 2),
+		"total_wears":           totalWears,
+		"period_wears":          periodWears,
+		"priced_items":          len(priced),
+		"average_cost_per_wear": roundTo(safeDivide(totalCost, float64(totalWears)), 2),
+		"best_value_items":      bestValue,
+		"worst_value_items":     worstValue,
+	}
+
+	costValue := func(g analyticsGroup) map[string]interface{} {
+		return map[string]interface{}{
+			"value": roundTo(groupCostPerWear(g), 2),
+			"cost":  roundTo(g.cost, 2),
+			"wears": g.totalWears,
+			"items": g.items,
+		}
+	}
+	bestValueChart := dto.ChartData{Type: "bar", Title: "性价比最高单品", Labels: []string{}, Data: []map[string]interface{}{}}
+	for _, item := range bestValue {
+		bestValueChart.Labels = append(bestValueChart.Labels, item.Name)
+		bestValueChart.Data = append(bestValueChart.Data, map[string]interface{}{
+			"label": item.Name,
+			"value": item.CostPerWear,
+			"wears": item.TotalWears,
+		})
+	}
+	charts := []dto.ChartData{
+		buildGroupChart("bar", "按分类每次穿着成本", byCategory, costValue),
+		buildGroupChart("bar", "按品牌每次穿着成本", byBrand, costValue),
+		buildGroupChart("bar", "按颜色每次穿着成本", byColor, costValue),
+		bestValueChart,
+	}
+
+	currency, err := s.currencyService.GetHomeCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AnalyticsDTO{
+		Period:    period.name,
+		Currency:  currency,
+		StartDate: period.start,
+		EndDate:   period.end,
+		Metrics:   metrics,
+		Charts:    charts,
+		Summary:   buildAnalyticsSummary(items, periodWears),
+	}, nil
+}
+
+// loadItemAnalytics 加载周期结束前已拥有的衣物及其穿着次数，同时返回周期内的穿着记录
+func loadItemAnalytics(
+	ctx context.Context,
+	clothingItemRepo repositories.ClothingItemRepository,
+	clothingCategoryRepo repositories.ClothingCategoryRepository,
+	wearRecordRepo repositories.WearRecordRepository,
+	userID uint,
+	period analyticsPeriod,
+) ([]itemAnalytics, []models.WearRecord, error) {
+	items, err := clothingItemRepo.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取衣物失败: %w", err)
+	}
+
+	categories, err := clothingCategoryRepo.GetAll(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取分类失败: %w", err)
+	}
+	categoryNames := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	records, err := wearRecordRepo.GetByDateRange(ctx, userID,
+		period.start.Format(analyticsTimeLayout), period.end.Format(analyticsTimeLayout))
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取穿着记录失败: %w", err)
+	}
+	periodCounts := make(map[uint]int64)
+	for _, record := range records {
+		periodCounts[record.ClothingItemID]++
+	}
+
+	totalCounts, err := wearRecordRepo.GetItemWearCountsBefore(ctx, userID, period.end)
+	if err != nil {
+		return nil, nil, fmt.Errorf("统计穿着次数失败: %w", err)
+	}
+
+	result := make([]itemAnalytics, 0, len(items))
+	for _, item := range items {
+		acquired := item.CreatedAt
+		if item.PurchaseDate != nil {
+			acquired = *item.PurchaseDate
+		}
+		if acquired.After(period.end) {
+			continue
+		}
+		result = append(result, itemAnalytics{
+			item:         item,
+			categoryName: categoryNames[item.CategoryID],
+			periodWears:  periodCounts[item.ID],
+			totalWears:   totalCounts[item.ID],
+		})
+	}
+
+	return result, records, nil
+}
+
+// resolveAnalyticsPeriod 根据请求参数计算统计周期，指定起止日期时为自定义周期
+func resolveAnalyticsPeriod(req *dto.AnalyticsRequestDTO, now time.Time) (analyticsPeriod, error) {
+	if req.StartDate != nil || req.EndDate != nil {
+		end := now
+		if req.EndDate != nil {
+			// 结束日期包含当天
+			end = req.EndDate.AddDate(0, 0, 1).Add(-time.Second)
+		}
+		start := end.AddDate(0, -1, 0)
+		if req.StartDate != nil {
+			start = *req.StartDate
+		}
+		if start.After(end) {
+			return analyticsPeriod{}, errors.ErrInvalidRequest("开始日期不能晚于结束日期")
+		}
+		return analyticsPeriod{name: "custom", start: start, end: end}, nil
+	}
+
+	name := req.Period
+	if name == "" {
+		name = defaultAnalyticsPeriod
+	}
+	var start time.Time
+	switch name {
+	case "week":
+		start = now.AddDate(0, 0, -7)
+	case "month":
+		start = now.AddDate(0, -1, 0)
+	case "quarter":
+		start = now.AddDate(0, -3, 0)
+	case "year":
+		start = now.AddDate(-1, 0, 0)
+	default:
+		return analyticsPeriod{}, errors.ErrInvalidRequest(fmt.Sprintf("无效的统计周期: %s", name))
+	}
+	return analyticsPeriod{name: name, start: start, end: now}, nil
+}
+
+// analyticsLimit 校验排行榜数量
+func analyticsLimit(limit int) int {
+	if limit <= 0 {
+		return defaultAnalyticsLimit
+	}
+	if limit > maxAnalyticsLimit {
+		return maxAnalyticsLimit
+	}
+	return limit
+}
+
+// groupItemAnalytics 按指定维度分组统计
+func groupItemAnalytics(items []itemAnalytics, keyFn func(itemAnalytics) string) []analyticsGroup {
+	index := make(map[string]int)
+	groups := make([]analyticsGroup, 0)
+	for _, a := range items {
+		key := keyFn(a)
+		if key == "" {
+			key = unknownAnalyticsLabel
+		}
+		i, exists := index[key]
+		if !exists {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, analyticsGroup{label: key})
+		}
+		groups[i].items++
+		groups[i].periodWears += a.periodWears
+		groups[i].totalWears += a.totalWears
+		groups[i].cost += a.item.Price
+	}
+	return groups
+}
+
+// sortGroups 按指定数值降序排列分组，数值相同时按名称排序
+func sortGroups(groups []analyticsGroup, valueFn func(analyticsGroup) float64) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		vi, vj := valueFn(groups[i]), valueFn(groups[j])
+		if vi == vj {
+			return groups[i].label < groups[j].label
+		}
+		return vi > vj
+	})
+}
+
+// groupCostPerWear 分组每次穿着成本，未穿着时为总成本
+func groupCostPerWear(g analyticsGroup) float64 {
+	if g.totalWears == 0 {
+		return g.cost
+	}
+	return g.cost / float64(g.totalWears)
+}
+
+// buildGroupChart 将分组统计转换为图表数据
+func buildGroupChart(chartType, title string, groups []analyticsGroup, valueFn func(analyticsGroup) map[string]interface{}) dto.ChartData {
+	chart := dto.ChartData{
+		Type:   chartType,
+		Title:  title,
+		Labels: make([]string, 0, len(groups)),
+		Data:   make([]map[string]interface{}, 0, len(groups)),
+	}
+	for _, g := range groups {
+		point := valueFn(g)
+		point["label"] = g.label
+		chart.Labels = append(chart.Labels, g.label)
+		chart.Data = append(chart.Data, point)
+	}
+	return chart
+}
+
+// buildWearTrendChart 按天、周或月汇总穿着次数（根据周期长度自动选择粒度）
+func buildWearTrendChart(records []models.WearRecord, period analyticsPeriod) dto.ChartData {
+	days := period.end.Sub(period.start).Hours() / 24
+	bucket := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+	step := func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	layout := "2006-01-02"
+	switch {
+	case days > 184:
+		bucket = func(t time.Time) time.Time {
+			return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		}
+		step = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+		layout = "2006-01"
+	case days > 31:
+		// 以周一为一周的开始
+		bucket = func(t time.Time) time.Time {
+			day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+			return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		}
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	}
+
+	counts := make(map[string]int64)
+	for _, record := range records {
+		counts[bucket(record.WearDate.In(period.end.Location())).Format(layout)]++
+	}
+
+	chart := dto.ChartData{Type: "line", Title: "穿着趋势", Labels: []string{}, Data: []map[string]interface{}{}}
+	for t := bucket(period.start); !t.After(period.end); t = step(t) {
+		label := t.Format(layout)
+		chart.Labels = append(chart.Labels, label)
+		chart.Data = append(chart.Data, map[string]interface{}{
+			"label": label,
+			"value": counts[label],
+		})
+	}
+	return chart
+}
+
+// buildCostPerWearItem 构建单品成本数据
+func buildCostPerWearItem(a itemAnalytics) dto.CostPerWearItem {
+	item := a.item
+	var price *float64
+	if item.Price > 0 {
+		price = &item.Price
+	}
+
+	// 使用截至周期结束的穿着次数计算单次成本
+	item.WearCount = int(a.totalWears)
+	return dto.CostPerWearItem{
+		ClothingItemSummary: dto.ClothingItemSummary{
+			ID:            item.ID,
+			Name:          item.Name,
+			Brand:         item.Brand,
+			Color:         item.Color,
+			CategoryName:  a.categoryName,
+			Status:        item.Condition,
+			WearCount:     item.WearCount,
+			LastWornDate:  item.LastWornDate,
+			PurchasePrice: price,
+		},
+		TotalWears:  a.totalWears,
+		PeriodWears: a.periodWears,
+		CostPerWear: roundTo(item.GetCostPerWear(), 2),
+	}
+}
+
+// buildAnalyticsSummary 构建分析摘要，周期内穿着次数最多的分组作为最常用分类、品牌和颜色
+func buildAnalyticsSummary(items []itemAnalytics, totalWears int64) dto.AnalyticsSummary {
+	var totalSpent float64
+	for _, a := range items {
+		totalSpent += a.item.Price
+	}
+
+	top := func(keyFn func(itemAnalytics) string) string {
+		groups := groupItemAnalytics(items, keyFn)
+		sortGroups(groups, func(g analyticsGroup) float64 { return float64(g.periodWears) })
+		if len(groups) == 0 || groups[0].periodWears == 0 {
+			return ""
+		}
+		return groups[0].label
+	}
+
+	return dto.AnalyticsSummary{
+		TotalItems:      int64(len(items)),
+		TotalWears:      totalWears,
+		TotalSpent:      roundTo(totalSpent, 2),
+		AverageWearRate: roundTo(safeDivide(float64(totalWears), float64(len(items))), 2),
+		TopCategory:     top(func(a itemAnalytics) string { return a.categoryName }),
+		TopBrand:        top(func(a itemAnalytics) string { return a.item.Brand }),
+		TopColor:        top(func(a itemAnalytics) string { return a.item.Color }),
+	}
+}
+
+// safeDivide 除数为0时返回0
+func safeDivide(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
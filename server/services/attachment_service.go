@@ -2,26 +2,33 @@ package services
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"mime/multipart"
+	"net/http"
 	"path/filepath"
 	"strings"
 	"time"
 	"what-to-wear/server/api"
 	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
+	"what-to-wear/server/config"
+	"what-to-wear/server/logger"
 	"what-to-wear/server/models"
 	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
 )
 
 type AttachmentServiceInterface interface {
 	// 上传单个附件
 	UploadAttachment(ctx context.Context, req *dto.UploadAttachmentDTO) (*dto.AttachmentDTO, error)
 
-	// 根据实体获取附件列表
-	GetAttachmentsByEntity(ctx context.Context, entityType api.EntityType, entityID uint) ([]dto.AttachmentDTO, error)
+	// 根据实体获取附件列表，只返回用户自己的附件和公开附件
+	GetAttachmentsByEntity(ctx context.Context, userID uint, entityType api.EntityType, entityID uint) ([]dto.AttachmentDTO, error)
 
 	// 获取单个附件信息
-	GetAttachment(ctx context.Context, id uint) (*dto.AttachmentDTO, error)
+	GetAttachment(ctx context.Context, id uint, userID uint) (*dto.AttachmentDTO, error)
 
 	// 删除附件
 	DeleteAttachment(ctx context.Context, id uint, userID uint) error
@@ -34,37 +41,96 @@ type AttachmentServiceInterface interface {
 
 	// 获取附件统计信息
 	GetAttachmentStats(ctx context.Context, userID uint) (*dto.AttachmentStatsDTO, error)
+
+	// 申请直传上传凭证，返回预签名上传链接
+	CreateUploadSlot(ctx context.Context, userID uint, req *dto.CreateUploadSlotDTO) (*dto.UploadSlotDTO, error)
+	// 确认直传上传完成，校验文件已上传后创建附件记录，重复确认返回同一附件
+	CompleteUpload(ctx context.Context, userID, slotID uint) (*dto.AttachmentDTO, error)
+	// 获取对象键对应的等待上传凭证声明的文件大小，凭证不存在、已确认或已过期时返回错误
+	GetPendingUploadSize(ctx context.Context, objectKey string) (int64, error)
+	// 将过期未确认的上传凭证标记为已过期并删除已上传的文件，返回处理的凭证数量
+	CleanupExpiredUploads(ctx context.Context) (int, error)
 }
 
+// expiredUploadBatchSize 单次清理的过期上传凭证数量
+const expiredUploadBatchSize = 100
+
 type AttachmentService struct {
-	attachmentRepo repositories.AttachmentRepository
+	attachmentRepo   repositories.AttachmentRepository
+	uploadSlotRepo   repositories.UploadSlotRepository
+	clothingItemRepo repositories.ClothingItemRepository
+	outfitRepo       repositories.OutfitRepository
+	maintenanceRepo  repositories.MaintenanceRecordRepository
+	wearRecordRepo   repositories.WearRecordRepository
+	purchaseRepo     repositories.PurchaseRecordRepository
+	storage          Storage
+	urlExpires       time.Duration
+	uploadExpires    time.Duration
+	maxUploadSize    int64
 }
 
-func NewAttachmentService(attachmentRepo repositories.AttachmentRepository) AttachmentServiceInterface {
+func NewAttachmentService(
+	cfg *config.Config,
+	attachmentRepo repositories.AttachmentRepository,
+	uploadSlotRepo repositories.UploadSlotRepository,
+	clothingItemRepo repositories.ClothingItemRepository,
+	outfitRepo repositories.OutfitRepository,
+	maintenanceRepo repositories.MaintenanceRecordRepository,
+	wearRecordRepo repositories.WearRecordRepository,
+	purchaseRepo repositories.PurchaseRecordRepository,
+	storage Storage,
+) AttachmentServiceInterface {
 	return &AttachmentService{
-		attachmentRepo: attachmentRepo,
+		attachmentRepo:   attachmentRepo,
+		uploadSlotRepo:   uploadSlotRepo,
+		clothingItemRepo: clothingItemRepo,
+		outfitRepo:       outfitRepo,
+		maintenanceRepo:  maintenanceRepo,
+		wearRecordRepo:   wearRecordRepo,
+		purchaseRepo:     purchaseRepo,
+		storage:          storage,
+		urlExpires:       time.Duration(cfg.Storage.URLExpires) * time.Second,
+		uploadExpires:    time.Duration(cfg.Storage.UploadExpires) * time.Second,
+		maxUploadSize:    cfg.Storage.MaxUploadSize * 1024 * 1024,
 	}
 }
 
 func (s *AttachmentService) UploadAttachment(ctx context.Context, req *dto.UploadAttachmentDTO) (*dto.AttachmentDTO, error) {
+	if !req.EntityType.IsValid() {
+		return nil, errors.ErrInvalidRequest("无效的实体类型")
+	}
+	// 只能向自己的实体上传附件
+	if err := s.checkEntityOwner(ctx, req.UserID, req.EntityType, req.EntityID); err != nil {
+		return nil, err
+	}
+
 	// 验证文件类型
 	if !s.isValidFileType(req.File) {
-		return nil, fmt.Errorf("不支持的文件类型")
+		return nil, errors.ErrInvalidRequest("不支持的文件类型")
+	}
+	if s.maxUploadSize > 0 && req.File.Size > s.maxUploadSize {
+		return nil, errors.ErrInvalidRequest(fmt.Sprintf("文件大小不能超过 %d MB", s.maxUploadSize/1024/1024))
 	}
 
-	// 生成文件名和路径
+	// 生成文件名和对象键
 	fileName := s.generateFileName(req.File.Filename)
-	filePath := s.generateFilePath(req.EntityType, fileName)
-
-	// 这里应该实现实际的文件上传逻辑到对象存储服务
-	// 目前作为示例，使用本地存储路径
-	publicURL := fmt.Sprintf("/uploads/%s/%s", req.EntityType, fileName)
+	objectKey := s.generateObjectKey(req.EntityType, req.UserID, fileName)
 
 	// 获取文件信息
 	fileSize := req.File.Size
 	mimeType := req.File.Header.Get("Content-Type")
 	extension := strings.ToLower(filepath.Ext(req.File.Filename))
 
+	// 写入存储
+	file, err := req.File.Open()
+	if err != nil {
+		return nil, fmt.Errorf("读取上传文件失败: %w", err)
+	}
+	defer file.Close()
+	if err := s.storage.Put(ctx, objectKey, file, fileSize, mimeType); err != nil {
+		return nil, fmt.Errorf("保存附件文件失败: %w", err)
+	}
+
 	// 确定附件类型
 	attachmentType := s.determineAttachmentType(mimeType)
 
@@ -72,7 +138,7 @@ func (s *AttachmentService) UploadAttachment(ctx context.Context, req *dto.Uploa
 	attachment := &models.Attachment{
 		OriginalName:    req.File.Filename,
 		FileName:        fileName,
-		FilePath:        filePath,
+		FilePath:        objectKey,
 		FileSize:        fileSize,
 		MimeType:        mimeType,
 		Extension:       extension,
@@ -80,8 +146,9 @@ func (s *AttachmentService) UploadAttachment(ctx context.Context, req *dto.Uploa
 		EntityType:      req.EntityType,
 		EntityID:        req.EntityID,
 		UserID:          req.UserID,
-		StorageProvider: "local", // 默认本地存储
-		PublicURL:       publicURL,
+		StorageProvider: s.storage.Name(),
+		BucketName:      s.storage.Bucket(),
+		ObjectKey:       objectKey,
 		Description:     req.Description,
 		Tags:            req.Tags,
 		IsPublic:        req.IsPublic,
@@ -90,46 +157,56 @@ func (s *AttachmentService) UploadAttachment(ctx context.Context, req *dto.Uploa
 
 	// 保存到数据库
 	if err := s.attachmentRepo.Create(ctx, attachment); err != nil {
+		// 记录保存失败时清理已写入的文件
+		s.deleteObject(ctx, attachment)
 		return nil, fmt.Errorf("保存附件记录失败: %v", err)
 	}
 
 	// 转换为响应DTO
-	return s.convertToAttachmentResponse(attachment), nil
+	return s.convertToAttachmentResponse(ctx, attachment), nil
 }
 
-func (s *AttachmentService) GetAttachmentsByEntity(ctx context.Context, entityType api.EntityType, entityID uint) ([]dto.AttachmentDTO, error) {
+func (s *AttachmentService) GetAttachmentsByEntity(ctx context.Context, userID uint, entityType api.EntityType, entityID uint) ([]dto.AttachmentDTO, error) {
 	attachments, err := s.attachmentRepo.GetByEntityID(ctx, entityType, entityID)
 	if err != nil {
 		return nil, fmt.Errorf("获取附件列表失败: %v", err)
 	}
 
-	var responses []dto.AttachmentDTO
+	responses := []dto.AttachmentDTO{}
 	for _, attachment := range attachments {
-		responses = append(responses, *s.convertToAttachmentResponse(&attachment))
+		if attachment.UserID != userID && !attachment.IsPublic {
+			continue
+		}
+		responses = append(responses, *s.convertToAttachmentResponse(ctx, &attachment))
 	}
 
 	return responses, nil
 }
 
-func (s *AttachmentService) GetAttachment(ctx context.Context, id uint) (*dto.AttachmentDTO, error) {
-	attachment, err := s.attachmentRepo.GetByID(ctx, id)
+func (s *AttachmentService) GetAttachment(ctx context.Context, id uint, userID uint) (*dto.AttachmentDTO, error) {
+	attachment, err := s.getAttachment(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("获取附件信息失败: %v", err)
+		return nil, err
 	}
 
-	return s.convertToAttachmentResponse(attachment), nil
+	// 非公开附件只有上传者可以查看
+	if attachment.UserID != userID && !attachment.IsPublic {
+		return nil, errors.ErrNotFound("附件不存在")
+	}
+
+	return s.convertToAttachmentResponse(ctx, attachment), nil
 }
 
 func (s *AttachmentService) DeleteAttachment(ctx context.Context, id uint, userID uint) error {
 	// 获取附件信息
-	attachment, err := s.attachmentRepo.GetByID(ctx, id)
+	attachment, err := s.getAttachment(ctx, id)
 	if err != nil {
-		return fmt.Errorf("附件不存在: %v", err)
+		return err
 	}
 
 	// 检查权限（只有上传者可以删除）
 	if attachment.UserID != userID {
-		return fmt.Errorf("没有权限删除此附件")
+		return errors.ErrForbidden("没有权限删除此附件")
 	}
 
 	// 软删除附件记录
@@ -137,22 +214,22 @@ func (s *AttachmentService) DeleteAttachment(ctx context.Context, id uint, userI
 		return fmt.Errorf("删除附件失败: %v", err)
 	}
 
-	// 这里应该实现从对象存储服务删除实际文件的逻辑
-	// TODO: 实现文件删除逻辑
+	// 删除存储中的文件，失败时仅记录日志
+	s.deleteObject(ctx, attachment)
 
 	return nil
 }
 
 func (s *AttachmentService) UpdateAttachmentOrder(ctx context.Context, attachmentID uint, sortOrder int, userID uint) error {
 	// 获取附件信息
-	attachment, err := s.attachmentRepo.GetByID(ctx, attachmentID)
+	attachment, err := s.getAttachment(ctx, attachmentID)
 	if err != nil {
-		return fmt.Errorf("附件不存在: %v", err)
+		return err
 	}
 
 	// 检查权限
 	if attachment.UserID != userID {
-		return fmt.Errorf("没有权限修改此附件")
+		return errors.ErrForbidden("没有权限修改此附件")
 	}
 
 	// 更新排序字段
@@ -161,14 +238,14 @@ func (s *AttachmentService) UpdateAttachmentOrder(ctx context.Context, attachmen
 }
 
 func (s *AttachmentService) UpdateAttachment(ctx context.Context, id uint, userID uint, req *dto.UpdateAttachmentDTO) (*dto.AttachmentDTO, error) {
-	attachment, err := s.attachmentRepo.GetByID(ctx, id)
+	attachment, err := s.getAttachment(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("附件不存在: %v", err)
+		return nil, err
 	}
 
 	// 检查权限
 	if attachment.UserID != userID {
-		return nil, fmt.Errorf("没有权限修改此附件")
+		return nil, errors.ErrForbidden("没有权限修改此附件")
 	}
 
 	// 更新字段
@@ -189,7 +266,7 @@ func (s *AttachmentService) UpdateAttachment(ctx context.Context, id uint, userI
 		return nil, fmt.Errorf("更新附件失败: %v", err)
 	}
 
-	return s.convertToAttachmentResponse(attachment), nil
+	return s.convertToAttachmentResponse(ctx, attachment), nil
 }
 
 // GetAttachmentStats 获取附件统计信息
@@ -232,20 +309,283 @@ func (s *AttachmentService) GetAttachmentStats(ctx context.Context, userID uint)
 	return stats, nil
 }
 
+// CreateUploadSlot 申请直传上传凭证
+func (s *AttachmentService) CreateUploadSlot(ctx context.Context, userID uint, req *dto.CreateUploadSlotDTO) (*dto.UploadSlotDTO, error) {
+	if !req.EntityType.IsValid() {
+		return nil, errors.ErrInvalidRequest("无效的实体类型")
+	}
+	// 只能向自己的实体上传附件
+	if err := s.checkEntityOwner(ctx, userID, req.EntityType, req.EntityID); err != nil {
+		return nil, err
+	}
+	if !isAllowedMimeType(req.MimeType) {
+		return nil, errors.ErrInvalidRequest("不支持的文件类型")
+	}
+	if s.maxUploadSize > 0 && req.FileSize > s.maxUploadSize {
+		return nil, errors.ErrInvalidRequest(fmt.Sprintf("文件大小不能超过 %d MB", s.maxUploadSize/1024/1024))
+	}
+
+	fileName := s.generateFileName(req.FileName)
+	slot := &models.UploadSlot{
+		UserID:          userID,
+		EntityType:      req.EntityType,
+		EntityID:        req.EntityID,
+		Status:          api.UploadStatusPending,
+		StorageProvider: s.storage.Name(),
+		BucketName:      s.storage.Bucket(),
+		ObjectKey:       s.generateObjectKey(req.EntityType, userID, fileName),
+		OriginalName:    req.FileName,
+		FileName:        fileName,
+		MimeType:        req.MimeType,
+		FileSize:        req.FileSize,
+		Description:     req.Description,
+		Tags:            req.Tags,
+		IsPublic:        req.IsPublic,
+		SortOrder:       req.SortOrder,
+		ExpiresAt:       time.Now().Add(s.uploadExpires),
+	}
+
+	uploadURL, err := s.storage.SignedUploadURL(ctx, slot.ObjectKey, slot.MimeType, s.uploadExpires)
+	if err != nil {
+		return nil, fmt.Errorf("生成上传链接失败: %w", err)
+	}
+	if err := s.uploadSlotRepo.Create(ctx, slot); err != nil {
+		return nil, fmt.Errorf("创建上传凭证失败: %w", err)
+	}
+
+	response := s.convertToUploadSlotResponse(slot)
+	response.UploadURL = uploadURL
+	response.Method = http.MethodPut
+	response.Headers = map[string]string{"Content-Type": slot.MimeType}
+	return response, nil
+}
+
+// CompleteUpload 确认直传上传完成
+func (s *AttachmentService) CompleteUpload(ctx context.Context, userID, slotID uint) (*dto.AttachmentDTO, error) {
+	slot, err := s.uploadSlotRepo.GetByID(ctx, slotID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("上传凭证不存在")
+		}
+		return nil, fmt.Errorf("获取上传凭证失败: %w", err)
+	}
+	if slot.UserID != userID {
+		return nil, errors.ErrNotFound("上传凭证不存在")
+	}
+
+	// 重复确认时返回已创建的附件
+	if slot.Status == api.UploadStatusCompleted && slot.AttachmentID != nil {
+		attachment, err := s.getAttachment(ctx, *slot.AttachmentID)
+		if err != nil {
+			return nil, err
+		}
+		return s.convertToAttachmentResponse(ctx, attachment), nil
+	}
+	if slot.IsExpired(time.Now()) {
+		return nil, errors.ErrConflict("上传凭证已过期，请重新申请")
+	}
+	if slot.StorageProvider != s.storage.Name() {
+		return nil, errors.ErrConflict("上传凭证的存储提供商与当前存储不一致")
+	}
+
+	// 校验文件已上传且大小不超过申请的大小
+	object, err := s.storage.Stat(ctx, slot.ObjectKey)
+	if err != nil {
+		if stderrors.Is(err, ErrStorageObjectNotFound) {
+			return nil, errors.ErrInvalidRequest("文件尚未上传")
+		}
+		return nil, fmt.Errorf("获取上传文件信息失败: %w", err)
+	}
+	if object.Size > slot.FileSize {
+		// 作废凭证并删除文件，客户端需重新申请
+		if _, err := s.uploadSlotRepo.MarkExpired(ctx, slot.ID); err != nil {
+			return nil, fmt.Errorf("作废上传凭证失败: %w", err)
+		}
+		s.deleteSlotObject(ctx, slot)
+		return nil, errors.ErrInvalidRequest("上传的文件大小超过申请的大小")
+	}
+
+	attachment := &models.Attachment{
+		OriginalName:    slot.OriginalName,
+		FileName:        slot.FileName,
+		FilePath:        slot.ObjectKey,
+		FileSize:        object.Size,
+		MimeType:        slot.MimeType,
+		Extension:       strings.ToLower(filepath.Ext(slot.OriginalName)),
+		AttachmentType:  s.determineAttachmentType(slot.MimeType),
+		EntityType:      slot.EntityType,
+		EntityID:        slot.EntityID,
+		UserID:          slot.UserID,
+		StorageProvider: slot.StorageProvider,
+		BucketName:      slot.BucketName,
+		ObjectKey:       slot.ObjectKey,
+		Description:     slot.Description,
+		Tags:            slot.Tags,
+		IsPublic:        slot.IsPublic,
+		SortOrder:       slot.SortOrder,
+	}
+	if err := s.uploadSlotRepo.Complete(ctx, slot, attachment); err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrConflict("上传凭证已失效，请重新申请")
+		}
+		return nil, fmt.Errorf("保存附件记录失败: %w", err)
+	}
+
+	return s.convertToAttachmentResponse(ctx, attachment), nil
+}
+
+// GetPendingUploadSize 获取等待上传的凭证声明的文件大小，用于限制本地存储直传的请求体
+func (s *AttachmentService) GetPendingUploadSize(ctx context.Context, objectKey string) (int64, error) {
+	slot, err := s.uploadSlotRepo.GetByObjectKey(ctx, objectKey)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, errors.ErrNotFound("上传凭证不存在")
+		}
+		return 0, fmt.Errorf("获取上传凭证失败: %w", err)
+	}
+	// 确认后不再接受上传，防止替换已确认的文件
+	if slot.Status == api.UploadStatusCompleted {
+		return 0, errors.ErrConflict("上传凭证已确认，不能再次上传")
+	}
+	if slot.IsExpired(time.Now()) {
+		return 0, errors.ErrConflict("上传凭证已过期，请重新申请")
+	}
+	return slot.FileSize, nil
+}
+
+// CleanupExpiredUploads 清理过期未确认的上传凭证
+func (s *AttachmentService) CleanupExpiredUploads(ctx context.Context) (int, error) {
+	slots, err := s.uploadSlotRepo.GetExpired(ctx, time.Now(), expiredUploadBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("获取过期上传凭证失败: %w", err)
+	}
+
+	expired := 0
+	for i := range slots {
+		slot := &slots[i]
+		updated, err := s.uploadSlotRepo.MarkExpired(ctx, slot.ID)
+		if err != nil {
+			return expired, fmt.Errorf("更新上传凭证 %d 失败: %w", slot.ID, err)
+		}
+		// 已被并发确认的凭证不删除文件
+		if !updated {
+			continue
+		}
+		s.deleteSlotObject(ctx, slot)
+		expired++
+	}
+	return expired, nil
+}
+
 // 辅助方法
+func (s *AttachmentService) getAttachment(ctx context.Context, id uint) (*models.Attachment, error) {
+	attachment, err := s.attachmentRepo.GetByID(ctx, id)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("附件不存在")
+		}
+		return nil, fmt.Errorf("获取附件信息失败: %w", err)
+	}
+	return attachment, nil
+}
+
+// checkEntityOwner 检查附件关联的实体存在且属于用户
+func (s *AttachmentService) checkEntityOwner(ctx context.Context, userID uint, entityType api.EntityType, entityID uint) error {
+	var ownerID, itemID uint
+	var err error
+	switch entityType {
+	case api.EntityTypeUser:
+		ownerID = entityID
+	case api.EntityTypeOutfit:
+		var outfit *models.Outfit
+		if outfit, err = s.outfitRepo.GetByID(ctx, entityID); err == nil {
+			ownerID = outfit.UserID
+		}
+	case api.EntityTypeClothingItem:
+		itemID = entityID
+	case api.EntityTypeMaintenance:
+		var record *models.MaintenanceRecord
+		if record, err = s.maintenanceRepo.GetByID(ctx, entityID); err == nil {
+			itemID = record.ClothingItemID
+		}
+	case api.EntityTypeWearRecord:
+		var record *models.WearRecord
+		if record, err = s.wearRecordRepo.GetByID(ctx, entityID); err == nil {
+			itemID = record.ClothingItemID
+		}
+	case api.EntityTypePurchase:
+		var record *models.PurchaseRecord
+		if record, err = s.purchaseRepo.GetByID(ctx, entityID); err == nil {
+			itemID = record.ClothingItemID
+		}
+	default:
+		return errors.ErrInvalidRequest("无效的实体类型")
+	}
+
+	// 保养、穿着和购买记录通过所属衣物确定用户
+	if err == nil && itemID != 0 {
+		var item *models.ClothingItem
+		if item, err = s.clothingItemRepo.GetByID(ctx, itemID); err == nil {
+			ownerID = item.UserID
+		}
+	}
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.ErrNotFound("关联的实体不存在")
+		}
+		return fmt.Errorf("获取关联实体失败: %w", err)
+	}
+	if ownerID != userID {
+		return errors.ErrForbidden("无权向该实体上传附件")
+	}
+	return nil
+}
+
+// deleteObject 删除附件在存储中的文件，失败不影响调用方
+func (s *AttachmentService) deleteObject(ctx context.Context, attachment *models.Attachment) {
+	if attachment.StorageProvider != s.storage.Name() || attachment.ObjectKey == "" {
+		return
+	}
+	if err := s.storage.Delete(ctx, attachment.ObjectKey); err != nil {
+		logger.GetLogger().WarnWithErr(err, "Failed to delete attachment object", logger.Fields{
+			"attachment_id": attachment.ID,
+			"object_key":    attachment.ObjectKey,
+		})
+	}
+}
+
+// deleteSlotObject 删除未确认的上传文件，文件可能尚未上传，失败时仅记录日志
+func (s *AttachmentService) deleteSlotObject(ctx context.Context, slot *models.UploadSlot) {
+	if slot.StorageProvider != s.storage.Name() {
+		return
+	}
+	if err := s.storage.Delete(ctx, slot.ObjectKey); err != nil {
+		logger.GetLogger().WarnWithErr(err, "Failed to delete expired upload object", logger.Fields{
+			"upload_slot_id": slot.ID,
+			"object_key":     slot.ObjectKey,
+		})
+	}
+}
+
 func (s *AttachmentService) generateFileName(originalName string) string {
-	// 生成唯一文件名，避免重复
+	// 生成唯一文件名，避免重复，只保留文件名部分防止路径穿越
+	originalName = filepath.Base(strings.ReplaceAll(originalName, "\\", "/"))
 	ext := filepath.Ext(originalName)
 	baseName := strings.TrimSuffix(originalName, ext)
-	timestamp := time.Now().Unix()
+	timestamp := time.Now().UnixNano()
 	return fmt.Sprintf("%s_%d%s", baseName, timestamp, ext)
 }
 
-func (s *AttachmentService) generateFilePath(entityType api.EntityType, fileName string) string {
-	return fmt.Sprintf("uploads/%s/%s", entityType, fileName)
+func (s *AttachmentService) generateObjectKey(entityType api.EntityType, userID uint, fileName string) string {
+	return fmt.Sprintf("%s/%d/%s", entityType, userID, fileName)
 }
 
 func (s *AttachmentService) isValidFileType(file *multipart.FileHeader) bool {
+	return isAllowedMimeType(file.Header.Get("Content-Type"))
+}
+
+// isAllowedMimeType 检查是否为允许上传的文件类型
+func isAllowedMimeType(mimeType string) bool {
 	allowedTypes := map[string]bool{
 		"image/jpeg": true,
 		"image/jpg":  true,
@@ -255,8 +595,6 @@ func (s *AttachmentService) isValidFileType(file *multipart.FileHeader) bool {
 		"video/avi":  true,
 		"video/mov":  true,
 	}
-
-	mimeType := file.Header.Get("Content-Type")
 	return allowedTypes[mimeType]
 }
 
@@ -270,7 +608,20 @@ func (s *AttachmentService) determineAttachmentType(mimeType string) api.Attachm
 	return api.AttachmentTypeFile
 }
 
-func (s *AttachmentService) convertToAttachmentResponse(attachment *models.Attachment) *dto.AttachmentDTO {
+// attachmentURL 获取附件访问链接，未设置公开链接时由存储生成签名链接
+func (s *AttachmentService) attachmentURL(ctx context.Context, attachment *models.Attachment) string {
+	if attachment.PublicURL != "" || attachment.StorageProvider != s.storage.Name() || attachment.ObjectKey == "" {
+		return attachment.GetURL()
+	}
+	signedURL, err := s.storage.SignedURL(ctx, attachment.ObjectKey, s.urlExpires)
+	if err != nil {
+		logger.GetLogger().WarnWithErr(err, "Failed to sign attachment URL", logger.Fields{"attachment_id": attachment.ID})
+		return ""
+	}
+	return signedURL
+}
+
+func (s *AttachmentService) convertToAttachmentResponse(ctx context.Context, attachment *models.Attachment) *dto.AttachmentDTO {
 	return &dto.AttachmentDTO{
 		ID:             attachment.ID,
 		OriginalName:   attachment.OriginalName,
@@ -280,7 +631,7 @@ func (s *AttachmentService) convertToAttachmentResponse(attachment *models.Attac
 		AttachmentType: api.AttachmentType(string(attachment.AttachmentType)),
 		EntityType:     api.EntityType(string(attachment.EntityType)),
 		EntityID:       attachment.EntityID,
-		PublicURL:      attachment.GetURL(),
+		PublicURL:      s.attachmentURL(ctx, attachment),
 		Width:          attachment.Width,
 		Height:         attachment.Height,
 		Duration:       attachment.Duration,
@@ -297,3 +648,17 @@ func (s *AttachmentService) convertToAttachmentResponse(attachment *models.Attac
 		UpdatedAt:   attachment.UpdatedAt,
 	}
 }
+
+func (s *AttachmentService) convertToUploadSlotResponse(slot *models.UploadSlot) *dto.UploadSlotDTO {
+	return &dto.UploadSlotDTO{
+		ID:           slot.ID,
+		Status:       slot.Status,
+		EntityType:   slot.EntityType,
+		EntityID:     slot.EntityID,
+		ObjectKey:    slot.ObjectKey,
+		MimeType:     slot.MimeType,
+		FileSize:     slot.FileSize,
+		AttachmentID: slot.AttachmentID,
+		ExpiresAt:    slot.ExpiresAt,
+	}
+}
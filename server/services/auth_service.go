@@ -121,7 +121,7 @@ func (s *authService) Login(ctx context.Context, username, password string) (str
 	}
 
 	// 生成JWT token
-	token, err := utils.GenerateToken(user.ID, user.Username)
+	token, err := utils.GenerateToken(user.ID, user.Username, string(user.Role))
 	if err != nil {
 		log.ErrorWithErr(err, "Failed to generate token", logger.Fields{
 			"username": username,
@@ -158,7 +158,7 @@ func (s *authService) RefreshToken(ctx context.Context, userID uint) (string, er
 	}
 
 	// 生成新的JWT token
-	token, err := utils.GenerateToken(user.ID, user.Username)
+	token, err := utils.GenerateToken(user.ID, user.Username, string(user.Role))
 	if err != nil {
 		return "", errors.NewInternalError("failed to generate token")
 	}
@@ -0,0 +1,488 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
+	"what-to-wear/server/logger"
+	"what-to-wear/server/models"
+	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultBudgetAlertLimit = 50
+	maxBudgetAlertLimit     = 200
+)
+
+// BudgetService 预算服务接口
+type BudgetService interface {
+	// 基础CRUD操作
+	CreateBudget(ctx context.Context, userID uint, req *dto.CreateBudgetDTO) (*dto.BudgetDTO, error)
+	GetBudget(ctx context.Context, userID, budgetID uint) (*dto.BudgetDTO, error)
+	GetBudgets(ctx context.Context, userID uint) ([]dto.BudgetDTO, error)
+	UpdateBudget(ctx context.Context, userID, budgetID uint, req *dto.UpdateBudgetDTO) (*dto.BudgetDTO, error)
+	DeleteBudget(ctx context.Context, userID, budgetID uint) error
+
+	// 指定日期所在周期的预算执行情况
+	GetBudgetStatus(ctx context.Context, userID uint, date time.Time) ([]dto.BudgetStatusDTO, error)
+
+	// 超出预算提醒
+	// 新增购买记录后检查是否使预算超支，失败只记录日志
+	CheckPurchase(ctx context.Context, userID uint, record *models.PurchaseRecord)
+	GetAlerts(ctx context.Context, userID uint, unreadOnly bool, limit int) ([]dto.BudgetAlertDTO, error)
+	MarkAlertRead(ctx context.Context, userID, alertID uint) error
+}
+
+// budgetService 预算服务实现
+type budgetService struct {
+	budgetRepo           repositories.BudgetRepository
+	purchaseRepo         repositories.PurchaseRecordRepository
+	clothingItemRepo     repositories.ClothingItemRepository
+	clothingCategoryRepo repositories.ClothingCategoryRepository
+	currencyService      CurrencyService
+}
+
+// NewBudgetService 创建预算服务实例
+func NewBudgetService(
+	budgetRepo repositories.BudgetRepository,
+	purchaseRepo repositories.PurchaseRecordRepository,
+	clothingItemRepo repositories.ClothingItemRepository,
+	clothingCategoryRepo repositories.ClothingCategoryRepository,
+	currencyService CurrencyService,
+) BudgetService {
+	return &budgetService{
+		budgetRepo:           budgetRepo,
+		purchaseRepo:         purchaseRepo,
+		clothingItemRepo:     clothingItemRepo,
+		clothingCategoryRepo: clothingCategoryRepo,
+		currencyService:      currencyService,
+	}
+}
+
+// CreateBudget 创建预算
+func (s *budgetService) CreateBudget(ctx context.Context, userID uint, req *dto.CreateBudgetDTO) (*dto.BudgetDTO, error) {
+	period := api.BudgetPeriod(req.Period)
+	if !period.IsValid() {
+		return nil, errors.ErrInvalidRequest(fmt.Sprintf("无效的预算周期: %s", req.Period))
+	}
+	if err := s.validateCategory(ctx, userID, req.CategoryID); err != nil {
+		return nil, err
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		home, err := s.currencyService.GetHomeCurrency(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		currency = home
+	}
+	if !s.currencyService.IsSupported(currency) {
+		return nil, errors.ErrInvalidRequest(fmt.Sprintf("不支持的货币: %s", currency))
+	}
+
+	budget := &models.Budget{
+		UserID:     userID,
+		Name:       req.Name,
+		Period:     period,
+		CategoryID: req.CategoryID,
+		Amount:     req.Amount,
+		Currency:   normalizeCurrency(currency),
+	}
+	if err := s.budgetRepo.Create(ctx, budget); err != nil {
+		return nil, fmt.Errorf("创建预算失败: %w", err)
+	}
+
+	return s.convertToDTO(ctx, budget)
+}
+
+// GetBudget 获取预算详情
+func (s *budgetService) GetBudget(ctx context.Context, userID, budgetID uint) (*dto.BudgetDTO, error) {
+	budget, err := s.getOwnedBudget(ctx, userID, budgetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToDTO(ctx, budget)
+}
+
+// GetBudgets 获取用户的预算列表
+func (s *budgetService) GetBudgets(ctx context.Context, userID uint) ([]dto.BudgetDTO, error) {
+	budgets, err := s.budgetRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取预算失败: %w", err)
+	}
+	categoryNames, err := s.loadCategoryNames(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.BudgetDTO, 0, len(budgets))
+	for i := range budgets {
+		result = append(result, buildBudgetDTO(&budgets[i], categoryNames))
+	}
+	return result, nil
+}
+
+// UpdateBudget 更新预算
+func (s *budgetService) UpdateBudget(ctx context.Context, userID, budgetID uint, req *dto.UpdateBudgetDTO) (*dto.BudgetDTO, error) {
+	budget, err := s.getOwnedBudget(ctx, userID, budgetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		budget.Name = *req.Name
+	}
+	if req.Period != nil {
+		period := api.BudgetPeriod(*req.Period)
+		if !period.IsValid() {
+			return nil, errors.ErrInvalidRequest(fmt.Sprintf("无效的预算周期: %s", *req.Period))
+		}
+		budget.Period = period
+	}
+	if req.CategoryID != nil {
+		if *req.CategoryID == 0 {
+			budget.CategoryID = nil
+		} else {
+			if err := s.validateCategory(ctx, userID, req.CategoryID); err != nil {
+				return nil, err
+			}
+			categoryID := *req.CategoryID
+			budget.CategoryID = &categoryID
+		}
+	}
+	if req.Amount != nil {
+		budget.Amount = *req.Amount
+	}
+	if req.Currency != nil {
+		if !s.currencyService.IsSupported(*req.Currency) {
+			return nil, errors.ErrInvalidRequest(fmt.Sprintf("不支持的货币: %s", *req.Currency))
+		}
+		budget.Currency = normalizeCurrency(*req.Currency)
+	}
+
+	if err := s.budgetRepo.Update(ctx, budget); err != nil {
+		return nil, fmt.Errorf("更新预算失败: %w", err)
+	}
+
+	return s.convertToDTO(ctx, budget)
+}
+
+// DeleteBudget 删除预算，已产生的提醒保留
+func (s *budgetService) DeleteBudget(ctx context.Context, userID, budgetID uint) error {
+	if _, err := s.getOwnedBudget(ctx, userID, budgetID); err != nil {
+		return err
+	}
+
+	if err := s.budgetRepo.Delete(ctx, budgetID); err != nil {
+		return fmt.Errorf("删除预算失败: %w", err)
+	}
+	return nil
+}
+
+// GetBudgetStatus 获取指定日期所在周期的预算执行情况
+func (s *budgetService) GetBudgetStatus(ctx context.Context, userID uint, date time.Time) ([]dto.BudgetStatusDTO, error) {
+	budgets, err := s.budgetRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取预算失败: %w", err)
+	}
+	if len(budgets) == 0 {
+		return []dto.BudgetStatusDTO{}, nil
+	}
+
+	home, err := s.currencyService.GetHomeCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	categoryNames, err := s.loadCategoryNames(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同一周期的预算共用一次分类支出查询
+	spentByPeriod := make(map[api.BudgetPeriod]map[uint]float64)
+	result := make([]dto.BudgetStatusDTO, 0, len(budgets))
+	for i := range budgets {
+		budget := &budgets[i]
+		bucket := budgetBucket(budget.Period, date)
+
+		categorySpent, exists := spentByPeriod[budget.Period]
+		if !exists {
+			if categorySpent, err = s.purchaseRepo.GetSpentByCategoryInRange(ctx, userID, bucket.start, bucket.end); err != nil {
+				return nil, fmt.Errorf("获取预算周期支出失败: %w", err)
+			}
+			spentByPeriod[budget.Period] = categorySpent
+		}
+
+		amount, err := s.currencyService.Convert(budget.Amount, budget.Currency, home, date)
+		if err != nil {
+			return nil, err
+		}
+		status := buildBudgetStatus(budget, bucket, home, amount, budgetSpent(budget, categorySpent))
+		status.CategoryName = budgetCategoryName(budget, categoryNames)
+		result = append(result, status)
+	}
+	return result, nil
+}
+
+// CheckPurchase 检查购买记录是否使所在周期的预算由未超支变为超支
+func (s *budgetService) CheckPurchase(ctx context.Context, userID uint, record *models.PurchaseRecord) {
+	if err := s.checkPurchase(ctx, userID, record); err != nil {
+		logger.GetLogger().WarnWithErr(err, "Failed to check budgets for purchase", logger.Fields{
+			"user_id":            userID,
+			"purchase_record_id": record.ID,
+		})
+	}
+}
+
+func (s *budgetService) checkPurchase(ctx context.Context, userID uint, record *models.PurchaseRecord) error {
+	if record.HomePrice <= 0 {
+		return nil
+	}
+	budgets, err := s.budgetRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("获取预算失败: %w", err)
+	}
+	if len(budgets) == 0 {
+		return nil
+	}
+
+	item, err := s.clothingItemRepo.GetByID(ctx, record.ClothingItemID)
+	if err != nil {
+		return fmt.Errorf("获取衣物失败: %w", err)
+	}
+	home, err := s.currencyService.GetHomeCurrency(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	spentByPeriod := make(map[api.BudgetPeriod]map[uint]float64)
+	for i := range budgets {
+		budget := &budgets[i]
+		if budget.CategoryID != nil && *budget.CategoryID != item.CategoryID {
+			continue
+		}
+		bucket := budgetBucket(budget.Period, record.PurchaseDate)
+
+		categorySpent, exists := spentByPeriod[budget.Period]
+		if !exists {
+			if categorySpent, err = s.purchaseRepo.GetSpentByCategoryInRange(ctx, userID, bucket.start, bucket.end); err != nil {
+				return fmt.Errorf("获取预算周期支出失败: %w", err)
+			}
+			spentByPeriod[budget.Period] = categorySpent
+		}
+
+		amount, err := s.currencyService.Convert(budget.Amount, budget.Currency, home, record.PurchaseDate)
+		if err != nil {
+			return err
+		}
+		spent := budgetSpent(budget, categorySpent)
+		if spent <= amount || spent-record.HomePrice > amount {
+			continue
+		}
+
+		alert := &models.BudgetAlert{
+			UserID:           userID,
+			BudgetID:         budget.ID,
+			PeriodStart:      bucket.start,
+			PurchaseRecordID: record.ID,
+			BudgetName:       budget.Name,
+			PeriodLabel:      bucket.label,
+			Currency:         home,
+			BudgetAmount:     amount,
+			Spent:            roundTo(spent, 2),
+		}
+		created, err := s.budgetRepo.CreateAlert(ctx, alert)
+		if err != nil {
+			return fmt.Errorf("创建预算提醒失败: %w", err)
+		}
+		if created {
+			logger.GetLogger().Info("Budget exceeded", logger.Fields{
+				"user_id":   userID,
+				"budget_id": budget.ID,
+				"period":    bucket.label,
+				"spent":     alert.Spent,
+				"amount":    amount,
+			})
+		}
+	}
+	return nil
+}
+
+// GetAlerts 获取超出预算提醒
+func (s *budgetService) GetAlerts(ctx context.Context, userID uint, unreadOnly bool, limit int) ([]dto.BudgetAlertDTO, error) {
+	if limit <= 0 {
+		limit = defaultBudgetAlertLimit
+	} else if limit > maxBudgetAlertLimit {
+		limit = maxBudgetAlertLimit
+	}
+
+	alerts, err := s.budgetRepo.GetAlerts(ctx, userID, unreadOnly, limit)
+	if err != nil {
+		return nil, fmt.Errorf("获取预算提醒失败: %w", err)
+	}
+
+	result := make([]dto.BudgetAlertDTO, 0, len(alerts))
+	for _, alert := range alerts {
+		result = append(result, dto.BudgetAlertDTO{
+			ID:               alert.ID,
+			BudgetID:         alert.BudgetID,
+			BudgetName:       alert.BudgetName,
+			PurchaseRecordID: alert.PurchaseRecordID,
+			PeriodLabel:      alert.PeriodLabel,
+			Currency:         alert.Currency,
+			BudgetAmount:     alert.BudgetAmount,
+			Spent:            alert.Spent,
+			Exceeded:         roundTo(alert.Spent-alert.BudgetAmount, 2),
+			IsRead:           alert.IsRead,
+			CreatedAt:        alert.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// MarkAlertRead 标记提醒为已读
+func (s *budgetService) MarkAlertRead(ctx context.Context, userID, alertID uint) error {
+	alert, err := s.budgetRepo.GetAlertByID(ctx, alertID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.ErrNotFound("预算提醒不存在")
+		}
+		return fmt.Errorf("获取预算提醒失败: %w", err)
+	}
+	if alert.UserID != userID {
+		return errors.ErrForbidden("无权访问此预算提醒")
+	}
+
+	if err := s.budgetRepo.MarkAlertRead(ctx, alertID); err != nil {
+		return fmt.Errorf("更新预算提醒失败: %w", err)
+	}
+	return nil
+}
+
+// getOwnedBudget 获取属于用户的预算
+func (s *budgetService) getOwnedBudget(ctx context.Context, userID, budgetID uint) (*models.Budget, error) {
+	budget, err := s.budgetRepo.GetByID(ctx, budgetID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("预算不存在")
+		}
+		return nil, fmt.Errorf("获取预算失败: %w", err)
+	}
+	if budget.UserID != userID {
+		return nil, errors.ErrForbidden("无权访问此预算")
+	}
+	return budget, nil
+}
+
+// validateCategory 校验预算分类是否存在且对用户可见
+func (s *budgetService) validateCategory(ctx context.Context, userID uint, categoryID *uint) error {
+	if categoryID == nil {
+		return nil
+	}
+	category, err := s.clothingCategoryRepo.GetByID(ctx, *categoryID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.ErrInvalidRequest("分类不存在")
+		}
+		return fmt.Errorf("获取分类失败: %w", err)
+	}
+	if !category.IsVisibleTo(userID) {
+		return errors.ErrInvalidRequest("分类不存在")
+	}
+	return nil
+}
+
+// loadCategoryNames 分类ID到名称的映射
+func (s *budgetService) loadCategoryNames(ctx context.Context, userID uint) (map[uint]string, error) {
+	categories, err := s.clothingCategoryRepo.GetAll(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取分类失败: %w", err)
+	}
+	names := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+	return names, nil
+}
+
+// convertToDTO 将模型转换为DTO
+func (s *budgetService) convertToDTO(ctx context.Context, budget *models.Budget) (*dto.BudgetDTO, error) {
+	categoryNames, err := s.loadCategoryNames(ctx, budget.UserID)
+	if err != nil {
+		return nil, err
+	}
+	result := buildBudgetDTO(budget, categoryNames)
+	return &result, nil
+}
+
+// buildBudgetDTO 构建预算DTO
+func buildBudgetDTO(budget *models.Budget, categoryNames map[uint]string) dto.BudgetDTO {
+	return dto.BudgetDTO{
+		ID:           budget.ID,
+		Name:         budget.Name,
+		Period:       string(budget.Period),
+		CategoryID:   budget.CategoryID,
+		CategoryName: budgetCategoryName(budget, categoryNames),
+		Amount:       budget.Amount,
+		Currency:     budget.Currency,
+		CreatedAt:    budget.CreatedAt,
+		UpdatedAt:    budget.UpdatedAt,
+	}
+}
+
+// buildBudgetStatus 构建预算执行情况，金额为本位币
+func buildBudgetStatus(budget *models.Budget, bucket trendBucket, currency string, amount, spent float64) dto.BudgetStatusDTO {
+	return dto.BudgetStatusDTO{
+		BudgetID:    budget.ID,
+		Name:        budget.Name,
+		Period:      string(budget.Period),
+		CategoryID:  budget.CategoryID,
+		PeriodLabel: bucket.label,
+		PeriodStart: bucket.start,
+		PeriodEnd:   bucket.end,
+		Currency:    currency,
+		Amount:      amount,
+		Spent:       roundTo(spent, 2),
+		Remaining:   roundTo(amount-spent, 2),
+		Percentage:  roundTo(safeDivide(spent, amount)*100, 2),
+		IsExceeded:  spent > amount,
+	}
+}
+
+// budgetBucket 日期所在的预算周期 [start, end)
+func budgetBucket(period api.BudgetPeriod, date time.Time) trendBucket {
+	calendar := monthCalendar
+	if period == api.BudgetPeriodSeasonal {
+		calendar = seasonCalendar
+	}
+	start := calendar.truncate(date)
+	return trendBucket{label: calendar.label(start), start: start, end: calendar.shift(start, 1)}
+}
+
+// budgetSpent 预算周期内计入该预算的支出，总预算计入全部分类
+func budgetSpent(budget *models.Budget, categorySpent map[uint]float64) float64 {
+	if budget.CategoryID != nil {
+		return categorySpent[*budget.CategoryID]
+	}
+	var total float64
+	for _, spent := range categorySpent {
+		total += spent
+	}
+	return total
+}
+
+// budgetCategoryName 预算分类名称，总预算为空
+func budgetCategoryName(budget *models.Budget, categoryNames map[uint]string) string {
+	if budget.CategoryID == nil {
+		return ""
+	}
+	return categoryNames[*budget.CategoryID]
+}
@@ -2,83 +2,90 @@ package services
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
 	"what-to-wear/server/models"
 	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
 )
 
 // CategoryService分类服务接口
+// 查询方法返回系统分类和 userID 的自定义分类，userID 为 0 时只返回系统分类；
+// 管理方法的 ownerID 为空时操作系统分类（管理员），否则操作该用户的自定义分类
 type ClothingCategoryService interface {
-	GetCategoryTree(ctx context.Context) ([]dto.CategoryTreeNode, error)
+	GetCategoryTree(ctx context.Context, userID uint) ([]dto.CategoryTreeNode, error)
 	GetCategoryPath(ctx context.Context, categoryID uint) (string, error)
-	GetAllCategories(ctx context.Context) ([]dto.CategoryDTO, error)
-	GetRootCategories(ctx context.Context) ([]dto.CategoryDTO, error)
+	GetAllCategories(ctx context.Context, userID uint) ([]dto.CategoryDTO, error)
+	GetRootCategories(ctx context.Context, userID uint) ([]dto.CategoryDTO, error)
 	GetCategory(ctx context.Context, categoryID uint) (*dto.CategoryDTO, error)
-	CreateCategory(ctx context.Context, createCategoryDTO *dto.CreateCategoryDTO) (*dto.CategoryDTO, error)
-	UpdateCategory(ctx context.Context, categoryID uint, updateCategoryDTO *dto.UpdateCategoryDTO) (*dto.CategoryDTO, error)
-	DeleteCategory(ctx context.Context, categoryID uint) error
-	GetCategoryStats(ctx context.Context) ([]dto.CategoryStatsItem, error)
+	CreateCategory(ctx context.Context, ownerID *uint, createCategoryDTO *dto.CreateCategoryDTO) (*dto.CategoryDTO, error)
+	UpdateCategory(ctx context.Context, ownerID *uint, categoryID uint, updateCategoryDTO *dto.UpdateCategoryDTO) (*dto.CategoryDTO, error)
+	// 删除分类：有衣物时须指定 reassignTo 将衣物转移到其他分类
+	DeleteCategory(ctx context.Context, ownerID *uint, categoryID uint, reassignTo *uint) error
+	// 调整系统分类的顺序
+	ReorderCategories(ctx context.Context, req *dto.ReorderCategoriesDTO) ([]dto.CategoryDTO, error)
+	GetCategoryStats(ctx context.Context, userID uint) ([]dto.CategoryStatsItem, error)
 }
 
 // CategoryServiceImpl 分类服务实现
 type CategoryServiceImpl struct {
+	transactor   repositories.Transactor
 	categoryRepo repositories.ClothingCategoryRepository
 }
 
 // NewCategoryService 创建分类服务
-func NewCategoryService(categoryRepo repositories.ClothingCategoryRepository) ClothingCategoryService {
+func NewCategoryService(transactor repositories.Transactor, categoryRepo repositories.ClothingCategoryRepository) ClothingCategoryService {
 	return &CategoryServiceImpl{
+		transactor:   transactor,
 		categoryRepo: categoryRepo,
 	}
 }
 
-// GetCategoryTree 获取分类树结构
-func (s *CategoryServiceImpl) GetCategoryTree(ctx context.Context) ([]dto.CategoryTreeNode, error) {
+// GetCategoryTree 获取分类树结构，用户的自定义分类挂在各自的父分类下
+func (s *CategoryServiceImpl) GetCategoryTree(ctx context.Context, userID uint) ([]dto.CategoryTreeNode, error) {
 	// 获取所有分类
-	categories, err := s.categoryRepo.GetAll(ctx)
+	categories, err := s.categoryRepo.GetAll(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// 构建分类映射
-	categoryMap := make(map[uint]*dto.CategoryTreeNode)
-	var rootNodes []dto.CategoryTreeNode
-
-	// 先创建所有节点
+	// 按父分类分组，分类已按排序值排好
+	names := make(map[uint]string, len(categories))
+	children := make(map[uint][]models.ClothingCategory)
+	var roots []models.ClothingCategory
 	for _, category := range categories {
-		node := &dto.CategoryTreeNode{
-			CategoryDTO: dto.CategoryDTO{
-				ID:          category.ID,
-				Name:        category.Name,
-				Description: category.Description,
-				ParentID:    category.ParentID,
-				Icon:        category.Icon,
-				SortOrder:   category.SortOrder,
-				IsActive:    category.IsActive,
-			},
-			Children: make([]dto.CategoryTreeNode, 0),
-		}
-		categoryMap[category.ID] = node
+		names[category.ID] = category.Name
 	}
-
-	// 构建树结构
 	for _, category := range categories {
-		node := categoryMap[category.ID]
-
 		if category.IsRootCategory() {
-			// 根节点
-			rootNodes = append(rootNodes, *node)
-		} else {
-			// 子节点，添加到父节点下
-			if parent, exists := categoryMap[*category.ParentID]; exists {
-				parent.Children = append(parent.Children, *node)
-				// 设置父分类名称
-				node.ParentName = parent.Name
-			}
+			roots = append(roots, category)
+		} else if _, exists := names[*category.ParentID]; exists {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
 		}
 	}
 
+	var build func(category models.ClothingCategory) dto.CategoryTreeNode
+	build = func(category models.ClothingCategory) dto.CategoryTreeNode {
+		node := dto.CategoryTreeNode{
+			CategoryDTO: toCategoryDTO(&category),
+			Children:    make([]dto.CategoryTreeNode, 0, len(children[category.ID])),
+		}
+		if category.HasParent() {
+			node.ParentName = names[*category.ParentID]
+		}
+		for _, child := range children[category.ID] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	rootNodes := make([]dto.CategoryTreeNode, 0, len(roots))
+	for _, root := range roots {
+		rootNodes = append(rootNodes, build(root))
+	}
 	return rootNodes, nil
 }
 
@@ -105,23 +112,15 @@ func (s *CategoryServiceImpl) GetCategoryPath(ctx context.Context, categoryID ui
 }
 
 // GetAllCategories 获取所有分类
-func (s *CategoryServiceImpl) GetAllCategories(ctx context.Context) ([]dto.CategoryDTO, error) {
-	categories, err := s.categoryRepo.GetAll(ctx)
+func (s *CategoryServiceImpl) GetAllCategories(ctx context.Context, userID uint) ([]dto.CategoryDTO, error) {
+	categories, err := s.categoryRepo.GetAll(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	var responses []dto.CategoryDTO
 	for _, category := range categories {
-		response := dto.CategoryDTO{
-			ID:          category.ID,
-			Name:        category.Name,
-			Description: category.Description,
-			ParentID:    category.ParentID,
-			Icon:        category.Icon,
-			SortOrder:   category.SortOrder,
-			IsActive:    category.IsActive,
-		}
+		response := toCategoryDTO(&category)
 
 		// 如果有父分类，获取父分类名称
 		if category.HasParent() {
@@ -137,23 +136,15 @@ func (s *CategoryServiceImpl) GetAllCategories(ctx context.Context) ([]dto.Categ
 }
 
 // GetRootCategories 获取根分类
-func (s *CategoryServiceImpl) GetRootCategories(ctx context.Context) ([]dto.CategoryDTO, error) {
-	categories, err := s.categoryRepo.GetRootCategories(ctx)
+func (s *CategoryServiceImpl) GetRootCategories(ctx context.Context, userID uint) ([]dto.CategoryDTO, error) {
+	categories, err := s.categoryRepo.GetRootCategories(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	var responses []dto.CategoryDTO
 	for _, category := range categories {
-		responses = append(responses, dto.CategoryDTO{
-			ID:          category.ID,
-			Name:        category.Name,
-			Description: category.Description,
-			ParentID:    category.ParentID,
-			Icon:        category.Icon,
-			SortOrder:   category.SortOrder,
-			IsActive:    category.IsActive,
-		})
+		responses = append(responses, toCategoryDTO(&category))
 	}
 
 	return responses, nil
@@ -166,15 +157,7 @@ func (s *CategoryServiceImpl) GetCategory(ctx context.Context, categoryID uint)
 		return nil, err
 	}
 
-	categoryDTO := &dto.CategoryDTO{
-		ID:          category.ID,
-		Name:        category.Name,
-		Description: category.Description,
-		ParentID:    category.ParentID,
-		Icon:        category.Icon,
-		SortOrder:   category.SortOrder,
-		IsActive:    category.IsActive,
-	}
+	categoryDTO := toCategoryDTO(category)
 
 	// 获取父分类名称
 	if category.HasParent() {
@@ -188,19 +171,23 @@ func (s *CategoryServiceImpl) GetCategory(ctx context.Context, categoryID uint)
 		categoryDTO.ItemCount = count
 	}
 
-	return categoryDTO, nil
+	return &categoryDTO, nil
 }
 
-// CreateCategory 创建分类
-func (s *CategoryServiceImpl) CreateCategory(ctx context.Context, req *dto.CreateCategoryDTO) (*dto.CategoryDTO, error) {
+// CreateCategory 创建分类，自定义分类可以挂在系统分类或自己的分类下
+func (s *CategoryServiceImpl) CreateCategory(ctx context.Context, ownerID *uint, req *dto.CreateCategoryDTO) (*dto.CategoryDTO, error) {
 	// 验证父分类是否存在
 	if req.ParentID != nil {
-		if _, err := s.categoryRepo.GetByID(ctx, *req.ParentID); err != nil {
-			return nil, fmt.Errorf("父分类不存在")
+		if _, err := s.getVisibleCategory(ctx, ownerID, *req.ParentID, "父分类不存在"); err != nil {
+			return nil, err
 		}
 	}
+	if err := s.ensureUniqueName(ctx, ownerID, req.Name, 0); err != nil {
+		return nil, err
+	}
 
 	category := &models.ClothingCategory{
+		UserID:      ownerID,
 		Name:        req.Name,
 		Description: req.Description,
 		ParentID:    req.ParentID,
@@ -210,26 +197,28 @@ func (s *CategoryServiceImpl) CreateCategory(ctx context.Context, req *dto.Creat
 	}
 
 	if err := s.categoryRepo.Create(ctx, category); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("创建分类失败: %w", err)
 	}
 
 	return s.GetCategory(ctx, category.ID)
 }
 
 // UpdateCategory 更新分类
-func (s *CategoryServiceImpl) UpdateCategory(ctx context.Context, categoryID uint, req *dto.UpdateCategoryDTO) (*dto.CategoryDTO, error) {
-	category, err := s.categoryRepo.GetByID(ctx, categoryID)
+func (s *CategoryServiceImpl) UpdateCategory(ctx context.Context, ownerID *uint, categoryID uint, req *dto.UpdateCategoryDTO) (*dto.CategoryDTO, error) {
+	category, err := s.getOwnedCategory(ctx, ownerID, categoryID)
 	if err != nil {
 		return nil, err
 	}
 
-	// 验证父分类
+	// 验证父分类，新的父分类不能是自身或自身的子孙分类
 	if req.ParentID != nil && *req.ParentID != 0 {
-		if *req.ParentID == categoryID {
-			return nil, fmt.Errorf("不能将分类设为自己的父分类")
+		if err := s.ensureNoCycle(ctx, ownerID, categoryID, *req.ParentID); err != nil {
+			return nil, err
 		}
-		if _, err := s.categoryRepo.GetByID(ctx, *req.ParentID); err != nil {
-			return nil, fmt.Errorf("父分类不存在")
+	}
+	if req.Name != nil && *req.Name != category.Name {
+		if err := s.ensureUniqueName(ctx, ownerID, *req.Name, categoryID); err != nil {
+			return nil, err
 		}
 	}
 
@@ -258,38 +247,219 @@ func (s *CategoryServiceImpl) UpdateCategory(ctx context.Context, categoryID uin
 	}
 
 	if err := s.categoryRepo.Update(ctx, category); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("更新分类失败: %w", err)
 	}
 
 	return s.GetCategory(ctx, categoryID)
 }
 
 // DeleteCategory 删除分类
-func (s *CategoryServiceImpl) DeleteCategory(ctx context.Context, categoryID uint) error {
-	// 检查是否有子分类
-	children, err := s.categoryRepo.GetChildCategories(ctx, categoryID)
-	if err != nil {
+func (s *CategoryServiceImpl) DeleteCategory(ctx context.Context, ownerID *uint, categoryID uint, reassignTo *uint) error {
+	if _, err := s.getOwnedCategory(ctx, ownerID, categoryID); err != nil {
 		return err
 	}
-	if len(children) > 0 {
-		return fmt.Errorf("不能删除有子分类的分类")
+
+	// 检查是否有子分类（包括其他用户挂在系统分类下的自定义分类）
+	children, err := s.categoryRepo.CountChildren(ctx, categoryID)
+	if err != nil {
+		return fmt.Errorf("获取子分类失败: %w", err)
+	}
+	if children > 0 {
+		return errors.ErrConflict("不能删除有子分类的分类")
+	}
+
+	if reassignTo != nil {
+		if *reassignTo == categoryID {
+			return errors.ErrInvalidRequest("不能将衣物转移到待删除的分类")
+		}
+		if _, err := s.getVisibleCategory(ctx, ownerID, *reassignTo, "目标分类不存在"); err != nil {
+			return err
+		}
+	}
+
+	// 统计、转移衣物和删除分类在同一事务中完成，已停用的衣物同样需要转移
+	return s.transactor.Transaction(ctx, func(tx *gorm.DB) error {
+		categoryRepo := s.categoryRepo.WithTx(tx)
+		count, err := categoryRepo.CountAllItems(ctx, categoryID)
+		if err != nil {
+			return fmt.Errorf("统计分类衣物失败: %w", err)
+		}
+		if count > 0 && reassignTo == nil {
+			return errors.ErrConflict(fmt.Sprintf("分类下还有 %d 件衣物，请指定转移到的分类", count))
+		}
+
+		if reassignTo != nil {
+			if _, err := categoryRepo.ReassignItems(ctx, categoryID, *reassignTo); err != nil {
+				return fmt.Errorf("转移分类衣物失败: %w", err)
+			}
+		}
+
+		if err := categoryRepo.Delete(ctx, categoryID); err != nil {
+			return fmt.Errorf("删除分类失败: %w", err)
+		}
+		return nil
+	})
+}
+
+// ReorderCategories 按给定顺序重排同级系统分类，须包含该层级的全部系统分类
+func (s *CategoryServiceImpl) ReorderCategories(ctx context.Context, req *dto.ReorderCategoriesDTO) ([]dto.CategoryDTO, error) {
+	var siblings []models.ClothingCategory
+	var err error
+	if req.ParentID == nil || *req.ParentID == 0 {
+		siblings, err = s.categoryRepo.GetRootCategories(ctx, 0)
+	} else {
+		if _, err := s.getOwnedCategory(ctx, nil, *req.ParentID); err != nil {
+			return nil, err
+		}
+		siblings, err = s.categoryRepo.GetChildCategories(ctx, *req.ParentID, 0)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("获取分类失败: %w", err)
+	}
+
+	pending := make(map[uint]bool, len(siblings))
+	for _, sibling := range siblings {
+		pending[sibling.ID] = true
+	}
+	for _, id := range req.CategoryIDs {
+		if !pending[id] {
+			return nil, errors.ErrInvalidRequest(fmt.Sprintf("分类 %d 不属于该层级或重复出现", id))
+		}
+		delete(pending, id)
+	}
+	if len(pending) > 0 {
+		return nil, errors.ErrInvalidRequest("排序列表须包含该层级的全部分类")
+	}
+
+	if err := s.categoryRepo.UpdateSortOrders(ctx, req.CategoryIDs); err != nil {
+		return nil, fmt.Errorf("更新分类排序失败: %w", err)
+	}
+
+	result := make([]dto.CategoryDTO, 0, len(req.CategoryIDs))
+	for _, id := range req.CategoryIDs {
+		category, err := s.GetCategory(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *category)
+	}
+	return result, nil
+}
+
+// getOwnedCategory 获取可由 ownerID 管理的启用分类：为空时只能是系统分类，否则只能是该用户的自定义分类
+func (s *CategoryServiceImpl) getOwnedCategory(ctx context.Context, ownerID *uint, categoryID uint) (*models.ClothingCategory, error) {
+	category, err := s.getActiveCategory(ctx, categoryID, "分类不存在")
+	if err != nil {
+		return nil, err
+	}
+	if ownerID == nil {
+		if category.IsCustom() {
+			return nil, errors.ErrForbidden("用户自定义分类只能由创建者管理")
+		}
+		return category, nil
+	}
+	if !category.IsCustom() {
+		return nil, errors.ErrForbidden("系统分类只能由管理员管理")
+	}
+	if *category.UserID != *ownerID {
+		return nil, errors.ErrNotFound("分类不存在")
+	}
+	return category, nil
+}
+
+// getVisibleCategory 获取 ownerID 可以引用的启用分类（作为父分类或转移目标）：
+// 系统分类只能引用系统分类，自定义分类可以引用系统分类和自己的分类
+func (s *CategoryServiceImpl) getVisibleCategory(ctx context.Context, ownerID *uint, categoryID uint, notFound string) (*models.ClothingCategory, error) {
+	category, err := s.getActiveCategory(ctx, categoryID, notFound)
+	if err != nil {
+		return nil, err
+	}
+	if category.IsCustom() && (ownerID == nil || *category.UserID != *ownerID) {
+		return nil, errors.ErrNotFound(notFound)
 	}
+	return category, nil
+}
 
-	// 检查是否有关联的衣物
-	count, err := s.categoryRepo.GetCategoryItemCount(ctx, categoryID)
+// getActiveCategory 获取启用中的分类，不存在或已停用时返回指定的提示
+func (s *CategoryServiceImpl) getActiveCategory(ctx context.Context, categoryID uint, notFound string) (*models.ClothingCategory, error) {
+	category, err := s.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound(notFound)
+		}
+		return nil, fmt.Errorf("获取分类失败: %w", err)
+	}
+	if !category.IsActive {
+		return nil, errors.ErrNotFound(notFound)
+	}
+	return category, nil
+}
+
+// ensureUniqueName 系统分类之间不重名；自定义分类既不能与自己的分类重名，也不能与系统分类重名
+func (s *CategoryServiceImpl) ensureUniqueName(ctx context.Context, ownerID *uint, name string, excludeID uint) error {
+	var userID uint
+	if ownerID != nil {
+		userID = *ownerID
+	}
+	exists, err := s.categoryRepo.NameExists(ctx, name, userID, excludeID)
+	if err != nil {
+		return fmt.Errorf("检查分类名称失败: %w", err)
+	}
+	if exists {
+		return errors.ErrConflict(fmt.Sprintf("分类名称已存在: %s", name))
+	}
+	return nil
+}
+
+// ensureNoCycle 沿新父分类向上检查祖先链，出现自身即说明会形成循环
+func (s *CategoryServiceImpl) ensureNoCycle(ctx context.Context, ownerID *uint, categoryID, parentID uint) error {
+	current, err := s.getVisibleCategory(ctx, ownerID, parentID, "父分类不存在")
 	if err != nil {
 		return err
 	}
-	if count > 0 {
-		return fmt.Errorf("不能删除有关联衣物的分类")
+
+	visited := make(map[uint]bool)
+	for {
+		if current.ID == categoryID {
+			return errors.ErrInvalidRequest("不能将分类移动到自身或其子分类之下")
+		}
+		if visited[current.ID] {
+			return errors.ErrConflict("分类层级已存在循环")
+		}
+		visited[current.ID] = true
+
+		if !current.HasParent() {
+			return nil
+		}
+		current, err = s.categoryRepo.GetByID(ctx, *current.ParentID)
+		if err != nil {
+			if stderrors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return fmt.Errorf("获取分类失败: %w", err)
+		}
 	}
+}
 
-	return s.categoryRepo.Delete(ctx, categoryID)
+// toCategoryDTO 将分类模型转换为DTO
+func toCategoryDTO(category *models.ClothingCategory) dto.CategoryDTO {
+	return dto.CategoryDTO{
+		ID:          category.ID,
+		Name:        category.Name,
+		Description: category.Description,
+		ParentID:    category.ParentID,
+		Icon:        category.Icon,
+		SortOrder:   category.SortOrder,
+		IsActive:    category.IsActive,
+		IsCustom:    category.IsCustom(),
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
 }
 
 // GetCategoryStats 获取分类统计
-func (s *CategoryServiceImpl) GetCategoryStats(ctx context.Context) ([]dto.CategoryStatsItem, error) {
-	categories, err := s.categoryRepo.GetAll(ctx)
+func (s *CategoryServiceImpl) GetCategoryStats(ctx context.Context, userID uint) ([]dto.CategoryStatsItem, error) {
+	categories, err := s.categoryRepo.GetAll(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -2,104 +2,216 @@ package services
 
 import (
 	"context"
-	"errors"
+	stderrors "errors"
 	"fmt"
 	"math"
+	"slices"
+	"sort"
 	"time"
 	"what-to-wear/server/api"
 	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
 	"what-to-wear/server/models"
 	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
 )
 
+// clothingStatsTopN 统计中最常穿着和最近添加的衣物数量
+const clothingStatsTopN = 5
+
 // ClothingItemService 衣物服务接口
 type ClothingItemService interface {
 	// 基础CRUD操作
 	CreateClothingItem(ctx context.Context, userID uint, req *dto.CreateClothingItemDTO) (*dto.ClothingItemDTO, error)
+	// 在同一事务中创建多件衣物，任一件失败时全部回滚并返回失败的请求序号
+	CreateClothingItems(ctx context.Context, userID uint, reqs []*dto.CreateClothingItemDTO) ([]*dto.ClothingItemDTO, int, error)
 	GetClothingItem(ctx context.Context, userID, itemID uint) (*dto.ClothingItemDTO, error)
 	GetClothingItems(ctx context.Context, userID uint, req *dto.ClothingItemListDTO) ([]models.ClothingItem, int, error)
 	UpdateClothingItem(ctx context.Context, userID, itemID uint, req *dto.UpdateClothingItemDTO) (*dto.ClothingItemDTO, error)
 	DeleteClothingItem(ctx context.Context, userID, itemID uint) error
 
 	// 批量操作
-	BatchDeleteClothingItems(ctx context.Context, userID uint, itemIDs []uint) (map[string]interface{}, error)
+	BatchDeleteClothingItems(ctx context.Context, userID uint, req *dto.BatchItemsDTO) (*dto.BulkOperationResponse, error)
+	BatchAddTags(ctx context.Context, userID uint, req *dto.BatchTagsDTO) (*dto.BulkOperationResponse, error)
+	BatchRemoveTags(ctx context.Context, userID uint, req *dto.BatchTagsDTO) (*dto.BulkOperationResponse, error)
+	BatchMoveCategory(ctx context.Context, userID uint, req *dto.BatchCategoryDTO) (*dto.BulkOperationResponse, error)
+	BatchUpdateCondition(ctx context.Context, userID uint, req *dto.BatchConditionDTO) (*dto.BulkOperationResponse, error)
 
 	// 高级功能
 	GetClothingStats(ctx context.Context, userID uint) (*dto.ClothingStatsDTO, error)
-	SearchClothingItems(ctx context.Context, userID uint, query string, limit int) ([]dto.ClothingItemSummary, error)
+	SearchClothingItems(ctx context.Context, userID uint, req *dto.ClothingSearchDTO) (*dto.ClothingSearchResultDTO, error)
 	GetRecommendations(ctx context.Context, userID uint, occasion string, weather string) ([]dto.ClothingItemSummary, error)
 }
 
 // clothingItemService 衣物服务实现
 type clothingItemService struct {
+	transactor           repositories.Transactor
 	clothingItemRepo     repositories.ClothingItemRepository
 	clothingCategoryRepo repositories.ClothingCategoryRepository
+	clothingTagRepo      repositories.ClothingTagRepository
 	attachmentRepo       repositories.AttachmentRepository
 	purchaseRecordRepo   repositories.PurchaseRecordRepository
 	wearRecordRepo       repositories.WearRecordRepository
+	currencyService      CurrencyService
+	budgetService        BudgetService
 }
 
 // NewClothingItemService 创建衣物服务实例
 func NewClothingItemService(
+	transactor repositories.Transactor,
 	clothingItemRepo repositories.ClothingItemRepository,
 	clothingCategoryRepo repositories.ClothingCategoryRepository,
+	clothingTagRepo repositories.ClothingTagRepository,
 	attachmentRepo repositories.AttachmentRepository,
 	purchaseRecordRepo repositories.PurchaseRecordRepository,
 	wearRecordRepo repositories.WearRecordRepository,
+	currencyService CurrencyService,
+	budgetService BudgetService,
 ) ClothingItemService {
 	return &clothingItemService{
+		transactor:           transactor,
 		clothingItemRepo:     clothingItemRepo,
 		clothingCategoryRepo: clothingCategoryRepo,
+		clothingTagRepo:      clothingTagRepo,
 		attachmentRepo:       attachmentRepo,
 		purchaseRecordRepo:   purchaseRecordRepo,
 		wearRecordRepo:       wearRecordRepo,
+		currencyService:      currencyService,
+		budgetService:        budgetService,
 	}
 }
 
 // CreateClothingItem 创建衣物
 func (s *clothingItemService) CreateClothingItem(ctx context.Context, userID uint, req *dto.CreateClothingItemDTO) (*dto.ClothingItemDTO, error) {
+	items, _, err := s.CreateClothingItems(ctx, userID, []*dto.CreateClothingItemDTO{req})
+	if err != nil {
+		return nil, err
+	}
+	return items[0], nil
+}
+
+// CreateClothingItems 批量创建衣物，衣物、购买记录和标签在同一事务中写入
+func (s *clothingItemService) CreateClothingItems(ctx context.Context, userID uint, reqs []*dto.CreateClothingItemDTO) ([]*dto.ClothingItemDTO, int, error) {
+	drafts := make([]*clothingItemDraft, len(reqs))
+	for i, req := range reqs {
+		draft, err := s.newClothingItemDraft(ctx, userID, req)
+		if err != nil {
+			return nil, i, err
+		}
+		drafts[i] = draft
+	}
+
+	failed := -1
+	err := s.transactor.Transaction(ctx, func(tx *gorm.DB) error {
+		itemRepo := s.clothingItemRepo.WithTx(tx)
+		purchaseRepo := s.purchaseRecordRepo.WithTx(tx)
+		for i, draft := range drafts {
+			if err := saveClothingItemDraft(ctx, itemRepo, purchaseRepo, draft); err != nil {
+				failed = i
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, failed, err
+	}
+
+	// 提交后再检查预算，避免回滚时已产生预算提醒
+	result := make([]*dto.ClothingItemDTO, len(drafts))
+	for i, draft := range drafts {
+		if draft.purchase != nil {
+			s.budgetService.CheckPurchase(ctx, userID, draft.purchase)
+		}
+		result[i] = s.convertToDTO(draft.item, draft.category)
+	}
+	return result, -1, nil
+}
+
+// clothingItemDraft 待写入的衣物及其购买记录和标签
+type clothingItemDraft struct {
+	item     *models.ClothingItem
+	category *models.ClothingCategory
+	purchase *models.PurchaseRecord
+	tagIDs   []uint
+}
+
+// newClothingItemDraft 校验分类并换算购买价格，生成待写入的衣物
+func (s *clothingItemService) newClothingItemDraft(ctx context.Context, userID uint, req *dto.CreateClothingItemDTO) (*clothingItemDraft, error) {
 	// 验证分类是否存在
 	category, err := s.clothingCategoryRepo.GetByID(ctx, req.CategoryID)
 	if err != nil {
 		return nil, fmt.Errorf("分类不存在: %w", err)
 	}
+	if !category.IsVisibleTo(userID) {
+		return nil, errors.ErrInvalidRequest("分类不存在")
+	}
 
 	// 创建衣物模型
 	clothingItem := &models.ClothingItem{
-		UserID:     userID,
-		CategoryID: req.CategoryID,
-		Name:       req.Name,
-		Brand:      req.Brand,
-		Color:      req.Color,
-		Material:   req.Material,
-		Condition:  req.Status,
-		IsActive:   true,
-		Size:       req.Size,
-		Style:      req.Style,
-		IsFavorite: req.IsFavorite,
-	}
-
-	// 设置价格（如果有购买信息）
+		UserID:      userID,
+		CategoryID:  req.CategoryID,
+		Name:        req.Name,
+		Brand:       req.Brand,
+		Color:       req.Color,
+		Material:    req.Material,
+		Condition:   req.Status,
+		IsActive:    true,
+		Size:        req.Size,
+		Style:       req.Style,
+		Description: req.Description,
+		IsFavorite:  req.IsFavorite,
+	}
+
+	// 设置价格（如果有购买信息），衣物价格为本位币金额
+	var purchase *models.PurchaseRecord
 	if req.PurchaseInfo != nil {
-		clothingItem.Price = req.PurchaseInfo.Price
+		currency, homePrice, err := s.currencyService.ToHomeCurrency(ctx, userID, req.PurchaseInfo.Price, req.PurchaseInfo.Currency, req.PurchaseInfo.PurchaseDate)
+		if err != nil {
+			return nil, err
+		}
+		purchase = &models.PurchaseRecord{
+			Price:        req.PurchaseInfo.Price,
+			Currency:     currency,
+			HomePrice:    homePrice,
+			Store:        req.PurchaseInfo.Store,
+			PurchaseDate: req.PurchaseInfo.PurchaseDate,
+			Notes:        req.PurchaseInfo.Notes,
+		}
+		clothingItem.Price = homePrice
 		clothingItem.PurchaseDate = &req.PurchaseInfo.PurchaseDate
 	}
 
-	// 创建衣物
-	err = s.clothingItemRepo.Create(ctx, clothingItem)
-	if err != nil {
-		return nil, fmt.Errorf("创建衣物失败: %w", err)
+	return &clothingItemDraft{item: clothingItem, category: category, purchase: purchase, tagIDs: req.Tags}, nil
+}
+
+// saveClothingItemDraft 写入衣物、购买记录和标签
+func saveClothingItemDraft(
+	ctx context.Context,
+	itemRepo repositories.ClothingItemRepository,
+	purchaseRepo repositories.PurchaseRecordRepository,
+	draft *clothingItemDraft,
+) error {
+	if err := itemRepo.Create(ctx, draft.item); err != nil {
+		return fmt.Errorf("创建衣物失败: %w", err)
+	}
+
+	// 有购买信息时同时创建购买记录
+	if draft.purchase != nil {
+		draft.purchase.ClothingItemID = draft.item.ID
+		if err := purchaseRepo.Create(ctx, draft.purchase); err != nil {
+			return fmt.Errorf("创建购买记录失败: %w", err)
+		}
 	}
 
 	// 添加标签
-	if len(req.Tags) > 0 {
-		err = s.clothingItemRepo.AddTags(ctx, clothingItem.ID, req.Tags)
-		if err != nil {
-			return nil, fmt.Errorf("添加标签失败: %w", err)
+	if len(draft.tagIDs) > 0 {
+		if err := itemRepo.AddTags(ctx, draft.item.ID, draft.tagIDs); err != nil {
+			return fmt.Errorf("添加标签失败: %w", err)
 		}
 	}
-
-	return s.convertToDTO(clothingItem, category), nil
+	return nil
 }
 
 // GetClothingItem 获取衣物详情
@@ -112,7 +224,7 @@ func (s *clothingItemService) GetClothingItem(ctx context.Context, userID, itemI
 
 	// 验证权限
 	if item.UserID != userID {
-		return nil, errors.New("无权访问该衣物")
+		return nil, stderrors.New("无权访问该衣物")
 	}
 
 	// 获取分类信息
@@ -126,6 +238,12 @@ func (s *clothingItemService) GetClothingItem(ctx context.Context, userID, itemI
 
 // GetClothingItems 获取衣物列表
 func (s *clothingItemService) GetClothingItems(ctx context.Context, userID uint, req *dto.ClothingItemListDTO) ([]models.ClothingItem, int, error) {
+	for key := range req.Filters {
+		if !slices.Contains(models.ClothingFilterFields, key) {
+			return nil, 0, errors.ErrInvalidRequest(fmt.Sprintf("不支持的筛选字段: %s", key))
+		}
+	}
+
 	// 获取衣物列表
 	items, total, err := s.clothingItemRepo.GetByUserID(ctx, userID, req)
 	if err != nil {
@@ -149,11 +267,15 @@ func (s *clothingItemService) UpdateClothingItem(ctx context.Context, userID, it
 
 	// 验证权限
 	if item.UserID != userID {
-		return nil, errors.New("无权修改该衣物")
+		return nil, stderrors.New("无权修改该衣物")
 	}
 
 	// 更新字段
 	if req.CategoryID != nil {
+		category, err := s.clothingCategoryRepo.GetByID(ctx, *req.CategoryID)
+		if err != nil || !category.IsVisibleTo(userID) {
+			return nil, errors.ErrInvalidRequest("分类不存在")
+		}
 		item.CategoryID = *req.CategoryID
 	}
 	if req.Name != nil {
@@ -197,7 +319,7 @@ func (s *clothingItemService) DeleteClothingItem(ctx context.Context, userID, it
 
 	// 验证权限
 	if item.UserID != userID {
-		return errors.New("无权删除该衣物")
+		return stderrors.New("无权删除该衣物")
 	}
 
 	// 软删除衣物
@@ -205,67 +327,281 @@ func (s *clothingItemService) DeleteClothingItem(ctx context.Context, userID, it
 }
 
 // BatchDeleteClothingItems 批量删除衣物
-func (s *clothingItemService) BatchDeleteClothingItems(ctx context.Context, userID uint, itemIDs []uint) (map[string]interface{}, error) {
-	result := make(map[string]interface{})
-	successCount := 0
-	failedCount := 0
+func (s *clothingItemService) BatchDeleteClothingItems(ctx context.Context, userID uint, req *dto.BatchItemsDTO) (*dto.BulkOperationResponse, error) {
+	return s.runBatch(ctx, userID, req, func(itemIDs []uint) error {
+		return s.clothingItemRepo.BatchUpdate(ctx, itemIDs, map[string]interface{}{"is_active": false})
+	})
+}
+
+// BatchAddTags 批量为衣物添加标签
+func (s *clothingItemService) BatchAddTags(ctx context.Context, userID uint, req *dto.BatchTagsDTO) (*dto.BulkOperationResponse, error) {
+	if err := s.validateTagIDs(ctx, userID, req.TagIDs); err != nil {
+		return nil, err
+	}
+	return s.runBatch(ctx, userID, &req.BatchItemsDTO, func(itemIDs []uint) error {
+		return s.clothingItemRepo.BatchAddTags(ctx, itemIDs, req.TagIDs)
+	})
+}
+
+// BatchRemoveTags 批量移除衣物标签
+func (s *clothingItemService) BatchRemoveTags(ctx context.Context, userID uint, req *dto.BatchTagsDTO) (*dto.BulkOperationResponse, error) {
+	if err := s.validateTagIDs(ctx, userID, req.TagIDs); err != nil {
+		return nil, err
+	}
+	return s.runBatch(ctx, userID, &req.BatchItemsDTO, func(itemIDs []uint) error {
+		return s.clothingItemRepo.BatchRemoveTags(ctx, itemIDs, req.TagIDs)
+	})
+}
+
+// BatchMoveCategory 批量移动衣物到指定分类
+func (s *clothingItemService) BatchMoveCategory(ctx context.Context, userID uint, req *dto.BatchCategoryDTO) (*dto.BulkOperationResponse, error) {
+	category, err := s.clothingCategoryRepo.GetByID(ctx, req.CategoryID)
+	if err != nil || !category.IsActive || !category.IsVisibleTo(userID) {
+		return nil, errors.ErrInvalidRequest("分类不存在")
+	}
+	return s.runBatch(ctx, userID, &req.BatchItemsDTO, func(itemIDs []uint) error {
+		return s.clothingItemRepo.BatchUpdate(ctx, itemIDs, map[string]interface{}{"category_id": req.CategoryID})
+	})
+}
+
+// BatchUpdateCondition 批量修改衣物状态
+func (s *clothingItemService) BatchUpdateCondition(ctx context.Context, userID uint, req *dto.BatchConditionDTO) (*dto.BulkOperationResponse, error) {
+	if !req.Condition.IsValid() {
+		return nil, errors.ErrInvalidRequest("无效的衣物状态: " + string(req.Condition))
+	}
+	return s.runBatch(ctx, userID, &req.BatchItemsDTO, func(itemIDs []uint) error {
+		return s.clothingItemRepo.BatchUpdate(ctx, itemIDs, map[string]interface{}{"condition": req.Condition})
+	})
+}
+
+// validateTagIDs 验证标签均为用户可用的标签（系统标签或用户自定义标签）
+func (s *clothingItemService) validateTagIDs(ctx context.Context, userID uint, tagIDs []uint) error {
+	tags, err := s.clothingTagRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("获取标签失败: %w", err)
+	}
+	available := make(map[uint]bool, len(tags))
+	for _, tag := range tags {
+		available[tag.ID] = true
+	}
+	for _, tagID := range tagIDs {
+		if !available[tagID] {
+			return errors.ErrInvalidRequest(fmt.Sprintf("标签不存在: %d", tagID))
+		}
+	}
+	return nil
+}
+
+// runBatch 校验每件衣物的归属，再将变更一次性应用到校验通过的衣物上。
+// 全有或全无模式下只要有衣物校验失败，就不应用任何变更。
+func (s *clothingItemService) runBatch(ctx context.Context, userID uint, req *dto.BatchItemsDTO, apply func(itemIDs []uint) error) (*dto.BulkOperationResponse, error) {
+	itemIDs := make([]uint, 0, len(req.ItemIDs))
+	for _, itemID := range req.ItemIDs {
+		if !slices.Contains(itemIDs, itemID) {
+			itemIDs = append(itemIDs, itemID)
+		}
+	}
+
+	items, err := s.clothingItemRepo.GetByIDs(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取衣物失败: %w", err)
+	}
+	owned := make(map[uint]bool, len(items))
+	for _, item := range items {
+		if item.UserID == userID && item.IsActive {
+			owned[item.ID] = true
+		}
+	}
 
+	var validIDs []uint
 	for _, itemID := range itemIDs {
-		err := s.DeleteClothingItem(ctx, userID, itemID)
-		if err != nil {
-			failedCount++
-		} else {
-			successCount++
+		if owned[itemID] {
+			validIDs = append(validIDs, itemID)
+		}
+	}
+
+	rolledBack := req.AllOrNothing && len(validIDs) < len(itemIDs)
+	if len(validIDs) > 0 && !rolledBack {
+		if err := apply(validIDs); err != nil {
+			return nil, fmt.Errorf("批量操作失败: %w", err)
 		}
 	}
 
-	result["success_count"] = successCount
-	result["failed_count"] = failedCount
-	result["total_count"] = len(itemIDs)
+	resp := &dto.BulkOperationResponse{
+		Items:      make([]dto.BulkItemResult, 0, len(itemIDs)),
+		RolledBack: rolledBack,
+	}
+	for _, itemID := range itemIDs {
+		result := dto.BulkItemResult{ID: itemID}
+		switch {
+		case !owned[itemID]:
+			result.Error = "衣物不存在或无权操作"
+		case rolledBack:
+			result.Error = "其他衣物处理失败，未执行"
+		default:
+			result.Success = true
+		}
 
-	return result, nil
+		resp.Items = append(resp.Items, result)
+		if result.Success {
+			resp.SuccessCount++
+			resp.Results = append(resp.Results, itemID)
+		} else {
+			resp.FailureCount++
+			resp.Errors = append(resp.Errors, fmt.Sprintf("衣物 %d: %s", itemID, result.Error))
+		}
+	}
+
+	return resp, nil
 }
 
 // GetClothingStats 获取衣物统计
 func (s *clothingItemService) GetClothingStats(ctx context.Context, userID uint) (*dto.ClothingStatsDTO, error) {
-	// 获取基础统计
-	req := &dto.ClothingItemListDTO{
-		SearchRequest: dto.SearchRequest{
-			PaginationRequest: dto.PaginationRequest{Page: 1, PageSize: 1},
-		},
+	items, err := s.clothingItemRepo.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取衣物列表失败: %w", err)
+	}
+
+	categories, err := s.clothingCategoryRepo.GetAll(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取分类失败: %w", err)
+	}
+	categoryNames := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	itemIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
 	}
-	_, total, err := s.clothingItemRepo.GetByUserID(ctx, userID, req)
+	itemTags, err := s.clothingItemRepo.GetTagsByItemIDs(ctx, itemIDs)
 	if err != nil {
-		return nil, fmt.Errorf("获取衣物总数失败: %w", err)
+		return nil, fmt.Errorf("获取衣物标签失败: %w", err)
+	}
+
+	currency, err := s.currencyService.GetHomeCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
 	stats := &dto.ClothingStatsDTO{
-		TotalItems:    total,
+		Currency:      currency,
+		TotalItems:    int64(len(items)),
 		ByCategory:    make(map[string]int64),
 		ByStatus:      make(map[api.ClothingStatus]int64),
 		BySeason:      make(map[string]int64),
 		ByOccasion:    make(map[string]int64),
 		ByBrand:       make(map[string]int64),
 		ByColor:       make(map[string]int64),
-		TotalValue:    0,
-		AveragePrice:  0,
 		MostWornItems: []dto.ClothingItemSummary{},
 		RecentlyAdded: []dto.ClothingItemSummary{},
 		LastUpdated:   time.Now(),
 	}
 
+	pricedCount := 0
+	for _, item := range items {
+		if name, exists := categoryNames[item.CategoryID]; exists {
+			stats.ByCategory[name]++
+		}
+		stats.ByStatus[item.Condition]++
+		if item.Brand != "" {
+			stats.ByBrand[item.Brand]++
+		}
+		if item.Color != "" {
+			stats.ByColor[item.Color]++
+		}
+		for _, tag := range itemTags[item.ID] {
+			switch tag.Type {
+			case api.TagTypeSeason:
+				stats.BySeason[tag.Name]++
+			case api.TagTypeOccasion:
+				stats.ByOccasion[tag.Name]++
+			}
+		}
+		if item.Price > 0 {
+			stats.TotalValue += item.Price
+			pricedCount++
+		}
+	}
+	if pricedCount > 0 {
+		stats.AveragePrice = math.Round(stats.TotalValue/float64(pricedCount)*100) / 100
+	}
+
+	// 衣物已按创建时间倒序排列
+	recent := items
+	if len(recent) > clothingStatsTopN {
+		recent = recent[:clothingStatsTopN]
+	}
+	stats.RecentlyAdded = s.convertToSummaryListWithCategory(recent, categoryNames)
+
+	mostWorn := make([]models.ClothingItem, 0, len(items))
+	for _, item := range items {
+		if item.WearCount > 0 {
+			mostWorn = append(mostWorn, item)
+		}
+	}
+	sort.SliceStable(mostWorn, func(i, j int) bool {
+		return mostWorn[i].WearCount > mostWorn[j].WearCount
+	})
+	if len(mostWorn) > clothingStatsTopN {
+		mostWorn = mostWorn[:clothingStatsTopN]
+	}
+	stats.MostWornItems = s.convertToSummaryListWithCategory(mostWorn, categoryNames)
+
 	return stats, nil
 }
 
-// SearchClothingItems 搜索衣物
-func (s *clothingItemService) SearchClothingItems(ctx context.Context, userID uint, query string, limit int) ([]dto.ClothingItemSummary, error) {
-	// 搜索衣物
-	items, err := s.clothingItemRepo.Search(ctx, userID, query, limit)
+// SearchClothingItems 高级搜索衣物，返回分页结果和分面统计
+func (s *clothingItemService) SearchClothingItems(ctx context.Context, userID uint, req *dto.ClothingSearchDTO) (*dto.ClothingSearchResultDTO, error) {
+	for key := range req.Attributes {
+		if !slices.Contains(models.SpecificTextAttributes, key) && !slices.Contains(models.SpecificNumericAttributes, key) {
+			return nil, errors.ErrInvalidRequest(fmt.Sprintf("不支持的特定属性: %s", key))
+		}
+	}
+	for _, bounds := range []map[string]float64{req.AttributeMin, req.AttributeMax} {
+		for key := range bounds {
+			if !slices.Contains(models.SpecificNumericAttributes, key) {
+				return nil, errors.ErrInvalidRequest(fmt.Sprintf("不支持按范围筛选的特定属性: %s", key))
+			}
+		}
+	}
+	for _, condition := range req.Conditions {
+		if !condition.IsValid() {
+			return nil, errors.ErrInvalidRequest(fmt.Sprintf("无效的衣物状态: %s", condition))
+		}
+	}
+	if req.MinDurability != nil && req.MaxDurability != nil && *req.MinDurability > *req.MaxDurability {
+		return nil, errors.ErrInvalidRequest("耐久度下限不能大于上限")
+	}
+
+	items, total, err := s.clothingItemRepo.Search(ctx, userID, req)
 	if err != nil {
 		return nil, fmt.Errorf("搜索衣物失败: %w", err)
 	}
 
-	return s.convertToSummaryList(items), nil
+	facets, err := s.clothingItemRepo.GetSearchFacets(ctx, userID, req)
+	if err != nil {
+		return nil, fmt.Errorf("统计搜索分面失败: %w", err)
+	}
+
+	categories, err := s.clothingCategoryRepo.GetAll(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取分类失败: %w", err)
+	}
+	categoryNames := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	return &dto.ClothingSearchResultDTO{
+		Items:      s.convertToSummaryListWithCategory(items, categoryNames),
+		TotalCount: total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: int((total + int64(req.PageSize) - 1) / int64(req.PageSize)),
+		Facets:     *facets,
+	}, nil
 }
 
 // GetRecommendations 获取推荐衣物
@@ -326,6 +662,15 @@ func (s *clothingItemService) convertToDTO(item *models.ClothingItem, category *
 	}
 }
 
+// convertToSummaryListWithCategory 将模型列表转换为带分类名称的摘要DTO列表
+func (s *clothingItemService) convertToSummaryListWithCategory(items []models.ClothingItem, categoryNames map[uint]string) []dto.ClothingItemSummary {
+	summaries := s.convertToSummaryList(items)
+	for i := range summaries {
+		summaries[i].CategoryName = categoryNames[items[i].CategoryID]
+	}
+	return summaries
+}
+
 // convertToSummaryList 将模型列表转换为摘要DTO列表
 func (s *clothingItemService) convertToSummaryList(items []models.ClothingItem) []dto.ClothingItemSummary {
 	summaries := make([]dto.ClothingItemSummary, len(items))
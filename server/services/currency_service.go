@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"math"
+	"time"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
+	"what-to-wear/server/config"
+	"what-to-wear/server/logger"
+	"what-to-wear/server/models"
+	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
+)
+
+// exchangeRatePrecision 汇率查询结果保留的小数位数
+const exchangeRatePrecision = 1e6
+
+// CurrencyService 货币与汇率服务接口
+type CurrencyService interface {
+	// 支持的货币（基准货币和汇率表中的货币）
+	GetCurrencyInfo(ctx context.Context, userID uint) (*dto.CurrencyInfoDTO, error)
+	IsSupported(currency string) bool
+	// 按指定日期的汇率换算金额
+	Convert(amount float64, from, to string, date time.Time) (float64, error)
+	// 获取指定日期各货币折合目标货币的汇率，目标货币为空时使用用户本位币
+	GetRates(ctx context.Context, userID uint, currency string, date time.Time) (*dto.ExchangeRatesDTO, error)
+
+	// 用户本位币
+	GetHomeCurrency(ctx context.Context, userID uint) (string, error)
+	// 将金额按指定日期的汇率折算为用户本位币，货币为空时视为本位币，返回规范化的货币代码和折算金额
+	ToHomeCurrency(ctx context.Context, userID uint, amount float64, currency string, date time.Time) (string, float64, error)
+	// 修改本位币并重新折算已有的购买价格和保养费用
+	ChangeHomeCurrency(ctx context.Context, userID uint, currency string) (*dto.CurrencyInfoDTO, error)
+}
+
+// currencyService 货币与汇率服务实现
+type currencyService struct {
+	transactor       repositories.Transactor
+	rates            *exchangeRateTable
+	userRepo         repositories.UserRepository
+	clothingItemRepo repositories.ClothingItemRepository
+	purchaseRepo     repositories.PurchaseRecordRepository
+	maintenanceRepo  repositories.MaintenanceRecordRepository
+}
+
+// NewCurrencyService 创建货币与汇率服务实例，汇率文件加载失败时只支持基准货币
+func NewCurrencyService(
+	transactor repositories.Transactor,
+	cfg *config.Config,
+	userRepo repositories.UserRepository,
+	clothingItemRepo repositories.ClothingItemRepository,
+	purchaseRepo repositories.PurchaseRecordRepository,
+	maintenanceRepo repositories.MaintenanceRecordRepository,
+) CurrencyService {
+	rates, err := loadExchangeRateTable(cfg.Currency.RatesPath, cfg.Currency.BaseCurrency)
+	if err != nil {
+		logger.GetLogger().WarnWithErr(err, "Failed to load exchange rates, only base currency is supported", logger.Fields{
+			"path": cfg.Currency.RatesPath,
+			"base": cfg.Currency.BaseCurrency,
+		})
+		rates = newExchangeRateTable(cfg.Currency.BaseCurrency)
+	}
+
+	return &currencyService{
+		transactor:       transactor,
+		rates:            rates,
+		userRepo:         userRepo,
+		clothingItemRepo: clothingItemRepo,
+		purchaseRepo:     purchaseRepo,
+		maintenanceRepo:  maintenanceRepo,
+	}
+}
+
+// GetCurrencyInfo 获取用户本位币和支持的货币
+func (s *currencyService) GetCurrencyInfo(ctx context.Context, userID uint) (*dto.CurrencyInfoDTO, error) {
+	home, err := s.GetHomeCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CurrencyInfoDTO{
+		HomeCurrency: home,
+		BaseCurrency: s.rates.base,
+		Currencies:   s.rates.currencies(),
+	}, nil
+}
+
+// IsSupported 是否支持该货币
+func (s *currencyService) IsSupported(currency string) bool {
+	return s.rates.supports(normalizeCurrency(currency))
+}
+
+// Convert 按指定日期的汇率换算金额
+func (s *currencyService) Convert(amount float64, from, to string, date time.Time) (float64, error) {
+	from, err := s.resolveCurrency(from)
+	if err != nil {
+		return 0, err
+	}
+	to, err = s.resolveCurrency(to)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.rates.convert(amount, from, to, date)
+}
+
+// GetRates 获取指定日期各货币折合目标货币的汇率
+func (s *currencyService) GetRates(ctx context.Context, userID uint, currency string, date time.Time) (*dto.ExchangeRatesDTO, error) {
+	target := currency
+	if target == "" {
+		home, err := s.GetHomeCurrency(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		target = home
+	}
+	target, err := s.resolveCurrency(target)
+	if err != nil {
+		return nil, err
+	}
+
+	targetRate, _ := s.rates.rateAt(target, date)
+	result := &dto.ExchangeRatesDTO{
+		Currency: target,
+		Date:     date,
+		Rates:    make(map[string]float64),
+	}
+	for _, code := range s.rates.currencies() {
+		if code == target {
+			continue
+		}
+		rate, _ := s.rates.rateAt(code, date)
+		result.Rates[code] = math.Round(rate/targetRate*exchangeRatePrecision) / exchangeRatePrecision
+	}
+	return result, nil
+}
+
+// GetHomeCurrency 获取用户本位币，未设置时使用基准货币
+func (s *currencyService) GetHomeCurrency(ctx context.Context, userID uint) (string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.ErrNotFound("用户不存在")
+		}
+		return "", fmt.Errorf("获取用户失败: %w", err)
+	}
+	if user.HomeCurrency == "" {
+		return s.rates.base, nil
+	}
+	return user.HomeCurrency, nil
+}
+
+// ToHomeCurrency 将金额折算为用户本位币
+func (s *currencyService) ToHomeCurrency(ctx context.Context, userID uint, amount float64, currency string, date time.Time) (string, float64, error) {
+	home, err := s.GetHomeCurrency(ctx, userID)
+	if err != nil {
+		return "", 0, err
+	}
+	if currency == "" {
+		return home, amount, nil
+	}
+	currency, err = s.resolveCurrency(currency)
+	if err != nil {
+		return "", 0, err
+	}
+
+	homeAmount, err := s.rates.convert(amount, currency, home, date)
+	if err != nil {
+		return "", 0, errors.ErrInvalidRequest(err.Error())
+	}
+	return currency, homeAmount, nil
+}
+
+// ChangeHomeCurrency 修改本位币，购买价格和保养费用按各自日期的汇率重新折算，
+// 没有购买记录的衣物价格视为原本位币金额，按购买日期或创建时间折算
+func (s *currencyService) ChangeHomeCurrency(ctx context.Context, userID uint, currency string) (*dto.CurrencyInfoDTO, error) {
+	home, err := s.resolveCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("用户不存在")
+		}
+		return nil, fmt.Errorf("获取用户失败: %w", err)
+	}
+	previous := user.HomeCurrency
+	if previous == "" {
+		previous = s.rates.base
+	}
+
+	if previous != home {
+		// 金额换算和本位币修改在同一事务中完成，任一步失败时全部回滚
+		err = s.transactor.Transaction(ctx, func(tx *gorm.DB) error {
+			if err := s.reconvertAmounts(ctx, tx, userID, previous, home); err != nil {
+				return err
+			}
+			user.HomeCurrency = home
+			if err := s.userRepo.WithTx(tx).Update(ctx, user); err != nil {
+				return fmt.Errorf("更新本位币失败: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetCurrencyInfo(ctx, userID)
+}
+
+// reconvertAmounts 在指定事务中先完成所有换算再写入，缺少汇率时不修改任何数据
+func (s *currencyService) reconvertAmounts(ctx context.Context, tx *gorm.DB, userID uint, previous, home string) error {
+	purchaseRepo := s.purchaseRepo.WithTx(tx)
+	maintenanceRepo := s.maintenanceRepo.WithTx(tx)
+	clothingItemRepo := s.clothingItemRepo.WithTx(tx)
+
+	purchases, err := purchaseRepo.GetByUserID(ctx, userID, 0)
+	if err != nil {
+		return fmt.Errorf("获取购买记录失败: %w", err)
+	}
+	purchased := make(map[uint]bool, len(purchases))
+	for i := range purchases {
+		record := &purchases[i]
+		purchased[record.ClothingItemID] = true
+		if record.HomePrice, err = s.rates.convert(record.Price, record.Currency, home, record.PurchaseDate); err != nil {
+			return errors.ErrInvalidRequest(err.Error())
+		}
+	}
+
+	maintenance, err := maintenanceRepo.GetByUserID(ctx, userID, 0)
+	if err != nil {
+		return fmt.Errorf("获取保养记录失败: %w", err)
+	}
+	for i := range maintenance {
+		record := &maintenance[i]
+		if record.HomeCost, err = s.rates.convert(record.Cost, record.Currency, home, record.MaintenanceDate); err != nil {
+			return errors.ErrInvalidRequest(err.Error())
+		}
+	}
+
+	items, err := clothingItemRepo.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("获取衣物失败: %w", err)
+	}
+	unpurchased := make([]models.ClothingItem, 0)
+	for _, item := range items {
+		if purchased[item.ID] || item.Price == 0 {
+			continue
+		}
+		if item.Price, err = s.rates.convert(item.Price, previous, home, itemAcquiredAt(item)); err != nil {
+			return errors.ErrInvalidRequest(err.Error())
+		}
+		unpurchased = append(unpurchased, item)
+	}
+
+	if err := purchaseRepo.UpdateHomePrices(ctx, purchases); err != nil {
+		return fmt.Errorf("更新购买记录本位币价格失败: %w", err)
+	}
+	if err := maintenanceRepo.UpdateHomeCosts(ctx, maintenance); err != nil {
+		return fmt.Errorf("更新保养记录本位币费用失败: %w", err)
+	}
+	for i := range unpurchased {
+		if err := clothingItemRepo.Update(ctx, &unpurchased[i]); err != nil {
+			return fmt.Errorf("更新衣物价格失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// resolveCurrency 规范化并校验货币代码
+func (s *currencyService) resolveCurrency(currency string) (string, error) {
+	code := normalizeCurrency(currency)
+	if !s.rates.supports(code) {
+		return "", errors.ErrInvalidRequest(fmt.Sprintf("不支持的货币: %s", currency))
+	}
+	return code, nil
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
+	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultActivityLimit = 20
+	maxActivityLimit     = 100
+	favoriteStatsTopN    = 3
+)
+
+// maintenanceTypeLabels 保养类型的中文名称
+var maintenanceTypeLabels = map[api.MaintenanceType]string{
+	api.MaintenanceWashing:     "清洗",
+	api.MaintenanceDryCleaning: "干洗",
+	api.MaintenanceRepair:      "修补",
+	api.MaintenancePolishing:   "抛光",
+	api.MaintenanceWaterproof:  "防水处理",
+	api.MaintenanceStorage:     "收纳保存",
+	api.MaintenanceOther:       "其他",
+}
+
+// DashboardService 仪表板服务接口
+type DashboardService interface {
+	// 汇总用户、衣物、穿搭、支出统计和最近活动
+	GetDashboard(ctx context.Context, userID uint, activityLimit int) (*dto.DashboardStatsDTO, error)
+}
+
+// dashboardService 仪表板服务实现
+type dashboardService struct {
+	userRepo              repositories.UserRepository
+	activityRepo          repositories.ActivityRepository
+	clothingItemService   ClothingItemService
+	outfitService         OutfitService
+	purchaseRecordService PurchaseRecordService
+}
+
+// NewDashboardService 创建仪表板服务实例
+func NewDashboardService(
+	userRepo repositories.UserRepository,
+	activityRepo repositories.ActivityRepository,
+	clothingItemService ClothingItemService,
+	outfitService OutfitService,
+	purchaseRecordService PurchaseRecordService,
+) DashboardService {
+	return &dashboardService{
+		userRepo:              userRepo,
+		activityRepo:          activityRepo,
+		clothingItemService:   clothingItemService,
+		outfitService:         outfitService,
+		purchaseRecordService: purchaseRecordService,
+	}
+}
+
+// GetDashboard 获取仪表板数据
+func (s *dashboardService) GetDashboard(ctx context.Context, userID uint, activityLimit int) (*dto.DashboardStatsDTO, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("用户不存在")
+		}
+		return nil, fmt.Errorf("获取用户信息失败: %w", err)
+	}
+
+	clothingStats, err := s.clothingItemService.GetClothingStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	outfitStats, err := s.outfitService.GetOutfitStats(userID)
+	if err != nil {
+		return nil, err
+	}
+	spendingStats, err := s.purchaseRecordService.GetSpendingStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.activityRepo.GetRecentActivity(ctx, userID, dashboardActivityLimit(activityLimit))
+	if err != nil {
+		return nil, fmt.Errorf("获取最近活动失败: %w", err)
+	}
+	activities := make([]dto.ActivityItem, 0, len(records))
+	for _, record := range records {
+		activities = append(activities, buildActivityItem(record))
+	}
+
+	mostWornItems := make([]uint, 0, len(clothingStats.MostWornItems))
+	for _, item := range clothingStats.MostWornItems {
+		mostWornItems = append(mostWornItems, item.ID)
+	}
+
+	return &dto.DashboardStatsDTO{
+		UserStats: dto.UserStatsDTO{
+			TotalClothingItems: clothingStats.TotalItems,
+			TotalOutfits:       outfitStats.TotalOutfits,
+			TotalSpent:         spendingStats.TotalSpent,
+			AccountAge:         int(time.Since(user.CreatedAt).Hours() / 24),
+			FavoriteColors:     topCountKeys(clothingStats.ByColor, favoriteStatsTopN),
+			FavoriteBrands:     topCountKeys(clothingStats.ByBrand, favoriteStatsTopN),
+			MostWornItems:      mostWornItems,
+		},
+		ClothingStats:  *clothingStats,
+		OutfitStats:    *outfitStats,
+		SpendingStats:  *spendingStats,
+		RecentActivity: activities,
+	}, nil
+}
+
+// dashboardActivityLimit 规范最近活动数量
+func dashboardActivityLimit(limit int) int {
+	if limit <= 0 {
+		return defaultActivityLimit
+	}
+	if limit > maxActivityLimit {
+		return maxActivityLimit
+	}
+	return limit
+}
+
+// buildActivityItem 根据活动记录生成标题和描述
+func buildActivityItem(record repositories.ActivityRecord) dto.ActivityItem {
+	item := dto.ActivityItem{
+		ID:          record.ID,
+		Type:        record.Type,
+		Description: record.Detail,
+		CreatedAt:   record.CreatedAt,
+	}
+
+	switch record.Type {
+	case api.EntityTypeClothingItem:
+		item.Title = "添加衣物: " + record.Name
+	case api.EntityTypeOutfit:
+		item.Title = "创建穿搭: " + record.Name
+	case api.EntityTypePurchase:
+		item.Title = "购买衣物: " + record.Name
+		if record.Detail != "" {
+			item.Description = "购买于 " + record.Detail
+		}
+	case api.EntityTypeMaintenance:
+		item.Title = "保养衣物: " + record.Name
+		if label, exists := maintenanceTypeLabels[api.MaintenanceType(record.Detail)]; exists {
+			item.Description = label
+		}
+	case api.EntityTypeWearRecord:
+		if record.ItemCount > 0 {
+			item.Title = "穿着穿搭: " + record.Name
+			item.Description = fmt.Sprintf("共%d件单品", record.ItemCount)
+		} else {
+			item.Title = "穿着衣物: " + record.Name
+		}
+	default:
+		item.Title = record.Name
+	}
+
+	return item
+}
+
+// topCountKeys 按数量倒序返回前N个键，数量相同时按键排序
+func topCountKeys(counts map[string]int64, n int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
+	"what-to-wear/server/models"
+	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
+)
+
+// categoryWearRates 各分类每次穿着的磨损分数，子分类未配置时使用父分类
+var categoryWearRates = map[string]float64{
+	// 鞋子与贴身衣物磨损最快
+	"鞋子": 1.5, "运动鞋": 1.8, "拖鞋": 2.0, "高跟鞋": 1.6, "凉鞋": 1.5, "皮鞋": 1.3, "靴子": 1.2,
+	"内衣": 1.5, "袜子": 2.0, "内裤": 1.6, "文胸": 1.4, "保暖内衣": 1.2,
+	// 上衣与下装
+	"上衣": 1.0, "T恤": 1.2, "背心": 1.2, "吊带": 1.2, "衬衫": 1.0, "毛衣": 1.1, "卫衣": 1.0,
+	"下装": 1.0, "牛仔裤": 0.8, "休闲裤": 1.0, "西裤": 0.9, "短裤": 1.0, "裙子": 1.0, "运动裤": 1.2,
+	// 外套穿着频率高但磨损较慢
+	"外套": 0.7, "夹克": 0.8, "大衣": 0.6, "羽绒服": 0.8, "西装": 0.7, "风衣": 0.6,
+	// 配饰几乎不因穿着磨损
+	"配饰": 0.4, "包包": 0.6, "帽子": 0.5, "围巾": 0.4, "手表": 0.2, "首饰": 0.2, "眼镜": 0.3, "腰带": 0.5,
+}
+
+// DurabilityService 耐久度服务接口
+type DurabilityService interface {
+	// 在 tx 事务中根据穿着和保养历史重新计算并保存耐久度，应与穿着、保养记录的写入在同一事务中调用
+	RecalculateItems(ctx context.Context, tx *gorm.DB, itemIDs []uint) error
+	// 获取衣物耐久度时间线
+	GetDurabilityTimeline(ctx context.Context, userID, itemID uint) (*dto.DurabilityTimelineDTO, error)
+}
+
+// durabilityService 耐久度服务实现
+type durabilityService struct {
+	clothingItemRepo     repositories.ClothingItemRepository
+	clothingCategoryRepo repositories.ClothingCategoryRepository
+	wearRecordRepo       repositories.WearRecordRepository
+	maintenanceRepo      repositories.MaintenanceRecordRepository
+}
+
+// NewDurabilityService 创建耐久度服务实例
+func NewDurabilityService(
+	clothingItemRepo repositories.ClothingItemRepository,
+	clothingCategoryRepo repositories.ClothingCategoryRepository,
+	wearRecordRepo repositories.WearRecordRepository,
+	maintenanceRepo repositories.MaintenanceRecordRepository,
+) DurabilityService {
+	return &durabilityService{
+		clothingItemRepo:     clothingItemRepo,
+		clothingCategoryRepo: clothingCategoryRepo,
+		wearRecordRepo:       wearRecordRepo,
+		maintenanceRepo:      maintenanceRepo,
+	}
+}
+
+// durabilityHistory 计算耐久度所需的数据
+type durabilityHistory struct {
+	wearDamageRate float64
+	wears          []models.WearRecord
+	maintenances   []models.MaintenanceRecord
+	points         []models.DurabilityPoint
+}
+
+// RecalculateItems 在事务中批量重新计算并保存耐久度，任一衣物失败时返回错误
+func (s *durabilityService) RecalculateItems(ctx context.Context, tx *gorm.DB, itemIDs []uint) error {
+	txService := &durabilityService{
+		clothingItemRepo:     s.clothingItemRepo.WithTx(tx),
+		clothingCategoryRepo: s.clothingCategoryRepo.WithTx(tx),
+		wearRecordRepo:       s.wearRecordRepo.WithTx(tx),
+		maintenanceRepo:      s.maintenanceRepo.WithTx(tx),
+	}
+
+	seen := make(map[uint]bool, len(itemIDs))
+	for _, itemID := range itemIDs {
+		if seen[itemID] {
+			continue
+		}
+		seen[itemID] = true
+
+		if err := txService.recalculateItem(ctx, itemID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recalculateItem 重新计算并保存单件衣物的耐久度
+func (s *durabilityService) recalculateItem(ctx context.Context, itemID uint) error {
+	item, err := s.clothingItemRepo.GetByID(ctx, itemID)
+	if err != nil {
+		return fmt.Errorf("获取衣物失败: %w", err)
+	}
+
+	history, err := s.loadHistory(ctx, item)
+	if err != nil {
+		return err
+	}
+
+	score := history.points[len(history.points)-1].Score
+	if err := s.clothingItemRepo.UpdateDurability(ctx, itemID, score); err != nil {
+		return fmt.Errorf("更新耐久度失败: %w", err)
+	}
+	return nil
+}
+
+// GetDurabilityTimeline 获取衣物耐久度时间线
+func (s *durabilityService) GetDurabilityTimeline(ctx context.Context, userID, itemID uint) (*dto.DurabilityTimelineDTO, error) {
+	item, err := s.clothingItemRepo.GetByID(ctx, itemID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("衣物不存在")
+		}
+		return nil, fmt.Errorf("获取衣物失败: %w", err)
+	}
+	if item.UserID != userID {
+		return nil, errors.ErrForbidden("无权访问此衣物")
+	}
+
+	history, err := s.loadHistory(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	maintenanceTypes := make(map[uint]string, len(history.maintenances))
+	for _, record := range history.maintenances {
+		maintenanceTypes[record.ID] = string(record.MaintenanceType)
+	}
+
+	timeline := make([]dto.DurabilityPointDTO, 0, len(history.points))
+	for _, point := range history.points {
+		timeline = append(timeline, dto.DurabilityPointDTO{
+			Date:        point.Date,
+			EventType:   point.EventType,
+			RecordID:    point.RecordID,
+			Description: describeDurabilityPoint(point, maintenanceTypes),
+			TimeDamage:  point.TimeDamage,
+			Change:      point.Change,
+			Score:       point.Score,
+		})
+	}
+
+	return &dto.DurabilityTimelineDTO{
+		ClothingItemID:   item.ID,
+		ClothingItemName: item.Name,
+		CurrentScore:     history.points[len(history.points)-1].Score,
+		WearDamageRate:   history.wearDamageRate,
+		MaterialFactor:   item.MaterialDurabilityFactor(),
+		WearCount:        len(history.wears),
+		MaintenanceCount: len(history.maintenances),
+		Timeline:         timeline,
+	}, nil
+}
+
+// loadHistory 加载衣物的分类磨损率、穿着和保养记录并计算时间线
+func (s *durabilityService) loadHistory(ctx context.Context, item *models.ClothingItem) (*durabilityHistory, error) {
+	wears, err := s.wearRecordRepo.GetByClothingItemID(ctx, item.ID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("获取穿着记录失败: %w", err)
+	}
+	maintenances, err := s.maintenanceRepo.GetByClothingItemID(ctx, item.ID)
+	if err != nil {
+		return nil, fmt.Errorf("获取保养记录失败: %w", err)
+	}
+
+	wearDamageRate := s.getWearDamageRate(ctx, item.CategoryID)
+	return &durabilityHistory{
+		wearDamageRate: wearDamageRate,
+		wears:          wears,
+		maintenances:   maintenances,
+		points:         item.CalculateDurability(wearDamageRate, wears, maintenances, time.Now()),
+	}, nil
+}
+
+// getWearDamageRate 获取分类的穿着磨损率，依次查找分类和父分类
+func (s *durabilityService) getWearDamageRate(ctx context.Context, categoryID uint) float64 {
+	category, err := s.clothingCategoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		return models.DefaultWearDamageRate
+	}
+	if rate, exists := categoryWearRates[category.Name]; exists {
+		return rate
+	}
+
+	if category.ParentID != nil {
+		if parent, err := s.clothingCategoryRepo.GetByID(ctx, *category.ParentID); err == nil {
+			if rate, exists := categoryWearRates[parent.Name]; exists {
+				return rate
+			}
+		}
+	}
+	return models.DefaultWearDamageRate
+}
+
+// describeDurabilityPoint 生成时间线节点描述
+func describeDurabilityPoint(point models.DurabilityPoint, maintenanceTypes map[uint]string) string {
+	switch point.EventType {
+	case models.DurabilityEventCreated:
+		return "开始使用"
+	case models.DurabilityEventWear:
+		return "穿着磨损"
+	case models.DurabilityEventMaintenance:
+		return "保养恢复: " + maintenanceTypes[point.RecordID]
+	default:
+		return "自然磨损"
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const exchangeRateDateLayout = "2006-01-02"
+
+// exchangeRate 某一日期起生效的汇率
+type exchangeRate struct {
+	date time.Time
+	rate float64 // 1单位货币折合基准货币的数量
+}
+
+// exchangeRateTable 本地汇率表，每种货币的汇率按日期升序排列
+type exchangeRateTable struct {
+	base  string
+	rates map[string][]exchangeRate
+}
+
+// newExchangeRateTable 创建只包含基准货币的汇率表
+func newExchangeRateTable(base string) *exchangeRateTable {
+	return &exchangeRateTable{
+		base:  normalizeCurrency(base),
+		rates: make(map[string][]exchangeRate),
+	}
+}
+
+// loadExchangeRateTable 从CSV文件加载汇率表，格式为 date,currency,rate，# 开头的行为注释
+func loadExchangeRateTable(path, base string) (*exchangeRateTable, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("打开汇率文件失败: %w", err)
+	}
+	defer file.Close()
+
+	table := newExchangeRateTable(base)
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("读取汇率文件失败: %w", err)
+		}
+		// 跳过表头
+		if strings.EqualFold(record[0], "date") {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		date, err := time.ParseInLocation(exchangeRateDateLayout, strings.TrimSpace(record[0]), time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("第%d行日期格式错误: %s", line, record[0])
+		}
+		currency := normalizeCurrency(record[1])
+		if !isCurrencyCode(currency) {
+			return nil, fmt.Errorf("第%d行货币代码无效: %s", line, record[1])
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("第%d行汇率无效: %s", line, record[2])
+		}
+		if currency == table.base {
+			continue
+		}
+		table.rates[currency] = append(table.rates[currency], exchangeRate{date: date, rate: rate})
+	}
+
+	for _, rates := range table.rates {
+		sort.Slice(rates, func(i, j int) bool { return rates[i].date.Before(rates[j].date) })
+	}
+	return table, nil
+}
+
+// supports 是否支持该货币
+func (t *exchangeRateTable) supports(currency string) bool {
+	if currency == t.base {
+		return true
+	}
+	_, exists := t.rates[currency]
+	return exists
+}
+
+// currencies 支持的货币，基准货币在前，其余按代码排序
+func (t *exchangeRateTable) currencies() []string {
+	others := make([]string, 0, len(t.rates))
+	for currency := range t.rates {
+		others = append(others, currency)
+	}
+	sort.Strings(others)
+	return append([]string{t.base}, others...)
+}
+
+// rateAt 指定日期生效的汇率：取不晚于该日期的最近一条，早于所有记录时取最早一条
+func (t *exchangeRateTable) rateAt(currency string, date time.Time) (float64, bool) {
+	if currency == t.base {
+		return 1, true
+	}
+	rates, exists := t.rates[currency]
+	if !exists || len(rates) == 0 {
+		return 0, false
+	}
+	i := sort.Search(len(rates), func(i int) bool { return rates[i].date.After(date) })
+	if i == 0 {
+		return rates[0].rate, true
+	}
+	return rates[i-1].rate, true
+}
+
+// convert 按指定日期的汇率换算金额，结果保留两位小数
+func (t *exchangeRateTable) convert(amount float64, from, to string, date time.Time) (float64, error) {
+	if from == to {
+		return amount, nil
+	}
+	fromRate, ok := t.rateAt(from, date)
+	if !ok {
+		return 0, fmt.Errorf("缺少%s的汇率数据", from)
+	}
+	toRate, ok := t.rateAt(to, date)
+	if !ok {
+		return 0, fmt.Errorf("缺少%s的汇率数据", to)
+	}
+	return math.Round(amount*fromRate/toRate*100) / 100, nil
+}
+
+// normalizeCurrency 规范化货币代码
+func normalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+// isCurrencyCode 是否为三位字母的货币代码
+func isCurrencyCode(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, c := range currency {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
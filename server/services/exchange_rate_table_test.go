@@ -0,0 +1,107 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExchangeRateTableConvert(t *testing.T) {
+	day := func(value string) time.Time {
+		date, err := time.ParseInLocation(exchangeRateDateLayout, value, time.Local)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return date
+	}
+
+	table := newExchangeRateTable("cny")
+	table.rates["USD"] = []exchangeRate{
+		{date: day("2024-01-01"), rate: 7.0},
+		{date: day("2024-06-01"), rate: 7.2},
+	}
+	table.rates["EUR"] = []exchangeRate{
+		{date: day("2024-01-01"), rate: 8.0},
+	}
+
+	tests := []struct {
+		name    string
+		amount  float64
+		from    string
+		to      string
+		date    string
+		want    float64
+		wantErr bool
+	}{
+		{name: "相同货币不换算", amount: 12.345, from: "USD", to: "USD", date: "2024-03-01", want: 12.345},
+		{name: "外币换算为基准货币", amount: 100, from: "USD", to: "CNY", date: "2024-03-01", want: 700},
+		{name: "基准货币换算为外币", amount: 100, from: "CNY", to: "USD", date: "2024-03-01", want: 14.29},
+		{name: "使用当天生效的新汇率", amount: 100, from: "USD", to: "CNY", date: "2024-06-01", want: 720},
+		{name: "早于所有记录时使用最早汇率", amount: 100, from: "USD", to: "CNY", date: "2023-01-01", want: 700},
+		{name: "两种外币之间换算", amount: 100, from: "EUR", to: "USD", date: "2024-07-01", want: 111.11},
+		{name: "不支持的源货币", amount: 100, from: "JPY", to: "CNY", date: "2024-03-01", wantErr: true},
+		{name: "不支持的目标货币", amount: 100, from: "CNY", to: "JPY", date: "2024-03-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := table.convert(tt.amount, tt.from, tt.to, day(tt.date))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("convert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("convert() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadExchangeRateTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]int
+		wantErr bool
+	}{
+		{
+			name:    "跳过表头、注释和基准货币",
+			content: "date,currency,rate\n# 注释\n2024-06-01,usd,7.2\n2024-01-01,USD,7.0\n2024-01-01,CNY,1\n",
+			want:    map[string]int{"USD": 2},
+		},
+		{name: "日期格式错误", content: "2024/01/01,USD,7.0\n", wantErr: true},
+		{name: "货币代码无效", content: "2024-01-01,US1,7.0\n", wantErr: true},
+		{name: "汇率必须为正数", content: "2024-01-01,USD,0\n", wantErr: true},
+		{name: "列数不对", content: "2024-01-01,USD\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rates.csv")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			table, err := loadExchangeRateTable(path, "CNY")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadExchangeRateTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(table.rates) != len(tt.want) {
+				t.Fatalf("got %d currencies, want %d", len(table.rates), len(tt.want))
+			}
+			for currency, count := range tt.want {
+				rates := table.rates[currency]
+				if len(rates) != count {
+					t.Fatalf("%s has %d rates, want %d", currency, len(rates), count)
+				}
+				for i := 1; i < len(rates); i++ {
+					if rates[i].date.Before(rates[i-1].date) {
+						t.Errorf("%s rates are not sorted by date", currency)
+					}
+				}
+			}
+		})
+	}
+}
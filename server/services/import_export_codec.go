@@ -0,0 +1,290 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/utils"
+)
+
+// wardrobeColumns 衣物表格的列，CSV 和 Excel 的导出、导入共用
+// wear_count 及之后的列仅导出，导入时忽略
+var wardrobeColumns = []string{
+	"name", "category", "brand", "color", "size", "material", "style", "description",
+	"status", "is_favorite", "tags",
+	"purchase_price", "purchase_currency", "purchase_store", "purchase_date", "purchase_notes",
+	"wear_count", "last_worn_date", "durability_score", "maintenance_count",
+}
+
+// 导入导出的日期格式与表格中多值字段的分隔符
+const (
+	exchangeDateFormat   = "2006-01-02"
+	exchangeTagSeparator = ";"
+)
+
+// importRow 解析后的一条待导入衣物
+type importRow struct {
+	row    int
+	item   dto.WardrobeItemDTO
+	errors []string
+}
+
+// renderWardrobeCSV 将衣物写入CSV，每件衣物一行
+func renderWardrobeCSV(items []dto.WardrobeItemDTO) ([]byte, error) {
+	var buf bytes.Buffer
+	// 写入 UTF-8 BOM，便于 Excel 正确识别中文
+	buf.WriteString("\xef\xbb\xbf")
+
+	writer := csv.NewWriter(&buf)
+	writer.Write(wardrobeColumns)
+	for _, item := range items {
+		writer.Write(wardrobeItemRow(item))
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("生成CSV失败: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderWardrobeExcel 将衣物写入Excel，detailed 时附加穿着记录和保养记录工作表
+func renderWardrobeExcel(items []dto.WardrobeItemDTO, detailed bool) ([]byte, error) {
+	itemRows := [][]string{wardrobeColumns}
+	for _, item := range items {
+		itemRows = append(itemRows, wardrobeItemRow(item))
+	}
+	sheets := []utils.XLSXSheet{{Name: "衣物", Rows: itemRows}}
+
+	if detailed {
+		wearRows := [][]string{{"item_name", "wear_date", "comfort_rating", "style_rating", "appropriateness_rating",
+			"duration_hours", "temperature", "weather_condition", "occasion", "location", "notes"}}
+		maintenanceRows := [][]string{{"item_name", "maintenance_type", "maintenance_date", "cost", "currency",
+			"service_provider", "next_maintenance_date", "notes"}}
+		for _, item := range items {
+			for _, record := range item.WearRecords {
+				var weather string
+				if record.WeatherCondition != nil {
+					weather = string(*record.WeatherCondition)
+				}
+				wearRows = append(wearRows, []string{
+					item.Name, record.WearDate.Format(exchangeDateFormat),
+					formatOptionalInt(record.ComfortRating), formatOptionalInt(record.StyleRating),
+					formatOptionalInt(record.AppropriatenessRating), formatOptionalFloat(record.DurationHours),
+					formatOptionalFloat(record.Temperature), weather, record.Occasion, record.Location, record.Notes,
+				})
+			}
+			for _, record := range item.MaintenanceRecords {
+				maintenanceRows = append(maintenanceRows, []string{
+					item.Name, record.MaintenanceType, record.MaintenanceDate.Format(exchangeDateFormat),
+					formatAmount(record.Cost), record.Currency, record.ServiceProvider,
+					formatOptionalDate(record.NextMaintenanceDate), record.Notes,
+				})
+			}
+		}
+		sheets = append(sheets,
+			utils.XLSXSheet{Name: "穿着记录", Rows: wearRows},
+			utils.XLSXSheet{Name: "保养记录", Rows: maintenanceRows},
+		)
+	}
+
+	content, err := utils.WriteXLSX(sheets)
+	if err != nil {
+		return nil, fmt.Errorf("生成Excel失败: %w", err)
+	}
+	return content, nil
+}
+
+// renderWardrobeJSON 输出包含完整记录的JSON
+func renderWardrobeJSON(items []dto.WardrobeItemDTO, exportedAt time.Time) ([]byte, error) {
+	content, err := json.MarshalIndent(dto.WardrobeExportDTO{ExportedAt: exportedAt, Items: items}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("生成JSON失败: %w", err)
+	}
+	return content, nil
+}
+
+// wardrobeItemRow 将衣物转换为与 wardrobeColumns 对应的一行
+func wardrobeItemRow(item dto.WardrobeItemDTO) []string {
+	var price, currency, store, purchaseDate, purchaseNotes string
+	if item.Purchase != nil {
+		price = formatAmount(item.Purchase.Price)
+		currency = item.Purchase.Currency
+		store = item.Purchase.Store
+		purchaseDate = item.Purchase.PurchaseDate.Format(exchangeDateFormat)
+		purchaseNotes = item.Purchase.Notes
+	}
+	return []string{
+		item.Name, item.Category, item.Brand, item.Color, item.Size, item.Material, item.Style, item.Description,
+		string(item.Status), strconv.FormatBool(item.IsFavorite), strings.Join(item.Tags, exchangeTagSeparator),
+		price, currency, store, purchaseDate, purchaseNotes,
+		strconv.Itoa(item.WearCount), formatOptionalDate(item.LastWornDate),
+		formatAmount(item.DurabilityScore), strconv.Itoa(item.MaintenanceCount),
+	}
+}
+
+// parseWardrobeCSV 解析CSV中的衣物
+func parseWardrobeCSV(content []byte, delimiter rune) ([]importRow, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+
+	var rows [][]string
+	var lines []int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析CSV失败: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, record)
+		lines = append(lines, line)
+	}
+	return parseWardrobeTable(rows, lines)
+}
+
+// parseWardrobeExcel 解析Excel第一个工作表中的衣物
+func parseWardrobeExcel(content []byte) ([]importRow, error) {
+	sheets, err := utils.ReadXLSX(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("Excel文件中没有工作表")
+	}
+
+	rows := sheets[0].Rows
+	lines := make([]int, len(rows))
+	for i := range rows {
+		lines[i] = i + 1
+	}
+	return parseWardrobeTable(rows, lines)
+}
+
+// parseWardrobeJSON 解析JSON中的衣物，格式与导出的JSON一致
+func parseWardrobeJSON(content []byte) ([]importRow, error) {
+	var document dto.WardrobeExportDTO
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("解析JSON失败: %w", err)
+	}
+
+	rows := make([]importRow, 0, len(document.Items))
+	for i, item := range document.Items {
+		rows = append(rows, importRow{row: i + 1, item: item})
+	}
+	return rows, nil
+}
+
+// parseWardrobeTable 按表头解析表格，第一行为表头，空行被忽略
+func parseWardrobeTable(rows [][]string, lines []int) ([]importRow, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("文件内容为空")
+	}
+
+	columns := make(map[string]int, len(rows[0]))
+	for i, header := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+	for _, required := range []string{"name", "category"} {
+		if _, exists := columns[required]; !exists {
+			return nil, fmt.Errorf("缺少必需的列: %s", required)
+		}
+	}
+
+	var result []importRow
+	for i, record := range rows[1:] {
+		if !slices.ContainsFunc(record, func(value string) bool { return strings.TrimSpace(value) != "" }) {
+			continue
+		}
+
+		row := importRow{row: lines[i+1]}
+		value := func(column string) string {
+			index, exists := columns[column]
+			if !exists || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		row.item = dto.WardrobeItemDTO{
+			Name:        value("name"),
+			Category:    value("category"),
+			Brand:       value("brand"),
+			Color:       value("color"),
+			Size:        value("size"),
+			Material:    value("material"),
+			Style:       value("style"),
+			Description: value("description"),
+			Status:      api.ClothingStatus(value("status")),
+		}
+
+		if favorite := value("is_favorite"); favorite != "" {
+			isFavorite, err := strconv.ParseBool(favorite)
+			if err != nil {
+				row.errors = append(row.errors, "is_favorite 必须为 true 或 false")
+			}
+			row.item.IsFavorite = isFavorite
+		}
+
+		for _, tag := range strings.Split(value("tags"), exchangeTagSeparator) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				row.item.Tags = append(row.item.Tags, tag)
+			}
+		}
+
+		price, purchaseDate := value("purchase_price"), value("purchase_date")
+		if price != "" || purchaseDate != "" {
+			purchase := &dto.CreatePurchaseRecordDTO{
+				Currency: strings.ToUpper(value("purchase_currency")),
+				Store:    value("purchase_store"),
+				Notes:    value("purchase_notes"),
+			}
+			var err error
+			if purchase.Price, err = strconv.ParseFloat(price, 64); err != nil {
+				row.errors = append(row.errors, "purchase_price 必须为数字")
+			}
+			if purchase.PurchaseDate, err = time.Parse(exchangeDateFormat, purchaseDate); err != nil {
+				row.errors = append(row.errors, "purchase_date 格式应为 YYYY-MM-DD")
+			}
+			row.item.Purchase = purchase
+		}
+
+		result = append(result, row)
+	}
+	return result, nil
+}
+
+// formatOptionalInt 格式化可选整数，为空时返回空字符串
+func formatOptionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+// formatOptionalFloat 格式化可选数值，为空时返回空字符串
+func formatOptionalFloat(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+// formatOptionalDate 格式化可选日期，为空时返回空字符串
+func formatOptionalDate(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(exchangeDateFormat)
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"what-to-wear/server/api"
+)
+
+func TestParseWardrobeCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		delimiter rune
+		wantRows  []int
+		wantErrs  [][]string
+		wantErr   bool
+	}{
+		{
+			name:      "带BOM的标准CSV",
+			content:   "\xef\xbb\xbfname,category,tags\n白衬衫,衬衫,通勤;夏季\n",
+			delimiter: ',',
+			wantRows:  []int{2},
+			wantErrs:  [][]string{nil},
+		},
+		{
+			name:      "分号分隔并跳过空行",
+			content:   "name;category\n白衬衫;衬衫\n;\n牛仔裤;裤子\n",
+			delimiter: ';',
+			wantRows:  []int{2, 4},
+			wantErrs:  [][]string{nil, nil},
+		},
+		{
+			name:      "表头大小写和空格不敏感",
+			content:   " Name ,CATEGORY\n白衬衫,衬衫\n",
+			delimiter: ',',
+			wantRows:  []int{2},
+			wantErrs:  [][]string{nil},
+		},
+		{
+			name:      "字段格式错误记录在行上",
+			content:   "name,category,is_favorite,purchase_price,purchase_date\n白衬衫,衬衫,maybe,abc,2024/01/01\n",
+			delimiter: ',',
+			wantRows:  []int{2},
+			wantErrs: [][]string{{
+				"is_favorite 必须为 true 或 false",
+				"purchase_price 必须为数字",
+				"purchase_date 格式应为 YYYY-MM-DD",
+			}},
+		},
+		{name: "缺少必需的列", content: "name,brand\n白衬衫,优衣库\n", delimiter: ',', wantErr: true},
+		{name: "空文件", content: "", delimiter: ',', wantErr: true},
+		{name: "引号不匹配", content: "name,category\n\"白衬衫,衬衫\n", delimiter: ',', wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseWardrobeCSV([]byte(tt.content), tt.delimiter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWardrobeCSV() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(rows) != len(tt.wantRows) {
+				t.Fatalf("got %d rows, want %d", len(rows), len(tt.wantRows))
+			}
+			for i, row := range rows {
+				if row.row != tt.wantRows[i] {
+					t.Errorf("rows[%d].row = %d, want %d", i, row.row, tt.wantRows[i])
+				}
+				if !reflect.DeepEqual(row.errors, tt.wantErrs[i]) {
+					t.Errorf("rows[%d].errors = %v, want %v", i, row.errors, tt.wantErrs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseWardrobeCSVFields(t *testing.T) {
+	content := "name,category,status,is_favorite,tags,purchase_price,purchase_currency,purchase_date,wear_count\n" +
+		" 白衬衫 ,衬衫,inactive,true, 通勤 ;;夏季,199.5,usd,2024-03-01,12\n"
+	rows, err := parseWardrobeCSV([]byte(content), ',')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+
+	item := rows[0].item
+	if item.Name != "白衬衫" || item.Category != "衬衫" || item.Status != api.ClothingStatusInactive || !item.IsFavorite {
+		t.Errorf("unexpected item: %+v", item)
+	}
+	if want := []string{"通勤", "夏季"}; !reflect.DeepEqual(item.Tags, want) {
+		t.Errorf("Tags = %v, want %v", item.Tags, want)
+	}
+	// wear_count 仅导出，导入时忽略
+	if item.WearCount != 0 {
+		t.Errorf("WearCount = %d, want 0", item.WearCount)
+	}
+	if item.Purchase == nil {
+		t.Fatal("Purchase is nil")
+	}
+	if item.Purchase.Price != 199.5 || item.Purchase.Currency != "USD" ||
+		!item.Purchase.PurchaseDate.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected purchase: %+v", item.Purchase)
+	}
+}
@@ -0,0 +1,506 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
+	"what-to-wear/server/models"
+	"what-to-wear/server/repositories"
+	"what-to-wear/server/utils"
+)
+
+// 导出内容详略与导入导出的数量限制
+const (
+	exportFormatDetailed = "detailed" // 包含穿着和保养记录
+	exportFormatSummary  = "summary"  // 仅衣物信息
+	exportPageSize       = 500        // 导出时分页读取衣物，每页数量
+	importMaxFileSize    = 5 << 20
+	importMaxRows        = 1000
+	// 通过 FileURL 导入时的下载超时时间
+	importDownloadTimeout = 30 * time.Second
+)
+
+// ExportFile 导出的文件
+type ExportFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// ImportExportService 衣橱导入导出服务接口
+type ImportExportService interface {
+	// 导出用户衣物及其标签、分类路径、购买、穿着和保养记录
+	ExportWardrobe(ctx context.Context, userID uint, req *dto.ExportRequest) (*ExportFile, error)
+	// 导入衣物并返回逐行校验报告，DryRun 时只校验不写入
+	ImportWardrobe(ctx context.Context, userID uint, req *dto.ImportRequest) (*dto.ImportResultDTO, error)
+}
+
+// importExportService 衣橱导入导出服务实现
+type importExportService struct {
+	clothingItemRepo    repositories.ClothingItemRepository
+	clothingTagRepo     repositories.ClothingTagRepository
+	purchaseRecordRepo  repositories.PurchaseRecordRepository
+	wearRecordRepo      repositories.WearRecordRepository
+	maintenanceRepo     repositories.MaintenanceRecordRepository
+	clothingItemService ClothingItemService
+	categoryService     ClothingCategoryService
+	currencyService     CurrencyService
+}
+
+// NewImportExportService 创建衣橱导入导出服务实例
+func NewImportExportService(
+	clothingItemRepo repositories.ClothingItemRepository,
+	clothingTagRepo repositories.ClothingTagRepository,
+	purchaseRecordRepo repositories.PurchaseRecordRepository,
+	wearRecordRepo repositories.WearRecordRepository,
+	maintenanceRepo repositories.MaintenanceRecordRepository,
+	clothingItemService ClothingItemService,
+	categoryService ClothingCategoryService,
+	currencyService CurrencyService,
+) ImportExportService {
+	return &importExportService{
+		clothingItemRepo:    clothingItemRepo,
+		clothingTagRepo:     clothingTagRepo,
+		purchaseRecordRepo:  purchaseRecordRepo,
+		wearRecordRepo:      wearRecordRepo,
+		maintenanceRepo:     maintenanceRepo,
+		clothingItemService: clothingItemService,
+		categoryService:     categoryService,
+		currencyService:     currencyService,
+	}
+}
+
+// ExportWardrobe 导出衣橱
+func (s *importExportService) ExportWardrobe(ctx context.Context, userID uint, req *dto.ExportRequest) (*ExportFile, error) {
+	format := api.ExchangeFormat(req.Type)
+	if !format.IsValid() {
+		return nil, errors.ErrInvalidRequest("不支持的导出格式: " + req.Type)
+	}
+	detail := req.Format
+	if detail == "" {
+		detail = exportFormatDetailed
+	}
+	if detail != exportFormatDetailed && detail != exportFormatSummary {
+		return nil, errors.ErrInvalidRequest("无效的导出内容: " + req.Format)
+	}
+	for key := range req.Filters {
+		if !slices.Contains(models.ClothingFilterFields, key) {
+			return nil, errors.ErrInvalidRequest("不支持的筛选字段: " + key)
+		}
+	}
+
+	items, err := s.loadExportItems(ctx, userID, req, detail == exportFormatDetailed)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	file := &ExportFile{FileName: "wardrobe-" + now.Format("20060102-150405")}
+	switch format {
+	case api.ExchangeFormatCSV:
+		file.FileName += ".csv"
+		file.ContentType = "text/csv; charset=utf-8"
+		file.Content, err = renderWardrobeCSV(items)
+	case api.ExchangeFormatExcel:
+		file.FileName += ".xlsx"
+		file.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		file.Content, err = renderWardrobeExcel(items, detail == exportFormatDetailed)
+	case api.ExchangeFormatJSON:
+		file.FileName += ".json"
+		file.ContentType = "application/json"
+		file.Content, err = renderWardrobeJSON(items, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// loadExportItems 加载需要导出的衣物及其关联数据
+func (s *importExportService) loadExportItems(ctx context.Context, userID uint, req *dto.ExportRequest, detailed bool) ([]dto.WardrobeItemDTO, error) {
+	// 分页读取全部符合条件的衣物，日期范围按衣物的创建时间筛选
+	var items []models.ClothingItem
+	for page := 1; ; page++ {
+		listReq := &dto.ClothingItemListDTO{
+			DateFrom: req.DateFrom,
+			DateTo:   req.DateTo,
+			SearchRequest: dto.SearchRequest{
+				Filters:           req.Filters,
+				SortBy:            "created_at",
+				SortOrder:         "asc",
+				PaginationRequest: dto.PaginationRequest{Page: page, PageSize: exportPageSize},
+			},
+		}
+		pageItems, total, err := s.clothingItemRepo.GetByUserID(ctx, userID, listReq)
+		if err != nil {
+			return nil, fmt.Errorf("获取衣物列表失败: %w", err)
+		}
+		items = append(items, pageItems...)
+		if len(pageItems) < exportPageSize || int64(len(items)) >= total {
+			break
+		}
+	}
+
+	itemIDs := make([]uint, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ID
+	}
+	tagsByItem, err := s.clothingItemRepo.GetTagsByItemIDs(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取衣物标签失败: %w", err)
+	}
+
+	purchases, err := s.purchaseRecordRepo.GetByUserID(ctx, userID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("获取购买记录失败: %w", err)
+	}
+	purchaseByItem := make(map[uint]models.PurchaseRecord, len(purchases))
+	for _, purchase := range purchases {
+		purchaseByItem[purchase.ClothingItemID] = purchase
+	}
+
+	// 摘要导出只需要保养次数，详细导出才读取穿着和保养记录
+	wearsByItem := make(map[uint][]dto.WearRecordDTO)
+	maintenanceByItem := make(map[uint][]dto.MaintenanceRecordDTO)
+	var maintenanceCounts map[uint]int64
+	if detailed {
+		wears, err := s.wearRecordRepo.GetByUserID(ctx, userID, 0)
+		if err != nil {
+			return nil, fmt.Errorf("获取穿着记录失败: %w", err)
+		}
+		for _, record := range wears {
+			wearsByItem[record.ClothingItemID] = append(wearsByItem[record.ClothingItemID], toWearRecordDTO(&record))
+		}
+		maintenances, err := s.maintenanceRepo.GetByUserID(ctx, userID, 0)
+		if err != nil {
+			return nil, fmt.Errorf("获取保养记录失败: %w", err)
+		}
+		maintenanceCounts = make(map[uint]int64)
+		for _, record := range maintenances {
+			maintenanceByItem[record.ClothingItemID] = append(maintenanceByItem[record.ClothingItemID], toMaintenanceRecordDTO(&record))
+			maintenanceCounts[record.ClothingItemID]++
+		}
+	} else {
+		maintenanceCounts, err = s.maintenanceRepo.GetMaintenanceCountByItem(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("获取保养次数失败: %w", err)
+		}
+	}
+
+	categoryPaths, err := s.categoryPaths(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.WardrobeItemDTO, 0, len(items))
+	for _, item := range items {
+		path, exists := categoryPaths[item.CategoryID]
+		if !exists {
+			// 已停用的分类不在可见分类中，单独获取路径
+			path, _ = s.categoryService.GetCategoryPath(ctx, item.CategoryID)
+			categoryPaths[item.CategoryID] = path
+		}
+
+		exported := dto.WardrobeItemDTO{
+			Name:             item.Name,
+			Category:         path,
+			Brand:            item.Brand,
+			Color:            item.Color,
+			Size:             item.Size,
+			Material:         item.Material,
+			Style:            item.Style,
+			Description:      item.Description,
+			Status:           item.Condition,
+			IsFavorite:       item.IsFavorite,
+			Tags:             []string{},
+			WearCount:        item.WearCount,
+			LastWornDate:     item.LastWornDate,
+			DurabilityScore:  item.DurabilityScore,
+			MaintenanceCount: int(maintenanceCounts[item.ID]),
+		}
+		for _, tag := range tagsByItem[item.ID] {
+			exported.Tags = append(exported.Tags, tag.Name)
+		}
+		if purchase, exists := purchaseByItem[item.ID]; exists {
+			exported.Purchase = &dto.CreatePurchaseRecordDTO{
+				Price:        purchase.Price,
+				Currency:     purchase.Currency,
+				Store:        purchase.Store,
+				PurchaseDate: purchase.PurchaseDate,
+				Notes:        purchase.Notes,
+			}
+		}
+		if detailed {
+			exported.WearRecords = wearsByItem[item.ID]
+			exported.MaintenanceRecords = maintenanceByItem[item.ID]
+		}
+		result = append(result, exported)
+	}
+	return result, nil
+}
+
+// ImportWardrobe 导入衣橱
+// 所有行先完成校验；未设置 SkipErrors 时存在无效行或任一行写入失败都不写入任何数据
+func (s *importExportService) ImportWardrobe(ctx context.Context, userID uint, req *dto.ImportRequest) (*dto.ImportResultDTO, error) {
+	format := api.ExchangeFormat(req.Type)
+	if !format.IsValid() {
+		return nil, errors.ErrInvalidRequest("不支持的导入格式: " + req.Type)
+	}
+	content, err := s.readImportFile(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	switch format {
+	case api.ExchangeFormatCSV:
+		delimiter := ','
+		if option := req.Options["delimiter"]; option != "" {
+			if utf8.RuneCountInString(option) != 1 {
+				return nil, errors.ErrInvalidRequest("分隔符只能是单个字符")
+			}
+			delimiter, _ = utf8.DecodeRuneInString(option)
+		}
+		rows, err = parseWardrobeCSV(content, delimiter)
+	case api.ExchangeFormatExcel:
+		rows, err = parseWardrobeExcel(content)
+	case api.ExchangeFormatJSON:
+		rows, err = parseWardrobeJSON(content)
+	}
+	if err != nil {
+		return nil, errors.ErrInvalidRequest(err.Error())
+	}
+	if len(rows) == 0 {
+		return nil, errors.ErrInvalidRequest("文件中没有衣物数据")
+	}
+	if len(rows) > importMaxRows {
+		return nil, errors.ErrInvalidRequest(fmt.Sprintf("单次最多导入 %d 件衣物", importMaxRows))
+	}
+
+	requests, err := s.validateImportRows(ctx, userID, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.ImportResultDTO{
+		DryRun:    req.DryRun,
+		TotalRows: len(rows),
+		Rows:      make([]dto.ImportRowResultDTO, len(rows)),
+	}
+	for i, row := range rows {
+		result.Rows[i] = dto.ImportRowResultDTO{
+			Row:    row.row,
+			Name:   row.item.Name,
+			Valid:  len(row.errors) == 0,
+			Errors: row.errors,
+		}
+		if result.Rows[i].Valid {
+			result.ValidRows++
+		}
+	}
+
+	if req.DryRun || (result.ValidRows < result.TotalRows && !req.SkipErrors) {
+		return result, nil
+	}
+
+	// 未设置 SkipErrors 时所有行在同一事务中写入，任一行失败时全部回滚
+	if !req.SkipErrors {
+		items, failed, err := s.clothingItemService.CreateClothingItems(ctx, userID, requests)
+		if err != nil {
+			if failed < 0 {
+				return nil, err
+			}
+			result.Rows[failed].Errors = append(result.Rows[failed].Errors, "导入失败，所有行均未写入: "+err.Error())
+			return result, nil
+		}
+		for i, item := range items {
+			result.Rows[i].Imported = true
+			result.Rows[i].ItemID = item.ID
+		}
+		result.ImportedCount = len(items)
+		return result, nil
+	}
+
+	// 设置 SkipErrors 时逐行写入，失败的行记录原因后继续
+	for i := range rows {
+		if !result.Rows[i].Valid {
+			continue
+		}
+		item, err := s.clothingItemService.CreateClothingItem(ctx, userID, requests[i])
+		if err != nil {
+			result.Rows[i].Errors = append(result.Rows[i].Errors, "导入失败: "+err.Error())
+			continue
+		}
+		result.Rows[i].Imported = true
+		result.Rows[i].ItemID = item.ID
+		result.ImportedCount++
+	}
+	return result, nil
+}
+
+// readImportFile 读取上传的文件或从 FileURL 下载文件，两者只能指定一个
+func (s *importExportService) readImportFile(ctx context.Context, req *dto.ImportRequest) ([]byte, error) {
+	switch {
+	case req.File != nil && req.FileURL != "":
+		return nil, errors.ErrInvalidRequest("file 和 file_url 只能指定一个")
+	case req.File != nil:
+		if req.File.Size > importMaxFileSize {
+			return nil, errors.ErrInvalidRequest(fmt.Sprintf("文件大小不能超过 %dMB", importMaxFileSize>>20))
+		}
+		file, err := req.File.Open()
+		if err != nil {
+			return nil, fmt.Errorf("打开文件失败: %w", err)
+		}
+		defer file.Close()
+		content, err := io.ReadAll(file)
+		if err != nil {
+			return nil, fmt.Errorf("读取文件失败: %w", err)
+		}
+		return content, nil
+	case req.FileURL != "":
+		content, err := utils.DownloadPublicFile(ctx, req.FileURL, importMaxFileSize, importDownloadTimeout)
+		if err != nil {
+			return nil, errors.ErrInvalidRequest("下载导入文件失败: " + err.Error())
+		}
+		return content, nil
+	default:
+		return nil, errors.ErrInvalidRequest("请上传文件或指定 file_url")
+	}
+}
+
+// validateImportRows 校验每一行并解析分类和标签，返回与行对应的创建请求（无效行为 nil）
+func (s *importExportService) validateImportRows(ctx context.Context, userID uint, rows []importRow) ([]*dto.CreateClothingItemDTO, error) {
+	categories, err := s.categoryService.GetAllCategories(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取分类失败: %w", err)
+	}
+	categoriesByPath := make(map[string]dto.CategoryDTO, len(categories))
+	categoriesByName := make(map[string][]dto.CategoryDTO, len(categories))
+	for _, category := range categories {
+		categoriesByPath[categoryPath(category)] = category
+		categoriesByName[category.Name] = append(categoriesByName[category.Name], category)
+	}
+
+	tags, err := s.clothingTagRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取标签失败: %w", err)
+	}
+	tagIDsByName := make(map[string][]uint, len(tags))
+	for _, tag := range tags {
+		tagIDsByName[tag.Name] = append(tagIDsByName[tag.Name], tag.ID)
+	}
+
+	requests := make([]*dto.CreateClothingItemDTO, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		item := row.item
+
+		if item.Name == "" {
+			row.errors = append(row.errors, "名称不能为空")
+		}
+
+		var category dto.CategoryDTO
+		if matched, exists := categoriesByPath[normalizeCategoryPath(item.Category)]; exists {
+			category = matched
+		} else {
+			switch matches := categoriesByName[strings.TrimSpace(item.Category)]; {
+			case item.Category == "":
+				row.errors = append(row.errors, "分类不能为空")
+			case len(matches) == 1:
+				category = matches[0]
+			case len(matches) > 1:
+				row.errors = append(row.errors, "分类名称不唯一，请填写完整路径: "+item.Category)
+			default:
+				row.errors = append(row.errors, "分类不存在: "+item.Category)
+			}
+		}
+
+		status := item.Status
+		if status == "" {
+			status = api.ClothingStatusActive
+		}
+		if !status.IsValid() {
+			row.errors = append(row.errors, "无效的衣物状态: "+string(item.Status))
+		}
+
+		var tagIDs []uint
+		for _, name := range item.Tags {
+			ids, exists := tagIDsByName[name]
+			if !exists {
+				row.errors = append(row.errors, "标签不存在: "+name)
+				continue
+			}
+			tagIDs = append(tagIDs, ids...)
+		}
+
+		if purchase := item.Purchase; purchase != nil {
+			if purchase.Price < 0 {
+				row.errors = append(row.errors, "购买价格不能为负数")
+			}
+			if purchase.PurchaseDate.IsZero() {
+				row.errors = append(row.errors, "缺少购买日期")
+			}
+			if purchase.Currency != "" && !s.currencyService.IsSupported(purchase.Currency) {
+				row.errors = append(row.errors, "不支持的货币: "+purchase.Currency)
+			}
+		}
+
+		if len(row.errors) > 0 {
+			continue
+		}
+		requests[i] = &dto.CreateClothingItemDTO{
+			CategoryID:   category.ID,
+			CategoryName: category.Name,
+			Name:         item.Name,
+			Brand:        item.Brand,
+			Color:        item.Color,
+			Size:         item.Size,
+			Material:     item.Material,
+			Style:        item.Style,
+			Description:  item.Description,
+			TagNames:     item.Tags,
+			Status:       status,
+			IsFavorite:   item.IsFavorite,
+			PurchaseInfo: item.Purchase,
+			Tags:         tagIDs,
+		}
+	}
+	return requests, nil
+}
+
+// categoryPaths 获取用户可见分类的完整路径
+func (s *importExportService) categoryPaths(ctx context.Context, userID uint) (map[uint]string, error) {
+	categories, err := s.categoryService.GetAllCategories(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取分类失败: %w", err)
+	}
+	paths := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		paths[category.ID] = categoryPath(category)
+	}
+	return paths, nil
+}
+
+// categoryPath 由分类及其父分类路径拼出完整路径，与 GetCategoryPath 的格式一致
+func categoryPath(category dto.CategoryDTO) string {
+	if category.ParentName == "" {
+		return category.Name
+	}
+	return category.ParentName + " > " + category.Name
+}
+
+// normalizeCategoryPath 统一分类路径中分隔符两侧的空白
+func normalizeCategoryPath(path string) string {
+	parts := strings.Split(path, ">")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return strings.Join(parts, " > ")
+}
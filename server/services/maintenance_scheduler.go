@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/config"
+	"what-to-wear/server/logger"
+	"what-to-wear/server/models"
+	"what-to-wear/server/repositories"
+)
+
+// maxReminderRetryDelay 提醒发送失败后的最长重试间隔
+const maxReminderRetryDelay = 24 * time.Hour
+
+// MaintenanceReminderNotifier 保养提醒通知接口
+type MaintenanceReminderNotifier interface {
+	// 向用户发送一批保养提醒，返回错误时提醒不会被标记为已发送
+	Notify(ctx context.Context, userID uint, reminders []dto.MaintenanceReminderDTO) error
+}
+
+// logReminderNotifier 通过日志输出保养提醒（默认通知方式）
+type logReminderNotifier struct{}
+
+// NewLogReminderNotifier 创建日志提醒通知实例
+func NewLogReminderNotifier() MaintenanceReminderNotifier {
+	return &logReminderNotifier{}
+}
+
+// Notify 记录保养提醒日志
+func (n *logReminderNotifier) Notify(ctx context.Context, userID uint, reminders []dto.MaintenanceReminderDTO) error {
+	log := logger.GetLogger()
+	for _, reminder := range reminders {
+		log.Info("Maintenance reminder", logger.Fields{
+			"user_id":               userID,
+			"record_id":             reminder.ID,
+			"clothing_item_id":      reminder.ClothingItemID,
+			"clothing_item_name":    reminder.ClothingItemName,
+			"maintenance_type":      reminder.MaintenanceType,
+			"next_maintenance_date": reminder.NextMaintenanceDate,
+			"days_overdue":          reminder.DaysOverdue,
+			"priority":              reminder.Priority,
+		})
+	}
+	return nil
+}
+
+// MaintenanceScheduler 保养提醒调度器接口
+type MaintenanceScheduler interface {
+	// 启动后台扫描，未启用或重复调用时无效
+	Start()
+	// 停止后台扫描并等待当前扫描结束
+	Stop()
+	// 执行一次扫描，返回发送的提醒数量
+	RunOnce(ctx context.Context) (int, error)
+}
+
+// maintenanceScheduler 保养提醒调度器实现
+type maintenanceScheduler struct {
+	maintenanceRepo repositories.MaintenanceRecordRepository
+	clothingRepo    repositories.ClothingItemRepository
+	notifier        MaintenanceReminderNotifier
+	enabled         bool
+	interval        time.Duration
+	leadDays        int
+	batchSize       int
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewMaintenanceScheduler 创建保养提醒调度器实例
+func NewMaintenanceScheduler(
+	cfg *config.Config,
+	maintenanceRepo repositories.MaintenanceRecordRepository,
+	clothingRepo repositories.ClothingItemRepository,
+	notifier MaintenanceReminderNotifier,
+) MaintenanceScheduler {
+	return &maintenanceScheduler{
+		maintenanceRepo: maintenanceRepo,
+		clothingRepo:    clothingRepo,
+		notifier:        notifier,
+		enabled:         cfg.Maintenance.ReminderEnabled,
+		interval:        time.Duration(cfg.Maintenance.ReminderInterval) * time.Second,
+		leadDays:        cfg.Maintenance.ReminderLeadDays,
+		batchSize:       cfg.Maintenance.ReminderBatchSize,
+	}
+}
+
+// Start 启动后台扫描
+func (s *maintenanceScheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.enabled || s.cancel != nil || s.interval <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go s.loop(ctx, s.done)
+	logger.GetLogger().Info("Maintenance reminder scheduler started", logger.Fields{
+		"interval":  s.interval.String(),
+		"lead_days": s.leadDays,
+	})
+}
+
+// Stop 停止后台扫描
+func (s *maintenanceScheduler) Stop() {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// loop 按间隔循环扫描，启动时立即执行一次
+func (s *maintenanceScheduler) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			logger.GetLogger().ErrorWithErr(err, "Maintenance reminder scan failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 分批扫描到期的保养记录，按用户发送提醒并标记为已发送，直到没有待发送的记录
+// 发送失败的记录按失败次数推迟重试，不会阻塞后续记录
+func (s *maintenanceScheduler) RunOnce(ctx context.Context) (int, error) {
+	sent := 0
+	for ctx.Err() == nil {
+		count, batchLen, err := s.runBatch(ctx, time.Now())
+		sent += count
+		if err != nil {
+			return sent, err
+		}
+		if batchLen == 0 || s.batchSize <= 0 || batchLen < s.batchSize {
+			break
+		}
+	}
+	return sent, nil
+}
+
+// runBatch 处理一批到期的保养记录，返回发送的提醒数量和本批记录数量
+// 本批中的每条记录都会被标记为已发送或推迟重试，下一批不会再次取到
+func (s *maintenanceScheduler) runBatch(ctx context.Context, now time.Time) (int, int, error) {
+	records, err := s.maintenanceRepo.GetDueReminders(ctx, now.AddDate(0, 0, s.leadDays), now, s.batchSize)
+	if err != nil {
+		return 0, 0, fmt.Errorf("获取待提醒保养记录失败: %w", err)
+	}
+	if len(records) == 0 {
+		return 0, 0, nil
+	}
+
+	itemIDs := make([]uint, 0, len(records))
+	for _, record := range records {
+		itemIDs = append(itemIDs, record.ClothingItemID)
+	}
+	items, err := s.clothingRepo.GetByIDs(ctx, itemIDs)
+	if err != nil {
+		return 0, len(records), fmt.Errorf("获取衣物信息失败: %w", err)
+	}
+	itemMap := make(map[uint]models.ClothingItem, len(items))
+	for _, item := range items {
+		itemMap[item.ID] = item
+	}
+
+	// 按用户分组生成提醒，衣物已不存在的记录无需提醒
+	userReminders := make(map[uint][]dto.MaintenanceReminderDTO)
+	attempts := make(map[uint]int, len(records))
+	for i := range records {
+		item, exists := itemMap[records[i].ClothingItemID]
+		if !exists {
+			if err := s.maintenanceRepo.MarkReminderSent(ctx, records[i].ID); err != nil {
+				return 0, len(records), fmt.Errorf("标记提醒已发送失败: %w", err)
+			}
+			continue
+		}
+		attempts[records[i].ID] = records[i].ReminderAttempts
+		userReminders[item.UserID] = append(userReminders[item.UserID], buildMaintenanceReminder(&records[i], item.Name, now))
+	}
+
+	log := logger.GetLogger()
+	sent := 0
+	for userID, reminders := range userReminders {
+		if err := s.notifier.Notify(ctx, userID, reminders); err != nil {
+			// 通知失败时保留未发送状态，推迟到退避时间后重试
+			log.WarnWithErr(err, "Failed to send maintenance reminders", logger.Fields{
+				"user_id": userID,
+				"count":   len(reminders),
+			})
+			for _, reminder := range reminders {
+				retryAt := now.Add(reminderRetryDelay(s.interval, attempts[reminder.ID]))
+				if err := s.maintenanceRepo.DeferReminder(ctx, reminder.ID, retryAt); err != nil {
+					return sent, len(records), fmt.Errorf("推迟提醒重试失败: %w", err)
+				}
+			}
+			continue
+		}
+
+		for _, reminder := range reminders {
+			if err := s.maintenanceRepo.MarkReminderSent(ctx, reminder.ID); err != nil {
+				return sent, len(records), fmt.Errorf("标记提醒已发送失败: %w", err)
+			}
+			sent++
+		}
+	}
+
+	return sent, len(records), nil
+}
+
+// reminderRetryDelay 计算提醒发送失败后的重试间隔，从扫描间隔开始按失败次数翻倍，最长一天
+func reminderRetryDelay(interval time.Duration, attempts int) time.Duration {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	delay := interval
+	for i := 0; i < attempts && delay < maxReminderRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxReminderRetryDelay {
+		delay = maxReminderRetryDelay
+	}
+	return delay
+}
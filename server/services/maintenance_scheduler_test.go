@@ -0,0 +1,31 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReminderRetryDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		attempts int
+		want     time.Duration
+	}{
+		{name: "首次失败", interval: time.Hour, attempts: 0, want: time.Hour},
+		{name: "第二次失败翻倍", interval: time.Hour, attempts: 1, want: 2 * time.Hour},
+		{name: "第四次失败", interval: time.Hour, attempts: 3, want: 8 * time.Hour},
+		{name: "超过上限", interval: time.Hour, attempts: 5, want: maxReminderRetryDelay},
+		{name: "失败次数很大时不溢出", interval: time.Hour, attempts: 1000, want: maxReminderRetryDelay},
+		{name: "间隔本身超过上限", interval: 48 * time.Hour, attempts: 0, want: maxReminderRetryDelay},
+		{name: "未设置间隔时从一分钟开始", interval: 0, attempts: 2, want: 4 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reminderRetryDelay(tt.interval, tt.attempts); got != tt.want {
+				t.Errorf("reminderRetryDelay(%v, %d) = %v, want %v", tt.interval, tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,20 +2,25 @@ package services
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"time"
 	"what-to-wear/server/api"
 	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
 	"what-to-wear/server/models"
 	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
 )
 
 // MaintenanceService 保养服务接口
 type MaintenanceService interface {
 	// 基础CRUD操作
-	CreateMaintenanceRecord(ctx context.Context, itemID uint, req *dto.CreateMaintenanceRecordDTO) (*dto.MaintenanceRecordDTO, error)
+	CreateMaintenanceRecord(ctx context.Context, userID, itemID uint, req *dto.CreateMaintenanceRecordDTO) (*dto.MaintenanceRecordDTO, error)
 	GetMaintenanceRecord(ctx context.Context, userID, recordID uint) (*dto.MaintenanceRecordDTO, error)
 	GetMaintenanceRecords(ctx context.Context, userID uint, limit int) ([]dto.MaintenanceRecordDTO, error)
+	GetItemMaintenanceRecords(ctx context.Context, userID, itemID uint) ([]dto.MaintenanceRecordDTO, error)
 	UpdateMaintenanceRecord(ctx context.Context, userID, recordID uint, req *dto.UpdateMaintenanceRecordDTO) (*dto.MaintenanceRecordDTO, error)
 	DeleteMaintenanceRecord(ctx context.Context, userID, recordID uint) error
 
@@ -30,179 +35,215 @@ type MaintenanceService interface {
 
 // maintenanceService 保养服务实现
 type maintenanceService struct {
-	maintenanceRepo repositories.MaintenanceRecordRepository
-	clothingRepo   repositories.ClothingItemRepository
+	transactor        repositories.Transactor
+	maintenanceRepo   repositories.MaintenanceRecordRepository
+	clothingRepo      repositories.ClothingItemRepository
+	durabilityService DurabilityService
+	currencyService   CurrencyService
 }
 
 // NewMaintenanceService 创建保养服务实例
 func NewMaintenanceService(
+	transactor repositories.Transactor,
 	maintenanceRepo repositories.MaintenanceRecordRepository,
 	clothingRepo repositories.ClothingItemRepository,
+	durabilityService DurabilityService,
+	currencyService CurrencyService,
 ) MaintenanceService {
 	return &maintenanceService{
-		maintenanceRepo: maintenanceRepo,
-		clothingRepo:   clothingRepo,
+		transactor:        transactor,
+		maintenanceRepo:   maintenanceRepo,
+		clothingRepo:      clothingRepo,
+		durabilityService: durabilityService,
+		currencyService:   currencyService,
 	}
 }
 
 // CreateMaintenanceRecord 创建保养记录
-func (s *maintenanceService) CreateMaintenanceRecord(ctx context.Context, itemID uint, req *dto.CreateMaintenanceRecordDTO) (*dto.MaintenanceRecordDTO, error) {
+func (s *maintenanceService) CreateMaintenanceRecord(ctx context.Context, userID, itemID uint, req *dto.CreateMaintenanceRecordDTO) (*dto.MaintenanceRecordDTO, error) {
 	// 验证保养类型
 	if !api.IsValidMaintenanceType(req.MaintenanceType) {
-		return nil, fmt.Errorf("invalid maintenance type: %s", req.MaintenanceType)
+		return nil, errors.ErrInvalidRequest(fmt.Sprintf("无效的保养类型: %s", req.MaintenanceType))
 	}
 
-	// 创建保养记录模型
-	record := &models.MaintenanceRecord{
-		ClothingItemID:  itemID,
-		MaintenanceType: api.MaintenanceType(req.MaintenanceType),
-		Cost:           req.Cost,
-		MaintenanceDate: req.MaintenanceDate,
-		ServiceProvider: req.ServiceProvider,
-		Notes:          req.Notes,
+	// 验证衣物属于该用户
+	if _, err := s.getOwnedItem(ctx, userID, itemID); err != nil {
+		return nil, err
 	}
 
-	// 如果指定了下一次保养日期，使用它
-	if req.NextMaintenanceDate != nil {
-		record.NextMaintenanceDate = req.NextMaintenanceDate
+	// 备注为空时使用描述
+	notes := req.Notes
+	if notes == "" {
+		notes = req.Description
 	}
 
-	// 创建记录
-	err := s.maintenanceRepo.Create(ctx, record)
+	currency, homeCost, err := s.currencyService.ToHomeCurrency(ctx, userID, req.Cost, req.Currency, req.MaintenanceDate)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create maintenance record: %w", err)
+		return nil, err
+	}
+
+	// 创建保养记录模型，未指定下一次保养日期时由模型钩子按类型计算
+	record := &models.MaintenanceRecord{
+		ClothingItemID:      itemID,
+		MaintenanceType:     api.MaintenanceType(req.MaintenanceType),
+		Cost:                req.Cost,
+		Currency:            currency,
+		HomeCost:            homeCost,
+		MaintenanceDate:     req.MaintenanceDate,
+		ServiceProvider:     req.ServiceProvider,
+		Notes:               notes,
+		NextMaintenanceDate: req.NextMaintenanceDate,
+	}
+
+	err = s.transactor.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := s.maintenanceRepo.WithTx(tx).Create(ctx, record); err != nil {
+			return fmt.Errorf("创建保养记录失败: %w", err)
+		}
+		return s.durabilityService.RecalculateItems(ctx, tx, []uint{itemID})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 转换为 DTO 返回
 	return s.convertToMaintenanceRecordDTO(record), nil
 }
 
 // GetMaintenanceRecord 获取保养记录
 func (s *maintenanceService) GetMaintenanceRecord(ctx context.Context, userID, recordID uint) (*dto.MaintenanceRecordDTO, error) {
-	// 获取记录
-	record, err := s.maintenanceRepo.GetByID(ctx, recordID)
+	record, err := s.getOwnedRecord(ctx, userID, recordID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get maintenance record: %w", err)
-	}
-
-	// 验证记录属于该用户
-	if err := s.validateRecordOwnership(ctx, userID, record.ClothingItemID); err != nil {
 		return nil, err
 	}
 
-	// 转换为 DTO 返回
 	return s.convertToMaintenanceRecordDTO(record), nil
 }
 
 // GetMaintenanceRecords 获取用户的保养记录列表
 func (s *maintenanceService) GetMaintenanceRecords(ctx context.Context, userID uint, limit int) ([]dto.MaintenanceRecordDTO, error) {
-	// 获取记录
 	records, err := s.maintenanceRepo.GetByUserID(ctx, userID, limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get maintenance records: %w", err)
+		return nil, fmt.Errorf("获取保养记录失败: %w", err)
 	}
 
-	// 转换为 DTO 返回
 	return s.convertToMaintenanceRecordDTOs(records), nil
 }
 
-// UpdateMaintenanceRecord 更新保养记录
-func (s *maintenanceService) UpdateMaintenanceRecord(ctx context.Context, userID, recordID uint, req *dto.UpdateMaintenanceRecordDTO) (*dto.MaintenanceRecordDTO, error) {
-	// 获取现有记录
-	record, err := s.maintenanceRepo.GetByID(ctx, recordID)
+// GetItemMaintenanceRecords 获取单件衣物的保养记录
+func (s *maintenanceService) GetItemMaintenanceRecords(ctx context.Context, userID, itemID uint) ([]dto.MaintenanceRecordDTO, error) {
+	if _, err := s.getOwnedItem(ctx, userID, itemID); err != nil {
+		return nil, err
+	}
+
+	records, err := s.maintenanceRepo.GetByClothingItemID(ctx, itemID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get maintenance record: %w", err)
+		return nil, fmt.Errorf("获取保养记录失败: %w", err)
 	}
 
-	// 验证记录属于该用户
-	if err := s.validateRecordOwnership(ctx, userID, record.ClothingItemID); err != nil {
+	return s.convertToMaintenanceRecordDTOs(records), nil
+}
+
+// UpdateMaintenanceRecord 更新保养记录
+func (s *maintenanceService) UpdateMaintenanceRecord(ctx context.Context, userID, recordID uint, req *dto.UpdateMaintenanceRecordDTO) (*dto.MaintenanceRecordDTO, error) {
+	record, err := s.getOwnedRecord(ctx, userID, recordID)
+	if err != nil {
 		return nil, err
 	}
 
 	// 更新字段
+	scheduleChanged := false
 	if req.MaintenanceType != nil {
 		if !api.IsValidMaintenanceType(*req.MaintenanceType) {
-			return nil, fmt.Errorf("invalid maintenance type: %s", *req.MaintenanceType)
+			return nil, errors.ErrInvalidRequest(fmt.Sprintf("无效的保养类型: %s", *req.MaintenanceType))
 		}
 		record.MaintenanceType = api.MaintenanceType(*req.MaintenanceType)
+		scheduleChanged = true
 	}
 	if req.Cost != nil {
 		record.Cost = *req.Cost
 	}
+	if req.Currency != nil {
+		record.Currency = *req.Currency
+	}
 	if req.MaintenanceDate != nil {
 		record.MaintenanceDate = *req.MaintenanceDate
+		scheduleChanged = true
+	}
+	// 费用、货币或日期变化后按保养日汇率重新折算
+	if req.Cost != nil || req.Currency != nil || req.MaintenanceDate != nil {
+		if record.Currency, record.HomeCost, err = s.currencyService.ToHomeCurrency(ctx, userID, record.Cost, record.Currency, record.MaintenanceDate); err != nil {
+			return nil, err
+		}
 	}
 	if req.ServiceProvider != nil {
 		record.ServiceProvider = *req.ServiceProvider
 	}
 	if req.Notes != nil {
 		record.Notes = *req.Notes
+	} else if req.Description != nil {
+		record.Notes = *req.Description
 	}
+
+	// 下次保养日期变化后需要重新发送提醒
 	if req.NextMaintenanceDate != nil {
 		record.NextMaintenanceDate = req.NextMaintenanceDate
+		record.ResetReminder()
+	} else if scheduleChanged {
+		record.CalculateNextMaintenanceDate()
+		record.ResetReminder()
 	}
 
-	// 更新记录
-	err = s.maintenanceRepo.Update(ctx, record)
+	err = s.transactor.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := s.maintenanceRepo.WithTx(tx).Update(ctx, record); err != nil {
+			return fmt.Errorf("更新保养记录失败: %w", err)
+		}
+		return s.durabilityService.RecalculateItems(ctx, tx, []uint{record.ClothingItemID})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update maintenance record: %w", err)
+		return nil, err
 	}
 
-	// 转换为 DTO 返回
 	return s.convertToMaintenanceRecordDTO(record), nil
 }
 
 // DeleteMaintenanceRecord 删除保养记录
 func (s *maintenanceService) DeleteMaintenanceRecord(ctx context.Context, userID, recordID uint) error {
-	// 获取记录
-	record, err := s.maintenanceRepo.GetByID(ctx, recordID)
+	record, err := s.getOwnedRecord(ctx, userID, recordID)
 	if err != nil {
-		return fmt.Errorf("failed to get maintenance record: %w", err)
-	}
-
-	// 验证记录属于该用户
-	if err := s.validateRecordOwnership(ctx, userID, record.ClothingItemID); err != nil {
 		return err
 	}
 
-	// 删除记录
-	err = s.maintenanceRepo.Delete(ctx, recordID)
-	if err != nil {
-		return fmt.Errorf("failed to delete maintenance record: %w", err)
-	}
-
-	return nil
+	return s.transactor.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := s.maintenanceRepo.WithTx(tx).Delete(ctx, recordID); err != nil {
+			return fmt.Errorf("删除保养记录失败: %w", err)
+		}
+		return s.durabilityService.RecalculateItems(ctx, tx, []uint{record.ClothingItemID})
+	})
 }
 
 // GetUpcomingMaintenance 获取即将到期的保养提醒
 func (s *maintenanceService) GetUpcomingMaintenance(ctx context.Context, userID uint, days int) ([]dto.MaintenanceReminderDTO, error) {
-	// 获取即将到期的保养记录
 	records, err := s.maintenanceRepo.GetUpcoming(ctx, userID, days)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get upcoming maintenance: %w", err)
+		return nil, fmt.Errorf("获取即将到期的保养失败: %w", err)
 	}
 
-	// 转换为提醒 DTO
-	return s.convertToMaintenanceReminderDTOs(records), nil
+	return s.convertToMaintenanceReminderDTOs(ctx, records)
 }
 
 // GetOverdueMaintenance 获取过期的保养记录
 func (s *maintenanceService) GetOverdueMaintenance(ctx context.Context, userID uint) ([]dto.MaintenanceReminderDTO, error) {
-	// 获取过期的保养记录
 	records, err := s.maintenanceRepo.GetOverdue(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get overdue maintenance: %w", err)
+		return nil, fmt.Errorf("获取过期保养失败: %w", err)
 	}
 
-	// 转换为提醒 DTO
-	return s.convertToMaintenanceReminderDTOs(records), nil
+	return s.convertToMaintenanceReminderDTOs(ctx, records)
 }
 
 // MarkReminderSent 标记提醒已发送
 func (s *maintenanceService) MarkReminderSent(ctx context.Context, recordID uint) error {
-	err := s.maintenanceRepo.MarkReminderSent(ctx, recordID)
-	if err != nil {
-		return fmt.Errorf("failed to mark reminder sent: %w", err)
+	if err := s.maintenanceRepo.MarkReminderSent(ctx, recordID); err != nil {
+		return fmt.Errorf("标记提醒已发送失败: %w", err)
 	}
 	return nil
 }
@@ -211,34 +252,56 @@ func (s *maintenanceService) MarkReminderSent(ctx context.Context, recordID uint
 func (s *maintenanceService) GetMaintenanceCostByType(ctx context.Context, userID uint) (map[string]float64, error) {
 	costMap, err := s.maintenanceRepo.GetMaintenanceCostByType(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get maintenance cost by type: %w", err)
+		return nil, fmt.Errorf("获取保养费用统计失败: %w", err)
 	}
 	return costMap, nil
 }
 
-// validateRecordOwnership 验证记录所有权
-func (s *maintenanceService) validateRecordOwnership(ctx context.Context, userID, itemID uint) error {
-	// 获取衣物项目
+// getOwnedItem 获取属于用户的衣物
+func (s *maintenanceService) getOwnedItem(ctx context.Context, userID, itemID uint) (*models.ClothingItem, error) {
 	item, err := s.clothingRepo.GetByID(ctx, itemID)
 	if err != nil {
-		return fmt.Errorf("failed to get clothing item: %w", err)
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("衣物不存在")
+		}
+		return nil, fmt.Errorf("获取衣物失败: %w", err)
 	}
-
-	// 验证衣物项目属于该用户
 	if item.UserID != userID {
-		return fmt.Errorf("unauthorized: record does not belong to user")
+		return nil, errors.ErrForbidden("无权访问此衣物")
 	}
+	return item, nil
+}
 
-	return nil
+// getOwnedRecord 获取属于用户的保养记录
+func (s *maintenanceService) getOwnedRecord(ctx context.Context, userID, recordID uint) (*models.MaintenanceRecord, error) {
+	record, err := s.maintenanceRepo.GetByID(ctx, recordID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("保养记录不存在")
+		}
+		return nil, fmt.Errorf("获取保养记录失败: %w", err)
+	}
+	if _, err := s.getOwnedItem(ctx, userID, record.ClothingItemID); err != nil {
+		return nil, err
+	}
+	return record, nil
 }
 
 // convertToMaintenanceRecordDTO 将模型转换为 DTO
 func (s *maintenanceService) convertToMaintenanceRecordDTO(record *models.MaintenanceRecord) *dto.MaintenanceRecordDTO {
-	return &dto.MaintenanceRecordDTO{
+	result := toMaintenanceRecordDTO(record)
+	return &result
+}
+
+// toMaintenanceRecordDTO 将保养记录模型转换为 DTO
+func toMaintenanceRecordDTO(record *models.MaintenanceRecord) dto.MaintenanceRecordDTO {
+	return dto.MaintenanceRecordDTO{
 		ID:                  record.ID,
 		ClothingItemID:      record.ClothingItemID,
 		MaintenanceType:     string(record.MaintenanceType),
 		Cost:                record.Cost,
+		Currency:            record.Currency,
+		HomeCost:            record.HomeCost,
 		MaintenanceDate:     record.MaintenanceDate,
 		ServiceProvider:     record.ServiceProvider,
 		Description:         record.Notes, // 注意：这里使用 Notes 字段
@@ -258,47 +321,70 @@ func (s *maintenanceService) convertToMaintenanceRecordDTOs(records []models.Mai
 	return dtos
 }
 
-// convertToMaintenanceReminderDTO 将模型转换为提醒 DTO
-func (s *maintenanceService) convertToMaintenanceReminderDTO(record *models.MaintenanceRecord) *dto.MaintenanceReminderDTO {
+// convertToMaintenanceReminderDTOs 将模型切片转换为提醒 DTO 切片
+func (s *maintenanceService) convertToMaintenanceReminderDTOs(ctx context.Context, records []models.MaintenanceRecord) ([]dto.MaintenanceReminderDTO, error) {
+	itemNames, err := loadMaintenanceItemNames(ctx, s.clothingRepo, records)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
+	dtos := make([]dto.MaintenanceReminderDTO, len(records))
+	for i := range records {
+		dtos[i] = buildMaintenanceReminder(&records[i], itemNames[records[i].ClothingItemID], now)
+	}
+	return dtos, nil
+}
+
+// loadMaintenanceItemNames 批量获取保养记录关联的衣物名称
+func loadMaintenanceItemNames(ctx context.Context, clothingRepo repositories.ClothingItemRepository, records []models.MaintenanceRecord) (map[uint]string, error) {
+	itemNames := make(map[uint]string)
+	if len(records) == 0 {
+		return itemNames, nil
+	}
+
+	itemIDs := make([]uint, 0, len(records))
+	for _, record := range records {
+		itemIDs = append(itemIDs, record.ClothingItemID)
+	}
+	items, err := clothingRepo.GetByIDs(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取衣物信息失败: %w", err)
+	}
+	for _, item := range items {
+		itemNames[item.ID] = item.Name
+	}
+	return itemNames, nil
+}
+
+// buildMaintenanceReminder 根据下次保养日期计算逾期天数和优先级
+// 已逾期至少为high，逾期超过7天为urgent；未到期时最高为medium，3天内到期为medium，其余为low
+func buildMaintenanceReminder(record *models.MaintenanceRecord, itemName string, now time.Time) dto.MaintenanceReminderDTO {
 	daysOverdue := 0
 	priority := "low"
-	itemName := "Unknown Item"
-
-	// 尝试获取衣物项目名称
-	if item, err := s.clothingRepo.GetByID(context.Background(), record.ClothingItemID); err == nil {
-		itemName = item.Name
+	if itemName == "" {
+		itemName = "未知衣物"
 	}
 
-	// 处理下次保养日期
-	var nextMaintenanceDate time.Time
+	nextMaintenanceDate := now
 	if record.NextMaintenanceDate != nil {
 		nextMaintenanceDate = *record.NextMaintenanceDate
-		
-		if record.NextMaintenanceDate.Before(now) {
-			daysOverdue = int(now.Sub(*record.NextMaintenanceDate).Hours() / 24)
-			if daysOverdue > 30 {
+
+		if nextMaintenanceDate.Before(now) {
+			daysOverdue = int(now.Sub(nextMaintenanceDate).Hours() / 24)
+			priority = "high"
+			if daysOverdue > 7 {
 				priority = "urgent"
-			} else if daysOverdue > 14 {
-				priority = "high"
-			} else if daysOverdue > 7 {
-				priority = "medium"
 			}
 		} else {
-			daysUntil := int(record.NextMaintenanceDate.Sub(now).Hours() / 24)
+			daysUntil := int(nextMaintenanceDate.Sub(now).Hours() / 24)
 			if daysUntil <= 3 {
-				priority = "high"
-			} else if daysUntil <= 7 {
 				priority = "medium"
 			}
 		}
-	} else {
-		// 如果没有下次保养日期，设置为当前时间
-		nextMaintenanceDate = now
-		priority = "low"
 	}
 
-	return &dto.MaintenanceReminderDTO{
+	return dto.MaintenanceReminderDTO{
 		ID:                  record.ID,
 		ClothingItemID:      record.ClothingItemID,
 		ClothingItemName:    itemName,
@@ -308,12 +394,3 @@ func (s *maintenanceService) convertToMaintenanceReminderDTO(record *models.Main
 		Priority:            priority,
 	}
 }
-
-// convertToMaintenanceReminderDTOs 将模型切片转换为提醒 DTO 切片
-func (s *maintenanceService) convertToMaintenanceReminderDTOs(records []models.MaintenanceRecord) []dto.MaintenanceReminderDTO {
-	dtos := make([]dto.MaintenanceReminderDTO, len(records))
-	for i, record := range records {
-		dtos[i] = *s.convertToMaintenanceReminderDTO(&record)
-	}
-	return dtos
-}
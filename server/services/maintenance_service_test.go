@@ -0,0 +1,63 @@
+package services
+
+import (
+	"testing"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/models"
+)
+
+func TestBuildMaintenanceReminder(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	days := func(n int) *time.Time {
+		date := now.AddDate(0, 0, n)
+		return &date
+	}
+
+	tests := []struct {
+		name         string
+		next         *time.Time
+		wantPriority string
+		wantOverdue  int
+	}{
+		{name: "未设置下次保养日期", next: nil, wantPriority: "low"},
+		{name: "10天后到期", next: days(10), wantPriority: "low"},
+		{name: "4天后到期", next: days(4), wantPriority: "low"},
+		{name: "3天后到期", next: days(3), wantPriority: "medium"},
+		{name: "今天稍后到期", next: &[]time.Time{now.Add(time.Hour)}[0], wantPriority: "medium"},
+		{name: "逾期1天", next: days(-1), wantPriority: "high", wantOverdue: 1},
+		{name: "逾期7天", next: days(-7), wantPriority: "high", wantOverdue: 7},
+		{name: "逾期8天", next: days(-8), wantPriority: "urgent", wantOverdue: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &models.MaintenanceRecord{
+				ClothingItemID:      3,
+				MaintenanceType:     api.MaintenanceWashing,
+				NextMaintenanceDate: tt.next,
+			}
+			reminder := buildMaintenanceReminder(record, "衬衫", now)
+			if reminder.Priority != tt.wantPriority {
+				t.Errorf("Priority = %q, want %q", reminder.Priority, tt.wantPriority)
+			}
+			if reminder.DaysOverdue != tt.wantOverdue {
+				t.Errorf("DaysOverdue = %d, want %d", reminder.DaysOverdue, tt.wantOverdue)
+			}
+			if reminder.ClothingItemName != "衬衫" || reminder.MaintenanceType != string(api.MaintenanceWashing) {
+				t.Errorf("unexpected reminder: %+v", reminder)
+			}
+		})
+	}
+}
+
+func TestBuildMaintenanceReminderUnknownItem(t *testing.T) {
+	now := time.Now()
+	reminder := buildMaintenanceReminder(&models.MaintenanceRecord{}, "", now)
+	if reminder.ClothingItemName != "未知衣物" {
+		t.Errorf("ClothingItemName = %q, want %q", reminder.ClothingItemName, "未知衣物")
+	}
+	if !reminder.NextMaintenanceDate.Equal(now) {
+		t.Errorf("NextMaintenanceDate = %v, want %v", reminder.NextMaintenanceDate, now)
+	}
+}
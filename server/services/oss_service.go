@@ -32,20 +32,20 @@ type ossService struct {
 
 // NewOSSService 创建OSS服务实例
 func NewOSSService(cfg *config.Config) (OSSService, error) {
-	// 创建配置
-	ossConfig := oss.LoadDefaultConfig().
-		WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cfg.OSS.AccessKeyID, cfg.OSS.AccessKeySecret)).
-		WithRegion(cfg.OSS.Region)
-
-	// 创建OSS客户端
-	client := oss.NewClient(ossConfig)
-
 	return &ossService{
-		client: client,
+		client: newOSSClient(cfg),
 		config: cfg,
 	}, nil
 }
 
+// newOSSClient 根据配置创建OSS客户端
+func newOSSClient(cfg *config.Config) *oss.Client {
+	ossConfig := oss.LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cfg.OSS.AccessKeyID, cfg.OSS.AccessKeySecret)).
+		WithRegion(cfg.OSS.Region)
+	return oss.NewClient(ossConfig)
+}
+
 // GeneratePresignedUploadURL 生成预签名上传URL
 func (s *ossService) GeneratePresignedUploadURL(ctx context.Context, bucketName, objectKey string, expires time.Duration) (string, error) {
 	log := logger.GetLogger()
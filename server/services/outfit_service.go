@@ -5,68 +5,95 @@ import (
 	"errors"
 	"fmt"
 	"math"
-
+	"sort"
 	"time"
 	"what-to-wear/server/api"
 	"what-to-wear/server/api/dto"
+	apierrors "what-to-wear/server/api/errors"
 	"what-to-wear/server/models"
 	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
 )
 
+// outfitStatsTopN 统计中最常穿着和最近穿搭的数量
+const outfitStatsTopN = 5
+
 // OutfitService 穿搭服务接口
 type OutfitService interface {
 	// 创建穿搭记录
-	CreateOutfit(userID uint, req *dto.CreateOutfitDTO) (*dto.Outfit, error)
+	CreateOutfit(userID uint, req *dto.CreateOutfitDTO) (*dto.OutfitDTO, error)
 
 	// 获取用户穿搭历史
-	GetUserOutfits(userID uint, page, pageSize int) ([]*dto.Outfit, int64, error)
+	GetUserOutfits(userID uint, page, pageSize int) ([]*dto.OutfitDTO, int64, error)
+
+	// 按条件查询穿搭列表
+	ListOutfits(userID uint, req *dto.OutfitListDTO) ([]*dto.OutfitDTO, int64, error)
+
+	// 获取穿搭详情
+	GetOutfit(userID, outfitID uint) (*dto.OutfitDTO, error)
+
+	// 更新穿搭记录
+	UpdateOutfit(userID, outfitID uint, req *dto.UpdateOutfitDTO) (*dto.OutfitDTO, error)
 
-	// 获取穿搭推荐
-	GetOutfitRecommendation(userID uint, weather string) (*dto.OutfitRecommendation, error)
+	// 删除穿搭记录
+	DeleteOutfit(userID, outfitID uint) error
+
+	// 穿着穿搭：为每件单品记录穿着
+	WearOutfit(userID, outfitID uint, req *dto.WearOutfitDTO) (*dto.OutfitDTO, error)
+
+	// 获取穿搭的穿着记录
+	GetOutfitWears(userID, outfitID uint, limit int) ([]dto.WearRecordDTO, error)
 
 	// 评价穿搭
 	RateOutfit(userID, outfitID uint, rating int, notes string) error
+
+	// 获取穿搭统计
+	GetOutfitStats(userID uint) (*dto.OutfitStatsDTO, error)
 }
 
 // outfitService 穿搭服务实现
 type outfitService struct {
+	transactor           repositories.Transactor
 	outfitRepo           repositories.OutfitRepository
 	outfitItemRepo       repositories.OutfitItemRepository
 	clothingItemRepo     repositories.ClothingItemRepository
 	clothingCategoryRepo repositories.ClothingCategoryRepository
 	attachmentRepo       repositories.AttachmentRepository
+	wearRecordRepo       repositories.WearRecordRepository
+	durabilityService    DurabilityService
 }
 
 // NewOutfitService 创建穿搭服务实例
 func NewOutfitService(
+	transactor repositories.Transactor,
 	outfitRepo repositories.OutfitRepository,
 	outfitItemRepo repositories.OutfitItemRepository,
 	clothingItemRepo repositories.ClothingItemRepository,
 	clothingCategoryRepo repositories.ClothingCategoryRepository,
 	attachmentRepo repositories.AttachmentRepository,
+	wearRecordRepo repositories.WearRecordRepository,
+	durabilityService DurabilityService,
 ) OutfitService {
 	return &outfitService{
+		transactor:           transactor,
 		outfitRepo:           outfitRepo,
 		outfitItemRepo:       outfitItemRepo,
 		clothingItemRepo:     clothingItemRepo,
 		clothingCategoryRepo: clothingCategoryRepo,
 		attachmentRepo:       attachmentRepo,
+		wearRecordRepo:       wearRecordRepo,
+		durabilityService:    durabilityService,
 	}
 }
 
 // CreateOutfit 创建穿搭记录
-func (s *outfitService) CreateOutfit(userID uint, req *dto.CreateOutfitDTO) (*dto.Outfit, error) {
+func (s *outfitService) CreateOutfit(userID uint, req *dto.CreateOutfitDTO) (*dto.OutfitDTO, error) {
 	ctx := context.Background()
 
 	// 验证衣物ID是否属于该用户
-	for _, clothingID := range req.ClothingIDs {
-		item, err := s.clothingItemRepo.GetByID(ctx, clothingID)
-		if err != nil {
-			return nil, fmt.Errorf("衣物ID %d 不存在", clothingID)
-		}
-		if item.UserID != userID {
-			return nil, fmt.Errorf("衣物ID %d 不属于当前用户", clothingID)
-		}
+	if err := s.validateClothingIDs(ctx, userID, req.ClothingIDs); err != nil {
+		return nil, err
 	}
 
 	// 创建穿搭记录
@@ -83,24 +110,18 @@ func (s *outfitService) CreateOutfit(userID uint, req *dto.CreateOutfitDTO) (*dt
 		IsPublic:    req.IsPublic,
 	}
 
-	if err := s.outfitRepo.Create(ctx, outfit); err != nil {
-		return nil, fmt.Errorf("创建穿搭记录失败: %w", err)
-	}
-
-	// 创建穿搭单品关联记录
-	outfitItems := make([]models.OutfitItem, 0, len(req.ClothingIDs))
-	for i, clothingID := range req.ClothingIDs {
-		outfitItem := models.OutfitItem{
-			OutfitID:       outfit.ID,
-			ClothingItemID: clothingID,
-			LayerOrder:     i + 1,                    // 从1开始
-			ItemRole:       string(api.ItemRoleMain), // 默认为主要单品
+	// 穿搭和单品关联在同一事务中创建
+	err := s.transactor.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := s.outfitRepo.WithTx(tx).Create(ctx, outfit); err != nil {
+			return fmt.Errorf("创建穿搭记录失败: %w", err)
 		}
-		outfitItems = append(outfitItems, outfitItem)
-	}
-
-	if err := s.outfitItemRepo.CreateBatch(ctx, outfitItems); err != nil {
-		return nil, fmt.Errorf("创建穿搭单品关联失败: %w", err)
+		if err := s.outfitItemRepo.WithTx(tx).CreateBatch(ctx, buildOutfitItems(outfit.ID, req.ClothingIDs)); err != nil {
+			return fmt.Errorf("创建穿搭单品关联失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// 转换为DTO并返回
@@ -108,7 +129,7 @@ func (s *outfitService) CreateOutfit(userID uint, req *dto.CreateOutfitDTO) (*dt
 }
 
 // GetUserOutfits 获取用户穿搭历史
-func (s *outfitService) GetUserOutfits(userID uint, page, pageSize int) ([]*dto.Outfit, int64, error) {
+func (s *outfitService) GetUserOutfits(userID uint, page, pageSize int) ([]*dto.OutfitDTO, int64, error) {
 	ctx := context.Background()
 
 	// 计算偏移量
@@ -121,73 +142,145 @@ func (s *outfitService) GetUserOutfits(userID uint, page, pageSize int) ([]*dto.
 	}
 
 	// 转换为DTO
-	outfitDTOs := make([]*dto.Outfit, 0, len(outfits))
-	for _, outfit := range outfits {
-		outfitDTO, err := s.convertToOutfitDTO(ctx, outfit)
-		if err != nil {
-			return nil, 0, fmt.Errorf("转换穿搭数据失败: %w", err)
-		}
-		outfitDTOs = append(outfitDTOs, outfitDTO)
+	outfitDTOs, err := s.convertToOutfitDTOs(ctx, outfits)
+	if err != nil {
+		return nil, 0, fmt.Errorf("转换穿搭数据失败: %w", err)
 	}
 
-	// 获取总数 (这里简化实现，实际项目中应该在repository中实现Count方法)
-	allOutfits, err := s.outfitRepo.GetByUserID(ctx, userID, 0, 0)
+	// 获取总数
+	total, err := s.outfitRepo.GetTotalCount(ctx, userID)
 	if err != nil {
 		return nil, 0, fmt.Errorf("获取总数失败: %w", err)
 	}
-	total := int64(len(allOutfits))
 
 	return outfitDTOs, total, nil
 }
 
-// GetOutfitRecommendation 获取穿搭推荐
-func (s *outfitService) GetOutfitRecommendation(userID uint, weather string) (*dto.OutfitRecommendation, error) {
+// ListOutfits 按条件查询穿搭列表
+func (s *outfitService) ListOutfits(userID uint, req *dto.OutfitListDTO) ([]*dto.OutfitDTO, int64, error) {
 	ctx := context.Background()
 
-	// 解析天气类型
-	weatherType := api.WeatherType(weather)
-	if !weatherType.IsValid() {
-		return nil, errors.New("无效的天气类型")
+	outfits, total, err := s.outfitRepo.List(ctx, userID, req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取穿搭列表失败: %w", err)
+	}
+
+	outfitDTOs, err := s.convertToOutfitDTOs(ctx, outfits)
+	if err != nil {
+		return nil, 0, fmt.Errorf("转换穿搭数据失败: %w", err)
 	}
 
-	// 获取用户所有衣物
-	clothingListReq := &dto.ClothingItemListDTO{
-		SearchRequest: dto.SearchRequest{
-			PaginationRequest: dto.PaginationRequest{
-				Page:     1,
-				PageSize: 100, // 暂时限制为100，实际项目中可以分批处理
-			},
-		},
+	return outfitDTOs, total, nil
+}
+
+// GetOutfit 获取穿搭详情
+func (s *outfitService) GetOutfit(userID, outfitID uint) (*dto.OutfitDTO, error) {
+	ctx := context.Background()
+
+	outfit, err := s.getOwnedOutfit(ctx, userID, outfitID)
+	if err != nil {
+		return nil, err
 	}
 
-	clothingItems, _, err := s.clothingItemRepo.GetByUserID(ctx, userID, clothingListReq)
+	return s.convertToOutfitDTO(ctx, outfit)
+}
+
+// UpdateOutfit 更新穿搭记录
+func (s *outfitService) UpdateOutfit(userID, outfitID uint, req *dto.UpdateOutfitDTO) (*dto.OutfitDTO, error) {
+	ctx := context.Background()
+
+	outfit, err := s.getOwnedOutfit(ctx, userID, outfitID)
 	if err != nil {
-		return nil, fmt.Errorf("获取用户衣物失败: %w", err)
+		return nil, err
+	}
+
+	// 只更新提供的字段
+	if req.Name != nil {
+		if *req.Name == "" {
+			return nil, apierrors.ErrInvalidRequest("穿搭名称不能为空")
+		}
+		outfit.Name = *req.Name
+	}
+	if req.Date != nil {
+		outfit.Date = *req.Date
+	}
+	if req.Temperature != nil {
+		outfit.Temperature = req.Temperature
+	}
+	if req.Weather != nil {
+		if !req.Weather.IsValid() {
+			return nil, apierrors.ErrInvalidRequest("无效的天气类型")
+		}
+		outfit.Weather = req.Weather
+	}
+	if req.Occasion != nil {
+		outfit.Occasion = *req.Occasion
+	}
+	if req.Location != nil {
+		outfit.Location = *req.Location
+	}
+	if req.Notes != nil {
+		outfit.Notes = *req.Notes
+	}
+	if req.Tags != nil {
+		outfit.Tags = req.Tags
+	}
+	if req.IsPublic != nil {
+		outfit.IsPublic = *req.IsPublic
 	}
 
-	if len(clothingItems) == 0 {
-		return nil, errors.New("用户暂无衣物，无法生成推荐")
+	// 提供了衣物列表时替换穿搭单品
+	if req.ClothingIDs != nil {
+		if len(req.ClothingIDs) == 0 {
+			return nil, apierrors.ErrInvalidRequest("穿搭至少需要一件衣物")
+		}
+		if err := s.validateClothingIDs(ctx, userID, req.ClothingIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	// 穿搭和单品关联在同一事务中更新，替换单品失败时不会留下空的穿搭
+	err = s.transactor.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := s.outfitRepo.WithTx(tx).Update(ctx, outfit); err != nil {
+			return fmt.Errorf("更新穿搭记录失败: %w", err)
+		}
+		if req.ClothingIDs == nil {
+			return nil
+		}
+		outfitItemRepo := s.outfitItemRepo.WithTx(tx)
+		if err := outfitItemRepo.DeleteByOutfitID(ctx, outfit.ID); err != nil {
+			return fmt.Errorf("删除原有穿搭单品失败: %w", err)
+		}
+		if err := outfitItemRepo.CreateBatch(ctx, buildOutfitItems(outfit.ID, req.ClothingIDs)); err != nil {
+			return fmt.Errorf("创建穿搭单品关联失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 基于天气和季节进行简单推荐逻辑
-	recommendedItems := s.generateRecommendations(ctx, clothingItems, weatherType)
+	return s.convertToOutfitDTO(ctx, outfit)
+}
+
+// DeleteOutfit 删除穿搭记录
+func (s *outfitService) DeleteOutfit(userID, outfitID uint) error {
+	ctx := context.Background()
 
-	// 构建推荐结果
-	recommendation := &dto.OutfitRecommendation{
-		ID:               0, // 推荐不保存到数据库，所以ID为0
-		RecommendedItems: recommendedItems,
-		Weather: dto.WeatherInfo{
-			Temperature: s.getTemperatureByWeather(weatherType),
-			Condition:   weatherType,
-			Description: s.getWeatherDescription(weatherType),
-		},
-		Occasion:   "日常", // 默认场合
-		Confidence: s.calculateConfidence(recommendedItems),
-		Reason:     s.generateRecommendationReason(weatherType, recommendedItems),
-		CreatedAt:  time.Now(),
+	outfit, err := s.getOwnedOutfit(ctx, userID, outfitID)
+	if err != nil {
+		return err
 	}
 
-	return recommendation, nil
+	return s.transactor.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := s.outfitItemRepo.WithTx(tx).DeleteByOutfitID(ctx, outfit.ID); err != nil {
+			return fmt.Errorf("删除穿搭单品失败: %w", err)
+		}
+		if err := s.outfitRepo.WithTx(tx).Delete(ctx, outfit.ID); err != nil {
+			return fmt.Errorf("删除穿搭记录失败: %w", err)
+		}
+		return nil
+	})
 }
 
 // RateOutfit 评价穿搭
@@ -197,18 +290,13 @@ func (s *outfitService) RateOutfit(userID, outfitID uint, rating int, notes stri
 	// 验证评分范围
 	outfitRating := api.OutfitRating(rating)
 	if !outfitRating.IsValid() {
-		return errors.New("评分必须在1-5之间")
+		return apierrors.ErrInvalidRequest("评分必须在1-5之间")
 	}
 
-	// 获取穿搭记录
-	outfit, err := s.outfitRepo.GetByID(ctx, outfitID)
+	// 获取穿搭记录并验证属于该用户
+	outfit, err := s.getOwnedOutfit(ctx, userID, outfitID)
 	if err != nil {
-		return fmt.Errorf("获取穿搭记录失败: %w", err)
-	}
-
-	// 验证穿搭属于该用户
-	if outfit.UserID != userID {
-		return errors.New("无权限修改此穿搭记录")
+		return err
 	}
 
 	// 更新评分
@@ -222,308 +310,367 @@ func (s *outfitService) RateOutfit(userID, outfitID uint, rating int, notes stri
 	return nil
 }
 
-// convertToOutfitDTO 将模型转换为DTO
-func (s *outfitService) convertToOutfitDTO(ctx context.Context, outfit *models.Outfit) (*dto.Outfit, error) {
-	// 获取穿搭单品
+// WearOutfit 穿着穿搭
+func (s *outfitService) WearOutfit(userID, outfitID uint, req *dto.WearOutfitDTO) (*dto.OutfitDTO, error) {
+	ctx := context.Background()
+
+	outfit, err := s.getOwnedOutfit(ctx, userID, outfitID)
+	if err != nil {
+		return nil, err
+	}
+
 	outfitItems, err := s.outfitItemRepo.GetByOutfitID(ctx, outfit.ID)
 	if err != nil {
 		return nil, fmt.Errorf("获取穿搭单品失败: %w", err)
 	}
+	if len(outfitItems) == 0 {
+		return nil, apierrors.ErrInvalidRequest("穿搭中没有衣物")
+	}
+
+	wearDate := time.Now()
+	if req.WearDate != nil {
+		wearDate = *req.WearDate
+	}
+	notes := req.Notes
+	if notes == "" {
+		notes = outfit.Name
+	}
+	if err := validateWearFeedback(&req.WearFeedbackDTO); err != nil {
+		return nil, err
+	}
+
+	feedback := req.WearFeedbackDTO
+	if feedback.WeatherCondition == nil {
+		feedback.WeatherCondition = outfit.Weather
+	}
+	if feedback.Temperature == nil {
+		feedback.Temperature = outfit.Temperature
+	}
+	if feedback.Occasion == "" {
+		feedback.Occasion = outfit.Occasion
+	}
+	if feedback.Location == "" {
+		feedback.Location = outfit.Location
+	}
 
-	// 获取衣物详情并转换
-	clothingItems := make([]dto.OutfitClothingItem, 0, len(outfitItems))
+	records := make([]models.WearRecord, 0, len(outfitItems))
+	itemIDs := make([]uint, 0, len(outfitItems))
 	for _, item := range outfitItems {
-		clothingItem, err := s.clothingItemRepo.GetByID(ctx, item.ClothingItemID)
-		if err != nil {
-			continue // 跳过不存在的衣物
+		itemIDs = append(itemIDs, item.ClothingItemID)
+		record := models.WearRecord{
+			ClothingItemID: item.ClothingItemID,
+			OutfitID:       &outfit.ID,
+			WearDate:       wearDate,
+			Notes:          notes,
 		}
+		applyWearFeedback(&record, &feedback)
+		records = append(records, record)
+	}
 
-		// 获取分类名称
-		var categoryName string
-		if category, err := s.clothingCategoryRepo.GetByID(ctx, clothingItem.CategoryID); err == nil {
-			categoryName = category.Name
+	err = s.transactor.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := s.wearRecordRepo.WithTx(tx).RecordWears(ctx, records); err != nil {
+			return fmt.Errorf("记录穿着失败: %w", err)
 		}
+		return s.durabilityService.RecalculateItems(ctx, tx, itemIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		// 获取主图片
-		var imageURL string
-		// 这里简化实现，实际中应该获取第一张图片
+	return s.convertToOutfitDTO(ctx, outfit)
+}
 
-		clothingDTO := dto.OutfitClothingItem{
-			ID:           clothingItem.ID,
-			Name:         clothingItem.Name,
-			Brand:        clothingItem.Brand,
-			Color:        clothingItem.Color,
-			CategoryName: categoryName,
-			ImageURL:     imageURL,
-			Layer:        item.LayerOrder,
-			Position:     item.ItemRole,
-		}
-		clothingItems = append(clothingItems, clothingDTO)
+// GetOutfitWears 获取穿搭的穿着记录
+func (s *outfitService) GetOutfitWears(userID, outfitID uint, limit int) ([]dto.WearRecordDTO, error) {
+	ctx := context.Background()
+
+	outfit, err := s.getOwnedOutfit(ctx, userID, outfitID)
+	if err != nil {
+		return nil, err
 	}
 
-	// 获取附件信息
-	attachments, err := s.attachmentRepo.GetByEntityID(ctx, api.EntityTypeOutfit, outfit.ID)
+	records, err := s.wearRecordRepo.GetByOutfitID(ctx, outfit.ID, limit)
 	if err != nil {
-		// 如果获取附件失败，记录日志但不中断流程
-		attachments = []models.Attachment{}
-	}
-
-	// 转换附件为DTO
-	attachmentDTOs := make([]dto.AttachmentDTO, 0, len(attachments))
-	for _, attachment := range attachments {
-		attachmentDTO := dto.AttachmentDTO{
-			ID:             attachment.ID,
-			OriginalName:   attachment.OriginalName,
-			FileName:       attachment.FileName,
-			FileSize:       attachment.FileSize,
-			MimeType:       attachment.MimeType,
-			AttachmentType: attachment.AttachmentType,
-			EntityType:     attachment.EntityType,
-			EntityID:       attachment.EntityID,
-			PublicURL:      attachment.PublicURL,
-			Description:    attachment.Description,
-			Tags:           attachment.Tags,
-			SortOrder:      attachment.SortOrder,
-			CreatedAt:      attachment.CreatedAt,
-			UpdatedAt:      attachment.UpdatedAt,
-		}
-		if attachment.Width != nil {
-			attachmentDTO.Width = attachment.Width
-		}
-		if attachment.Height != nil {
-			attachmentDTO.Height = attachment.Height
-		}
-		if attachment.Duration != nil {
-			attachmentDTO.Duration = attachment.Duration
-		}
-		if attachment.Thumbnail != nil {
-			attachmentDTO.Thumbnail = *attachment.Thumbnail
-		}
-		attachmentDTOs = append(attachmentDTOs, attachmentDTO)
-	}
-
-	outfitDTO := &dto.Outfit{
-		ID:          outfit.ID,
-		UserID:      outfit.UserID,
-		Name:        outfit.Name,
-		Date:        outfit.Date,
-		Temperature: outfit.Temperature,
-		Weather:     outfit.Weather,
-		Occasion:    outfit.Occasion,
-		Location:    outfit.Location,
-		Notes:       outfit.Notes,
-		IsPublic:    outfit.IsPublic,
-		ClothingIDs: s.extractClothingIDs(outfitItems),
-		Tags:        outfit.Tags,
-		CreatedAt:   outfit.CreatedAt,
-		UpdatedAt:   outfit.UpdatedAt,
-	}
-
-	return outfitDTO, nil
-}
+		return nil, fmt.Errorf("获取穿着记录失败: %w", err)
+	}
 
-// extractClothingIDs 提取衣物ID列表
-func (s *outfitService) extractClothingIDs(outfitItems []models.OutfitItem) []uint {
-	ids := make([]uint, 0, len(outfitItems))
-	for _, item := range outfitItems {
-		ids = append(ids, item.ClothingItemID)
+	result := make([]dto.WearRecordDTO, 0, len(records))
+	for _, record := range records {
+		result = append(result, toWearRecordDTO(&record))
 	}
-	return ids
+	return result, nil
 }
 
-// generateRecommendations 生成推荐算法（简化实现）
-func (s *outfitService) generateRecommendations(ctx context.Context, clothingItems []models.ClothingItem, weather api.WeatherType) []dto.RecommendedClothingItem {
-	recommendations := make([]dto.RecommendedClothingItem, 0)
+// GetOutfitStats 获取穿搭统计
+func (s *outfitService) GetOutfitStats(userID uint) (*dto.OutfitStatsDTO, error) {
+	ctx := context.Background()
 
-	// 按分类分组衣物
-	itemsByCategory := make(map[string][]models.ClothingItem)
-	for _, item := range clothingItems {
-		// 获取分类名称
-		category, err := s.clothingCategoryRepo.GetByID(ctx, item.CategoryID)
-		if err != nil {
-			continue
-		}
-		itemsByCategory[category.Name] = append(itemsByCategory[category.Name], item)
-	}
-
-	// 根据天气推荐合适的单品
-	recommendationStrategy := s.getRecommendationStrategy(weather)
-
-	for categoryName, strategy := range recommendationStrategy {
-		if items, exists := itemsByCategory[categoryName]; exists && len(items) > 0 {
-			// 选择第一个合适的单品（简化逻辑）
-			selectedItem := items[0]
-
-			recommendation := dto.RecommendedClothingItem{
-				ID:           selectedItem.ID,
-				Name:         selectedItem.Name,
-				Brand:        selectedItem.Brand,
-				Color:        selectedItem.Color,
-				CategoryName: categoryName,
-				Position:     strategy.Position,
-				Layer:        strategy.Layer,
-				Confidence:   strategy.Confidence,
-				Reason:       strategy.Reason,
-			}
-			recommendations = append(recommendations, recommendation)
-		}
+	outfits, err := s.outfitRepo.GetByUserID(ctx, userID, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("获取穿搭列表失败: %w", err)
 	}
 
-	return recommendations
-}
+	outfitIDs := make([]uint, 0, len(outfits))
+	for _, outfit := range outfits {
+		outfitIDs = append(outfitIDs, outfit.ID)
+	}
+	wearSummaries, err := s.wearRecordRepo.GetOutfitWearSummaries(ctx, outfitIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取穿着统计失败: %w", err)
+	}
+	itemCounts, err := s.outfitItemRepo.GetItemCountsByOutfitIDs(ctx, outfitIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取穿搭单品数量失败: %w", err)
+	}
 
-// RecommendationStrategy 推荐策略
-type RecommendationStrategy struct {
-	Position   string
-	Layer      int
-	Confidence float64
-	Reason     string
-}
+	stats := &dto.OutfitStatsDTO{
+		TotalOutfits:     int64(len(outfits)),
+		FavoriteWeather:  make(map[api.WeatherType]int64),
+		FavoriteOccasion: make(map[string]int64),
+		MostWornOutfits:  []dto.OutfitSummaryDTO{},
+		RecentOutfits:    []dto.OutfitSummaryDTO{},
+	}
 
-// getRecommendationStrategy 根据天气获取推荐策略
-func (s *outfitService) getRecommendationStrategy(weather api.WeatherType) map[string]RecommendationStrategy {
-	strategies := make(map[string]RecommendationStrategy)
-
-	switch weather {
-	case api.WeatherTypeSunny:
-		strategies["上衣"] = RecommendationStrategy{
-			Position:   "top",
-			Layer:      1,
-			Confidence: 0.9,
-			Reason:     "晴天适合轻薄透气的上衣",
-		}
-		strategies["裤子"] = RecommendationStrategy{
-			Position:   "bottom",
-			Layer:      1,
-			Confidence: 0.9,
-			Reason:     "晴天适合轻便的下装",
-		}
-		strategies["鞋子"] = RecommendationStrategy{
-			Position:   "shoes",
-			Layer:      1,
-			Confidence: 0.8,
-			Reason:     "晴天可选择透气的鞋子",
-		}
-	case api.WeatherTypeRainy:
-		strategies["外套"] = RecommendationStrategy{
-			Position:   "outer",
-			Layer:      2,
-			Confidence: 0.95,
-			Reason:     "雨天需要防水外套",
-		}
-		strategies["裤子"] = RecommendationStrategy{
-			Position:   "bottom",
-			Layer:      1,
-			Confidence: 0.8,
-			Reason:     "雨天适合不易湿的下装",
+	var ratingSum, ratedCount int
+	summaries := make([]dto.OutfitSummaryDTO, 0, len(outfits))
+	for _, outfit := range outfits {
+		if outfit.Weather != nil {
+			stats.FavoriteWeather[*outfit.Weather]++
 		}
-		strategies["鞋子"] = RecommendationStrategy{
-			Position:   "shoes",
-			Layer:      1,
-			Confidence: 0.9,
-			Reason:     "雨天需要防水鞋",
+		if outfit.Occasion != "" {
+			stats.FavoriteOccasion[outfit.Occasion]++
 		}
-	case api.WeatherTypeSnowy:
-		strategies["外套"] = RecommendationStrategy{
-			Position:   "outer",
-			Layer:      3,
-			Confidence: 0.95,
-			Reason:     "雪天需要保暖外套",
+		if outfit.Rating != nil {
+			ratingSum += int(*outfit.Rating)
+			ratedCount++
 		}
-		strategies["内衣"] = RecommendationStrategy{
-			Position:   "inner",
-			Layer:      1,
-			Confidence: 0.9,
-			Reason:     "雪天需要保暖内衣",
+
+		wearSummary := wearSummaries[outfit.ID]
+		summaries = append(summaries, dto.OutfitSummaryDTO{
+			ID:           outfit.ID,
+			Name:         outfit.Name,
+			Date:         outfit.Date,
+			Temperature:  outfit.Temperature,
+			Weather:      outfit.Weather,
+			Occasion:     outfit.Occasion,
+			ItemCount:    int(itemCounts[outfit.ID]),
+			Rating:       outfit.Rating,
+			WearCount:    int(wearSummary.WearCount),
+			LastWornDate: wearSummary.LastWornDate,
+		})
+	}
+	if ratedCount > 0 {
+		stats.AverageRating = math.Round(float64(ratingSum)/float64(ratedCount)*100) / 100
+	}
+
+	// 穿搭已按日期倒序排列
+	recent := summaries
+	if len(recent) > outfitStatsTopN {
+		recent = recent[:outfitStatsTopN]
+	}
+	stats.RecentOutfits = append(stats.RecentOutfits, recent...)
+
+	for _, summary := range summaries {
+		if summary.WearCount > 0 {
+			stats.MostWornOutfits = append(stats.MostWornOutfits, summary)
 		}
-		strategies["靴子"] = RecommendationStrategy{
-			Position:   "shoes",
-			Layer:      1,
-			Confidence: 0.9,
-			Reason:     "雪天需要保暖防滑靴",
+	}
+	sort.SliceStable(stats.MostWornOutfits, func(i, j int) bool {
+		return stats.MostWornOutfits[i].WearCount > stats.MostWornOutfits[j].WearCount
+	})
+	if len(stats.MostWornOutfits) > outfitStatsTopN {
+		stats.MostWornOutfits = stats.MostWornOutfits[:outfitStatsTopN]
+	}
+
+	return stats, nil
+}
+
+// getOwnedOutfit 获取属于用户的穿搭记录
+func (s *outfitService) getOwnedOutfit(ctx context.Context, userID, outfitID uint) (*models.Outfit, error) {
+	outfit, err := s.outfitRepo.GetByID(ctx, outfitID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.ErrNotFound("穿搭记录不存在")
 		}
-	default:
-		// 默认推荐
-		strategies["上衣"] = RecommendationStrategy{
-			Position:   "top",
-			Layer:      1,
-			Confidence: 0.7,
-			Reason:     "基础搭配",
+		return nil, fmt.Errorf("获取穿搭记录失败: %w", err)
+	}
+	if outfit.UserID != userID {
+		return nil, apierrors.ErrForbidden("无权访问此穿搭记录")
+	}
+	return outfit, nil
+}
+
+// validateClothingIDs 验证衣物ID是否存在且属于该用户
+func (s *outfitService) validateClothingIDs(ctx context.Context, userID uint, clothingIDs []uint) error {
+	for _, clothingID := range clothingIDs {
+		item, err := s.clothingItemRepo.GetByID(ctx, clothingID)
+		if err != nil {
+			return apierrors.ErrInvalidRequest(fmt.Sprintf("衣物ID %d 不存在", clothingID))
 		}
-		strategies["裤子"] = RecommendationStrategy{
-			Position:   "bottom",
-			Layer:      1,
-			Confidence: 0.7,
-			Reason:     "基础搭配",
+		if item.UserID != userID {
+			return apierrors.ErrForbidden(fmt.Sprintf("衣物ID %d 不属于当前用户", clothingID))
 		}
 	}
-
-	return strategies
+	return nil
 }
 
-// getTemperatureByWeather 根据天气获取参考温度
-func (s *outfitService) getTemperatureByWeather(weather api.WeatherType) float64 {
-	switch weather {
-	case api.WeatherTypeSunny:
-		return 25.0
-	case api.WeatherTypeRainy:
-		return 18.0
-	case api.WeatherTypeCloudy:
-		return 20.0
-	case api.WeatherTypeSnowy:
-		return 0.0
-	case api.WeatherTypeFoggy:
-		return 15.0
-	case api.WeatherTypeWindy:
-		return 15.0
-	default:
-		return 20.0
+// buildOutfitItems 根据衣物ID列表构建穿搭单品
+func buildOutfitItems(outfitID uint, clothingIDs []uint) []models.OutfitItem {
+	outfitItems := make([]models.OutfitItem, 0, len(clothingIDs))
+	for i, clothingID := range clothingIDs {
+		outfitItems = append(outfitItems, models.OutfitItem{
+			OutfitID:       outfitID,
+			ClothingItemID: clothingID,
+			LayerOrder:     i + 1,                    // 从1开始
+			ItemRole:       string(api.ItemRoleMain), // 默认为主要单品
+		})
 	}
+	return outfitItems
 }
 
-// getWeatherDescription 获取天气描述
-func (s *outfitService) getWeatherDescription(weather api.WeatherType) string {
-	switch weather {
-	case api.WeatherTypeSunny:
-		return "晴朗温暖，适合轻薄衣物"
-	case api.WeatherTypeRainy:
-		return "阴雨天气，注意防水保暖"
-	case api.WeatherTypeCloudy:
-		return "多云天气，温度适中"
-	case api.WeatherTypeSnowy:
-		return "雪天寒冷，注意保暖防滑"
-	case api.WeatherTypeFoggy:
-		return "雾天湿润，注意保暖"
-	case api.WeatherTypeWindy:
-		return "大风天气，注意防风保暖"
-	default:
-		return "天气状况良好"
+// convertToOutfitDTO 将模型转换为DTO
+func (s *outfitService) convertToOutfitDTO(ctx context.Context, outfit *models.Outfit) (*dto.OutfitDTO, error) {
+	outfitDTOs, err := s.convertToOutfitDTOs(ctx, []*models.Outfit{outfit})
+	if err != nil {
+		return nil, err
 	}
+	return outfitDTOs[0], nil
 }
 
-// calculateConfidence 计算推荐置信度
-func (s *outfitService) calculateConfidence(items []dto.RecommendedClothingItem) float64 {
-	if len(items) == 0 {
-		return 0.0
+// convertToOutfitDTOs 批量将模型转换为DTO，单品、衣物、分类、附件和穿着统计各用一次查询加载
+func (s *outfitService) convertToOutfitDTOs(ctx context.Context, outfits []*models.Outfit) ([]*dto.OutfitDTO, error) {
+	outfitIDs := make([]uint, 0, len(outfits))
+	for _, outfit := range outfits {
+		outfitIDs = append(outfitIDs, outfit.ID)
 	}
 
-	totalConfidence := 0.0
-	for _, item := range items {
-		totalConfidence += item.Confidence
+	// 获取穿搭单品
+	outfitItems, err := s.outfitItemRepo.GetByOutfitIDs(ctx, outfitIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取穿搭单品失败: %w", err)
 	}
 
-	avgConfidence := totalConfidence / float64(len(items))
-	return math.Round(avgConfidence*100) / 100 // 保留2位小数
-}
+	// 获取衣物详情和分类名称
+	clothingIDs := make([]uint, 0, len(outfitItems))
+	for _, item := range outfitItems {
+		clothingIDs = append(clothingIDs, item.ClothingItemID)
+	}
+	clothingList, err := s.clothingItemRepo.GetByIDs(ctx, clothingIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取衣物信息失败: %w", err)
+	}
+	clothingMap := make(map[uint]models.ClothingItem, len(clothingList))
+	categoryIDs := make([]uint, 0, len(clothingList))
+	for _, item := range clothingList {
+		clothingMap[item.ID] = item
+		categoryIDs = append(categoryIDs, item.CategoryID)
+	}
+	categories, err := s.clothingCategoryRepo.GetByIDs(ctx, categoryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取分类信息失败: %w", err)
+	}
+	categoryNames := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
 
-// generateRecommendationReason 生成推荐理由
-func (s *outfitService) generateRecommendationReason(weather api.WeatherType, items []dto.RecommendedClothingItem) string {
-	if len(items) == 0 {
-		return "暂无合适的推荐"
+	clothingByOutfit := make(map[uint][]dto.OutfitClothingItem, len(outfits))
+	for _, item := range outfitItems {
+		clothingItem, exists := clothingMap[item.ClothingItemID]
+		if !exists {
+			continue // 跳过不存在的衣物
+		}
+		clothingByOutfit[item.OutfitID] = append(clothingByOutfit[item.OutfitID], dto.OutfitClothingItem{
+			ID:           clothingItem.ID,
+			Name:         clothingItem.Name,
+			Brand:        clothingItem.Brand,
+			Color:        clothingItem.Color,
+			CategoryName: categoryNames[clothingItem.CategoryID],
+			Layer:        item.LayerOrder,
+			Position:     item.ItemRole,
+		})
+	}
+
+	// 获取附件信息，获取失败时不中断流程
+	attachmentsByOutfit := make(map[uint][]dto.AttachmentDTO, len(outfits))
+	if attachments, err := s.attachmentRepo.GetByEntityIDs(ctx, api.EntityTypeOutfit, outfitIDs); err == nil {
+		for _, attachment := range attachments {
+			attachmentsByOutfit[attachment.EntityID] = append(attachmentsByOutfit[attachment.EntityID], toOutfitAttachmentDTO(&attachment))
+		}
 	}
 
-	weatherDesc := s.getWeatherDescription(weather)
-	itemCount := len(items)
+	// 获取穿着统计
+	summaries, err := s.wearRecordRepo.GetOutfitWearSummaries(ctx, outfitIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取穿着统计失败: %w", err)
+	}
+
+	result := make([]*dto.OutfitDTO, 0, len(outfits))
+	for _, outfit := range outfits {
+		clothingItems := clothingByOutfit[outfit.ID]
+		if clothingItems == nil {
+			clothingItems = []dto.OutfitClothingItem{}
+		}
+		attachmentDTOs := attachmentsByOutfit[outfit.ID]
+		if attachmentDTOs == nil {
+			attachmentDTOs = []dto.AttachmentDTO{}
+		}
+		summary := summaries[outfit.ID]
+
+		result = append(result, &dto.OutfitDTO{
+			ID:            outfit.ID,
+			UserID:        outfit.UserID,
+			Name:          outfit.Name,
+			Date:          outfit.Date,
+			Temperature:   outfit.Temperature,
+			Weather:       outfit.Weather,
+			Occasion:      outfit.Occasion,
+			Location:      outfit.Location,
+			Notes:         outfit.Notes,
+			Tags:          outfit.Tags,
+			IsPublic:      outfit.IsPublic,
+			ClothingItems: clothingItems,
+			Attachments:   attachmentDTOs,
+			Rating:        outfit.Rating,
+			RatingNotes:   outfit.RatingNotes,
+			WearCount:     int(summary.WearCount),
+			LastWornDate:  summary.LastWornDate,
+			CreatedAt:     outfit.CreatedAt,
+			UpdatedAt:     outfit.UpdatedAt,
+		})
+	}
+	return result, nil
+}
 
-	return fmt.Sprintf("基于%s的天气条件，为您推荐了%d件单品的搭配方案", weatherDesc, itemCount)
+// toOutfitAttachmentDTO 将穿搭附件转换为DTO
+func toOutfitAttachmentDTO(attachment *models.Attachment) dto.AttachmentDTO {
+	attachmentDTO := dto.AttachmentDTO{
+		ID:             attachment.ID,
+		OriginalName:   attachment.OriginalName,
+		FileName:       attachment.FileName,
+		FileSize:       attachment.FileSize,
+		MimeType:       attachment.MimeType,
+		AttachmentType: attachment.AttachmentType,
+		EntityType:     attachment.EntityType,
+		EntityID:       attachment.EntityID,
+		PublicURL:      attachment.PublicURL,
+		Description:    attachment.Description,
+		Tags:           attachment.Tags,
+		SortOrder:      attachment.SortOrder,
+		CreatedAt:      attachment.CreatedAt,
+		UpdatedAt:      attachment.UpdatedAt,
+	}
+	if attachment.Width != nil {
+		attachmentDTO.Width = attachment.Width
+	}
+	if attachment.Height != nil {
+		attachmentDTO.Height = attachment.Height
+	}
+	if attachment.Duration != nil {
+		attachmentDTO.Duration = attachment.Duration
+	}
+	if attachment.Thumbnail != nil {
+		attachmentDTO.Thumbnail = *attachment.Thumbnail
+	}
+	return attachmentDTO
 }
@@ -2,31 +2,39 @@ package services
 
 import (
 	"context"
-	"errors"
+	stderrors "errors"
 	"fmt"
+	"sort"
 	"time"
 	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
 	"what-to-wear/server/models"
 	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
 )
 
+// spendingStatsTopN 支出统计中性价比最高的衣物数量
+const spendingStatsTopN = 5
+
 // PurchaseRecordService 购买记录服务接口
 type PurchaseRecordService interface {
 	// 基础CRUD操作
-	CreatePurchaseRecord(userID, itemID uint, req *dto.CreatePurchaseRecordDTO) (*dto.PurchaseRecordDTO, error)
-	GetPurchaseRecord(userID, recordID uint) (*dto.PurchaseRecordDTO, error)
-	GetPurchaseRecords(userID uint, limit int) ([]dto.PurchaseRecordDTO, error)
-	UpdatePurchaseRecord(userID, recordID uint, req *dto.UpdatePurchaseRecordDTO) (*dto.PurchaseRecordDTO, error)
-	DeletePurchaseRecord(userID, recordID uint) error
+	CreatePurchaseRecord(ctx context.Context, userID, itemID uint, req *dto.CreatePurchaseRecordDTO) (*dto.PurchaseRecordDTO, error)
+	GetPurchaseRecord(ctx context.Context, userID, recordID uint) (*dto.PurchaseRecordDTO, error)
+	GetItemPurchaseRecord(ctx context.Context, userID, itemID uint) (*dto.PurchaseRecordDTO, error)
+	GetPurchaseRecords(ctx context.Context, userID uint, limit int) ([]dto.PurchaseRecordDTO, error)
+	UpdatePurchaseRecord(ctx context.Context, userID, recordID uint, req *dto.UpdatePurchaseRecordDTO) (*dto.PurchaseRecordDTO, error)
+	DeletePurchaseRecord(ctx context.Context, userID, recordID uint) error
 
 	// 查询
-	GetPurchasesByDateRange(userID uint, startDate, endDate string) ([]dto.PurchaseRecordDTO, error)
-	GetPurchasesByStore(userID uint, storeName string) ([]dto.PurchaseRecordDTO, error)
+	GetPurchasesByDateRange(ctx context.Context, userID uint, startDate, endDate time.Time) ([]dto.PurchaseRecordDTO, error)
+	GetPurchasesByStore(ctx context.Context, userID uint, storeName string) ([]dto.PurchaseRecordDTO, error)
 
 	// 统计
-	GetSpendingStats(userID uint) (*dto.SpendingStatsDTO, error)
-	GetSpendingByMonth(userID uint, year int) (map[string]float64, error)
-	GetSpendingByCategory(userID uint) (map[string]float64, error)
+	GetSpendingStats(ctx context.Context, userID uint) (*dto.SpendingStatsDTO, error)
+	GetSpendingByMonth(ctx context.Context, userID uint, year int) (map[string]float64, error)
+	GetSpendingByCategory(ctx context.Context, userID uint) (map[string]float64, error)
 }
 
 // purchaseRecordService 购买记录服务实现
@@ -34,6 +42,8 @@ type purchaseRecordService struct {
 	purchaseRepo         repositories.PurchaseRecordRepository
 	clothingItemRepo     repositories.ClothingItemRepository
 	clothingCategoryRepo repositories.ClothingCategoryRepository
+	currencyService      CurrencyService
+	budgetService        BudgetService
 }
 
 // NewPurchaseRecordService 创建购买记录服务实例
@@ -41,158 +51,145 @@ func NewPurchaseRecordService(
 	purchaseRepo repositories.PurchaseRecordRepository,
 	clothingItemRepo repositories.ClothingItemRepository,
 	clothingCategoryRepo repositories.ClothingCategoryRepository,
+	currencyService CurrencyService,
+	budgetService BudgetService,
 ) PurchaseRecordService {
 	return &purchaseRecordService{
 		purchaseRepo:         purchaseRepo,
 		clothingItemRepo:     clothingItemRepo,
 		clothingCategoryRepo: clothingCategoryRepo,
+		currencyService:      currencyService,
+		budgetService:        budgetService,
 	}
 }
 
-// CreatePurchaseRecord 创建购买记录
-func (s *purchaseRecordService) CreatePurchaseRecord(userID, itemID uint, req *dto.CreatePurchaseRecordDTO) (*dto.PurchaseRecordDTO, error) {
-	ctx := context.Background()
+// CreatePurchaseRecord 创建购买记录，衣物的价格和购买日期随之更新
+func (s *purchaseRecordService) CreatePurchaseRecord(ctx context.Context, userID, itemID uint, req *dto.CreatePurchaseRecordDTO) (*dto.PurchaseRecordDTO, error) {
+	if req.Price < 0 {
+		return nil, errors.ErrInvalidRequest("购买价格不能为负数")
+	}
 
 	// 验证衣物是否属于该用户
-	clothingItem, err := s.clothingItemRepo.GetByID(ctx, itemID)
-	if err != nil {
-		return nil, fmt.Errorf("衣物不存在: %w", err)
-	}
-	if clothingItem.UserID != userID {
-		return nil, errors.New("无权限为此衣物创建购买记录")
+	if _, err := s.getOwnedItem(ctx, userID, itemID); err != nil {
+		return nil, err
 	}
 
 	// 检查是否已存在购买记录（一个衣物只能有一个购买记录）
-	existingRecord, err := s.purchaseRepo.GetByClothingItemID(ctx, itemID)
-	if err == nil && existingRecord != nil {
-		return nil, errors.New("该衣物已存在购买记录")
+	if _, err := s.purchaseRepo.GetByClothingItemID(ctx, itemID); err == nil {
+		return nil, errors.ErrConflict("该衣物已存在购买记录")
+	} else if !stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("获取购买记录失败: %w", err)
 	}
 
-	// 创建购买记录模型
-	purchaseRecord := &models.PurchaseRecord{
+	currency, homePrice, err := s.currencyService.ToHomeCurrency(ctx, userID, req.Price, req.Currency, req.PurchaseDate)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.PurchaseRecord{
 		ClothingItemID: itemID,
 		Price:          req.Price,
+		Currency:       currency,
+		HomePrice:      homePrice,
 		Store:          req.Store,
 		PurchaseDate:   req.PurchaseDate,
 		Notes:          req.Notes,
 	}
-
-	if err := s.purchaseRepo.Create(ctx, purchaseRecord); err != nil {
+	if err := s.purchaseRepo.Create(ctx, record); err != nil {
 		return nil, fmt.Errorf("创建购买记录失败: %w", err)
 	}
+	s.budgetService.CheckPurchase(ctx, userID, record)
 
-	// 转换为DTO并返回
-	return s.convertToDTO(ctx, purchaseRecord), nil
+	return s.convertToDTO(record), nil
 }
 
 // GetPurchaseRecord 获取单个购买记录
-func (s *purchaseRecordService) GetPurchaseRecord(userID, recordID uint) (*dto.PurchaseRecordDTO, error) {
-	ctx := context.Background()
-
-	// 获取购买记录
-	record, err := s.purchaseRepo.GetByID(ctx, recordID)
+func (s *purchaseRecordService) GetPurchaseRecord(ctx context.Context, userID, recordID uint) (*dto.PurchaseRecordDTO, error) {
+	record, err := s.getOwnedRecord(ctx, userID, recordID)
 	if err != nil {
-		return nil, fmt.Errorf("购买记录不存在: %w", err)
+		return nil, err
 	}
 
-	// 验证权限
-	clothingItem, err := s.clothingItemRepo.GetByID(ctx, record.ClothingItemID)
-	if err != nil {
-		return nil, fmt.Errorf("关联的衣物不存在: %w", err)
+	return s.convertToDTO(record), nil
+}
+
+// GetItemPurchaseRecord 获取衣物的购买记录
+func (s *purchaseRecordService) GetItemPurchaseRecord(ctx context.Context, userID, itemID uint) (*dto.PurchaseRecordDTO, error) {
+	if _, err := s.getOwnedItem(ctx, userID, itemID); err != nil {
+		return nil, err
 	}
-	if clothingItem.UserID != userID {
-		return nil, errors.New("无权限访问此购买记录")
+
+	record, err := s.purchaseRepo.GetByClothingItemID(ctx, itemID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("该衣物没有购买记录")
+		}
+		return nil, fmt.Errorf("获取购买记录失败: %w", err)
 	}
 
-	// 转换为DTO并返回
-	return s.convertToDTO(ctx, record), nil
+	return s.convertToDTO(record), nil
 }
 
 // GetPurchaseRecords 获取用户的购买记录列表
-func (s *purchaseRecordService) GetPurchaseRecords(userID uint, limit int) ([]dto.PurchaseRecordDTO, error) {
-	ctx := context.Background()
-
-	// 获取购买记录列表
+func (s *purchaseRecordService) GetPurchaseRecords(ctx context.Context, userID uint, limit int) ([]dto.PurchaseRecordDTO, error) {
 	records, err := s.purchaseRepo.GetByUserID(ctx, userID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("获取购买记录失败: %w", err)
 	}
 
-	// 转换为DTO列表
-	result := make([]dto.PurchaseRecordDTO, 0, len(records))
-	for _, record := range records {
-		dto := s.convertToDTO(ctx, &record)
-		result = append(result, *dto)
-	}
-
-	return result, nil
+	return s.convertToDTOs(records), nil
 }
 
-// UpdatePurchaseRecord 更新购买记录
-func (s *purchaseRecordService) UpdatePurchaseRecord(userID, recordID uint, req *dto.UpdatePurchaseRecordDTO) (*dto.PurchaseRecordDTO, error) {
-	ctx := context.Background()
-
-	// 获取现有记录
-	record, err := s.purchaseRepo.GetByID(ctx, recordID)
-	if err != nil {
-		return nil, fmt.Errorf("购买记录不存在: %w", err)
-	}
-
-	// 验证权限
-	clothingItem, err := s.clothingItemRepo.GetByID(ctx, record.ClothingItemID)
+// UpdatePurchaseRecord 更新购买记录，衣物的价格和购买日期随之更新
+func (s *purchaseRecordService) UpdatePurchaseRecord(ctx context.Context, userID, recordID uint, req *dto.UpdatePurchaseRecordDTO) (*dto.PurchaseRecordDTO, error) {
+	record, err := s.getOwnedRecord(ctx, userID, recordID)
 	if err != nil {
-		return nil, fmt.Errorf("关联的衣物不存在: %w", err)
-	}
-	if clothingItem.UserID != userID {
-		return nil, errors.New("无权限修改此购买记录")
+		return nil, err
 	}
 
 	// 更新字段
 	if req.Price != nil {
+		if *req.Price < 0 {
+			return nil, errors.ErrInvalidRequest("购买价格不能为负数")
+		}
 		record.Price = *req.Price
 	}
+	if req.Currency != nil {
+		record.Currency = *req.Currency
+	}
 	if req.PurchaseDate != nil {
 		record.PurchaseDate = *req.PurchaseDate
 	}
+	// 价格、货币或日期变化后按购买日汇率重新折算
+	if req.Price != nil || req.Currency != nil || req.PurchaseDate != nil {
+		if record.Currency, record.HomePrice, err = s.currencyService.ToHomeCurrency(ctx, userID, record.Price, record.Currency, record.PurchaseDate); err != nil {
+			return nil, err
+		}
+	}
 	if req.Store != nil {
 		record.Store = *req.Store
 	}
-	if req.Store != nil && *req.Store != "" {
-		record.Store = *req.Store // 优先使用在线商店
-	}
 	if req.Notes != nil {
 		record.Notes = *req.Notes
 	}
 
-	// 保存更新
 	if err := s.purchaseRepo.Update(ctx, record); err != nil {
 		return nil, fmt.Errorf("更新购买记录失败: %w", err)
 	}
+	// 价格或日期变化后重新检查预算
+	if req.Price != nil || req.Currency != nil || req.PurchaseDate != nil {
+		s.budgetService.CheckPurchase(ctx, userID, record)
+	}
 
-	// 转换为DTO并返回
-	return s.convertToDTO(ctx, record), nil
+	return s.convertToDTO(record), nil
 }
 
-// DeletePurchaseRecord 删除购买记录
-func (s *purchaseRecordService) DeletePurchaseRecord(userID, recordID uint) error {
-	ctx := context.Background()
-
-	// 获取购买记录
-	record, err := s.purchaseRepo.GetByID(ctx, recordID)
-	if err != nil {
-		return fmt.Errorf("购买记录不存在: %w", err)
-	}
-
-	// 验证权限
-	clothingItem, err := s.clothingItemRepo.GetByID(ctx, record.ClothingItemID)
-	if err != nil {
-		return fmt.Errorf("关联的衣物不存在: %w", err)
-	}
-	if clothingItem.UserID != userID {
-		return errors.New("无权限删除此购买记录")
+// DeletePurchaseRecord 删除购买记录，同时清空衣物的价格和购买日期
+func (s *purchaseRecordService) DeletePurchaseRecord(ctx context.Context, userID, recordID uint) error {
+	if _, err := s.getOwnedRecord(ctx, userID, recordID); err != nil {
+		return err
 	}
 
-	// 删除记录
 	if err := s.purchaseRepo.Delete(ctx, recordID); err != nil {
 		return fmt.Errorf("删除购买记录失败: %w", err)
 	}
@@ -200,108 +197,164 @@ func (s *purchaseRecordService) DeletePurchaseRecord(userID, recordID uint) erro
 	return nil
 }
 
-// GetPurchasesByDateRange 根据日期范围获取购买记录
-func (s *purchaseRecordService) GetPurchasesByDateRange(userID uint, startDate, endDate string) ([]dto.PurchaseRecordDTO, error) {
-	ctx := context.Background()
+// GetPurchasesByDateRange 根据日期范围获取购买记录，结束日期包含当天
+func (s *purchaseRecordService) GetPurchasesByDateRange(ctx context.Context, userID uint, startDate, endDate time.Time) ([]dto.PurchaseRecordDTO, error) {
+	if startDate.After(endDate) {
+		return nil, errors.ErrInvalidRequest("开始日期不能晚于结束日期")
+	}
 
-	// 获取日期范围内的购买记录
-	records, err := s.purchaseRepo.GetByDateRange(ctx, userID, startDate, endDate)
+	end := endDate.AddDate(0, 0, 1).Add(-time.Second)
+	records, err := s.purchaseRepo.GetByDateRange(ctx, userID,
+		startDate.Format(analyticsTimeLayout), end.Format(analyticsTimeLayout))
 	if err != nil {
 		return nil, fmt.Errorf("获取购买记录失败: %w", err)
 	}
 
-	// 转换为DTO列表
-	result := make([]dto.PurchaseRecordDTO, 0, len(records))
-	for _, record := range records {
-		dto := s.convertToDTO(ctx, &record)
-		result = append(result, *dto)
-	}
-
-	return result, nil
+	return s.convertToDTOs(records), nil
 }
 
-// GetPurchasesByStore 根据商店获取购买记录
-func (s *purchaseRecordService) GetPurchasesByStore(userID uint, storeName string) ([]dto.PurchaseRecordDTO, error) {
-	ctx := context.Background()
-
-	// 获取指定商店的购买记录
+// GetPurchasesByStore 根据商店获取购买记录（模糊匹配）
+func (s *purchaseRecordService) GetPurchasesByStore(ctx context.Context, userID uint, storeName string) ([]dto.PurchaseRecordDTO, error) {
 	records, err := s.purchaseRepo.GetByStore(ctx, userID, storeName)
 	if err != nil {
 		return nil, fmt.Errorf("获取购买记录失败: %w", err)
 	}
 
-	// 转换为DTO列表
-	result := make([]dto.PurchaseRecordDTO, 0, len(records))
-	for _, record := range records {
-		dto := s.convertToDTO(ctx, &record)
-		result = append(result, *dto)
-	}
-
-	return result, nil
+	return s.convertToDTOs(records), nil
 }
 
 // GetSpendingStats 获取支出统计
-func (s *purchaseRecordService) GetSpendingStats(userID uint) (*dto.SpendingStatsDTO, error) {
-	ctx := context.Background()
-
-	// 获取总支出
+func (s *purchaseRecordService) GetSpendingStats(ctx context.Context, userID uint) (*dto.SpendingStatsDTO, error) {
 	totalSpent, err := s.purchaseRepo.GetTotalSpent(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("获取总支出失败: %w", err)
 	}
 
-	// 获取平均价格
 	averagePrice, err := s.purchaseRepo.GetAverageItemPrice(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("获取平均价格失败: %w", err)
 	}
 
 	// 获取当年的月度支出
-	currentYear := time.Now().Year()
-	monthlySpending, err := s.purchaseRepo.GetSpentByMonth(ctx, userID, currentYear)
+	monthlySpending, err := s.purchaseRepo.GetSpentByMonth(ctx, userID, time.Now().Year())
 	if err != nil {
 		return nil, fmt.Errorf("获取月度支出失败: %w", err)
 	}
 
-	// 获取分类支出
 	categorySpending, err := s.purchaseRepo.GetSpentByCategory(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("获取分类支出失败: %w", err)
 	}
 
-	// 获取商店支出（作为品牌支出的替代）
-	brandSpending, err := s.purchaseRepo.GetSpentByStore(ctx, userID)
+	brandSpending, err := s.purchaseRepo.GetSpentByBrand(ctx, userID)
 	if err != nil {
-		// 如果获取失败，使用空map
-		brandSpending = make(map[string]float64)
+		return nil, fmt.Errorf("获取品牌支出失败: %w", err)
 	}
 
-	// 计算每次穿着成本（简化实现）
-	costPerWear := 0.0
-	if totalSpent > 0 {
-		// 这里应该结合穿着记录来计算，暂时简化为平均值
-		costPerWear = totalSpent / 100 // 假设平均每件衣物穿着100次
+	currency, err := s.currencyService.GetHomeCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	// 构建统计DTO
 	stats := &dto.SpendingStatsDTO{
-		TotalSpent:        totalSpent,
-		MonthlySpending:   monthlySpending,
-		CategorySpending:  categorySpending,
-		BrandSpending:     brandSpending,
-		AverageItemPrice:  averagePrice,
-		CostPerWear:       costPerWear,
-		MostExpensiveItem: nil,                         // TODO: 实现最贵商品查询
-		BestValueItems:    []dto.ClothingItemSummary{}, // TODO: 实现最佳性价比商品查询
+		Currency:         currency,
+		TotalSpent:       roundTo(totalSpent, 2),
+		MonthlySpending:  monthlySpending,
+		CategorySpending: categorySpending,
+		BrandSpending:    brandSpending,
+		AverageItemPrice: roundTo(averagePrice, 2),
+		BestValueItems:   []dto.ClothingItemSummary{},
+	}
+	if err := s.fillItemSpending(ctx, userID, stats); err != nil {
+		return nil, err
+	}
+	if stats.Budgets, err = s.budgetService.GetBudgetStatus(ctx, userID, time.Now()); err != nil {
+		return nil, err
 	}
 
 	return stats, nil
 }
 
-// GetSpendingByMonth 获取月度支出统计
-func (s *purchaseRecordService) GetSpendingByMonth(userID uint, year int) (map[string]float64, error) {
-	ctx := context.Background()
+// fillItemSpending 结合衣物的穿着次数计算每次穿着成本、最贵衣物和性价比最高的衣物
+func (s *purchaseRecordService) fillItemSpending(ctx context.Context, userID uint, stats *dto.SpendingStatsDTO) error {
+	records, err := s.purchaseRepo.GetByUserID(ctx, userID, 0)
+	if err != nil {
+		return fmt.Errorf("获取购买记录失败: %w", err)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	items, err := s.clothingItemRepo.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("获取衣物失败: %w", err)
+	}
+	itemMap := make(map[uint]models.ClothingItem, len(items))
+	for _, item := range items {
+		itemMap[item.ID] = item
+	}
+
+	categories, err := s.clothingCategoryRepo.GetAll(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("获取分类失败: %w", err)
+	}
+	categoryNames := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	var spent, maxPrice float64
+	var wears int64
+	purchased := make([]itemAnalytics, 0, len(records))
+	for _, record := range records {
+		item, exists := itemMap[record.ClothingItemID]
+		if !exists {
+			continue
+		}
+		// 以购买记录的本位币价格为准
+		item.Price = record.HomePrice
+		a := itemAnalytics{
+			item:         item,
+			categoryName: categoryNames[item.CategoryID],
+			totalWears:   int64(item.WearCount),
+		}
+		purchased = append(purchased, a)
+		spent += record.HomePrice
+		wears += a.totalWears
+
+		if stats.MostExpensiveItem == nil || record.HomePrice > maxPrice {
+			summary := buildCostPerWearItem(a).ClothingItemSummary
+			stats.MostExpensiveItem = &summary
+			maxPrice = record.HomePrice
+		}
+	}
+	stats.CostPerWear = roundTo(safeDivide(spent, float64(wears)), 2)
+
+	// 性价比：穿着过的衣物中每次穿着成本最低的
+	bestValue := make([]dto.CostPerWearItem, 0, len(purchased))
+	for _, a := range purchased {
+		if a.totalWears > 0 {
+			bestValue = append(bestValue, buildCostPerWearItem(a))
+		}
+	}
+	sort.SliceStable(bestValue, func(i, j int) bool {
+		if bestValue[i].CostPerWear != bestValue[j].CostPerWear {
+			return bestValue[i].CostPerWear < bestValue[j].CostPerWear
+		}
+		return bestValue[i].TotalWears > bestValue[j].TotalWears
+	})
+	if len(bestValue) > spendingStatsTopN {
+		bestValue = bestValue[:spendingStatsTopN]
+	}
+	for _, item := range bestValue {
+		stats.BestValueItems = append(stats.BestValueItems, item.ClothingItemSummary)
+	}
+
+	return nil
+}
 
+// GetSpendingByMonth 获取月度支出统计
+func (s *purchaseRecordService) GetSpendingByMonth(ctx context.Context, userID uint, year int) (map[string]float64, error) {
 	monthlySpending, err := s.purchaseRepo.GetSpentByMonth(ctx, userID, year)
 	if err != nil {
 		return nil, fmt.Errorf("获取月度支出失败: %w", err)
@@ -311,9 +364,7 @@ func (s *purchaseRecordService) GetSpendingByMonth(userID uint, year int) (map[s
 }
 
 // GetSpendingByCategory 获取分类支出统计
-func (s *purchaseRecordService) GetSpendingByCategory(userID uint) (map[string]float64, error) {
-	ctx := context.Background()
-
+func (s *purchaseRecordService) GetSpendingByCategory(ctx context.Context, userID uint) (map[string]float64, error) {
 	categorySpending, err := s.purchaseRepo.GetSpentByCategory(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("获取分类支出失败: %w", err)
@@ -322,18 +373,57 @@ func (s *purchaseRecordService) GetSpendingByCategory(userID uint) (map[string]f
 	return categorySpending, nil
 }
 
+// getOwnedItem 获取属于用户的衣物
+func (s *purchaseRecordService) getOwnedItem(ctx context.Context, userID, itemID uint) (*models.ClothingItem, error) {
+	item, err := s.clothingItemRepo.GetByID(ctx, itemID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("衣物不存在")
+		}
+		return nil, fmt.Errorf("获取衣物失败: %w", err)
+	}
+	if item.UserID != userID {
+		return nil, errors.ErrForbidden("无权访问此衣物")
+	}
+	return item, nil
+}
+
+// getOwnedRecord 获取属于用户的购买记录
+func (s *purchaseRecordService) getOwnedRecord(ctx context.Context, userID, recordID uint) (*models.PurchaseRecord, error) {
+	record, err := s.purchaseRepo.GetByID(ctx, recordID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("购买记录不存在")
+		}
+		return nil, fmt.Errorf("获取购买记录失败: %w", err)
+	}
+	if _, err := s.getOwnedItem(ctx, userID, record.ClothingItemID); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
 // convertToDTO 将模型转换为DTO
-func (s *purchaseRecordService) convertToDTO(ctx context.Context, record *models.PurchaseRecord) *dto.PurchaseRecordDTO {
-	dto := &dto.PurchaseRecordDTO{
+func (s *purchaseRecordService) convertToDTO(record *models.PurchaseRecord) *dto.PurchaseRecordDTO {
+	return &dto.PurchaseRecordDTO{
 		ID:             record.ID,
 		ClothingItemID: record.ClothingItemID,
-		Price:          record.Price, // 简化模型中没有原价，使用实际价格
+		Price:          record.Price,
+		Currency:       record.Currency,
+		HomePrice:      record.HomePrice,
 		PurchaseDate:   record.PurchaseDate,
 		Store:          record.Store,
 		Notes:          record.Notes,
 		CreatedAt:      record.CreatedAt,
 		UpdatedAt:      record.UpdatedAt,
 	}
+}
 
-	return dto
+// convertToDTOs 将模型列表转换为DTO列表
+func (s *purchaseRecordService) convertToDTOs(records []models.PurchaseRecord) []dto.PurchaseRecordDTO {
+	result := make([]dto.PurchaseRecordDTO, 0, len(records))
+	for i := range records {
+		result = append(result, *s.convertToDTO(&records[i]))
+	}
+	return result
 }
@@ -0,0 +1,1073 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
+	"what-to-wear/server/models"
+	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
+)
+
+// 推荐引擎相关常量
+const (
+	minRecommendDurability   = 20.0 // 耐久度低于该值的衣物不参与推荐
+	minAccessoryScore        = 0.6  // 配饰等可选单品的最低入选分数
+	maxAlternativeOutfits    = 3    // 最多返回的替代搭配数量
+	unknownAttributeScore    = 0.6  // 未填写属性时的中性分数
+	mismatchedTagScore       = 0.3  // 标签存在但不匹配时的分数
+	defaultRecommendOccasion = "日常"
+
+	feedbackWindowDays     = 90             // 参与学习的推荐历史天数
+	feedbackIgnoreAfter    = 24 * time.Hour // 超过该时间未处理的推荐视为被忽略
+	feedbackFactorWeight   = 0.2            // 用户反馈在评分中的权重
+	feedbackAcceptWeight   = 1.0            // 采纳时单品的加分
+	feedbackRejectWeight   = 1.0            // 明确不喜欢的单品的扣分
+	feedbackRejectAllRatio = 0.5            // 整体拒绝时每件单品的扣分比例
+	feedbackRejectRestRate = 0.25           // 指定了不喜欢的单品时，其余单品的扣分比例
+	feedbackIgnoreWeight   = 0.2            // 忽略推荐时单品的扣分
+)
+
+// temperatureBand 温度区间
+type temperatureBand int
+
+const (
+	bandHot  temperatureBand = iota // 炎热 >= 28℃
+	bandWarm                        // 温暖 20-28℃
+	bandMild                        // 舒适 12-20℃
+	bandCool                        // 凉爽 5-12℃
+	bandCold                        // 寒冷 < 5℃
+)
+
+// outfitSlot 穿搭中的一个位置
+type outfitSlot struct {
+	Role     api.ItemRole
+	Layer    int
+	Required bool
+}
+
+// recommendCandidate 某个位置上的候选单品
+type recommendCandidate struct {
+	Item         models.ClothingItem
+	CategoryName string
+	Role         api.ItemRole
+	Layer        int
+	Score        float64
+	Reasons      []string
+}
+
+// recommendContext 单次推荐的上下文数据
+type recommendContext struct {
+	Temperature float64
+	Condition   api.WeatherType
+	Band        temperatureBand
+	Occasion    string
+	Style       string
+	Gender      *api.Gender
+	ItemTags    map[uint][]models.ClothingTag
+	Feedback    map[uint]float64 // 单品的用户反馈得分（0-1），无记录的单品不在其中
+}
+
+// rootCategoryRoles 一级分类对应的单品角色
+var rootCategoryRoles = map[string]api.ItemRole{
+	"上衣": api.ItemRoleBase,
+	"下装": api.ItemRoleBottom,
+	"鞋子": api.ItemRoleShoes,
+	"配饰": api.ItemRoleAccessory,
+	"外套": api.ItemRoleOuter,
+}
+
+// categoryRoles 特定分类对应的单品角色（优先于一级分类）
+var categoryRoles = map[string]api.ItemRole{
+	"保暖内衣": api.ItemRoleInner,
+}
+
+// genderSpecificCategories 仅适合特定性别的分类
+var genderSpecificCategories = map[string]api.Gender{
+	"高跟鞋": api.GenderFemale,
+}
+
+// RecommendationService 穿搭推荐服务接口
+type RecommendationService interface {
+	// 根据天气、场合、风格生成完整的分层穿搭推荐
+	GenerateRecommendation(ctx context.Context, req *dto.ClothingRecommendationRequest) (*dto.OutfitRecommendationDTO, error)
+
+	// 推荐历史
+	GetRecommendationHistory(ctx context.Context, userID uint, req *dto.RecommendationListDTO) ([]*dto.OutfitRecommendationDTO, int64, error)
+	GetRecommendation(ctx context.Context, userID, recommendationID uint) (*dto.OutfitRecommendationDTO, error)
+
+	// 推荐反馈：采纳后生成穿搭记录，拒绝时记录原因
+	AcceptRecommendation(ctx context.Context, userID, recommendationID uint, req *dto.AcceptRecommendationDTO) (*dto.OutfitRecommendationDTO, error)
+	RejectRecommendation(ctx context.Context, userID, recommendationID uint, req *dto.RejectRecommendationDTO) (*dto.OutfitRecommendationDTO, error)
+}
+
+// recommendationService 穿搭推荐服务实现
+type recommendationService struct {
+	recommendationRepo   repositories.OutfitRecommendationRepository
+	clothingItemRepo     repositories.ClothingItemRepository
+	clothingCategoryRepo repositories.ClothingCategoryRepository
+	userRepo             repositories.UserRepository
+}
+
+// NewRecommendationService 创建穿搭推荐服务实例
+func NewRecommendationService(
+	recommendationRepo repositories.OutfitRecommendationRepository,
+	clothingItemRepo repositories.ClothingItemRepository,
+	clothingCategoryRepo repositories.ClothingCategoryRepository,
+	userRepo repositories.UserRepository,
+) RecommendationService {
+	return &recommendationService{
+		recommendationRepo:   recommendationRepo,
+		clothingItemRepo:     clothingItemRepo,
+		clothingCategoryRepo: clothingCategoryRepo,
+		userRepo:             userRepo,
+	}
+}
+
+// GenerateRecommendation 生成穿搭推荐
+func (s *recommendationService) GenerateRecommendation(ctx context.Context, req *dto.ClothingRecommendationRequest) (*dto.OutfitRecommendationDTO, error) {
+	condition := req.Weather.Current.Condition
+	if !condition.IsValid() {
+		return nil, errors.ErrInvalidRequest("无效的天气类型")
+	}
+
+	// 体感温度优先
+	temperature := req.Weather.Current.Temperature
+	if req.Weather.Current.FeelsLike != nil {
+		temperature = *req.Weather.Current.FeelsLike
+	}
+
+	// 未指定性别时使用用户资料中的性别
+	gender := req.Gender
+	if gender == nil {
+		if user, err := s.userRepo.GetByID(ctx, req.UserID); err == nil && user.Gender != "" {
+			gender = &user.Gender
+		}
+	}
+
+	items, err := s.clothingItemRepo.GetAllActive(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("获取用户衣物失败: %w", err)
+	}
+	if len(items) == 0 {
+		return nil, errors.ErrNotFound("用户暂无衣物，无法生成推荐")
+	}
+
+	categories, err := s.clothingCategoryRepo.GetAll(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("获取分类失败: %w", err)
+	}
+	categoryMap := make(map[uint]models.ClothingCategory, len(categories))
+	for _, category := range categories {
+		categoryMap[category.ID] = category
+	}
+
+	itemIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+	itemTags, err := s.clothingItemRepo.GetTagsByItemIDs(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取衣物标签失败: %w", err)
+	}
+
+	feedback, err := s.loadItemFeedback(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("获取推荐反馈失败: %w", err)
+	}
+
+	rc := &recommendContext{
+		Temperature: temperature,
+		Condition:   condition,
+		Band:        getTemperatureBand(temperature),
+		Occasion:    req.Occasion,
+		Style:       req.Style,
+		Gender:      gender,
+		ItemTags:    itemTags,
+		Feedback:    feedback,
+	}
+
+	// 按位置收集候选并打分
+	slots := buildOutfitSlots(rc.Band, rc.Condition)
+	candidates := make(map[api.ItemRole][]recommendCandidate)
+	for _, item := range items {
+		if item.DurabilityScore < minRecommendDurability {
+			continue
+		}
+		category, ok := categoryMap[item.CategoryID]
+		if !ok {
+			continue
+		}
+		if required, ok := genderSpecificCategories[category.Name]; ok && rc.Gender != nil && *rc.Gender != required {
+			continue
+		}
+		role, ok := resolveItemRole(category, categoryMap)
+		if !ok {
+			continue
+		}
+		score, reasons := s.scoreItem(rc, item, category.Name, role)
+		candidates[role] = append(candidates[role], recommendCandidate{
+			Item:         item,
+			CategoryName: category.Name,
+			Role:         role,
+			Score:        score,
+			Reasons:      reasons,
+		})
+	}
+	for role := range candidates {
+		sort.SliceStable(candidates[role], func(i, j int) bool {
+			return candidates[role][i].Score > candidates[role][j].Score
+		})
+	}
+
+	// 主推荐和替代搭配
+	primary, missing := assembleOutfit(slots, candidates, 0)
+	if len(primary) == 0 {
+		return nil, errors.ErrNotFound("衣橱中没有适合当前天气的衣物")
+	}
+
+	recommendation := &models.OutfitRecommendation{
+		UserID:           req.UserID,
+		RecommendedItems: collectItemIDs(primary),
+		Weather:          &condition,
+		Temperature:      &temperature,
+		Occasion:         req.Occasion,
+		Confidence:       calculateOutfitConfidence(slots, primary),
+		Reason:           buildRecommendationReason(rc, primary, missing),
+		ItemLayers:       collectItemLayers(primary),
+		Status:           api.RecommendationStatusPending,
+	}
+	if recommendation.Occasion == "" {
+		recommendation.Occasion = defaultRecommendOccasion
+	}
+
+	alternatives := make([][]recommendCandidate, 0, maxAlternativeOutfits)
+	seen := map[string]bool{outfitKey(primary): true}
+	for rank := 1; rank <= maxAlternativeOutfits; rank++ {
+		alternative, _ := assembleOutfit(slots, candidates, rank)
+		key := outfitKey(alternative)
+		if len(alternative) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		alternatives = append(alternatives, alternative)
+		recommendation.AlternativeItems = append(recommendation.AlternativeItems, collectItemIDs(alternative))
+		recommendation.ItemLayers = mergeItemLayers(recommendation.ItemLayers, collectItemLayers(alternative))
+	}
+
+	// 保存推荐记录，用于历史查询和反馈学习
+	if err := s.recommendationRepo.Create(ctx, recommendation); err != nil {
+		return nil, fmt.Errorf("保存推荐记录失败: %w", err)
+	}
+
+	return s.convertToDTO(recommendation, rc, primary, alternatives), nil
+}
+
+// GetRecommendationHistory 获取推荐历史
+func (s *recommendationService) GetRecommendationHistory(ctx context.Context, userID uint, req *dto.RecommendationListDTO) ([]*dto.OutfitRecommendationDTO, int64, error) {
+	offset := (req.Page - 1) * req.PageSize
+	recommendations, total, err := s.recommendationRepo.GetByUserID(ctx, userID, req.Status, req.PageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取推荐历史失败: %w", err)
+	}
+
+	result, err := s.convertHistoryToDTOs(ctx, userID, recommendations)
+	if err != nil {
+		return nil, 0, err
+	}
+	return result, total, nil
+}
+
+// GetRecommendation 获取单条推荐
+func (s *recommendationService) GetRecommendation(ctx context.Context, userID, recommendationID uint) (*dto.OutfitRecommendationDTO, error) {
+	recommendation, err := s.getOwnedRecommendation(ctx, userID, recommendationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.convertHistoryToDTOs(ctx, userID, []models.OutfitRecommendation{*recommendation})
+	if err != nil {
+		return nil, err
+	}
+	return result[0], nil
+}
+
+// AcceptRecommendation 采纳推荐，生成穿搭记录
+func (s *recommendationService) AcceptRecommendation(ctx context.Context, userID, recommendationID uint, req *dto.AcceptRecommendationDTO) (*dto.OutfitRecommendationDTO, error) {
+	recommendation, err := s.getOwnedRecommendation(ctx, userID, recommendationID)
+	if err != nil {
+		return nil, err
+	}
+	if !recommendation.IsPending() {
+		return nil, errors.ErrConflict("该推荐已处理")
+	}
+
+	// 选择主推荐或替代搭配
+	itemIDs := recommendation.RecommendedItems
+	if req.Alternative > 0 {
+		if req.Alternative > len(recommendation.AlternativeItems) {
+			return nil, errors.ErrInvalidRequest("替代搭配不存在")
+		}
+		itemIDs = recommendation.AlternativeItems[req.Alternative-1]
+	}
+	if len(itemIDs) == 0 {
+		return nil, errors.ErrInvalidRequest("推荐中没有可用的衣物")
+	}
+
+	// 确认衣物仍然存在且属于当前用户
+	items, err := s.clothingItemRepo.GetByIDs(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取衣物失败: %w", err)
+	}
+	if len(items) != len(itemIDs) {
+		return nil, errors.ErrInvalidRequest("推荐中的部分衣物已被删除")
+	}
+	for _, item := range items {
+		if item.UserID != userID {
+			return nil, errors.ErrForbidden("无权使用该衣物")
+		}
+	}
+
+	date := time.Now()
+	if req.Date != nil {
+		date = *req.Date
+	}
+	name := req.Name
+	if name == "" {
+		name = fmt.Sprintf("%s推荐穿搭 %s", recommendation.Occasion, date.Format("2006-01-02"))
+	}
+	notes := req.Notes
+	if notes == "" {
+		notes = recommendation.Reason
+	}
+
+	outfit := &models.Outfit{
+		UserID:      userID,
+		Name:        name,
+		Date:        date,
+		Temperature: recommendation.Temperature,
+		Weather:     recommendation.Weather,
+		Occasion:    recommendation.Occasion,
+		Notes:       notes,
+	}
+
+	layers := make(map[uint]models.RecommendedItemLayer, len(recommendation.ItemLayers))
+	for _, layer := range recommendation.ItemLayers {
+		layers[layer.ClothingItemID] = layer
+	}
+	outfitItems := make([]models.OutfitItem, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		layer, ok := layers[itemID]
+		if !ok {
+			layer = models.RecommendedItemLayer{Role: api.ItemRoleMain, Layer: 1}
+		}
+		outfitItems = append(outfitItems, models.OutfitItem{
+			ClothingItemID: itemID,
+			ItemRole:       string(layer.Role),
+			LayerOrder:     layer.Layer,
+			IsOptional:     layer.Role == api.ItemRoleAccessory,
+		})
+	}
+
+	recommendation.AcceptedItems = itemIDs
+	if err := s.recommendationRepo.Accept(ctx, recommendation, outfit, outfitItems); err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrConflict("该推荐已处理")
+		}
+		return nil, fmt.Errorf("采纳推荐失败: %w", err)
+	}
+
+	return s.GetRecommendation(ctx, userID, recommendationID)
+}
+
+// RejectRecommendation 拒绝推荐并记录原因
+func (s *recommendationService) RejectRecommendation(ctx context.Context, userID, recommendationID uint, req *dto.RejectRecommendationDTO) (*dto.OutfitRecommendationDTO, error) {
+	recommendation, err := s.getOwnedRecommendation(ctx, userID, recommendationID)
+	if err != nil {
+		return nil, err
+	}
+	if !recommendation.IsPending() {
+		return nil, errors.ErrConflict("该推荐已处理")
+	}
+
+	// 不喜欢的单品必须来自本次推荐
+	recommended := make(map[uint]bool)
+	for _, id := range recommendation.RecommendedItems {
+		recommended[id] = true
+	}
+	for _, alternative := range recommendation.AlternativeItems {
+		for _, id := range alternative {
+			recommended[id] = true
+		}
+	}
+	for _, id := range req.ItemIDs {
+		if !recommended[id] {
+			return nil, errors.ErrInvalidRequest(fmt.Sprintf("衣物 %d 不在该推荐中", id))
+		}
+	}
+
+	now := time.Now()
+	recommendation.Status = api.RecommendationStatusRejected
+	recommendation.RejectReason = strings.TrimSpace(req.Reason)
+	recommendation.RejectedItems = req.ItemIDs
+	recommendation.RespondedAt = &now
+	if err := s.recommendationRepo.Reject(ctx, recommendation); err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrConflict("该推荐已处理")
+		}
+		return nil, fmt.Errorf("拒绝推荐失败: %w", err)
+	}
+
+	return s.GetRecommendation(ctx, userID, recommendationID)
+}
+
+// getOwnedRecommendation 获取属于用户的推荐记录
+func (s *recommendationService) getOwnedRecommendation(ctx context.Context, userID, recommendationID uint) (*models.OutfitRecommendation, error) {
+	recommendation, err := s.recommendationRepo.GetByID(ctx, recommendationID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("推荐记录不存在")
+		}
+		return nil, fmt.Errorf("获取推荐记录失败: %w", err)
+	}
+	if recommendation.UserID != userID {
+		return nil, errors.ErrForbidden("无权访问该推荐记录")
+	}
+	return recommendation, nil
+}
+
+// loadItemFeedback 根据近期推荐历史计算每件衣物的反馈得分
+// 被采纳的单品加分，被拒绝或长期忽略的单品扣分，得分按出现次数归一化到0-1
+func (s *recommendationService) loadItemFeedback(ctx context.Context, userID uint) (map[uint]float64, error) {
+	now := time.Now()
+	history, err := s.recommendationRepo.GetSince(ctx, userID, now.AddDate(0, 0, -feedbackWindowDays))
+	if err != nil {
+		return nil, err
+	}
+
+	shown := make(map[uint]float64)
+	net := make(map[uint]float64)
+	for _, recommendation := range history {
+		switch recommendation.Status {
+		case api.RecommendationStatusAccepted:
+			// 采纳替代搭配时，只有实际采纳的单品加分
+			accepted := recommendation.AcceptedItems
+			if len(accepted) == 0 {
+				accepted = recommendation.RecommendedItems
+			}
+			for _, id := range accepted {
+				shown[id]++
+				net[id] += feedbackAcceptWeight
+			}
+		case api.RecommendationStatusRejected:
+			rejected := make(map[uint]bool, len(recommendation.RejectedItems))
+			for _, id := range recommendation.RejectedItems {
+				rejected[id] = true
+				shown[id]++
+				net[id] -= feedbackRejectWeight
+			}
+			for _, id := range recommendation.RecommendedItems {
+				if rejected[id] {
+					continue
+				}
+				shown[id]++
+				if len(rejected) > 0 {
+					net[id] -= feedbackRejectWeight * feedbackRejectRestRate
+				} else {
+					net[id] -= feedbackRejectWeight * feedbackRejectAllRatio
+				}
+			}
+		default:
+			if now.Sub(recommendation.CreatedAt) < feedbackIgnoreAfter {
+				continue
+			}
+			for _, id := range recommendation.RecommendedItems {
+				shown[id]++
+				net[id] -= feedbackIgnoreWeight
+			}
+		}
+	}
+
+	feedback := make(map[uint]float64, len(shown))
+	for id, count := range shown {
+		score := 0.5 + 0.5*net[id]/count
+		feedback[id] = math.Max(0, math.Min(1, score))
+	}
+	return feedback, nil
+}
+
+// scoreItem 对单品在当前条件下的适合程度打分（0-1）
+func (s *recommendationService) scoreItem(rc *recommendContext, item models.ClothingItem, categoryName string, role api.ItemRole) (float64, []string) {
+	var reasons []string
+	totalWeight := 0.0
+	totalScore := 0.0
+	addFactor := func(weight, score float64, reason string) {
+		totalWeight += weight
+		totalScore += weight * score
+		if reason != "" && score >= 0.8 {
+			reasons = append(reasons, reason)
+		}
+	}
+
+	attrs := item.SpecificAttributes
+	if role == api.ItemRoleBase || role == api.ItemRoleInner || role == api.ItemRoleOuter || role == api.ItemRoleBottom {
+		addFactor(0.3, thicknessScore(attrs.Thickness, rc.Band), "厚度适合当前气温")
+	}
+	if role == api.ItemRoleBase || role == api.ItemRoleOuter {
+		addFactor(0.15, sleeveScore(attrs.Sleeve, rc.Band), "袖长适合当前气温")
+	}
+
+	tags := rc.ItemTags[item.ID]
+	addFactor(0.2, seasonScore(tags, rc.Band), "适合当前季节")
+	if rc.Occasion != "" {
+		addFactor(0.2, tagMatchScore(tags, api.TagTypeOccasion, rc.Occasion), "适合"+rc.Occasion+"场合")
+	}
+	if rc.Style != "" {
+		score := tagMatchScore(tags, api.TagTypeStyle, rc.Style)
+		if strings.Contains(item.Style, rc.Style) {
+			score = 1.0
+		}
+		addFactor(0.1, score, rc.Style+"风格")
+	}
+	addFactor(0.15, weatherFitScore(categoryName, attrs, role, rc), "适合"+weatherLabel(rc.Condition))
+	addFactor(0.1, item.DurabilityScore/100, "状态良好")
+	if score, ok := rc.Feedback[item.ID]; ok {
+		addFactor(feedbackFactorWeight, score, "您常采纳的单品")
+	}
+
+	if totalWeight == 0 {
+		return 0, reasons
+	}
+	return math.Round(totalScore/totalWeight*100) / 100, reasons
+}
+
+// convertToDTO 将推荐结果转换为DTO
+func (s *recommendationService) convertToDTO(recommendation *models.OutfitRecommendation, rc *recommendContext, primary []recommendCandidate, alternatives [][]recommendCandidate) *dto.OutfitRecommendationDTO {
+	result := &dto.OutfitRecommendationDTO{
+		ID:               recommendation.ID,
+		RecommendedItems: toRecommendedItems(primary),
+		Weather: dto.WeatherInfo{
+			Temperature: rc.Temperature,
+			Condition:   rc.Condition,
+			Description: weatherLabel(rc.Condition),
+		},
+		Occasion:         recommendation.Occasion,
+		Confidence:       recommendation.Confidence,
+		Reason:           recommendation.Reason,
+		AlternativeItems: make([][]dto.RecommendedClothingItem, 0, len(alternatives)),
+		Status:           recommendation.Status,
+		CreatedAt:        recommendation.CreatedAt,
+	}
+	if result.CreatedAt.IsZero() {
+		result.CreatedAt = time.Now()
+	}
+	for _, alternative := range alternatives {
+		result.AlternativeItems = append(result.AlternativeItems, toRecommendedItems(alternative))
+	}
+	return result
+}
+
+// convertHistoryToDTOs 将已保存的推荐记录转换为DTO
+func (s *recommendationService) convertHistoryToDTOs(ctx context.Context, userID uint, recommendations []models.OutfitRecommendation) ([]*dto.OutfitRecommendationDTO, error) {
+	result := make([]*dto.OutfitRecommendationDTO, 0, len(recommendations))
+	if len(recommendations) == 0 {
+		return result, nil
+	}
+
+	// 批量加载涉及的衣物和分类
+	idSet := make(map[uint]bool)
+	for _, recommendation := range recommendations {
+		for _, id := range recommendation.RecommendedItems {
+			idSet[id] = true
+		}
+		for _, alternative := range recommendation.AlternativeItems {
+			for _, id := range alternative {
+				idSet[id] = true
+			}
+		}
+	}
+	ids := make([]uint, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+
+	items, err := s.clothingItemRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("获取衣物失败: %w", err)
+	}
+	itemMap := make(map[uint]models.ClothingItem, len(items))
+	for _, item := range items {
+		itemMap[item.ID] = item
+	}
+
+	categories, err := s.clothingCategoryRepo.GetAll(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取分类失败: %w", err)
+	}
+	categoryNames := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	for _, recommendation := range recommendations {
+		layers := make(map[uint]models.RecommendedItemLayer, len(recommendation.ItemLayers))
+		for _, layer := range recommendation.ItemLayers {
+			layers[layer.ClothingItemID] = layer
+		}
+		toItems := func(itemIDs []uint) []dto.RecommendedClothingItem {
+			list := make([]dto.RecommendedClothingItem, 0, len(itemIDs))
+			for _, id := range itemIDs {
+				// 已删除的衣物不再展示
+				item, ok := itemMap[id]
+				if !ok {
+					continue
+				}
+				layer := layers[id]
+				list = append(list, dto.RecommendedClothingItem{
+					ID:           item.ID,
+					Name:         item.Name,
+					Brand:        item.Brand,
+					Color:        item.Color,
+					CategoryName: categoryNames[item.CategoryID],
+					Position:     string(layer.Role),
+					Layer:        layer.Layer,
+				})
+			}
+			return list
+		}
+
+		recommendationDTO := &dto.OutfitRecommendationDTO{
+			ID:               recommendation.ID,
+			RecommendedItems: toItems(recommendation.RecommendedItems),
+			Occasion:         recommendation.Occasion,
+			Confidence:       recommendation.Confidence,
+			Reason:           recommendation.Reason,
+			AlternativeItems: make([][]dto.RecommendedClothingItem, 0, len(recommendation.AlternativeItems)),
+			Status:           recommendation.Status,
+			OutfitID:         recommendation.OutfitID,
+			RejectReason:     recommendation.RejectReason,
+			RespondedAt:      recommendation.RespondedAt,
+			CreatedAt:        recommendation.CreatedAt,
+		}
+		if recommendation.Temperature != nil {
+			recommendationDTO.Weather.Temperature = *recommendation.Temperature
+		}
+		if recommendation.Weather != nil {
+			recommendationDTO.Weather.Condition = *recommendation.Weather
+			recommendationDTO.Weather.Description = weatherLabel(*recommendation.Weather)
+		}
+		for _, alternative := range recommendation.AlternativeItems {
+			recommendationDTO.AlternativeItems = append(recommendationDTO.AlternativeItems, toItems(alternative))
+		}
+		result = append(result, recommendationDTO)
+	}
+	return result, nil
+}
+
+// getTemperatureBand 根据温度获取温度区间
+func getTemperatureBand(temperature float64) temperatureBand {
+	switch {
+	case temperature >= 28:
+		return bandHot
+	case temperature >= 20:
+		return bandWarm
+	case temperature >= 12:
+		return bandMild
+	case temperature >= 5:
+		return bandCool
+	default:
+		return bandCold
+	}
+}
+
+// buildOutfitSlots 根据温度和天气确定需要的穿搭位置
+func buildOutfitSlots(band temperatureBand, condition api.WeatherType) []outfitSlot {
+	badWeather := condition == api.WeatherTypeRainy || condition == api.WeatherTypeSnowy || condition == api.WeatherTypeWindy
+
+	slots := make([]outfitSlot, 0, 6)
+	if band == bandCold {
+		slots = append(slots, outfitSlot{Role: api.ItemRoleInner, Layer: 1, Required: false})
+	}
+	slots = append(slots, outfitSlot{Role: api.ItemRoleBase, Layer: 2, Required: true})
+
+	switch {
+	case band >= bandCool:
+		slots = append(slots, outfitSlot{Role: api.ItemRoleOuter, Layer: 3, Required: true})
+	case band == bandMild:
+		slots = append(slots, outfitSlot{Role: api.ItemRoleOuter, Layer: 3, Required: badWeather})
+	case badWeather:
+		slots = append(slots, outfitSlot{Role: api.ItemRoleOuter, Layer: 3, Required: false})
+	}
+
+	slots = append(slots,
+		outfitSlot{Role: api.ItemRoleBottom, Layer: 2, Required: true},
+		outfitSlot{Role: api.ItemRoleShoes, Layer: 1, Required: true},
+		outfitSlot{Role: api.ItemRoleAccessory, Layer: 4, Required: false},
+	)
+	return slots
+}
+
+// resolveItemRole 根据分类确定单品角色
+func resolveItemRole(category models.ClothingCategory, categoryMap map[uint]models.ClothingCategory) (api.ItemRole, bool) {
+	if role, ok := categoryRoles[category.Name]; ok {
+		return role, true
+	}
+
+	// 向上查找一级分类，防止循环引用
+	root := category
+	for depth := 0; root.HasParent() && depth < 10; depth++ {
+		parent, ok := categoryMap[*root.ParentID]
+		if !ok {
+			break
+		}
+		root = parent
+	}
+
+	role, ok := rootCategoryRoles[root.Name]
+	return role, ok
+}
+
+// assembleOutfit 按排名为每个位置挑选单品，返回搭配及缺失的必需位置
+func assembleOutfit(slots []outfitSlot, candidates map[api.ItemRole][]recommendCandidate, rank int) ([]recommendCandidate, []api.ItemRole) {
+	outfit := make([]recommendCandidate, 0, len(slots))
+	var missing []api.ItemRole
+	changed := rank == 0
+
+	for _, slot := range slots {
+		list := candidates[slot.Role]
+		if len(list) == 0 {
+			if slot.Required {
+				missing = append(missing, slot.Role)
+			}
+			continue
+		}
+
+		index := 0
+		if rank < len(list) {
+			index = rank
+			changed = true
+		}
+		candidate := list[index]
+		candidate.Layer = slot.Layer
+		if !slot.Required && candidate.Score < minAccessoryScore {
+			continue
+		}
+		outfit = append(outfit, candidate)
+	}
+
+	// 所有位置都没有更多候选时，不再产生替代搭配
+	if !changed {
+		return nil, missing
+	}
+	return outfit, missing
+}
+
+// calculateOutfitConfidence 计算整套搭配的置信度
+func calculateOutfitConfidence(slots []outfitSlot, outfit []recommendCandidate) float64 {
+	if len(outfit) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	filled := make(map[api.ItemRole]bool, len(outfit))
+	for _, candidate := range outfit {
+		total += candidate.Score
+		filled[candidate.Role] = true
+	}
+
+	required, present := 0, 0
+	for _, slot := range slots {
+		if !slot.Required {
+			continue
+		}
+		required++
+		if filled[slot.Role] {
+			present++
+		}
+	}
+
+	// 缺少必需位置时按完整度折算
+	completeness := 1.0
+	if required > 0 {
+		completeness = float64(present) / float64(required)
+	}
+	return math.Round(total/float64(len(outfit))*completeness*100) / 100
+}
+
+// buildRecommendationReason 生成整体推荐理由
+func buildRecommendationReason(rc *recommendContext, outfit []recommendCandidate, missing []api.ItemRole) string {
+	layers := 0
+	for _, candidate := range outfit {
+		if candidate.Role == api.ItemRoleInner || candidate.Role == api.ItemRoleBase || candidate.Role == api.ItemRoleOuter {
+			layers++
+		}
+	}
+
+	reason := fmt.Sprintf("当前体感温度%.1f℃，%s，为您搭配了%d件单品（上身%d层）",
+		rc.Temperature, weatherLabel(rc.Condition), len(outfit), layers)
+	if rc.Occasion != "" {
+		reason += "，适合" + rc.Occasion + "场合"
+	}
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for _, role := range missing {
+			names = append(names, itemRoleLabel(role))
+		}
+		reason += "；衣橱中缺少合适的" + strings.Join(names, "、")
+	}
+	return reason
+}
+
+// thicknessScore 厚度与温度的匹配度
+func thicknessScore(thickness string, band temperatureBand) float64 {
+	levels := map[string]float64{"薄": 0, "中等": 1, "厚": 2}
+	level, ok := levels[thickness]
+	if !ok {
+		return unknownAttributeScore
+	}
+
+	// 温度越低期望越厚：炎热0 温暖0.5 舒适1 凉爽1.5 寒冷2
+	desired := float64(band) / 2
+	return 1 - math.Abs(level-desired)/2
+}
+
+// sleeveScore 袖长与温度的匹配度
+func sleeveScore(sleeve string, band temperatureBand) float64 {
+	var short bool
+	switch sleeve {
+	case "短袖", "无袖":
+		short = true
+	case "长袖":
+		short = false
+	default:
+		return unknownAttributeScore
+	}
+
+	switch band {
+	case bandHot:
+		if short {
+			return 1.0
+		}
+		return 0.3
+	case bandWarm:
+		if short {
+			return 1.0
+		}
+		return 0.7
+	case bandMild:
+		if short {
+			return 0.6
+		}
+		return 1.0
+	default:
+		if short {
+			return 0.2
+		}
+		return 1.0
+	}
+}
+
+// seasonScore 季节标签与温度的匹配度
+func seasonScore(tags []models.ClothingTag, band temperatureBand) float64 {
+	seasons := map[temperatureBand][]string{
+		bandHot:  {api.SeasonSummer},
+		bandWarm: {api.SeasonSpring, api.SeasonSummer},
+		bandMild: {api.SeasonSpring, api.SeasonAutumn},
+		bandCool: {api.SeasonAutumn, api.SeasonWinter},
+		bandCold: {api.SeasonWinter},
+	}[band]
+
+	hasSeasonTag := false
+	for _, tag := range tags {
+		if tag.Type != api.TagTypeSeason {
+			continue
+		}
+		hasSeasonTag = true
+		for _, season := range seasons {
+			if tag.Name == season {
+				return 1.0
+			}
+		}
+	}
+	if hasSeasonTag {
+		return mismatchedTagScore
+	}
+	return unknownAttributeScore
+}
+
+// tagMatchScore 指定类型标签的匹配度
+func tagMatchScore(tags []models.ClothingTag, tagType api.TagType, name string) float64 {
+	hasTypeTag := false
+	for _, tag := range tags {
+		if tag.Type != tagType {
+			continue
+		}
+		hasTypeTag = true
+		if tag.Name == name {
+			return 1.0
+		}
+	}
+	if hasTypeTag {
+		return mismatchedTagScore
+	}
+	return unknownAttributeScore
+}
+
+// weatherFitScore 天气状况对特定单品的影响
+func weatherFitScore(categoryName string, attrs models.SpecificAttributes, role api.ItemRole, rc *recommendContext) float64 {
+	wet := rc.Condition == api.WeatherTypeRainy || rc.Condition == api.WeatherTypeSnowy
+
+	switch role {
+	case api.ItemRoleShoes:
+		isBoot := categoryName == "靴子" || strings.Contains(attrs.ShoeType, "靴")
+		isOpen := categoryName == "凉鞋" || categoryName == "拖鞋"
+		switch {
+		case wet && isBoot:
+			return 1.0
+		case wet && isOpen:
+			return 0.1
+		case rc.Condition == api.WeatherTypeSnowy && attrs.HeelHeight > 3:
+			return 0.2
+		case rc.Band >= bandCool && isOpen:
+			return 0.2
+		case rc.Band == bandHot && isBoot:
+			return 0.3
+		case rc.Band <= bandWarm && isOpen:
+			return 0.9
+		}
+	case api.ItemRoleOuter:
+		if (wet || rc.Condition == api.WeatherTypeWindy) && (categoryName == "风衣" || categoryName == "夹克") {
+			return 1.0
+		}
+		if rc.Band == bandCold && categoryName == "羽绒服" {
+			return 1.0
+		}
+	case api.ItemRoleAccessory:
+		switch categoryName {
+		case "围巾":
+			if rc.Band >= bandCool || rc.Condition == api.WeatherTypeWindy {
+				return 1.0
+			}
+			return 0.2
+		case "帽子":
+			if rc.Band == bandCold || (rc.Condition == api.WeatherTypeSunny && rc.Band <= bandWarm) {
+				return 1.0
+			}
+		case "眼镜":
+			if rc.Condition == api.WeatherTypeSunny {
+				return 1.0
+			}
+			return 0.4
+		}
+	}
+	return unknownAttributeScore
+}
+
+// weatherLabel 天气类型的中文描述
+func weatherLabel(condition api.WeatherType) string {
+	switch condition {
+	case api.WeatherTypeSunny:
+		return "晴天"
+	case api.WeatherTypeRainy:
+		return "雨天"
+	case api.WeatherTypeCloudy:
+		return "多云"
+	case api.WeatherTypeSnowy:
+		return "雪天"
+	case api.WeatherTypeFoggy:
+		return "雾天"
+	case api.WeatherTypeWindy:
+		return "大风天气"
+	default:
+		return "当前天气"
+	}
+}
+
+// itemRoleLabel 单品角色的中文描述
+func itemRoleLabel(role api.ItemRole) string {
+	switch role {
+	case api.ItemRoleInner:
+		return "内层衣物"
+	case api.ItemRoleBase:
+		return "上衣"
+	case api.ItemRoleOuter:
+		return "外套"
+	case api.ItemRoleBottom:
+		return "下装"
+	case api.ItemRoleShoes:
+		return "鞋子"
+	case api.ItemRoleAccessory:
+		return "配饰"
+	default:
+		return string(role)
+	}
+}
+
+// collectItemIDs 提取搭配中的衣物ID
+func collectItemIDs(outfit []recommendCandidate) []uint {
+	ids := make([]uint, 0, len(outfit))
+	for _, candidate := range outfit {
+		ids = append(ids, candidate.Item.ID)
+	}
+	return ids
+}
+
+// collectItemLayers 提取搭配中衣物的角色和层次
+func collectItemLayers(outfit []recommendCandidate) []models.RecommendedItemLayer {
+	layers := make([]models.RecommendedItemLayer, 0, len(outfit))
+	for _, candidate := range outfit {
+		layers = append(layers, models.RecommendedItemLayer{
+			ClothingItemID: candidate.Item.ID,
+			Role:           candidate.Role,
+			Layer:          candidate.Layer,
+		})
+	}
+	return layers
+}
+
+// mergeItemLayers 合并衣物层次信息，已存在的衣物不重复添加
+func mergeItemLayers(layers, extra []models.RecommendedItemLayer) []models.RecommendedItemLayer {
+	seen := make(map[uint]bool, len(layers))
+	for _, layer := range layers {
+		seen[layer.ClothingItemID] = true
+	}
+	for _, layer := range extra {
+		if !seen[layer.ClothingItemID] {
+			seen[layer.ClothingItemID] = true
+			layers = append(layers, layer)
+		}
+	}
+	return layers
+}
+
+// outfitKey 搭配的唯一标识，用于去重
+func outfitKey(outfit []recommendCandidate) string {
+	return fmt.Sprint(collectItemIDs(outfit))
+}
+
+// toRecommendedItems 将候选单品转换为推荐DTO
+func toRecommendedItems(outfit []recommendCandidate) []dto.RecommendedClothingItem {
+	items := make([]dto.RecommendedClothingItem, 0, len(outfit))
+	for _, candidate := range outfit {
+		items = append(items, dto.RecommendedClothingItem{
+			ID:           candidate.Item.ID,
+			Name:         candidate.Item.Name,
+			Brand:        candidate.Item.Brand,
+			Color:        candidate.Item.Color,
+			CategoryName: candidate.CategoryName,
+			Position:     string(candidate.Role),
+			Layer:        candidate.Layer,
+			Confidence:   candidate.Score,
+			Reason:       strings.Join(candidate.Reasons, "，"),
+		})
+	}
+	return items
+}
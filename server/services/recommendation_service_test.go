@@ -0,0 +1,337 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/models"
+	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
+)
+
+// fakeRecommendationRepo 只实现 GetSince 的推荐仓库
+type fakeRecommendationRepo struct {
+	repositories.OutfitRecommendationRepository
+	history []models.OutfitRecommendation
+	err     error
+}
+
+func (r *fakeRecommendationRepo) GetSince(ctx context.Context, userID uint, since time.Time) ([]models.OutfitRecommendation, error) {
+	return r.history, r.err
+}
+
+// testCandidate 构造指定衣物ID、角色和分数的候选单品
+func testCandidate(id uint, role api.ItemRole, score float64) recommendCandidate {
+	return recommendCandidate{
+		Item:  models.ClothingItem{Model: gorm.Model{ID: id}},
+		Role:  role,
+		Score: score,
+	}
+}
+
+func TestScoreItem(t *testing.T) {
+	seasonTag := func(name string) models.ClothingTag {
+		return models.ClothingTag{Name: name, Type: api.TagTypeSeason}
+	}
+
+	tests := []struct {
+		name         string
+		rc           recommendContext
+		item         models.ClothingItem
+		categoryName string
+		role         api.ItemRole
+		wantScore    float64
+		wantReasons  []string
+	}{
+		{
+			name: "炎热天气的夏季短袖",
+			rc: recommendContext{
+				Condition: api.WeatherTypeSunny,
+				Band:      bandHot,
+				ItemTags:  map[uint][]models.ClothingTag{1: {seasonTag(api.SeasonSummer)}},
+			},
+			item: models.ClothingItem{
+				Model:              gorm.Model{ID: 1},
+				DurabilityScore:    100,
+				SpecificAttributes: models.SpecificAttributes{Thickness: "薄", Sleeve: "短袖"},
+			},
+			categoryName: "T恤",
+			role:         api.ItemRoleBase,
+			wantScore:    0.93,
+			wantReasons:  []string{"厚度适合当前气温", "袖长适合当前气温", "适合当前季节", "状态良好"},
+		},
+		{
+			name: "寒冷天气的夏季短袖",
+			rc: recommendContext{
+				Condition: api.WeatherTypeCloudy,
+				Band:      bandCold,
+				ItemTags:  map[uint][]models.ClothingTag{1: {seasonTag(api.SeasonSummer)}},
+			},
+			item: models.ClothingItem{
+				Model:              gorm.Model{ID: 1},
+				DurabilityScore:    50,
+				SpecificAttributes: models.SpecificAttributes{Thickness: "薄", Sleeve: "短袖"},
+			},
+			categoryName: "T恤",
+			role:         api.ItemRoleBase,
+			wantScore:    0.26,
+		},
+		{
+			name: "雨天靴子匹配场合、风格和用户反馈",
+			rc: recommendContext{
+				Condition: api.WeatherTypeRainy,
+				Band:      bandMild,
+				Occasion:  "通勤",
+				Style:     "休闲",
+				ItemTags:  map[uint][]models.ClothingTag{2: {{Name: "通勤", Type: api.TagTypeOccasion}}},
+				Feedback:  map[uint]float64{2: 1},
+			},
+			item: models.ClothingItem{
+				Model:           gorm.Model{ID: 2},
+				Style:           "休闲运动",
+				DurabilityScore: 80,
+			},
+			categoryName: "靴子",
+			role:         api.ItemRoleShoes,
+			wantScore:    0.89,
+			wantReasons:  []string{"适合通勤场合", "休闲风格", "适合雨天", "状态良好", "您常采纳的单品"},
+		},
+		{
+			name: "炎热天气的围巾",
+			rc: recommendContext{
+				Condition: api.WeatherTypeSunny,
+				Band:      bandHot,
+			},
+			item:         models.ClothingItem{Model: gorm.Model{ID: 3}},
+			categoryName: "围巾",
+			role:         api.ItemRoleAccessory,
+			wantScore:    0.33,
+		},
+	}
+
+	s := &recommendationService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, reasons := s.scoreItem(&tt.rc, tt.item, tt.categoryName, tt.role)
+			if score != tt.wantScore {
+				t.Errorf("score = %v, want %v", score, tt.wantScore)
+			}
+			if !reflect.DeepEqual(reasons, tt.wantReasons) {
+				t.Errorf("reasons = %q, want %q", reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestAssembleOutfit(t *testing.T) {
+	slots := []outfitSlot{
+		{Role: api.ItemRoleBase, Layer: 2, Required: true},
+		{Role: api.ItemRoleOuter, Layer: 3, Required: false},
+		{Role: api.ItemRoleBottom, Layer: 2, Required: true},
+		{Role: api.ItemRoleShoes, Layer: 1, Required: true},
+		{Role: api.ItemRoleAccessory, Layer: 4, Required: false},
+	}
+	candidates := map[api.ItemRole][]recommendCandidate{
+		api.ItemRoleBase:      {testCandidate(1, api.ItemRoleBase, 0.9), testCandidate(2, api.ItemRoleBase, 0.7)},
+		api.ItemRoleOuter:     {testCandidate(3, api.ItemRoleOuter, 0.7)},
+		api.ItemRoleBottom:    {testCandidate(4, api.ItemRoleBottom, 0.8)},
+		api.ItemRoleAccessory: {testCandidate(5, api.ItemRoleAccessory, 0.5)},
+	}
+
+	tests := []struct {
+		name        string
+		rank        int
+		wantItems   []uint
+		wantLayers  []int
+		wantMissing []api.ItemRole
+	}{
+		{
+			name:        "主推荐取各位置第一名，跳过低分配饰",
+			rank:        0,
+			wantItems:   []uint{1, 3, 4},
+			wantLayers:  []int{2, 3, 2},
+			wantMissing: []api.ItemRole{api.ItemRoleShoes},
+		},
+		{
+			name:        "替代搭配取第二名，候选不足的位置沿用第一名",
+			rank:        1,
+			wantItems:   []uint{2, 3, 4},
+			wantLayers:  []int{2, 3, 2},
+			wantMissing: []api.ItemRole{api.ItemRoleShoes},
+		},
+		{
+			name:        "所有位置都没有更多候选",
+			rank:        2,
+			wantMissing: []api.ItemRole{api.ItemRoleShoes},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outfit, missing := assembleOutfit(slots, candidates, tt.rank)
+			var items []uint
+			var layers []int
+			for _, candidate := range outfit {
+				items = append(items, candidate.Item.ID)
+				layers = append(layers, candidate.Layer)
+			}
+			if !reflect.DeepEqual(items, tt.wantItems) {
+				t.Errorf("items = %v, want %v", items, tt.wantItems)
+			}
+			if !reflect.DeepEqual(layers, tt.wantLayers) {
+				t.Errorf("layers = %v, want %v", layers, tt.wantLayers)
+			}
+			if !reflect.DeepEqual(missing, tt.wantMissing) {
+				t.Errorf("missing = %v, want %v", missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestCalculateOutfitConfidence(t *testing.T) {
+	slots := []outfitSlot{
+		{Role: api.ItemRoleBase, Required: true},
+		{Role: api.ItemRoleBottom, Required: true},
+		{Role: api.ItemRoleShoes, Required: true},
+		{Role: api.ItemRoleAccessory, Required: false},
+	}
+
+	tests := []struct {
+		name   string
+		slots  []outfitSlot
+		outfit []recommendCandidate
+		want   float64
+	}{
+		{name: "空搭配", slots: slots, want: 0},
+		{
+			name:  "必需位置齐全",
+			slots: slots,
+			outfit: []recommendCandidate{
+				testCandidate(1, api.ItemRoleBase, 0.9),
+				testCandidate(2, api.ItemRoleBottom, 0.8),
+				testCandidate(3, api.ItemRoleShoes, 0.7),
+			},
+			want: 0.8,
+		},
+		{
+			name:  "缺少鞋子时按完整度折算",
+			slots: slots,
+			outfit: []recommendCandidate{
+				testCandidate(1, api.ItemRoleBase, 0.9),
+				testCandidate(2, api.ItemRoleBottom, 0.7),
+			},
+			want: 0.53,
+		},
+		{
+			name:  "可选配饰参与平均分",
+			slots: slots,
+			outfit: []recommendCandidate{
+				testCandidate(1, api.ItemRoleBase, 1),
+				testCandidate(2, api.ItemRoleBottom, 1),
+				testCandidate(3, api.ItemRoleShoes, 1),
+				testCandidate(4, api.ItemRoleAccessory, 0.6),
+			},
+			want: 0.9,
+		},
+		{
+			name:   "没有必需位置",
+			slots:  []outfitSlot{{Role: api.ItemRoleAccessory}},
+			outfit: []recommendCandidate{testCandidate(1, api.ItemRoleAccessory, 0.7)},
+			want:   0.7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calculateOutfitConfidence(tt.slots, tt.outfit); got != tt.want {
+				t.Errorf("calculateOutfitConfidence() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadItemFeedback(t *testing.T) {
+	now := time.Now()
+	recommendation := func(status api.RecommendationStatus, createdAt time.Time, recommended, accepted, rejected []uint) models.OutfitRecommendation {
+		return models.OutfitRecommendation{
+			Model:            gorm.Model{CreatedAt: createdAt},
+			Status:           status,
+			RecommendedItems: recommended,
+			AcceptedItems:    accepted,
+			RejectedItems:    rejected,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		history []models.OutfitRecommendation
+		err     error
+		want    map[uint]float64
+		wantErr bool
+	}{
+		{
+			name: "采纳时只有实际采纳的单品加分",
+			history: []models.OutfitRecommendation{
+				recommendation(api.RecommendationStatusAccepted, now, []uint{1, 2}, []uint{1}, nil),
+				recommendation(api.RecommendationStatusAccepted, now, []uint{3}, nil, nil),
+			},
+			want: map[uint]float64{1: 1, 3: 1},
+		},
+		{
+			name: "拒绝时区分不喜欢的单品和其余单品",
+			history: []models.OutfitRecommendation{
+				recommendation(api.RecommendationStatusRejected, now, []uint{4, 5}, nil, []uint{4}),
+				recommendation(api.RecommendationStatusRejected, now, []uint{6}, nil, nil),
+			},
+			want: map[uint]float64{4: 0, 5: 0.375, 6: 0.25},
+		},
+		{
+			name: "超过一天未处理视为忽略，近期待处理的不计入",
+			history: []models.OutfitRecommendation{
+				recommendation(api.RecommendationStatusPending, now.Add(-48*time.Hour), []uint{7}, nil, nil),
+				recommendation(api.RecommendationStatusPending, now, []uint{8}, nil, nil),
+			},
+			want: map[uint]float64{7: 0.4},
+		},
+		{
+			name: "多次出现时按次数归一化",
+			history: []models.OutfitRecommendation{
+				recommendation(api.RecommendationStatusAccepted, now, []uint{1}, nil, nil),
+				recommendation(api.RecommendationStatusRejected, now, []uint{1}, nil, nil),
+			},
+			want: map[uint]float64{1: 0.625},
+		},
+		{
+			name:    "读取历史失败",
+			err:     stderrors.New("db down"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &recommendationService{recommendationRepo: &fakeRecommendationRepo{history: tt.history, err: tt.err}}
+			feedback, err := s.loadItemFeedback(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadItemFeedback() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(feedback) != len(tt.want) {
+				t.Fatalf("feedback = %v, want %v", feedback, tt.want)
+			}
+			for id, want := range tt.want {
+				got, exists := feedback[id]
+				if !exists || math.Abs(got-want) > 1e-9 {
+					t.Errorf("feedback[%d] = %v, want %v", id, got, want)
+				}
+			}
+		})
+	}
+}
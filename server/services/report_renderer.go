@@ -0,0 +1,367 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/utils"
+)
+
+// reportTable 报告中的一张表格，CSV、PDF 和 XLSX 共用
+type reportTable struct {
+	title   string
+	headers []string
+	rows    [][]string
+}
+
+// renderReport 按格式渲染报告中选中的章节
+func renderReport(format api.ReportFormat, reportType api.ReportType, period analyticsPeriod, sections []api.ReportSection, data *dto.ReportData, generatedAt time.Time) ([]byte, error) {
+	switch format {
+	case api.ReportFormatJSON:
+		return renderReportJSON(reportType, period, sections, data, generatedAt)
+	case api.ReportFormatCSV:
+		return renderReportCSV(buildReportTables(sections, data))
+	case api.ReportFormatPDF:
+		return renderReportPDF(period, buildReportTables(sections, data), generatedAt), nil
+	case api.ReportFormatXLSX:
+		return renderReportXLSX(sections, data)
+	default:
+		return nil, fmt.Errorf("不支持的报告格式: %s", format)
+	}
+}
+
+// renderReportJSON 输出包含周期信息和选中章节的JSON
+func renderReportJSON(reportType api.ReportType, period analyticsPeriod, sections []api.ReportSection, data *dto.ReportData, generatedAt time.Time) ([]byte, error) {
+	document := map[string]interface{}{
+		"type":         reportType,
+		"period":       reportPeriodLabel(period),
+		"start_date":   period.start,
+		"end_date":     period.end,
+		"generated_at": generatedAt,
+	}
+	for _, section := range sections {
+		switch section {
+		case api.ReportSectionOverview:
+			document[string(section)] = data.Overview
+		case api.ReportSectionClothing:
+			document[string(section)] = data.Clothing
+		case api.ReportSectionSpending:
+			document[string(section)] = data.Spending
+		case api.ReportSectionWear:
+			document[string(section)] = data.Wear
+		case api.ReportSectionMaintenance:
+			document[string(section)] = data.Maintenance
+		}
+	}
+
+	content, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("生成JSON报告失败: %w", err)
+	}
+	return content, nil
+}
+
+// renderReportCSV 将表格依次写入CSV，表格之间以空行分隔
+func renderReportCSV(tables []reportTable) ([]byte, error) {
+	var buf bytes.Buffer
+	// 写入 UTF-8 BOM，便于 Excel 正确识别中文
+	buf.WriteString("\xef\xbb\xbf")
+
+	writer := csv.NewWriter(&buf)
+	for i, table := range tables {
+		if i > 0 {
+			writer.Write([]string{})
+		}
+		writer.Write([]string{table.title})
+		writer.Write(table.headers)
+		writer.WriteAll(table.rows)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("生成CSV报告失败: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderReportPDF 将表格以文本行的形式写入PDF
+func renderReportPDF(period analyticsPeriod, tables []reportTable, generatedAt time.Time) []byte {
+	doc := utils.NewPDFDocument()
+	doc.AddTitle("衣橱报告 - " + reportPeriodLabel(period))
+	doc.AddLine("生成时间: " + generatedAt.Format("2006-01-02 15:04"))
+	doc.AddBlankLine()
+
+	for _, table := range tables {
+		doc.AddHeading(table.title)
+		doc.AddLine(strings.Join(table.headers, " | "))
+		if len(table.rows) == 0 {
+			doc.AddLine("暂无数据")
+		}
+		for _, row := range table.rows {
+			doc.AddLine(strings.Join(row, " | "))
+		}
+		doc.AddBlankLine()
+	}
+	return doc.Bytes()
+}
+
+// renderReportXLSX 每个章节写入一张工作表，章节内的表格之间以空行分隔
+func renderReportXLSX(sections []api.ReportSection, data *dto.ReportData) ([]byte, error) {
+	sheets := make([]utils.XLSXSheet, 0, len(sections))
+	for _, section := range sections {
+		var rows [][]string
+		for i, table := range sectionTables(section, data) {
+			if i > 0 {
+				rows = append(rows, []string{})
+			}
+			rows = append(rows, []string{table.title}, table.headers)
+			rows = append(rows, table.rows...)
+		}
+		sheets = append(sheets, utils.XLSXSheet{Name: reportSectionLabels[section], Rows: rows})
+	}
+
+	content, err := utils.WriteXLSX(sheets)
+	if err != nil {
+		return nil, fmt.Errorf("生成XLSX报告失败: %w", err)
+	}
+	return content, nil
+}
+
+// reportSectionLabels 报告章节的中文名称，用作工作表名
+var reportSectionLabels = map[api.ReportSection]string{
+	api.ReportSectionOverview:    "概览",
+	api.ReportSectionClothing:    "衣物",
+	api.ReportSectionSpending:    "支出",
+	api.ReportSectionWear:        "穿着",
+	api.ReportSectionMaintenance: "保养",
+}
+
+// buildReportTables 将选中的章节转换为表格
+func buildReportTables(sections []api.ReportSection, data *dto.ReportData) []reportTable {
+	var tables []reportTable
+	for _, section := range sections {
+		tables = append(tables, sectionTables(section, data)...)
+	}
+	return tables
+}
+
+// sectionTables 将一个章节转换为表格，数据中未包含的章节返回空
+func sectionTables(section api.ReportSection, data *dto.ReportData) []reportTable {
+	switch {
+	case section == api.ReportSectionOverview && data.Overview != nil:
+		return overviewTables(data.Currency, *data.Overview)
+	case section == api.ReportSectionClothing && data.Clothing != nil:
+		return clothingTables(*data.Clothing)
+	case section == api.ReportSectionSpending && data.Spending != nil:
+		return spendingTables(*data.Spending)
+	case section == api.ReportSectionWear && data.Wear != nil:
+		return wearTables(*data.Wear)
+	case section == api.ReportSectionMaintenance && data.Maintenance != nil:
+		return maintenanceTables(*data.Maintenance)
+	default:
+		return nil
+	}
+}
+
+// overviewTables 概览章节，金额均为本位币
+func overviewTables(currency string, overview dto.ReportOverview) []reportTable {
+	return []reportTable{{
+		title:   "概览",
+		headers: []string{"指标", "数值"},
+		rows: [][]string{
+			{"统计周期", overview.Period},
+			{"金额货币", currency},
+			{"衣物总数", formatCount(overview.TotalItems)},
+			{"新增衣物", formatCount(overview.NewItems)},
+			{"穿着次数", formatCount(overview.TotalWears)},
+			{"购买支出", formatAmount(overview.TotalSpent)},
+			{"保养费用", formatAmount(overview.MaintenanceCost)},
+			{"每次穿着成本", formatAmount(overview.CostPerWear)},
+		},
+	}}
+}
+
+// clothingTables 衣物章节
+func clothingTables(clothing dto.ReportClothing) []reportTable {
+	categories := reportTable{title: "分类构成", headers: []string{"分类", "数量", "占比(%)"}}
+	for _, item := range clothing.CategoryBreakdown {
+		categories.rows = append(categories.rows, []string{item.CategoryName, formatCount(item.Count), formatAmount(item.Percentage)})
+	}
+	brands := reportTable{title: "品牌构成", headers: []string{"品牌", "数量", "金额", "占比(%)"}}
+	for _, item := range clothing.BrandBreakdown {
+		brands.rows = append(brands.rows, []string{item.BrandName, formatCount(item.Count), formatAmount(item.TotalSpent), formatAmount(item.Percentage)})
+	}
+	colors := reportTable{title: "颜色构成", headers: []string{"颜色", "数量", "占比(%)"}}
+	for _, item := range clothing.ColorBreakdown {
+		colors.rows = append(colors.rows, []string{item.ColorName, formatCount(item.Count), formatAmount(item.Percentage)})
+	}
+
+	return []reportTable{
+		categories,
+		brands,
+		colors,
+		itemSummaryTable("最常穿着", clothing.MostWorn),
+		itemSummaryTable("最少穿着", clothing.LeastWorn),
+		itemSummaryTable("新增衣物", clothing.NewAdditions),
+	}
+}
+
+// spendingTables 支出章节
+func spendingTables(spending dto.ReportSpending) []reportTable {
+	summary := reportTable{
+		title:   "支出概览",
+		headers: []string{"指标", "数值"},
+		rows: [][]string{
+			{"总支出", formatAmount(spending.TotalSpent)},
+			{"平均单价", formatAmount(spending.AverageItemPrice)},
+		},
+	}
+	if spending.MostExpensive != nil && spending.MostExpensive.PurchasePrice != nil {
+		summary.rows = append(summary.rows, []string{"最贵单品",
+			fmt.Sprintf("%s (%s)", spending.MostExpensive.Name, formatAmount(*spending.MostExpensive.PurchasePrice))})
+	}
+
+	// 月度支出按月份排序，其余按金额倒序
+	monthly := reportTable{title: "月度支出", headers: []string{"月份", "金额"}}
+	months := make([]string, 0, len(spending.MonthlyBreakdown))
+	for month := range spending.MonthlyBreakdown {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+	for _, month := range months {
+		monthly.rows = append(monthly.rows, []string{month, formatAmount(spending.MonthlyBreakdown[month])})
+	}
+
+	return []reportTable{
+		summary,
+		monthly,
+		amountTable("分类支出", "分类", spending.CategorySpending),
+		amountTable("品牌支出", "品牌", spending.BrandSpending),
+	}
+}
+
+// wearTables 穿着章节
+func wearTables(wear dto.ReportWear) []reportTable {
+	weather := make(map[string]int64, len(wear.WearsByWeather))
+	for key, count := range wear.WearsByWeather {
+		weather[string(key)] = count
+	}
+
+	return []reportTable{
+		{
+			title:   "穿着概览",
+			headers: []string{"指标", "数值"},
+			rows: [][]string{
+				{"穿着次数", formatCount(wear.TotalWears)},
+				{"平均每件穿着次数", formatAmount(wear.AveragePerItem)},
+				{"平均舒适度", formatAmount(wear.ComfortAnalysis.AverageComfort)},
+				{"平均风格评分", formatAmount(wear.ComfortAnalysis.AverageStyle)},
+				{"平均得体度", formatAmount(wear.ComfortAnalysis.AverageAppropriateness)},
+			},
+		},
+		countTable("按分类穿着", "分类", wear.WearsByCategory),
+		countTable("按场合穿着", "场合", wear.WearsByOccasion),
+		countTable("按天气穿着", "天气", weather),
+	}
+}
+
+// maintenanceTables 保养章节
+func maintenanceTables(maintenance dto.ReportMaintenance) []reportTable {
+	costByType := make(map[string]float64, len(maintenance.CostByType))
+	for key, cost := range maintenance.CostByType {
+		costByType[maintenanceLabel(key)] += cost
+	}
+
+	upcoming := reportTable{title: "即将到期的保养", headers: []string{"衣物", "保养类型", "建议日期", "优先级"}}
+	for _, task := range maintenance.UpcomingTasks {
+		upcoming.rows = append(upcoming.rows, []string{task.ClothingItemName, maintenanceLabel(task.MaintenanceType),
+			task.NextMaintenanceDate.Format(reportDateLayout), task.Priority})
+	}
+	overdue := reportTable{title: "已过期的保养", headers: []string{"衣物", "保养类型", "建议日期", "逾期天数"}}
+	for _, task := range maintenance.OverdueTasks {
+		overdue.rows = append(overdue.rows, []string{task.ClothingItemName, maintenanceLabel(task.MaintenanceType),
+			task.NextMaintenanceDate.Format(reportDateLayout), fmt.Sprintf("%d", task.DaysOverdue)})
+	}
+
+	return []reportTable{
+		{
+			title:   "保养概览",
+			headers: []string{"指标", "数值"},
+			rows: [][]string{
+				{"保养次数", formatCount(maintenance.MaintenanceCount)},
+				{"保养费用", formatAmount(maintenance.TotalCost)},
+			},
+		},
+		amountTable("按类型保养费用", "保养类型", costByType),
+		upcoming,
+		overdue,
+	}
+}
+
+// itemSummaryTable 衣物列表表格
+func itemSummaryTable(title string, items []dto.ClothingItemSummary) reportTable {
+	table := reportTable{title: title, headers: []string{"名称", "分类", "品牌", "颜色", "穿着次数"}}
+	for _, item := range items {
+		table.rows = append(table.rows, []string{item.Name, item.CategoryName, item.Brand, item.Color, fmt.Sprintf("%d", item.WearCount)})
+	}
+	return table
+}
+
+// amountTable 按金额倒序的两列表格
+func amountTable(title, keyHeader string, values map[string]float64) reportTable {
+	table := reportTable{title: title, headers: []string{keyHeader, "金额"}}
+	for _, key := range sortedKeys(values, func(a, b string) bool { return values[a] > values[b] }) {
+		table.rows = append(table.rows, []string{key, formatAmount(values[key])})
+	}
+	return table
+}
+
+// countTable 按次数倒序的两列表格
+func countTable(title, keyHeader string, values map[string]int64) reportTable {
+	table := reportTable{title: title, headers: []string{keyHeader, "次数"}}
+	for _, key := range sortedKeys(values, func(a, b string) bool { return values[a] > values[b] }) {
+		table.rows = append(table.rows, []string{key, formatCount(values[key])})
+	}
+	return table
+}
+
+// sortedKeys 按指定规则排序map的键，规则相同时按键排序
+func sortedKeys[V any](values map[string]V, less func(a, b string) bool) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if less(keys[i], keys[j]) {
+			return true
+		}
+		if less(keys[j], keys[i]) {
+			return false
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// maintenanceLabel 保养类型的中文名称
+func maintenanceLabel(maintenanceType string) string {
+	if label, exists := maintenanceTypeLabels[api.MaintenanceType(maintenanceType)]; exists {
+		return label
+	}
+	return maintenanceType
+}
+
+// formatAmount 格式化金额和比例
+func formatAmount(value float64) string {
+	return fmt.Sprintf("%.2f", value)
+}
+
+// formatCount 格式化数量
+func formatCount(value int64) string {
+	return fmt.Sprintf("%d", value)
+}
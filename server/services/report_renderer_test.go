@@ -0,0 +1,64 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/utils"
+)
+
+func TestRenderReportXLSX(t *testing.T) {
+	data := &dto.ReportData{
+		Currency: "CNY",
+		Overview: &dto.ReportOverview{Period: "2024年05月", TotalItems: 3},
+		Spending: &dto.ReportSpending{TotalSpent: 199.5},
+	}
+
+	tests := []struct {
+		name       string
+		sections   []api.ReportSection
+		wantSheets []string
+		wantRows   []int
+	}{
+		{
+			name:       "每个章节一张工作表",
+			sections:   []api.ReportSection{api.ReportSectionOverview, api.ReportSectionSpending},
+			wantSheets: []string{"概览", "支出"},
+			// 概览：标题、表头和8行数据；支出：4张表格，各有标题和表头，之间以空行分隔
+			wantRows: []int{10, 13},
+		},
+		{
+			name:       "数据中未包含的章节为空工作表",
+			sections:   []api.ReportSection{api.ReportSectionWear},
+			wantSheets: []string{"穿着"},
+			wantRows:   []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := renderReport(api.ReportFormatXLSX, api.ReportTypeMonthly, analyticsPeriod{}, tt.sections, data, time.Now())
+			if err != nil {
+				t.Fatalf("renderReport() error = %v", err)
+			}
+			sheets, err := utils.ReadXLSX(content)
+			if err != nil {
+				t.Fatalf("ReadXLSX() error = %v", err)
+			}
+			var names []string
+			var rows []int
+			for _, sheet := range sheets {
+				names = append(names, sheet.Name)
+				rows = append(rows, len(sheet.Rows))
+			}
+			if !reflect.DeepEqual(names, tt.wantSheets) {
+				t.Errorf("sheets = %v, want %v", names, tt.wantSheets)
+			}
+			if !reflect.DeepEqual(rows, tt.wantRows) {
+				t.Errorf("rows = %v, want %v", rows, tt.wantRows)
+			}
+		})
+	}
+}
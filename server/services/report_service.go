@@ -0,0 +1,722 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
+	"what-to-wear/server/config"
+	"what-to-wear/server/models"
+	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
+)
+
+const (
+	reportRankingLimit      = 10
+	reportUpcomingDays      = 30
+	reportCleanupBatchSize  = 100
+	defaultReportListLimit  = 20
+	reportDateLayout        = "2006-01-02"
+	reportMonthLayout       = "2006-01"
+	reportDownloadURLFormat = "/api/reports/%d/download"
+)
+
+// ReportService 衣橱报告服务接口
+type ReportService interface {
+	// 同步生成报告数据
+	GetReportData(ctx context.Context, userID uint, req *dto.ReportDTO) (*dto.ReportData, error)
+
+	// 报告任务管理
+	CreateReport(ctx context.Context, userID uint, req *dto.ReportDTO) (*dto.ReportJobDTO, error)
+	GetReports(ctx context.Context, userID uint, limit int) ([]dto.ReportJobDTO, error)
+	GetReport(ctx context.Context, userID, jobID uint) (*dto.ReportJobDTO, error)
+	// 获取可下载的报告任务，返回的任务包含文件路径
+	GetReportFile(ctx context.Context, userID, jobID uint) (*models.ReportJob, error)
+	DeleteReport(ctx context.Context, userID, jobID uint) error
+
+	// 生成任务对应的报告文件（由后台任务调用）
+	ProcessReport(ctx context.Context, job *models.ReportJob) error
+	// 删除过期的报告文件和任务，返回删除数量
+	CleanupExpired(ctx context.Context) (int, error)
+}
+
+// reportService 衣橱报告服务实现
+type reportService struct {
+	reportJobRepo        repositories.ReportJobRepository
+	clothingItemRepo     repositories.ClothingItemRepository
+	clothingCategoryRepo repositories.ClothingCategoryRepository
+	wearRecordRepo       repositories.WearRecordRepository
+	purchaseRecordRepo   repositories.PurchaseRecordRepository
+	maintenanceRepo      repositories.MaintenanceRecordRepository
+	outfitRepo           repositories.OutfitRepository
+	maintenanceService   MaintenanceService
+	wearRecordService    WearRecordService
+	currencyService      CurrencyService
+	outputDir            string
+	retention            time.Duration
+}
+
+// NewReportService 创建衣橱报告服务实例
+func NewReportService(
+	cfg *config.Config,
+	reportJobRepo repositories.ReportJobRepository,
+	clothingItemRepo repositories.ClothingItemRepository,
+	clothingCategoryRepo repositories.ClothingCategoryRepository,
+	wearRecordRepo repositories.WearRecordRepository,
+	purchaseRecordRepo repositories.PurchaseRecordRepository,
+	maintenanceRepo repositories.MaintenanceRecordRepository,
+	outfitRepo repositories.OutfitRepository,
+	maintenanceService MaintenanceService,
+	wearRecordService WearRecordService,
+	currencyService CurrencyService,
+) ReportService {
+	return &reportService{
+		reportJobRepo:        reportJobRepo,
+		clothingItemRepo:     clothingItemRepo,
+		clothingCategoryRepo: clothingCategoryRepo,
+		wearRecordRepo:       wearRecordRepo,
+		purchaseRecordRepo:   purchaseRecordRepo,
+		maintenanceRepo:      maintenanceRepo,
+		outfitRepo:           outfitRepo,
+		maintenanceService:   maintenanceService,
+		wearRecordService:    wearRecordService,
+		currencyService:      currencyService,
+		outputDir:            cfg.Report.OutputDir,
+		retention:            time.Duration(cfg.Report.RetentionDays) * 24 * time.Hour,
+	}
+}
+
+// reportRequest 校验后的报告参数
+type reportRequest struct {
+	reportType api.ReportType
+	format     api.ReportFormat
+	sections   []api.ReportSection
+	period     analyticsPeriod
+}
+
+// spendingEntry 一次购买支出，没有购买记录的衣物使用衣物价格和购买日期
+type spendingEntry struct {
+	item  itemAnalytics
+	price float64
+	date  time.Time
+}
+
+// GetReportData 同步生成报告数据
+func (s *reportService) GetReportData(ctx context.Context, userID uint, req *dto.ReportDTO) (*dto.ReportData, error) {
+	request, err := resolveReportRequest(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return s.buildReportData(ctx, userID, request.period, request.sections)
+}
+
+// CreateReport 创建报告生成任务
+func (s *reportService) CreateReport(ctx context.Context, userID uint, req *dto.ReportDTO) (*dto.ReportJobDTO, error) {
+	request, err := resolveReportRequest(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	sections := make([]string, 0, len(request.sections))
+	for _, section := range request.sections {
+		sections = append(sections, string(section))
+	}
+	job := &models.ReportJob{
+		UserID:    userID,
+		Type:      request.reportType,
+		Format:    request.format,
+		Sections:  sections,
+		StartDate: request.period.start,
+		EndDate:   request.period.end,
+		Status:    api.JobStatusPending,
+	}
+	if err := s.reportJobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("创建报告任务失败: %w", err)
+	}
+
+	return toReportJobDTO(job, time.Now()), nil
+}
+
+// GetReports 获取用户的报告任务列表
+func (s *reportService) GetReports(ctx context.Context, userID uint, limit int) ([]dto.ReportJobDTO, error) {
+	if limit <= 0 {
+		limit = defaultReportListLimit
+	}
+	jobs, err := s.reportJobRepo.GetByUserID(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("获取报告列表失败: %w", err)
+	}
+
+	now := time.Now()
+	result := make([]dto.ReportJobDTO, 0, len(jobs))
+	for i := range jobs {
+		result = append(result, *toReportJobDTO(&jobs[i], now))
+	}
+	return result, nil
+}
+
+// GetReport 获取报告任务详情
+func (s *reportService) GetReport(ctx context.Context, userID, jobID uint) (*dto.ReportJobDTO, error) {
+	job, err := s.getOwnedReport(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return toReportJobDTO(job, time.Now()), nil
+}
+
+// GetReportFile 获取可下载的报告任务
+func (s *reportService) GetReportFile(ctx context.Context, userID, jobID uint) (*models.ReportJob, error) {
+	job, err := s.getOwnedReport(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case job.Status == api.JobStatusFailed:
+		return nil, errors.ErrConflict("报告生成失败", job.Error)
+	case job.Status != api.JobStatusCompleted:
+		return nil, errors.ErrConflict("报告尚未生成完成")
+	case !job.IsDownloadable(time.Now()):
+		return nil, errors.ErrNotFound("报告文件已过期")
+	}
+	if _, err := os.Stat(job.FilePath); err != nil {
+		return nil, errors.ErrNotFound("报告文件不存在")
+	}
+	return job, nil
+}
+
+// DeleteReport 删除报告任务及其文件
+func (s *reportService) DeleteReport(ctx context.Context, userID, jobID uint) error {
+	job, err := s.getOwnedReport(ctx, userID, jobID)
+	if err != nil {
+		return err
+	}
+	if job.Status == api.JobStatusProcessing {
+		return errors.ErrConflict("报告正在生成中，请稍后再删除")
+	}
+
+	if err := removeReportFile(job.FilePath); err != nil {
+		return fmt.Errorf("删除报告文件失败: %w", err)
+	}
+	if err := s.reportJobRepo.Delete(ctx, job.ID); err != nil {
+		return fmt.Errorf("删除报告任务失败: %w", err)
+	}
+	return nil
+}
+
+// ProcessReport 生成报告文件并更新任务状态，失败时记录原因
+func (s *reportService) ProcessReport(ctx context.Context, job *models.ReportJob) error {
+	if err := s.generateReportFile(ctx, job); err != nil {
+		job.Status = api.JobStatusFailed
+		job.Error = err.Error()
+		if updateErr := s.reportJobRepo.Update(ctx, job); updateErr != nil {
+			return fmt.Errorf("更新报告任务失败: %w", updateErr)
+		}
+		return err
+	}
+
+	now := time.Now()
+	job.Status = api.JobStatusCompleted
+	job.Error = ""
+	job.CompletedAt = &now
+	if s.retention > 0 {
+		expiresAt := now.Add(s.retention)
+		job.ExpiresAt = &expiresAt
+	}
+	if err := s.reportJobRepo.Update(ctx, job); err != nil {
+		return fmt.Errorf("更新报告任务失败: %w", err)
+	}
+	return nil
+}
+
+// CleanupExpired 删除过期的报告
+func (s *reportService) CleanupExpired(ctx context.Context) (int, error) {
+	jobs, err := s.reportJobRepo.GetExpired(ctx, time.Now(), reportCleanupBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("获取过期报告失败: %w", err)
+	}
+
+	removed := 0
+	for _, job := range jobs {
+		if err := removeReportFile(job.FilePath); err != nil {
+			return removed, fmt.Errorf("删除报告文件失败: %w", err)
+		}
+		if err := s.reportJobRepo.Delete(ctx, job.ID); err != nil {
+			return removed, fmt.Errorf("删除报告任务失败: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// generateReportFile 生成报告数据并按格式写入文件
+func (s *reportService) generateReportFile(ctx context.Context, job *models.ReportJob) error {
+	period := analyticsPeriod{name: string(job.Type), start: job.StartDate, end: job.EndDate}
+	sections := make([]api.ReportSection, 0, len(job.Sections))
+	for _, section := range job.Sections {
+		sections = append(sections, api.ReportSection(section))
+	}
+	data, err := s.buildReportData(ctx, job.UserID, period, sections)
+	if err != nil {
+		return err
+	}
+
+	content, err := renderReport(job.Format, job.Type, period, sections, data, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fileName := fmt.Sprintf("wardrobe-report-%s-%s-%s.%s", job.Type,
+		period.start.Format("20060102"), period.end.Format("20060102"), job.Format)
+	dir := filepath.Join(s.outputDir, fmt.Sprintf("%d", job.UserID))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("创建报告目录失败: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%d-%s", job.ID, fileName))
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("写入报告文件失败: %w", err)
+	}
+
+	job.FileName = fileName
+	job.FilePath = path
+	job.FileSize = int64(len(content))
+	return nil
+}
+
+// buildReportData 汇总周期内的衣物、支出、穿着和保养数据，只返回选中的章节
+func (s *reportService) buildReportData(ctx context.Context, userID uint, period analyticsPeriod, sections []api.ReportSection) (*dto.ReportData, error) {
+	items, wears, err := loadItemAnalytics(ctx, s.clothingItemRepo, s.clothingCategoryRepo, s.wearRecordRepo, userID, period)
+	if err != nil {
+		return nil, err
+	}
+	spending, err := loadSpending(ctx, s.purchaseRecordRepo, userID, items, period)
+	if err != nil {
+		return nil, err
+	}
+	maintenances, err := s.maintenanceRepo.GetMaintenanceByDateRange(ctx, userID, period.start, period.end)
+	if err != nil {
+		return nil, fmt.Errorf("获取保养记录失败: %w", err)
+	}
+	currency, err := s.currencyService.GetHomeCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	clothing := buildReportClothing(items, period)
+	spendingData := buildReportSpending(spending)
+	wear, err := s.buildReportWear(ctx, userID, items, wears)
+	if err != nil {
+		return nil, err
+	}
+	maintenance, err := s.buildReportMaintenance(ctx, userID, maintenances)
+	if err != nil {
+		return nil, err
+	}
+
+	// 每次穿着成本：已拥有且有价格的衣物总价 / 截至周期结束的穿着次数
+	var totalCost float64
+	var totalWears int64
+	for _, a := range items {
+		if a.item.Price > 0 {
+			totalCost += a.item.Price
+			totalWears += a.totalWears
+		}
+	}
+	overview := dto.ReportOverview{
+		Period:          reportPeriodLabel(period),
+		TotalItems:      int64(len(items)),
+		NewItems:        int64(len(clothing.NewAdditions)),
+		TotalWears:      int64(len(wears)),
+		TotalSpent:      spendingData.TotalSpent,
+		MaintenanceCost: maintenance.TotalCost,
+		CostPerWear:     roundTo(safeDivide(totalCost, float64(totalWears)), 2),
+	}
+
+	data := &dto.ReportData{Currency: currency}
+	for _, section := range sections {
+		switch section {
+		case api.ReportSectionOverview:
+			data.Overview = &overview
+		case api.ReportSectionClothing:
+			data.Clothing = &clothing
+		case api.ReportSectionSpending:
+			data.Spending = &spendingData
+		case api.ReportSectionWear:
+			data.Wear = &wear
+		case api.ReportSectionMaintenance:
+			data.Maintenance = &maintenance
+		}
+	}
+	return data, nil
+}
+
+// loadSpending 获取周期内的购买支出
+func loadSpending(
+	ctx context.Context,
+	purchaseRecordRepo repositories.PurchaseRecordRepository,
+	userID uint,
+	items []itemAnalytics,
+	period analyticsPeriod,
+) ([]spendingEntry, error) {
+	records, err := purchaseRecordRepo.GetByUserID(ctx, userID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("获取购买记录失败: %w", err)
+	}
+	purchases := make(map[uint]models.PurchaseRecord, len(records))
+	for _, record := range records {
+		purchases[record.ClothingItemID] = record
+	}
+
+	entries := make([]spendingEntry, 0)
+	for _, a := range items {
+		entry := spendingEntry{item: a, price: a.item.Price, date: itemAcquiredAt(a.item)}
+		if record, exists := purchases[a.item.ID]; exists {
+			entry.price = record.HomePrice
+			entry.date = record.PurchaseDate
+		}
+		if entry.price <= 0 || entry.date.Before(period.start) || entry.date.After(period.end) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// buildReportWear 统计周期内的穿着情况，场合和天气取自穿着所属的穿搭
+func (s *reportService) buildReportWear(ctx context.Context, userID uint, items []itemAnalytics, wears []models.WearRecord) (dto.ReportWear, error) {
+	outfits, err := s.outfitRepo.GetByUserID(ctx, userID, 0, 0)
+	if err != nil {
+		return dto.ReportWear{}, fmt.Errorf("获取穿搭失败: %w", err)
+	}
+	outfitMap := make(map[uint]*models.Outfit, len(outfits))
+	for _, outfit := range outfits {
+		outfitMap[outfit.ID] = outfit
+	}
+
+	comfort, err := s.wearRecordService.GetComfortAnalysis(userID)
+	if err != nil {
+		return dto.ReportWear{}, fmt.Errorf("获取舒适度分析失败: %w", err)
+	}
+
+	wear := dto.ReportWear{
+		TotalWears:      int64(len(wears)),
+		AveragePerItem:  roundTo(safeDivide(float64(len(wears)), float64(len(items))), 2),
+		WearsByCategory: make(map[string]int64),
+		WearsByOccasion: make(map[string]int64),
+		WearsByWeather:  make(map[api.WeatherType]int64),
+		ComfortAnalysis: *comfort,
+	}
+	for _, g := range groupItemAnalytics(items, func(a itemAnalytics) string { return a.categoryName }) {
+		if g.periodWears > 0 {
+			wear.WearsByCategory[g.label] = g.periodWears
+		}
+	}
+	for _, record := range wears {
+		if record.OutfitID == nil {
+			continue
+		}
+		outfit, exists := outfitMap[*record.OutfitID]
+		if !exists {
+			continue
+		}
+		if outfit.Occasion != "" {
+			wear.WearsByOccasion[outfit.Occasion]++
+		}
+		if outfit.Weather != nil {
+			wear.WearsByWeather[*outfit.Weather]++
+		}
+	}
+	return wear, nil
+}
+
+// buildReportMaintenance 统计周期内的保养费用，并附带当前待办的保养提醒
+func (s *reportService) buildReportMaintenance(ctx context.Context, userID uint, records []models.MaintenanceRecord) (dto.ReportMaintenance, error) {
+	maintenance := dto.ReportMaintenance{
+		MaintenanceCount: int64(len(records)),
+		CostByType:       make(map[string]float64),
+	}
+	for _, record := range records {
+		maintenance.TotalCost += record.HomeCost
+		maintenance.CostByType[string(record.MaintenanceType)] += record.HomeCost
+	}
+	maintenance.TotalCost = roundTo(maintenance.TotalCost, 2)
+	for key, cost := range maintenance.CostByType {
+		maintenance.CostByType[key] = roundTo(cost, 2)
+	}
+
+	var err error
+	if maintenance.UpcomingTasks, err = s.maintenanceService.GetUpcomingMaintenance(ctx, userID, reportUpcomingDays); err != nil {
+		return maintenance, err
+	}
+	if maintenance.OverdueTasks, err = s.maintenanceService.GetOverdueMaintenance(ctx, userID); err != nil {
+		return maintenance, err
+	}
+	return maintenance, nil
+}
+
+// getOwnedReport 获取属于用户的报告任务
+func (s *reportService) getOwnedReport(ctx context.Context, userID, jobID uint) (*models.ReportJob, error) {
+	job, err := s.reportJobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("报告不存在")
+		}
+		return nil, fmt.Errorf("获取报告任务失败: %w", err)
+	}
+	if job.UserID != userID {
+		return nil, errors.ErrForbidden("无权访问此报告")
+	}
+	return job, nil
+}
+
+// resolveReportRequest 校验报告参数并计算报告周期
+// monthly/yearly 取开始日期所在的月份或年份，未指定时为上一个完整的月份或年份
+func resolveReportRequest(req *dto.ReportDTO, now time.Time) (reportRequest, error) {
+	request := reportRequest{
+		reportType: api.ReportType(req.Type),
+		format:     api.ReportFormat(req.Format),
+	}
+	if !request.reportType.IsValid() {
+		return request, errors.ErrInvalidRequest("无效的报告类型")
+	}
+	if request.format == "" {
+		request.format = api.ReportFormatJSON
+	}
+	if !request.format.IsValid() {
+		return request, errors.ErrInvalidRequest("无效的报告格式")
+	}
+
+	request.sections = api.AllReportSections
+	if len(req.Sections) > 0 {
+		selected := make(map[api.ReportSection]bool, len(req.Sections))
+		for _, section := range req.Sections {
+			if !api.ReportSection(section).IsValid() {
+				return request, errors.ErrInvalidRequest(fmt.Sprintf("无效的报告章节: %s", section))
+			}
+			selected[api.ReportSection(section)] = true
+		}
+		// 按固定顺序输出章节
+		request.sections = make([]api.ReportSection, 0, len(selected))
+		for _, section := range api.AllReportSections {
+			if selected[section] {
+				request.sections = append(request.sections, section)
+			}
+		}
+	}
+
+	startOfDay := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location())
+	}
+	var start, end time.Time
+	switch request.reportType {
+	case api.ReportTypeMonthly:
+		ref := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0)
+		if !req.StartDate.IsZero() {
+			ref = req.StartDate
+		}
+		start = time.Date(ref.Year(), ref.Month(), 1, 0, 0, 0, 0, now.Location())
+		end = start.AddDate(0, 1, 0).Add(-time.Second)
+	case api.ReportTypeYearly:
+		year := now.Year() - 1
+		if !req.StartDate.IsZero() {
+			year = req.StartDate.Year()
+		}
+		start = time.Date(year, 1, 1, 0, 0, 0, 0, now.Location())
+		end = start.AddDate(1, 0, 0).Add(-time.Second)
+	case api.ReportTypeCustom:
+		if req.StartDate.IsZero() || req.EndDate.IsZero() {
+			return request, errors.ErrInvalidRequest("自定义报告必须指定开始和结束日期")
+		}
+		start = startOfDay(req.StartDate)
+		// 结束日期包含当天
+		end = startOfDay(req.EndDate).AddDate(0, 0, 1).Add(-time.Second)
+		if start.After(end) {
+			return request, errors.ErrInvalidRequest("开始日期不能晚于结束日期")
+		}
+	}
+	if start.After(now) {
+		return request, errors.ErrInvalidRequest("报告周期不能晚于当前时间")
+	}
+
+	request.period = analyticsPeriod{name: string(request.reportType), start: start, end: end}
+	return request, nil
+}
+
+// reportPeriodLabel 报告周期的显示名称
+func reportPeriodLabel(period analyticsPeriod) string {
+	switch api.ReportType(period.name) {
+	case api.ReportTypeMonthly:
+		return period.start.Format("2006年01月")
+	case api.ReportTypeYearly:
+		return period.start.Format("2006年")
+	default:
+		return period.start.Format(reportDateLayout) + " 至 " + period.end.Format(reportDateLayout)
+	}
+}
+
+// buildReportClothing 统计衣物构成、周期内穿着排行和新增衣物
+func buildReportClothing(items []itemAnalytics, period analyticsPeriod) dto.ReportClothing {
+	total := float64(len(items))
+	clothing := dto.ReportClothing{
+		CategoryBreakdown: []dto.CategoryStatsItem{},
+		BrandBreakdown:    []dto.BrandStatsItem{},
+		ColorBreakdown:    []dto.ColorStatsItem{},
+		MostWorn:          []dto.ClothingItemSummary{},
+		LeastWorn:         []dto.ClothingItemSummary{},
+		NewAdditions:      []dto.ClothingItemSummary{},
+	}
+
+	byCount := func(g analyticsGroup) float64 { return float64(g.items) }
+	categories := groupItemAnalytics(items, func(a itemAnalytics) string { return a.categoryName })
+	sortGroups(categories, byCount)
+	for _, g := range categories {
+		clothing.CategoryBreakdown = append(clothing.CategoryBreakdown, dto.CategoryStatsItem{
+			CategoryName: g.label,
+			Count:        int64(g.items),
+			Percentage:   roundTo(safeDivide(float64(g.items), total)*100, 2),
+		})
+	}
+	brands := groupItemAnalytics(items, func(a itemAnalytics) string { return a.item.Brand })
+	sortGroups(brands, byCount)
+	for _, g := range brands {
+		clothing.BrandBreakdown = append(clothing.BrandBreakdown, dto.BrandStatsItem{
+			BrandName:  g.label,
+			Count:      int64(g.items),
+			TotalSpent: roundTo(g.cost, 2),
+			Percentage: roundTo(safeDivide(float64(g.items), total)*100, 2),
+		})
+	}
+	colors := groupItemAnalytics(items, func(a itemAnalytics) string { return a.item.Color })
+	sortGroups(colors, byCount)
+	for _, g := range colors {
+		clothing.ColorBreakdown = append(clothing.ColorBreakdown, dto.ColorStatsItem{
+			ColorName:  g.label,
+			Count:      int64(g.items),
+			Percentage: roundTo(safeDivide(float64(g.items), total)*100, 2),
+		})
+	}
+
+	// 排行中的穿着次数为周期内的穿着次数
+	sorted := make([]itemAnalytics, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].periodWears > sorted[j].periodWears })
+	for _, a := range sorted {
+		if a.periodWears == 0 || len(clothing.MostWorn) >= reportRankingLimit {
+			break
+		}
+		clothing.MostWorn = append(clothing.MostWorn, reportItemSummary(a, a.periodWears))
+	}
+	for i := len(sorted) - 1; i >= 0 && len(clothing.LeastWorn) < reportRankingLimit; i-- {
+		clothing.LeastWorn = append(clothing.LeastWorn, reportItemSummary(sorted[i], sorted[i].periodWears))
+	}
+
+	for _, a := range items {
+		acquired := itemAcquiredAt(a.item)
+		if !acquired.Before(period.start) && !acquired.After(period.end) {
+			clothing.NewAdditions = append(clothing.NewAdditions, reportItemSummary(a, a.periodWears))
+		}
+	}
+
+	return clothing
+}
+
+// buildReportSpending 统计周期内的支出
+func buildReportSpending(entries []spendingEntry) dto.ReportSpending {
+	spending := dto.ReportSpending{
+		MonthlyBreakdown: make(map[string]float64),
+		CategorySpending: make(map[string]float64),
+		BrandSpending:    make(map[string]float64),
+	}
+
+	var mostExpensive *spendingEntry
+	for i, entry := range entries {
+		spending.TotalSpent += entry.price
+		spending.MonthlyBreakdown[entry.date.Format(reportMonthLayout)] += entry.price
+
+		category := entry.item.categoryName
+		if category == "" {
+			category = unknownAnalyticsLabel
+		}
+		spending.CategorySpending[category] += entry.price
+		brand := entry.item.item.Brand
+		if brand == "" {
+			brand = unknownAnalyticsLabel
+		}
+		spending.BrandSpending[brand] += entry.price
+
+		if mostExpensive == nil || entry.price > mostExpensive.price {
+			mostExpensive = &entries[i]
+		}
+	}
+
+	for _, breakdown := range []map[string]float64{spending.MonthlyBreakdown, spending.CategorySpending, spending.BrandSpending} {
+		for key, value := range breakdown {
+			breakdown[key] = roundTo(value, 2)
+		}
+	}
+	spending.AverageItemPrice = roundTo(safeDivide(spending.TotalSpent, float64(len(entries))), 2)
+	spending.TotalSpent = roundTo(spending.TotalSpent, 2)
+	if mostExpensive != nil {
+		summary := reportItemSummary(mostExpensive.item, mostExpensive.item.periodWears)
+		summary.PurchasePrice = &mostExpensive.price
+		spending.MostExpensive = &summary
+	}
+
+	return spending
+}
+
+// reportItemSummary 构建报告中的衣物摘要
+func reportItemSummary(a itemAnalytics, wearCount int64) dto.ClothingItemSummary {
+	summary := buildCostPerWearItem(a).ClothingItemSummary
+	summary.WearCount = int(wearCount)
+	return summary
+}
+
+// itemAcquiredAt 衣物的获得时间，未填写购买日期时使用创建时间
+func itemAcquiredAt(item models.ClothingItem) time.Time {
+	if item.PurchaseDate != nil {
+		return *item.PurchaseDate
+	}
+	return item.CreatedAt
+}
+
+// toReportJobDTO 将报告任务转换为DTO
+func toReportJobDTO(job *models.ReportJob, now time.Time) *dto.ReportJobDTO {
+	result := &dto.ReportJobDTO{
+		ID:          job.ID,
+		Type:        job.Type,
+		Format:      job.Format,
+		Sections:    job.Sections,
+		StartDate:   job.StartDate,
+		EndDate:     job.EndDate,
+		Status:      job.Status,
+		FileName:    job.FileName,
+		FileSize:    job.FileSize,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		CompletedAt: job.CompletedAt,
+		ExpiresAt:   job.ExpiresAt,
+	}
+	if job.IsDownloadable(now) {
+		result.DownloadURL = fmt.Sprintf(reportDownloadURLFormat, job.ID)
+	}
+	return result
+}
+
+// removeReportFile 删除报告文件，文件不存在时忽略
+func removeReportFile(path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"what-to-wear/server/config"
+	"what-to-wear/server/logger"
+	"what-to-wear/server/repositories"
+)
+
+// ReportWorker 报告生成后台任务接口
+type ReportWorker interface {
+	// 启动后台处理，未启用或重复调用时无效
+	Start()
+	// 停止后台处理并等待当前任务结束
+	Stop()
+	// 处理一批排队中的任务并清理过期报告，返回处理的任务数量
+	RunOnce(ctx context.Context) (int, error)
+}
+
+// reportWorker 报告生成后台任务实现
+type reportWorker struct {
+	reportJobRepo     repositories.ReportJobRepository
+	reportService     ReportService
+	enabled           bool
+	interval          time.Duration
+	batchSize         int
+	processingTimeout time.Duration
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewReportWorker 创建报告生成后台任务实例
+func NewReportWorker(
+	cfg *config.Config,
+	reportJobRepo repositories.ReportJobRepository,
+	reportService ReportService,
+) ReportWorker {
+	return &reportWorker{
+		reportJobRepo:     reportJobRepo,
+		reportService:     reportService,
+		enabled:           cfg.Report.WorkerEnabled,
+		interval:          time.Duration(cfg.Report.PollInterval) * time.Second,
+		batchSize:         cfg.Report.BatchSize,
+		processingTimeout: time.Duration(cfg.Report.ProcessingTimeout) * time.Second,
+	}
+}
+
+// Start 启动后台处理
+func (w *reportWorker) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.enabled || w.cancel != nil || w.interval <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go w.loop(ctx, w.done)
+	logger.GetLogger().Info("Report worker started", logger.Fields{
+		"interval":   w.interval.String(),
+		"batch_size": w.batchSize,
+	})
+}
+
+// Stop 停止后台处理
+func (w *reportWorker) Stop() {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// loop 按间隔轮询任务，启动时立即执行一次
+func (w *reportWorker) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			logger.GetLogger().ErrorWithErr(err, "Report worker run failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 处理一批排队中的任务
+func (w *reportWorker) RunOnce(ctx context.Context) (int, error) {
+	log := logger.GetLogger()
+
+	// 进程异常退出时遗留的处理中任务重新排队
+	if w.processingTimeout > 0 {
+		requeued, err := w.reportJobRepo.RequeueStale(ctx, time.Now().Add(-w.processingTimeout))
+		if err != nil {
+			return 0, fmt.Errorf("重新排队超时报告任务失败: %w", err)
+		}
+		if requeued > 0 {
+			log.Warn("Requeued stale report jobs", logger.Fields{"count": requeued})
+		}
+	}
+
+	jobs, err := w.reportJobRepo.ClaimPending(ctx, w.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("领取报告任务失败: %w", err)
+	}
+
+	processed := 0
+	for i := range jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		job := &jobs[i]
+		if err := w.reportService.ProcessReport(ctx, job); err != nil {
+			log.WarnWithErr(err, "Failed to generate report", logger.Fields{
+				"report_id": job.ID,
+				"user_id":   job.UserID,
+				"format":    job.Format,
+			})
+			continue
+		}
+		processed++
+		log.Info("Report generated", logger.Fields{
+			"report_id": job.ID,
+			"user_id":   job.UserID,
+			"format":    job.Format,
+			"file_size": job.FileSize,
+		})
+	}
+
+	if removed, err := w.reportService.CleanupExpired(ctx); err != nil {
+		return processed, err
+	} else if removed > 0 {
+		log.Info("Expired reports removed", logger.Fields{"count": removed})
+	}
+
+	return processed, nil
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"what-to-wear/server/config"
+)
+
+// 存储提供商名称，与 Attachment.StorageProvider 一致
+const (
+	StorageProviderLocal = "local"
+	StorageProviderOSS   = "oss"
+)
+
+// ErrStorageObjectNotFound 存储中不存在指定对象
+var ErrStorageObjectNotFound = stderrors.New("存储对象不存在")
+
+// StorageObject 存储对象的元信息
+type StorageObject struct {
+	Size        int64
+	ContentType string // 本地存储不记录内容类型，为空
+}
+
+// Storage 附件文件存储接口，key 为以 / 分隔的相对路径
+type Storage interface {
+	// 存储提供商名称
+	Name() string
+	// 存储桶名称，本地存储为空
+	Bucket() string
+
+	// 写入对象，已存在时覆盖
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	// 读取对象，调用方负责关闭，对象不存在时返回 ErrStorageObjectNotFound
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// 删除对象，对象不存在时忽略
+	Delete(ctx context.Context, key string) error
+	// 获取对象元信息，对象不存在时返回 ErrStorageObjectNotFound
+	Stat(ctx context.Context, key string) (*StorageObject, error)
+	// 生成限时有效的下载链接
+	SignedURL(ctx context.Context, key string, expires time.Duration) (string, error)
+	// 生成限时有效的上传链接，客户端使用 PUT 方法直接上传，请求需带上相同的 Content-Type
+	SignedUploadURL(ctx context.Context, key, contentType string, expires time.Duration) (string, error)
+}
+
+// NewStorage 根据配置创建附件存储
+func NewStorage(cfg *config.Config) (Storage, error) {
+	switch strings.ToLower(cfg.Storage.Provider) {
+	case StorageProviderLocal:
+		// 签名密钥泄露后可伪造任意附件的下载链接，不允许使用默认值或复用 JWT 密钥
+		if cfg.Storage.SigningSecret == "" {
+			return nil, fmt.Errorf("本地存储必须配置 STORAGE_SIGNING_SECRET")
+		}
+		if cfg.Storage.SigningSecret == cfg.JWT.Secret {
+			return nil, fmt.Errorf("STORAGE_SIGNING_SECRET 不能与 JWT_SECRET 相同")
+		}
+		return NewLocalStorage(cfg.Storage.LocalDir, cfg.Storage.SigningSecret), nil
+	case StorageProviderOSS:
+		return NewOSSStorage(cfg), nil
+	default:
+		return nil, fmt.Errorf("不支持的存储提供商: %s", cfg.Storage.Provider)
+	}
+}
+
+// cleanStorageKey 规范化对象键，拒绝绝对路径和跳出根目录的路径
+func cleanStorageKey(key string) (string, error) {
+	cleaned := path.Clean(strings.ReplaceAll(key, "\\", "/"))
+	if key == "" || strings.HasPrefix(cleaned, "/") || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("无效的存储路径: %s", key)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+	"what-to-wear/server/api/errors"
+)
+
+// localStorageURLPrefix 本地存储签名链接的路径前缀，由 StorageController 提供下载
+const localStorageURLPrefix = "/api/files/"
+
+// LocalStorage 本地磁盘存储，用于开发和测试环境，无需云存储账号
+type LocalStorage interface {
+	Storage
+	// 校验下载链接的参数，返回文件在磁盘上的路径
+	Resolve(key string, expires int64, signature string) (string, error)
+	// 校验上传链接的参数，返回规范化后的对象键
+	ResolveUpload(key, contentType string, expires int64, signature string) (string, error)
+}
+
+// localStorage 本地磁盘存储实现
+type localStorage struct {
+	root   string
+	secret []byte
+}
+
+// NewLocalStorage 创建本地磁盘存储，root 为存储根目录，secret 用于签名下载链接
+func NewLocalStorage(root, secret string) LocalStorage {
+	return &localStorage{
+		root:   root,
+		secret: []byte(secret),
+	}
+}
+
+// Name 存储提供商名称
+func (s *localStorage) Name() string {
+	return StorageProviderLocal
+}
+
+// Bucket 本地存储没有存储桶
+func (s *localStorage) Bucket() string {
+	return ""
+}
+
+// Put 写入文件，先写入临时文件再重命名，避免读到不完整的文件
+func (s *localStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return fmt.Errorf("创建存储目录失败: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("创建文件失败: %w", err)
+	}
+	tmpPath := file.Name()
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("保存文件失败: %w", err)
+	}
+	return nil
+}
+
+// Get 读取文件
+func (s *localStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrStorageObjectNotFound
+		}
+		return nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+	return file, nil
+}
+
+// Delete 删除文件
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("删除文件失败: %w", err)
+	}
+	return nil
+}
+
+// Stat 获取文件大小
+func (s *localStorage) Stat(ctx context.Context, key string) (*StorageObject, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrStorageObjectNotFound
+		}
+		return nil, fmt.Errorf("读取文件信息失败: %w", err)
+	}
+	return &StorageObject{Size: info.Size()}, nil
+}
+
+// SignedURL 生成带过期时间和签名的下载链接
+func (s *localStorage) SignedURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return s.signedURL(http.MethodGet, key, "", expires)
+}
+
+// SignedUploadURL 生成带过期时间和签名的上传链接，由 StorageController 接收文件
+func (s *localStorage) SignedUploadURL(ctx context.Context, key, contentType string, expires time.Duration) (string, error) {
+	return s.signedURL(http.MethodPut, key, contentType, expires)
+}
+
+// Resolve 校验下载链接的签名和过期时间
+func (s *localStorage) Resolve(key string, expires int64, signature string) (string, error) {
+	key, err := s.verify(http.MethodGet, key, "", expires, signature)
+	if err != nil {
+		return "", err
+	}
+
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		return "", errors.ErrNotFound("文件不存在")
+	}
+	return filePath, nil
+}
+
+// ResolveUpload 校验上传链接的签名和过期时间
+func (s *localStorage) ResolveUpload(key, contentType string, expires int64, signature string) (string, error) {
+	return s.verify(http.MethodPut, key, contentType, expires, signature)
+}
+
+// signedURL 生成指定请求方法的签名链接
+func (s *localStorage) signedURL(method, key, contentType string, expires time.Duration) (string, error) {
+	key, err := cleanStorageKey(key)
+	if err != nil {
+		return "", err
+	}
+	expiresAt := time.Now().Add(expires).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt, 10))
+	query.Set("signature", s.sign(method, key, contentType, expiresAt))
+	return localStorageURLPrefix + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode(), nil
+}
+
+// verify 校验签名和过期时间，返回规范化后的对象键
+func (s *localStorage) verify(method, key, contentType string, expires int64, signature string) (string, error) {
+	key, err := cleanStorageKey(key)
+	if err != nil {
+		return "", errors.ErrInvalidRequest(err.Error())
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(method, key, contentType, expires))) {
+		return "", errors.ErrForbidden("链接签名无效")
+	}
+	if time.Now().Unix() > expires {
+		return "", errors.ErrForbidden("链接已过期")
+	}
+	return key, nil
+}
+
+// filePath 对象键对应的磁盘路径
+func (s *localStorage) filePath(key string) (string, error) {
+	key, err := cleanStorageKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// sign 计算请求方法、对象键、内容类型和过期时间的签名
+func (s *localStorage) sign(method, key, contentType string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%d", method, key, contentType, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"what-to-wear/server/api/errors"
+)
+
+// parseLocalSignedURL 拆出签名链接中的对象键、过期时间和签名
+func parseLocalSignedURL(t *testing.T, signedURL string) (string, int64, string) {
+	t.Helper()
+	parsed, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expires, err := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimPrefix(parsed.Path, localStorageURLPrefix), expires, parsed.Query().Get("signature")
+}
+
+func TestLocalStorageVerify(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir(), "test-secret").(*localStorage)
+	signedURL, err := storage.SignedUploadURL(context.Background(), "users/1/photo.jpg", "image/jpeg", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, expires, signature := parseLocalSignedURL(t, signedURL)
+	expired := time.Now().Add(-time.Minute).Unix()
+
+	tests := []struct {
+		name        string
+		storage     *localStorage
+		method      string
+		key         string
+		contentType string
+		expires     int64
+		signature   string
+		wantKey     string
+		wantCode    int
+	}{
+		{name: "有效签名", method: http.MethodPut, key: key, contentType: "image/jpeg", expires: expires, signature: signature, wantKey: "users/1/photo.jpg"},
+		{name: "等价路径规范化后通过", method: http.MethodPut, key: "users/1/./photo.jpg", contentType: "image/jpeg", expires: expires, signature: signature, wantKey: "users/1/photo.jpg"},
+		{name: "签名被篡改", method: http.MethodPut, key: key, contentType: "image/jpeg", expires: expires, signature: signature[:len(signature)-1] + "0", wantCode: http.StatusForbidden},
+		{name: "对象键不同", method: http.MethodPut, key: "users/2/photo.jpg", contentType: "image/jpeg", expires: expires, signature: signature, wantCode: http.StatusForbidden},
+		{name: "内容类型不同", method: http.MethodPut, key: key, contentType: "text/html", expires: expires, signature: signature, wantCode: http.StatusForbidden},
+		{name: "上传签名不能用于下载", method: http.MethodGet, key: key, expires: expires, signature: signature, wantCode: http.StatusForbidden},
+		{name: "过期时间被修改", method: http.MethodPut, key: key, contentType: "image/jpeg", expires: expires + 3600, signature: signature, wantCode: http.StatusForbidden},
+		{name: "其他密钥签名", storage: NewLocalStorage(t.TempDir(), "other-secret").(*localStorage), method: http.MethodPut, key: key, contentType: "image/jpeg", expires: expires, signature: signature, wantCode: http.StatusForbidden},
+		{name: "链接已过期", method: http.MethodPut, key: key, contentType: "image/jpeg", expires: expired, signature: storage.sign(http.MethodPut, key, "image/jpeg", expired), wantCode: http.StatusForbidden},
+		{name: "路径越界", method: http.MethodPut, key: "../secret", contentType: "image/jpeg", expires: expires, signature: signature, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage
+			if tt.storage != nil {
+				s = tt.storage
+			}
+			got, err := s.verify(tt.method, tt.key, tt.contentType, tt.expires, tt.signature)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("verify() error = %v", err)
+				}
+				if got != tt.wantKey {
+					t.Errorf("verify() = %q, want %q", got, tt.wantKey)
+				}
+				return
+			}
+			var apiErr *errors.APIError
+			if !stderrors.As(err, &apiErr) {
+				t.Fatalf("verify() error = %v, want APIError", err)
+			}
+			if apiErr.Code != tt.wantCode {
+				t.Errorf("verify() code = %d, want %d", apiErr.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestLocalStorageResolve(t *testing.T) {
+	root := t.TempDir()
+	storage := NewLocalStorage(root, "test-secret")
+	if err := os.MkdirAll(filepath.Join(root, "users", "1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "users", "1", "photo.jpg"), []byte("jpg"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		wantCode int
+	}{
+		{name: "文件存在", key: "users/1/photo.jpg"},
+		{name: "文件不存在", key: "users/1/missing.jpg", wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signedURL, err := storage.SignedURL(context.Background(), tt.key, time.Minute)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, expires, signature := parseLocalSignedURL(t, signedURL)
+			got, err := storage.Resolve(key, expires, signature)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("Resolve() error = %v", err)
+				}
+				if want := filepath.Join(root, "users", "1", "photo.jpg"); got != want {
+					t.Errorf("Resolve() = %q, want %q", got, want)
+				}
+				return
+			}
+			var apiErr *errors.APIError
+			if !stderrors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+				t.Errorf("Resolve() error = %v, want code %d", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	"what-to-wear/server/config"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+)
+
+// ossStorage 阿里云OSS存储实现
+type ossStorage struct {
+	client *oss.Client
+	bucket string
+}
+
+// NewOSSStorage 创建阿里云OSS存储，使用配置中的存储桶
+func NewOSSStorage(cfg *config.Config) Storage {
+	return &ossStorage{
+		client: newOSSClient(cfg),
+		bucket: cfg.OSS.BucketName,
+	}
+}
+
+// Name 存储提供商名称
+func (s *ossStorage) Name() string {
+	return StorageProviderOSS
+}
+
+// Bucket 存储桶名称
+func (s *ossStorage) Bucket() string {
+	return s.bucket
+}
+
+// Put 上传对象
+func (s *ossStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	key, err := cleanStorageKey(key)
+	if err != nil {
+		return err
+	}
+	request := &oss.PutObjectRequest{
+		Bucket: oss.Ptr(s.bucket),
+		Key:    oss.Ptr(key),
+		Body:   reader,
+	}
+	if size >= 0 {
+		request.ContentLength = oss.Ptr(size)
+	}
+	if contentType != "" {
+		request.ContentType = oss.Ptr(contentType)
+	}
+	if _, err := s.client.PutObject(ctx, request); err != nil {
+		return fmt.Errorf("上传对象失败: %w", err)
+	}
+	return nil
+}
+
+// Get 下载对象
+func (s *ossStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	key, err := cleanStorageKey(key)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.client.GetObject(ctx, &oss.GetObjectRequest{
+		Bucket: oss.Ptr(s.bucket),
+		Key:    oss.Ptr(key),
+	})
+	if err != nil {
+		if isOSSNotFound(err) {
+			return nil, ErrStorageObjectNotFound
+		}
+		return nil, fmt.Errorf("下载对象失败: %w", err)
+	}
+	return result.Body, nil
+}
+
+// Delete 删除对象，OSS删除不存在的对象不会报错
+func (s *ossStorage) Delete(ctx context.Context, key string) error {
+	key, err := cleanStorageKey(key)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.DeleteObject(ctx, &oss.DeleteObjectRequest{
+		Bucket: oss.Ptr(s.bucket),
+		Key:    oss.Ptr(key),
+	})
+	if err != nil {
+		return fmt.Errorf("删除对象失败: %w", err)
+	}
+	return nil
+}
+
+// Stat 获取对象元信息
+func (s *ossStorage) Stat(ctx context.Context, key string) (*StorageObject, error) {
+	key, err := cleanStorageKey(key)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.client.HeadObject(ctx, &oss.HeadObjectRequest{
+		Bucket: oss.Ptr(s.bucket),
+		Key:    oss.Ptr(key),
+	})
+	if err != nil {
+		if isOSSNotFound(err) {
+			return nil, ErrStorageObjectNotFound
+		}
+		return nil, fmt.Errorf("获取对象信息失败: %w", err)
+	}
+	return &StorageObject{Size: result.ContentLength, ContentType: oss.ToString(result.ContentType)}, nil
+}
+
+// SignedURL 生成预签名下载链接
+func (s *ossStorage) SignedURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	key, err := cleanStorageKey(key)
+	if err != nil {
+		return "", err
+	}
+	result, err := s.client.Presign(ctx, &oss.GetObjectRequest{
+		Bucket: oss.Ptr(s.bucket),
+		Key:    oss.Ptr(key),
+	}, oss.PresignExpires(expires))
+	if err != nil {
+		return "", fmt.Errorf("生成下载链接失败: %w", err)
+	}
+	return result.URL, nil
+}
+
+// SignedUploadURL 生成预签名上传链接，Content-Type 参与签名
+func (s *ossStorage) SignedUploadURL(ctx context.Context, key, contentType string, expires time.Duration) (string, error) {
+	key, err := cleanStorageKey(key)
+	if err != nil {
+		return "", err
+	}
+	request := &oss.PutObjectRequest{
+		Bucket: oss.Ptr(s.bucket),
+		Key:    oss.Ptr(key),
+	}
+	if contentType != "" {
+		request.ContentType = oss.Ptr(contentType)
+	}
+	result, err := s.client.Presign(ctx, request, oss.PresignExpires(expires))
+	if err != nil {
+		return "", fmt.Errorf("生成上传链接失败: %w", err)
+	}
+	return result.URL, nil
+}
+
+// isOSSNotFound 检查OSS错误是否为对象不存在
+func isOSSNotFound(err error) bool {
+	var serviceErr *oss.ServiceError
+	return stderrors.As(err, &serviceErr) && serviceErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,445 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
+	"what-to-wear/server/repositories"
+)
+
+const (
+	defaultTrendGranularity = "month"
+	defaultTrendPeriods     = 6
+	maxTrendPeriods         = 24
+	trendStableThreshold    = 5.0 // 变化百分比绝对值不超过该值时视为持平
+	trendForecastWeeks      = 8   // 预测下周时参考的周数
+	trendForecastMonths     = 6   // 预测下月时参考的月数
+	trendSeasonCount        = 4   // 季节趋势展示的季节数，每个季节与上一年同季比较
+	trendUpcomingDays       = 30
+
+	trendDirectionUp     = "up"
+	trendDirectionDown   = "down"
+	trendDirectionStable = "stable"
+
+	predictionTypeWear        = "wear"
+	predictionTypeSpending    = "spending"
+	predictionTypeMaintenance = "maintenance"
+	predictionNextWeek        = "next_week"
+	predictionNextMonth       = "next_month"
+	predictionNextSeason      = "next_season"
+)
+
+// TrendService 趋势分析服务接口
+type TrendService interface {
+	// 穿着、支出、分类和季节趋势，以及下周、下月和下一季的预测
+	GetTrends(ctx context.Context, userID uint, req *dto.TrendRequestDTO) (*dto.TrendAnalysisDTO, error)
+}
+
+// trendService 趋势分析服务实现
+type trendService struct {
+	clothingItemRepo     repositories.ClothingItemRepository
+	clothingCategoryRepo repositories.ClothingCategoryRepository
+	wearRecordRepo       repositories.WearRecordRepository
+	purchaseRecordRepo   repositories.PurchaseRecordRepository
+	maintenanceRepo      repositories.MaintenanceRecordRepository
+	currencyService      CurrencyService
+}
+
+// NewTrendService 创建趋势分析服务实例
+func NewTrendService(
+	clothingItemRepo repositories.ClothingItemRepository,
+	clothingCategoryRepo repositories.ClothingCategoryRepository,
+	wearRecordRepo repositories.WearRecordRepository,
+	purchaseRecordRepo repositories.PurchaseRecordRepository,
+	maintenanceRepo repositories.MaintenanceRecordRepository,
+	currencyService CurrencyService,
+) TrendService {
+	return &trendService{
+		clothingItemRepo:     clothingItemRepo,
+		clothingCategoryRepo: clothingCategoryRepo,
+		wearRecordRepo:       wearRecordRepo,
+		purchaseRecordRepo:   purchaseRecordRepo,
+		maintenanceRepo:      maintenanceRepo,
+		currencyService:      currencyService,
+	}
+}
+
+// trendBucket 统计区间 [start, end)
+type trendBucket struct {
+	label string
+	start time.Time
+	end   time.Time
+}
+
+// trendPoint 按时间累加的数值
+type trendPoint struct {
+	at    time.Time
+	value float64
+}
+
+// trendCalendar 周期的划分方式
+type trendCalendar struct {
+	truncate func(time.Time) time.Time
+	shift    func(time.Time, int) time.Time
+	label    func(time.Time) string
+}
+
+var (
+	weekCalendar = trendCalendar{
+		truncate: startOfWeek,
+		shift:    func(t time.Time, n int) time.Time { return t.AddDate(0, 0, 7*n) },
+		label:    func(t time.Time) string { return t.Format("2006-01-02") },
+	}
+	monthCalendar = trendCalendar{
+		truncate: func(t time.Time) time.Time {
+			return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		},
+		shift: func(t time.Time, n int) time.Time { return t.AddDate(0, n, 0) },
+		label: func(t time.Time) string { return t.Format("2006-01") },
+	}
+	seasonCalendar = trendCalendar{
+		truncate: startOfSeason,
+		shift:    func(t time.Time, n int) time.Time { return t.AddDate(0, 3*n, 0) },
+		label:    func(t time.Time) string { return fmt.Sprintf("%d %s", t.Year(), seasonName(t)) },
+	}
+)
+
+// completed 返回截至 now 最近 n 个已结束的周期，按时间先后排序
+func (c trendCalendar) completed(now time.Time, n int) []trendBucket {
+	current := c.truncate(now)
+	buckets := make([]trendBucket, 0, n)
+	for i := n; i >= 1; i-- {
+		start := c.shift(current, -i)
+		buckets = append(buckets, trendBucket{
+			label: c.label(start),
+			start: start,
+			end:   c.shift(current, -i+1),
+		})
+	}
+	return buckets
+}
+
+// GetTrends 趋势分析，只统计已结束的周期，避免当前周期数据不完整导致误判
+func (s *trendService) GetTrends(ctx context.Context, userID uint, req *dto.TrendRequestDTO) (*dto.TrendAnalysisDTO, error) {
+	calendar, periods, err := resolveTrendRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	// 多取一个周期用于计算第一个周期的变化
+	buckets := calendar.completed(now, periods+1)
+	weeks := weekCalendar.completed(now, trendForecastWeeks)
+	months := monthCalendar.completed(now, trendForecastMonths)
+	seasons := seasonCalendar.completed(now, trendSeasonCount*2)
+
+	period := analyticsPeriod{name: "custom", start: buckets[0].start, end: now}
+	for _, first := range []trendBucket{weeks[0], months[0], seasons[0]} {
+		if first.start.Before(period.start) {
+			period.start = first.start
+		}
+	}
+
+	items, wears, err := loadItemAnalytics(ctx, s.clothingItemRepo, s.clothingCategoryRepo, s.wearRecordRepo, userID, period)
+	if err != nil {
+		return nil, err
+	}
+	spending, err := loadSpending(ctx, s.purchaseRecordRepo, userID, items, period)
+	if err != nil {
+		return nil, err
+	}
+	maintenance, err := s.maintenanceRepo.GetMaintenanceByDateRange(ctx, userID, period.start, now)
+	if err != nil {
+		return nil, fmt.Errorf("获取保养记录失败: %w", err)
+	}
+	upcoming, err := s.maintenanceRepo.GetUpcoming(ctx, userID, trendUpcomingDays)
+	if err != nil {
+		return nil, fmt.Errorf("获取保养提醒失败: %w", err)
+	}
+
+	categoryNames := make(map[uint]string, len(items))
+	for _, a := range items {
+		categoryNames[a.item.ID] = a.categoryName
+	}
+	wearPoints := make([]trendPoint, 0, len(wears))
+	categoryPoints := make(map[string][]trendPoint)
+	for _, record := range wears {
+		point := trendPoint{at: record.WearDate, value: 1}
+		wearPoints = append(wearPoints, point)
+
+		category := categoryNames[record.ClothingItemID]
+		if category == "" {
+			category = unknownAnalyticsLabel
+		}
+		categoryPoints[category] = append(categoryPoints[category], point)
+	}
+	spendingPoints := make([]trendPoint, 0, len(spending))
+	for _, entry := range spending {
+		spendingPoints = append(spendingPoints, trendPoint{at: entry.date, value: entry.price})
+	}
+	maintenancePoints := make([]trendPoint, 0, len(maintenance))
+	for _, record := range maintenance {
+		maintenancePoints = append(maintenancePoints, trendPoint{at: record.MaintenanceDate, value: 1})
+	}
+
+	currency, err := s.currencyService.GetHomeCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seasonalSpending := sumByBucket(seasons, spendingPoints)
+	return &dto.TrendAnalysisDTO{
+		Currency:       currency,
+		WearTrends:     buildTrendItems(buckets, sumByBucket(buckets, wearPoints)),
+		SpendingTrends: buildTrendItems(buckets, sumByBucket(buckets, spendingPoints)),
+		CategoryTrends: buildCategoryTrends(buckets[len(buckets)-2:], categoryPoints),
+		SeasonalTrends: buildSeasonalTrends(seasons, sumByBucket(seasons, wearPoints)),
+		Predictions: []dto.Prediction{
+			predictWear(predictionNextWeek, "下周", sumByBucket(weeks, wearPoints)),
+			predictWear(predictionNextMonth, "下个月", sumByBucket(months, wearPoints)),
+			predictSpending(currency, sumByBucket(months, spendingPoints)),
+			predictSeasonSpending(now, currency, sumByBucket(months, spendingPoints), seasonalSpending[len(seasonalSpending)-3]),
+			predictMaintenance(sumByBucket(months, maintenancePoints), len(upcoming)),
+		},
+	}, nil
+}
+
+// resolveTrendRequest 校验统计粒度和周期数
+func resolveTrendRequest(req *dto.TrendRequestDTO) (trendCalendar, int, error) {
+	granularity := req.Granularity
+	if granularity == "" {
+		granularity = defaultTrendGranularity
+	}
+	var calendar trendCalendar
+	switch granularity {
+	case "week":
+		calendar = weekCalendar
+	case "month":
+		calendar = monthCalendar
+	default:
+		return trendCalendar{}, 0, errors.ErrInvalidRequest(fmt.Sprintf("无效的统计粒度: %s", granularity))
+	}
+
+	periods := req.Periods
+	if periods == 0 {
+		periods = defaultTrendPeriods
+	}
+	if periods < 1 || periods > maxTrendPeriods {
+		return trendCalendar{}, 0, errors.ErrInvalidRequest(fmt.Sprintf("统计周期数必须在1-%d之间", maxTrendPeriods))
+	}
+	return calendar, periods, nil
+}
+
+// sumByBucket 按周期汇总数值，不在任何周期内的数据忽略
+func sumByBucket(buckets []trendBucket, points []trendPoint) []float64 {
+	values := make([]float64, len(buckets))
+	for _, point := range points {
+		i := sort.Search(len(buckets), func(i int) bool { return buckets[i].end.After(point.at) })
+		if i < len(buckets) && !point.at.Before(buckets[i].start) {
+			values[i] += point.value
+		}
+	}
+	return values
+}
+
+// buildTrendItems 构建趋势序列，第一个周期只用于计算变化，不输出
+func buildTrendItems(buckets []trendBucket, values []float64) []dto.TrendItem {
+	items := make([]dto.TrendItem, 0, len(buckets)-1)
+	for i := 1; i < len(buckets); i++ {
+		items = append(items, newTrendItem(buckets[i].label, "", values[i], values[i-1]))
+	}
+	return items
+}
+
+// buildCategoryTrends 比较最近两个周期各分类的穿着次数，按最近周期的穿着次数降序排列
+func buildCategoryTrends(buckets []trendBucket, categoryPoints map[string][]trendPoint) []dto.TrendItem {
+	items := make([]dto.TrendItem, 0, len(categoryPoints))
+	for category, points := range categoryPoints {
+		values := sumByBucket(buckets, points)
+		if values[0] == 0 && values[1] == 0 {
+			continue
+		}
+		items = append(items, newTrendItem(buckets[1].label, category, values[1], values[0]))
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Value != items[j].Value {
+			return items[i].Value > items[j].Value
+		}
+		return items[i].Label < items[j].Label
+	})
+	return items
+}
+
+// buildSeasonalTrends 最近几个季节的穿着次数，与上一年同一季节比较
+func buildSeasonalTrends(seasons []trendBucket, values []float64) []dto.TrendItem {
+	items := make([]dto.TrendItem, 0, trendSeasonCount)
+	for i := len(seasons) - trendSeasonCount; i < len(seasons); i++ {
+		items = append(items, newTrendItem(seasons[i].label, seasonName(seasons[i].start), values[i], values[i-trendSeasonCount]))
+	}
+	return items
+}
+
+// newTrendItem 构建趋势项并计算变化百分比和方向
+func newTrendItem(period, label string, value, previous float64) dto.TrendItem {
+	change := percentChange(previous, value)
+	direction := trendDirectionStable
+	if change > trendStableThreshold {
+		direction = trendDirectionUp
+	} else if change < -trendStableThreshold {
+		direction = trendDirectionDown
+	}
+	return dto.TrendItem{
+		Period:    period,
+		Label:     label,
+		Value:     roundTo(value, 2),
+		Change:    change,
+		Direction: direction,
+	}
+}
+
+// percentChange 计算变化百分比，上一周期为0时有数据视为增长100%
+func percentChange(previous, value float64) float64 {
+	if previous == 0 {
+		if value == 0 {
+			return 0
+		}
+		return 100
+	}
+	return roundTo((value-previous)/previous*100, 2)
+}
+
+// predictWear 预测下一周期的穿着次数
+func predictWear(period, periodLabel string, history []float64) dto.Prediction {
+	value, confidence := forecastNext(history)
+	value = math.Round(value)
+	return dto.Prediction{
+		Type:        predictionTypeWear,
+		Period:      period,
+		Value:       value,
+		Confidence:  confidence,
+		Description: fmt.Sprintf("预计%s穿着约 %.0f 次", periodLabel, value),
+	}
+}
+
+// predictSpending 预测下个月的支出
+func predictSpending(currency string, monthly []float64) dto.Prediction {
+	value, confidence := forecastNext(monthly)
+	value = roundTo(value, 2)
+	return dto.Prediction{
+		Type:        predictionTypeSpending,
+		Period:      predictionNextMonth,
+		Value:       value,
+		Confidence:  confidence,
+		Description: fmt.Sprintf("预计下个月支出约 %.2f %s", value, currency),
+	}
+}
+
+// predictSeasonSpending 预测下一季的支出：按月预测值推算三个月，有上一年同季数据时取两者平均
+func predictSeasonSpending(now time.Time, currency string, monthly []float64, lastYear float64) dto.Prediction {
+	monthValue, confidence := forecastNext(monthly)
+	value := monthValue * 3
+	if lastYear > 0 {
+		value = (value + lastYear) / 2
+	}
+	// 预测跨度更长，降低置信度
+	confidence = roundTo(confidence*0.8, 2)
+	value = roundTo(value, 2)
+
+	season := seasonName(seasonCalendar.shift(startOfSeason(now), 1))
+	return dto.Prediction{
+		Type:        predictionTypeSpending,
+		Period:      predictionNextSeason,
+		Value:       value,
+		Confidence:  confidence,
+		Description: fmt.Sprintf("预计%s支出约 %.2f %s", season, value, currency),
+	}
+}
+
+// predictMaintenance 预测下个月的保养次数，已安排的保养提醒数量作为下限
+func predictMaintenance(monthly []float64, scheduled int) dto.Prediction {
+	value, confidence := forecastNext(monthly)
+	value = math.Round(value)
+	if float64(scheduled) >= value && scheduled > 0 {
+		value = float64(scheduled)
+		confidence = math.Max(confidence, 0.8)
+	}
+	return dto.Prediction{
+		Type:        predictionTypeMaintenance,
+		Period:      predictionNextMonth,
+		Value:       value,
+		Confidence:  confidence,
+		Description: fmt.Sprintf("预计下个月需要保养约 %.0f 次", value),
+	}
+}
+
+// forecastNext 用最小二乘线性回归预测下一个周期的值，置信度由拟合误差和有数据的周期占比决定
+func forecastNext(history []float64) (float64, float64) {
+	n := float64(len(history))
+	if n == 0 {
+		return 0, 0
+	}
+
+	var sumX, sumY, sumXY, sumXX, active float64
+	for i, v := range history {
+		x := float64(i)
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumXX += x * x
+		if v > 0 {
+			active++
+		}
+	}
+	mean := sumY / n
+	if mean == 0 {
+		return 0, 0.5
+	}
+
+	var slope float64
+	if denom := n*sumXX - sumX*sumX; denom != 0 {
+		slope = (n*sumXY - sumX*sumY) / denom
+	}
+	intercept := mean - slope*sumX/n
+
+	var squaredError float64
+	for i, v := range history {
+		diff := v - (intercept + slope*float64(i))
+		squaredError += diff * diff
+	}
+	fit := math.Max(0, 1-math.Sqrt(squaredError/n)/mean)
+	confidence := math.Min(0.95, math.Max(0.05, fit*active/n))
+
+	return math.Max(0, intercept+slope*n), roundTo(confidence, 2)
+}
+
+// startOfWeek 所在周的周一零点
+func startOfWeek(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// startOfSeason 所在季节第一天零点：春季3-5月，夏季6-8月，秋季9-11月，冬季12-2月
+func startOfSeason(t time.Time) time.Time {
+	month := int(t.Month())
+	// 1、2月计算得到的月份为0，time.Date 会归一到上一年12月
+	return time.Date(t.Year(), time.Month(month-month%3), 1, 0, 0, 0, 0, t.Location())
+}
+
+// seasonName 季节起始时间对应的季节名称
+func seasonName(start time.Time) string {
+	switch start.Month() {
+	case time.March:
+		return api.SeasonSpring
+	case time.June:
+		return api.SeasonSummer
+	case time.September:
+		return api.SeasonAutumn
+	default:
+		return api.SeasonWinter
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+	"what-to-wear/server/config"
+	"what-to-wear/server/logger"
+)
+
+// UploadCleanupWorker 过期直传上传凭证清理后台任务接口
+type UploadCleanupWorker interface {
+	// 启动后台清理，未启用或重复调用时无效
+	Start()
+	// 停止后台清理并等待当前清理结束
+	Stop()
+	// 清理一批过期的上传凭证，返回清理的数量
+	RunOnce(ctx context.Context) (int, error)
+}
+
+// uploadCleanupWorker 过期直传上传凭证清理后台任务实现
+type uploadCleanupWorker struct {
+	attachmentService AttachmentServiceInterface
+	interval          time.Duration
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewUploadCleanupWorker 创建过期直传上传凭证清理后台任务实例
+func NewUploadCleanupWorker(cfg *config.Config, attachmentService AttachmentServiceInterface) UploadCleanupWorker {
+	return &uploadCleanupWorker{
+		attachmentService: attachmentService,
+		interval:          time.Duration(cfg.Storage.CleanupInterval) * time.Second,
+	}
+}
+
+// Start 启动后台清理
+func (w *uploadCleanupWorker) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cancel != nil || w.interval <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go w.loop(ctx, w.done)
+	logger.GetLogger().Info("Upload cleanup worker started", logger.Fields{
+		"interval": w.interval.String(),
+	})
+}
+
+// Stop 停止后台清理
+func (w *uploadCleanupWorker) Stop() {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// loop 按间隔清理，启动时立即执行一次
+func (w *uploadCleanupWorker) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			logger.GetLogger().ErrorWithErr(err, "Upload cleanup worker run failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 清理一批过期的上传凭证
+func (w *uploadCleanupWorker) RunOnce(ctx context.Context) (int, error) {
+	expired, err := w.attachmentService.CleanupExpiredUploads(ctx)
+	if expired > 0 {
+		logger.GetLogger().Info("Expired upload slots removed", logger.Fields{"count": expired})
+	}
+	return expired, err
+}
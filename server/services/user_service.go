@@ -3,11 +3,15 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 	"what-to-wear/server/api/dto"
+	apierrors "what-to-wear/server/api/errors"
 	"what-to-wear/server/models"
 	"what-to-wear/server/repositories"
 	"what-to-wear/server/utils"
+
+	"gorm.io/gorm"
 )
 
 type UserService interface {
@@ -20,19 +24,23 @@ type UserService interface {
 	// 更改密码
 	ChangePassword(ctx context.Context, userID uint, oldPassword, newPassword string) error
 
-	// 删除用户
-	DeleteUser(ctx context.Context, userID uint) error
+	// 删除用户及其所有数据，exportData 为 true 时先导出账户数据，导出完成后再删除并返回导出任务
+	DeleteUser(ctx context.Context, userID uint, exportData bool) (*dto.AccountExportDTO, error)
 }
 
 // userService 用户服务实现
 type userService struct {
-	userRepo repositories.UserRepository
+	userRepo             repositories.UserRepository
+	accountExportService AccountExportService
+	accountPurger        AccountPurger
 }
 
 // NewUserService 创建用户服务实例
-func NewUserService(userRepo repositories.UserRepository) UserService {
+func NewUserService(userRepo repositories.UserRepository, accountExportService AccountExportService, accountPurger AccountPurger) UserService {
 	return &userService{
-		userRepo: userRepo,
+		userRepo:             userRepo,
+		accountExportService: accountExportService,
+		accountPurger:        accountPurger,
 	}
 }
 
@@ -130,17 +138,24 @@ func (s *userService) ChangePassword(ctx context.Context, userID uint, oldPasswo
 }
 
 // DeleteUser 删除用户
-func (s *userService) DeleteUser(ctx context.Context, userID uint) error {
+func (s *userService) DeleteUser(ctx context.Context, userID uint, exportData bool) (*dto.AccountExportDTO, error) {
 	// 检查用户是否存在
-	_, err := s.userRepo.GetByID(ctx, userID)
-	if err != nil {
-		return errors.New("user not found")
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.ErrNotFound("用户不存在")
+		}
+		return nil, fmt.Errorf("获取用户失败: %w", err)
 	}
 
-	// 删除用户
-	if err := s.userRepo.Delete(ctx, userID); err != nil {
-		return errors.New("failed to delete user")
+	// 先导出数据，由后台任务在导出完成后删除账户
+	if exportData {
+		return s.accountExportService.CreateExport(ctx, userID, true)
 	}
 
-	return nil
+	// 彻底删除用户及其数据
+	if err := s.accountPurger.Purge(ctx, userID); err != nil {
+		return nil, fmt.Errorf("删除用户失败: %w", err)
+	}
+
+	return nil, nil
 }
@@ -2,12 +2,16 @@ package services
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"time"
 	"what-to-wear/server/api"
 	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
 	"what-to-wear/server/models"
 	"what-to-wear/server/repositories"
+
+	"gorm.io/gorm"
 )
 
 // WearRecordService 穿着记录服务接口
@@ -32,15 +36,24 @@ type WearRecordService interface {
 
 // wearRecordService 穿着记录服务实现
 type wearRecordService struct {
-	wearRecordRepo repositories.WearRecordRepository
-	clothingRepo   repositories.ClothingItemRepository
+	transactor        repositories.Transactor
+	wearRecordRepo    repositories.WearRecordRepository
+	clothingRepo      repositories.ClothingItemRepository
+	durabilityService DurabilityService
 }
 
 // NewWearRecordService 创建穿着记录服务实例
-func NewWearRecordService(wearRecordRepo repositories.WearRecordRepository, clothingRepo repositories.ClothingItemRepository) WearRecordService {
+func NewWearRecordService(
+	transactor repositories.Transactor,
+	wearRecordRepo repositories.WearRecordRepository,
+	clothingRepo repositories.ClothingItemRepository,
+	durabilityService DurabilityService,
+) WearRecordService {
 	return &wearRecordService{
-		wearRecordRepo: wearRecordRepo,
-		clothingRepo:   clothingRepo,
+		transactor:        transactor,
+		wearRecordRepo:    wearRecordRepo,
+		clothingRepo:      clothingRepo,
+		durabilityService: durabilityService,
 	}
 }
 
@@ -49,12 +62,12 @@ func (s *wearRecordService) CreateWearRecord(userID, itemID uint, req *dto.Creat
 	ctx := context.Background()
 
 	// 验证衣物存在且属于用户
-	item, err := s.clothingRepo.GetByID(ctx, itemID)
-	if err != nil {
-		return nil, fmt.Errorf("衣物不存在: %w", err)
+	if _, err := s.getOwnedItem(ctx, userID, itemID); err != nil {
+		return nil, err
 	}
-	if item.UserID != userID {
-		return nil, fmt.Errorf("无权操作该衣物")
+
+	if err := validateWearFeedback(&req.WearFeedbackDTO); err != nil {
+		return nil, err
 	}
 
 	// 创建穿着记录
@@ -63,31 +76,30 @@ func (s *wearRecordService) CreateWearRecord(userID, itemID uint, req *dto.Creat
 		WearDate:       req.WearDate,
 		Notes:          req.Notes,
 	}
+	applyWearFeedback(wearRecord, &req.WearFeedbackDTO)
 
-	err = s.wearRecordRepo.Create(ctx, wearRecord)
+	// 同一事务中更新衣物的穿着次数、最后穿着时间和耐久度
+	records := []models.WearRecord{*wearRecord}
+	err := s.transactor.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := s.wearRecordRepo.WithTx(tx).RecordWears(ctx, records); err != nil {
+			return fmt.Errorf("创建穿着记录失败: %w", err)
+		}
+		return s.durabilityService.RecalculateItems(ctx, tx, []uint{itemID})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("创建穿着记录失败: %w", err)
+		return nil, err
 	}
 
-	return s.convertToDTO(wearRecord), nil
+	return s.convertToDTO(&records[0]), nil
 }
 
 // GetWearRecord 获取穿着记录
 func (s *wearRecordService) GetWearRecord(userID, recordID uint) (*dto.WearRecordDTO, error) {
 	ctx := context.Background()
 
-	wearRecord, err := s.wearRecordRepo.GetByID(ctx, recordID)
+	wearRecord, err := s.getOwnedRecord(ctx, userID, recordID)
 	if err != nil {
-		return nil, fmt.Errorf("穿着记录不存在: %w", err)
-	}
-
-	// 验证权限
-	item, err := s.clothingRepo.GetByID(ctx, wearRecord.ClothingItemID)
-	if err != nil {
-		return nil, fmt.Errorf("衣物不存在: %w", err)
-	}
-	if item.UserID != userID {
-		return nil, fmt.Errorf("无权访问该记录")
+		return nil, err
 	}
 
 	return s.convertToDTO(wearRecord), nil
@@ -109,18 +121,13 @@ func (s *wearRecordService) GetWearRecords(userID uint, limit int) ([]dto.WearRe
 func (s *wearRecordService) UpdateWearRecord(userID, recordID uint, req *dto.UpdateWearRecordDTO) (*dto.WearRecordDTO, error) {
 	ctx := context.Background()
 
-	wearRecord, err := s.wearRecordRepo.GetByID(ctx, recordID)
+	wearRecord, err := s.getOwnedRecord(ctx, userID, recordID)
 	if err != nil {
-		return nil, fmt.Errorf("穿着记录不存在: %w", err)
+		return nil, err
 	}
 
-	// 验证权限
-	item, err := s.clothingRepo.GetByID(ctx, wearRecord.ClothingItemID)
-	if err != nil {
-		return nil, fmt.Errorf("衣物不存在: %w", err)
-	}
-	if item.UserID != userID {
-		return nil, fmt.Errorf("无权修改该记录")
+	if req.WeatherCondition != nil && !req.WeatherCondition.IsValid() {
+		return nil, errors.ErrInvalidRequest("无效的天气类型: " + string(*req.WeatherCondition))
 	}
 
 	// 更新字段
@@ -130,10 +137,39 @@ func (s *wearRecordService) UpdateWearRecord(userID, recordID uint, req *dto.Upd
 	if req.Notes != nil {
 		wearRecord.Notes = *req.Notes
 	}
+	if req.ComfortRating != nil {
+		wearRecord.ComfortRating = req.ComfortRating
+	}
+	if req.StyleRating != nil {
+		wearRecord.StyleRating = req.StyleRating
+	}
+	if req.AppropriatenessRating != nil {
+		wearRecord.AppropriatenessRating = req.AppropriatenessRating
+	}
+	if req.DurationHours != nil {
+		wearRecord.DurationHours = req.DurationHours
+	}
+	if req.Temperature != nil {
+		wearRecord.Temperature = req.Temperature
+	}
+	if req.WeatherCondition != nil {
+		wearRecord.WeatherCondition = req.WeatherCondition
+	}
+	if req.Occasion != nil {
+		wearRecord.Occasion = *req.Occasion
+	}
+	if req.Location != nil {
+		wearRecord.Location = *req.Location
+	}
 
-	err = s.wearRecordRepo.Update(ctx, wearRecord)
+	err = s.transactor.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := s.wearRecordRepo.WithTx(tx).Update(ctx, wearRecord); err != nil {
+			return fmt.Errorf("更新穿着记录失败: %w", err)
+		}
+		return s.durabilityService.RecalculateItems(ctx, tx, []uint{wearRecord.ClothingItemID})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("更新穿着记录失败: %w", err)
+		return nil, err
 	}
 
 	return s.convertToDTO(wearRecord), nil
@@ -143,26 +179,17 @@ func (s *wearRecordService) UpdateWearRecord(userID, recordID uint, req *dto.Upd
 func (s *wearRecordService) DeleteWearRecord(userID, recordID uint) error {
 	ctx := context.Background()
 
-	wearRecord, err := s.wearRecordRepo.GetByID(ctx, recordID)
+	wearRecord, err := s.getOwnedRecord(ctx, userID, recordID)
 	if err != nil {
-		return fmt.Errorf("穿着记录不存在: %w", err)
+		return err
 	}
 
-	// 验证权限
-	item, err := s.clothingRepo.GetByID(ctx, wearRecord.ClothingItemID)
-	if err != nil {
-		return fmt.Errorf("衣物不存在: %w", err)
-	}
-	if item.UserID != userID {
-		return fmt.Errorf("无权删除该记录")
-	}
-
-	err = s.wearRecordRepo.Delete(ctx, recordID)
-	if err != nil {
-		return fmt.Errorf("删除穿着记录失败: %w", err)
-	}
-
-	return nil
+	return s.transactor.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := s.wearRecordRepo.WithTx(tx).Delete(ctx, recordID); err != nil {
+			return fmt.Errorf("删除穿着记录失败: %w", err)
+		}
+		return s.durabilityService.RecalculateItems(ctx, tx, []uint{wearRecord.ClothingItemID})
+	})
 }
 
 // GetWearRecordsByItem 根据衣物ID获取穿着记录
@@ -170,12 +197,8 @@ func (s *wearRecordService) GetWearRecordsByItem(userID, itemID uint, limit int)
 	ctx := context.Background()
 
 	// 验证衣物权限
-	item, err := s.clothingRepo.GetByID(ctx, itemID)
-	if err != nil {
-		return nil, fmt.Errorf("衣物不存在: %w", err)
-	}
-	if item.UserID != userID {
-		return nil, fmt.Errorf("无权访问该衣物")
+	if _, err := s.getOwnedItem(ctx, userID, itemID); err != nil {
+		return nil, err
 	}
 
 	wearRecords, err := s.wearRecordRepo.GetByClothingItemID(ctx, itemID, limit)
@@ -226,6 +249,15 @@ func (s *wearRecordService) GetWearStats(userID uint) (*dto.WearStatsDTO, error)
 		return nil, fmt.Errorf("获取最近穿着记录失败: %w", err)
 	}
 
+	weatherStats, err := s.wearRecordRepo.GetWeatherStats(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取天气统计失败: %w", err)
+	}
+	wearsByWeather := make(map[api.WeatherType]int64, len(weatherStats))
+	for weather, count := range weatherStats {
+		wearsByWeather[api.WeatherType(weather)] = count
+	}
+
 	// 计算总穿着次数
 	totalWears := int64(0)
 	for _, count := range frequency {
@@ -249,8 +281,8 @@ func (s *wearRecordService) GetWearStats(userID uint) (*dto.WearStatsDTO, error)
 		AveragePerItem:  averagePerItem,
 		WearsByCategory: make(map[string]int64), // TODO: 实现分类统计
 		WearsByOccasion: frequency,
-		WearsByWeather:  make(map[api.WeatherType]int64), // TODO: 实现天气统计
-		MostWornItems:   []dto.ClothingItemSummary{},     // TODO: 实现最常穿衣物
+		WearsByWeather:  wearsByWeather,
+		MostWornItems:   []dto.ClothingItemSummary{}, // TODO: 实现最常穿衣物
 		RecentWears:     s.convertToDTOList(recentRecords),
 		LastWearDate:    lastWearDate,
 	}
@@ -266,28 +298,48 @@ func (s *wearRecordService) GetWearFrequency(userID uint) (map[string]int64, err
 
 // GetComfortAnalysis 获取舒适度分析
 func (s *wearRecordService) GetComfortAnalysis(userID uint) (*dto.ComfortAnalysisDTO, error) {
-	// 由于简化的模型中没有舒适度字段，返回空的分析
-	analysis := &dto.ComfortAnalysisDTO{
-		AverageComfort:         0,
-		AverageStyle:           0,
-		AverageAppropriateness: 0,
-		ComfortByCategory:      make(map[string]float64),
-		ComfortByWeather:       make(map[api.WeatherType]float64),
+	ctx := context.Background()
+
+	averages, err := s.wearRecordRepo.GetRatingAverages(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取评分统计失败: %w", err)
 	}
 
-	return analysis, nil
+	byCategory, err := s.wearRecordRepo.GetComfortByCategory(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取分类舒适度失败: %w", err)
+	}
+
+	byWeather, err := s.wearRecordRepo.GetComfortByWeather(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取天气舒适度失败: %w", err)
+	}
+
+	return buildComfortAnalysis(averages, byCategory, byWeather), nil
+}
+
+// buildComfortAnalysis 汇总评分统计，平均分保留两位小数
+func buildComfortAnalysis(averages *repositories.WearRatingAverages, byCategory map[string]float64, byWeather map[api.WeatherType]float64) *dto.ComfortAnalysisDTO {
+	analysis := &dto.ComfortAnalysisDTO{
+		AverageComfort:         roundTo(averages.AvgComfort, 2),
+		AverageStyle:           roundTo(averages.AvgStyle, 2),
+		AverageAppropriateness: roundTo(averages.AvgAppropriateness, 2),
+		ComfortByCategory:      make(map[string]float64, len(byCategory)),
+		ComfortByWeather:       make(map[api.WeatherType]float64, len(byWeather)),
+	}
+	for category, comfort := range byCategory {
+		analysis.ComfortByCategory[category] = roundTo(comfort, 2)
+	}
+	for weather, comfort := range byWeather {
+		analysis.ComfortByWeather[weather] = roundTo(comfort, 2)
+	}
+	return analysis
 }
 
 // convertToDTO 将模型转换为DTO
 func (s *wearRecordService) convertToDTO(record *models.WearRecord) *dto.WearRecordDTO {
-	return &dto.WearRecordDTO{
-		ID:             record.ID,
-		ClothingItemID: record.ClothingItemID,
-		WearDate:       record.WearDate,
-		Notes:          record.Notes,
-		CreatedAt:      record.CreatedAt,
-		UpdatedAt:      record.UpdatedAt,
-	}
+	result := toWearRecordDTO(record)
+	return &result
 }
 
 // convertToDTOList 将模型列表转换为DTO列表
@@ -298,3 +350,76 @@ func (s *wearRecordService) convertToDTOList(records []models.WearRecord) []dto.
 	}
 	return dtos
 }
+
+// getOwnedItem 获取属于用户的衣物
+func (s *wearRecordService) getOwnedItem(ctx context.Context, userID, itemID uint) (*models.ClothingItem, error) {
+	item, err := s.clothingRepo.GetByID(ctx, itemID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("衣物不存在")
+		}
+		return nil, fmt.Errorf("获取衣物失败: %w", err)
+	}
+	if item.UserID != userID {
+		return nil, errors.ErrForbidden("无权操作该衣物")
+	}
+	return item, nil
+}
+
+// getOwnedRecord 获取属于用户的穿着记录
+func (s *wearRecordService) getOwnedRecord(ctx context.Context, userID, recordID uint) (*models.WearRecord, error) {
+	record, err := s.wearRecordRepo.GetByID(ctx, recordID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound("穿着记录不存在")
+		}
+		return nil, fmt.Errorf("获取穿着记录失败: %w", err)
+	}
+	if _, err := s.getOwnedItem(ctx, userID, record.ClothingItemID); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// validateWearFeedback 验证穿着反馈，评分和时长的范围由请求绑定校验
+func validateWearFeedback(feedback *dto.WearFeedbackDTO) error {
+	if feedback.WeatherCondition != nil && !feedback.WeatherCondition.IsValid() {
+		return errors.ErrInvalidRequest("无效的天气类型: " + string(*feedback.WeatherCondition))
+	}
+	return nil
+}
+
+// applyWearFeedback 将穿着反馈写入穿着记录
+func applyWearFeedback(record *models.WearRecord, feedback *dto.WearFeedbackDTO) {
+	record.ComfortRating = feedback.ComfortRating
+	record.StyleRating = feedback.StyleRating
+	record.AppropriatenessRating = feedback.AppropriatenessRating
+	record.DurationHours = feedback.DurationHours
+	record.Temperature = feedback.Temperature
+	record.WeatherCondition = feedback.WeatherCondition
+	record.Occasion = feedback.Occasion
+	record.Location = feedback.Location
+}
+
+// toWearRecordDTO 将穿着记录模型转换为DTO
+func toWearRecordDTO(record *models.WearRecord) dto.WearRecordDTO {
+	return dto.WearRecordDTO{
+		ID:             record.ID,
+		ClothingItemID: record.ClothingItemID,
+		OutfitID:       record.OutfitID,
+		WearDate:       record.WearDate,
+		Notes:          record.Notes,
+		WearFeedbackDTO: dto.WearFeedbackDTO{
+			ComfortRating:         record.ComfortRating,
+			StyleRating:           record.StyleRating,
+			AppropriatenessRating: record.AppropriatenessRating,
+			DurationHours:         record.DurationHours,
+			Temperature:           record.Temperature,
+			WeatherCondition:      record.WeatherCondition,
+			Occasion:              record.Occasion,
+			Location:              record.Location,
+		},
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/repositories"
+)
+
+func TestBuildComfortAnalysis(t *testing.T) {
+	tests := []struct {
+		name           string
+		averages       repositories.WearRatingAverages
+		byCategory     map[string]float64
+		byWeather      map[api.WeatherType]float64
+		want           [3]float64
+		wantByCategory map[string]float64
+		wantByWeather  map[api.WeatherType]float64
+	}{
+		{
+			name:           "没有评分记录",
+			wantByCategory: map[string]float64{},
+			wantByWeather:  map[api.WeatherType]float64{},
+		},
+		{
+			name:           "平均分保留两位小数",
+			averages:       repositories.WearRatingAverages{AvgComfort: 3.3333333, AvgStyle: 4.666666, AvgAppropriateness: 4},
+			byCategory:     map[string]float64{"衬衫": 4.125, "裤子": 2.0},
+			byWeather:      map[api.WeatherType]float64{api.WeatherTypeSunny: 4.5, api.WeatherTypeRainy: 2.3333},
+			want:           [3]float64{3.33, 4.67, 4},
+			wantByCategory: map[string]float64{"衬衫": 4.13, "裤子": 2},
+			wantByWeather:  map[api.WeatherType]float64{api.WeatherTypeSunny: 4.5, api.WeatherTypeRainy: 2.33},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := buildComfortAnalysis(&tt.averages, tt.byCategory, tt.byWeather)
+			got := [3]float64{analysis.AverageComfort, analysis.AverageStyle, analysis.AverageAppropriateness}
+			if got != tt.want {
+				t.Errorf("averages = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(analysis.ComfortByCategory, tt.wantByCategory) {
+				t.Errorf("ComfortByCategory = %v, want %v", analysis.ComfortByCategory, tt.wantByCategory)
+			}
+			if !reflect.DeepEqual(analysis.ComfortByWeather, tt.wantByWeather) {
+				t.Errorf("ComfortByWeather = %v, want %v", analysis.ComfortByWeather, tt.wantByWeather)
+			}
+		})
+	}
+}
+
+func TestValidateWearFeedback(t *testing.T) {
+	valid := api.WeatherTypeSunny
+	invalid := api.WeatherType("hail-storm")
+
+	tests := []struct {
+		name    string
+		weather *api.WeatherType
+		wantErr bool
+	}{
+		{name: "未填写天气", weather: nil},
+		{name: "有效天气", weather: &valid},
+		{name: "无效天气", weather: &invalid, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feedback := &dto.WearFeedbackDTO{WeatherCondition: tt.weather}
+			if err := validateWearFeedback(feedback); (err != nil) != tt.wantErr {
+				t.Errorf("validateWearFeedback() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"what-to-wear/server/api"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/config"
+)
+
+// 天气数据源名称
+const (
+	WeatherProviderOpenWeather = "openweathermap"
+	WeatherProviderLocal       = "local"
+)
+
+// 温度单位
+const (
+	WeatherUnitsMetric   = "metric"   // 摄氏度, m/s
+	WeatherUnitsImperial = "imperial" // 华氏度, mph
+	WeatherUnitsKelvin   = "kelvin"   // 开尔文, m/s
+)
+
+// WeatherProvider 天气数据源接口
+// 所有数据源统一返回公制单位的数据，单位换算由 WeatherService 负责
+type WeatherProvider interface {
+	// 数据源名称
+	Name() string
+
+	// 获取当前天气及预报
+	GetWeather(ctx context.Context, req *dto.WeatherRequest) (*dto.WeatherResponse, error)
+}
+
+// NewWeatherProvider 根据名称创建天气数据源
+func NewWeatherProvider(name string, cfg *config.WeatherConfig) (WeatherProvider, error) {
+	switch strings.ToLower(name) {
+	case WeatherProviderOpenWeather:
+		return NewOpenWeatherProvider(cfg)
+	case WeatherProviderLocal:
+		return NewLocalWeatherProvider(cfg.FixturePath), nil
+	default:
+		return nil, fmt.Errorf("不支持的天气数据源: %s", name)
+	}
+}
+
+// IsValidWeatherUnits 检查单位是否有效
+func IsValidWeatherUnits(units string) bool {
+	switch units {
+	case "", WeatherUnitsMetric, WeatherUnitsImperial, WeatherUnitsKelvin:
+		return true
+	default:
+		return false
+	}
+}
+
+// MapWeatherCondition 将数据源的天气描述映射为系统天气类型
+func MapWeatherCondition(condition string) api.WeatherType {
+	switch strings.ToLower(condition) {
+	case "clear", "sunny", "晴":
+		return api.WeatherTypeSunny
+	case "rain", "drizzle", "thunderstorm", "shower", "雨", "小雨", "中雨", "大雨", "雷阵雨":
+		return api.WeatherTypeRainy
+	case "snow", "sleet", "雪", "小雪", "中雪", "大雪", "雨夹雪":
+		return api.WeatherTypeSnowy
+	case "mist", "fog", "haze", "smoke", "dust", "sand", "ash", "雾", "霾":
+		return api.WeatherTypeFoggy
+	case "squall", "tornado", "wind", "windy", "大风":
+		return api.WeatherTypeWindy
+	default:
+		// clouds、阴、多云等
+		return api.WeatherTypeCloudy
+	}
+}
+
+// convertWeatherUnits 将公制单位的天气数据转换为指定单位
+func convertWeatherUnits(weather *dto.WeatherResponse, units string) *dto.WeatherResponse {
+	result := *weather
+	if units == "" || units == WeatherUnitsMetric {
+		return &result
+	}
+
+	convertTemp := func(celsius float64) float64 {
+		if units == WeatherUnitsImperial {
+			return roundTo(celsius*9/5+32, 1)
+		}
+		return roundTo(celsius+273.15, 2)
+	}
+	convertSpeed := func(speed float64) float64 {
+		if units == WeatherUnitsImperial {
+			return roundTo(speed*2.23694, 1)
+		}
+		return speed
+	}
+
+	result.Current.Temperature = convertTemp(weather.Current.Temperature)
+	if weather.Current.FeelsLike != nil {
+		feelsLike := convertTemp(*weather.Current.FeelsLike)
+		result.Current.FeelsLike = &feelsLike
+	}
+	result.Current.WindSpeed = convertSpeed(weather.Current.WindSpeed)
+	if weather.Current.WindGust != nil {
+		gust := convertSpeed(*weather.Current.WindGust)
+		result.Current.WindGust = &gust
+	}
+
+	result.Forecast = make([]dto.ForecastDay, len(weather.Forecast))
+	for i, day := range weather.Forecast {
+		day.MaxTemp = convertTemp(day.MaxTemp)
+		day.MinTemp = convertTemp(day.MinTemp)
+		day.WindSpeed = convertSpeed(day.WindSpeed)
+
+		hours := make([]dto.HourlyWeather, len(day.Hours))
+		for j, hour := range day.Hours {
+			hour.Temperature = convertTemp(hour.Temperature)
+			hour.WindSpeed = convertSpeed(hour.WindSpeed)
+			hours[j] = hour
+		}
+		day.Hours = hours
+		result.Forecast[i] = day
+	}
+
+	return &result
+}
+
+// roundTo 保留指定位数的小数
+func roundTo(value float64, digits int) float64 {
+	factor := math.Pow(10, float64(digits))
+	return math.Round(value*factor) / factor
+}
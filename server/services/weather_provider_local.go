@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"what-to-wear/server/api/dto"
+)
+
+// 本地默认天气数据文件
+const localWeatherDefaultFile = "default.json"
+
+// localWeatherProvider 基于本地JSON文件的天气数据源，用于开发和离线测试
+// 文件格式与 dto.WeatherResponse 一致，按城市名（小写）命名，如 beijing.json
+type localWeatherProvider struct {
+	fixturePath string
+}
+
+// NewLocalWeatherProvider 创建本地天气数据源，相对路径按 resolveFixturePath 解析
+func NewLocalWeatherProvider(fixturePath string) WeatherProvider {
+	return &localWeatherProvider{
+		fixturePath: resolveFixturePath(fixturePath),
+	}
+}
+
+// resolveFixturePath 解析数据目录：相对路径先按工作目录查找，不存在时按可执行文件所在目录查找
+// 从其他目录启动服务时也能找到随程序部署的数据文件
+func resolveFixturePath(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return path
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+	candidate := filepath.Join(filepath.Dir(executable), path)
+	if _, err := os.Stat(candidate); err == nil {
+		return candidate
+	}
+	return path
+}
+
+// Name 数据源名称
+func (p *localWeatherProvider) Name() string {
+	return WeatherProviderLocal
+}
+
+// GetWeather 从本地文件读取天气数据
+func (p *localWeatherProvider) GetWeather(ctx context.Context, req *dto.WeatherRequest) (*dto.WeatherResponse, error) {
+	data, err := p.readFixture(req.City)
+	if err != nil {
+		return nil, err
+	}
+
+	var weather dto.WeatherResponse
+	if err := json.Unmarshal(data, &weather); err != nil {
+		return nil, fmt.Errorf("解析本地天气数据失败: %w", err)
+	}
+
+	// 天气类型统一映射到系统枚举
+	if !weather.Current.Condition.IsValid() {
+		weather.Current.Condition = MapWeatherCondition(string(weather.Current.Condition))
+	}
+
+	// 预报日期按今天重新排列，保证离线数据始终可用
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for i := range weather.Forecast {
+		day := &weather.Forecast[i]
+		if !day.Condition.IsValid() {
+			day.Condition = MapWeatherCondition(string(day.Condition))
+		}
+		date := today.AddDate(0, 0, i)
+		for j := range day.Hours {
+			hour := &day.Hours[j]
+			if !hour.Condition.IsValid() {
+				hour.Condition = MapWeatherCondition(string(hour.Condition))
+			}
+			hour.Time = date.Add(time.Duration(hour.Time.Hour()) * time.Hour)
+		}
+		day.Date = date
+	}
+	for i := range weather.Alerts {
+		alert := &weather.Alerts[i]
+		alert.StartTime = today.Add(time.Duration(alert.StartTime.Hour()) * time.Hour)
+		alert.EndTime = today.Add(time.Duration(alert.EndTime.Hour()) * time.Hour)
+	}
+
+	if req.City != "" {
+		weather.Location.City = req.City
+	}
+	if req.Latitude != 0 || req.Longitude != 0 {
+		weather.Location.Latitude = req.Latitude
+		weather.Location.Longitude = req.Longitude
+	}
+	weather.LastUpdated = now
+
+	return &weather, nil
+}
+
+// readFixture 读取城市对应的数据文件，不存在时使用默认文件
+func (p *localWeatherProvider) readFixture(city string) ([]byte, error) {
+	if city != "" {
+		name := strings.ToLower(strings.TrimSpace(city))
+		// 防止路径穿越
+		if !strings.ContainsAny(name, `/\`) && !strings.Contains(name, "..") {
+			if data, err := os.ReadFile(filepath.Join(p.fixturePath, name+".json")); err == nil {
+				return data, nil
+			}
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(p.fixturePath, localWeatherDefaultFile))
+	if err != nil {
+		return nil, fmt.Errorf("读取本地天气数据失败: %w", err)
+	}
+	return data, nil
+}
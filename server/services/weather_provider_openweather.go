@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/config"
+)
+
+// openWeatherProvider OpenWeatherMap 数据源
+type openWeatherProvider struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// openWeatherCondition 天气状况
+type openWeatherCondition struct {
+	ID          int    `json:"id"`
+	Main        string `json:"main"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+}
+
+// openWeatherCurrent 当前天气响应
+type openWeatherCurrent struct {
+	Coord struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"coord"`
+	Weather []openWeatherCondition `json:"weather"`
+	Main    struct {
+		Temp      float64  `json:"temp"`
+		FeelsLike *float64 `json:"feels_like"`
+		Pressure  float64  `json:"pressure"`
+		Humidity  int      `json:"humidity"`
+	} `json:"main"`
+	Visibility float64 `json:"visibility"`
+	Wind       struct {
+		Speed float64  `json:"speed"`
+		Deg   int      `json:"deg"`
+		Gust  *float64 `json:"gust"`
+	} `json:"wind"`
+	Sys struct {
+		Country string `json:"country"`
+		Sunrise int64  `json:"sunrise"`
+		Sunset  int64  `json:"sunset"`
+	} `json:"sys"`
+	Timezone int    `json:"timezone"`
+	Name     string `json:"name"`
+	Dt       int64  `json:"dt"`
+}
+
+// openWeatherForecast 3小时预报响应
+type openWeatherForecast struct {
+	List []struct {
+		Dt   int64 `json:"dt"`
+		Main struct {
+			Temp     float64 `json:"temp"`
+			TempMin  float64 `json:"temp_min"`
+			TempMax  float64 `json:"temp_max"`
+			Humidity int     `json:"humidity"`
+		} `json:"main"`
+		Weather []openWeatherCondition `json:"weather"`
+		Wind    struct {
+			Speed float64 `json:"speed"`
+		} `json:"wind"`
+		Rain map[string]float64 `json:"rain"`
+		Snow map[string]float64 `json:"snow"`
+	} `json:"list"`
+	City struct {
+		Timezone int `json:"timezone"`
+	} `json:"city"`
+}
+
+// NewOpenWeatherProvider 创建 OpenWeatherMap 数据源
+func NewOpenWeatherProvider(cfg *config.WeatherConfig) (WeatherProvider, error) {
+	if cfg.APIKey == "" {
+		return nil, errors.New("未配置 WEATHER_API_KEY")
+	}
+
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &openWeatherProvider{
+		apiKey:  cfg.APIKey,
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Name 数据源名称
+func (p *openWeatherProvider) Name() string {
+	return WeatherProviderOpenWeather
+}
+
+// GetWeather 获取当前天气及预报
+func (p *openWeatherProvider) GetWeather(ctx context.Context, req *dto.WeatherRequest) (*dto.WeatherResponse, error) {
+	var current openWeatherCurrent
+	if err := p.fetch(ctx, "/data/2.5/weather", req, &current); err != nil {
+		return nil, err
+	}
+
+	var forecast openWeatherForecast
+	if err := p.fetch(ctx, "/data/2.5/forecast", req, &forecast); err != nil {
+		return nil, err
+	}
+
+	condition := firstOpenWeatherCondition(current.Weather)
+	zone := time.FixedZone("", current.Timezone)
+	weather := &dto.WeatherResponse{
+		Location: dto.LocationInfo{
+			City:      current.Name,
+			Country:   current.Sys.Country,
+			Latitude:  current.Coord.Lat,
+			Longitude: current.Coord.Lon,
+			Timezone:  formatUTCOffset(current.Timezone),
+		},
+		Current: dto.CurrentWeather{
+			Temperature:   current.Main.Temp,
+			FeelsLike:     current.Main.FeelsLike,
+			Humidity:      current.Main.Humidity,
+			Pressure:      current.Main.Pressure,
+			Visibility:    current.Visibility / 1000, // 米转换为公里
+			WindSpeed:     current.Wind.Speed,
+			WindDirection: current.Wind.Deg,
+			WindGust:      current.Wind.Gust,
+			Condition:     MapWeatherCondition(condition.Main),
+			Description:   condition.Description,
+			Icon:          condition.Icon,
+			IsDay:         current.Dt >= current.Sys.Sunrise && current.Dt < current.Sys.Sunset,
+		},
+		Forecast:    p.buildForecast(&forecast, zone),
+		LastUpdated: time.Unix(current.Dt, 0),
+	}
+
+	return weather, nil
+}
+
+// buildForecast 将3小时预报聚合为按天预报
+func (p *openWeatherProvider) buildForecast(forecast *openWeatherForecast, zone *time.Location) []dto.ForecastDay {
+	days := make([]dto.ForecastDay, 0, 6)
+	dayIndex := make(map[string]int)
+
+	for _, entry := range forecast.List {
+		at := time.Unix(entry.Dt, 0).In(zone)
+		key := at.Format("2006-01-02")
+		condition := firstOpenWeatherCondition(entry.Weather)
+		precipitation := entry.Rain["3h"] + entry.Snow["3h"]
+
+		index, exists := dayIndex[key]
+		if !exists {
+			days = append(days, dto.ForecastDay{
+				Date:        time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, zone),
+				MaxTemp:     entry.Main.TempMax,
+				MinTemp:     entry.Main.TempMin,
+				Condition:   MapWeatherCondition(condition.Main),
+				Description: condition.Description,
+				Icon:        condition.Icon,
+			})
+			index = len(days) - 1
+			dayIndex[key] = index
+		}
+
+		day := &days[index]
+		day.MaxTemp = math.Max(day.MaxTemp, entry.Main.TempMax)
+		day.MinTemp = math.Min(day.MinTemp, entry.Main.TempMin)
+		day.Precipitation += precipitation
+		day.Hours = append(day.Hours, dto.HourlyWeather{
+			Time:          at,
+			Temperature:   entry.Main.Temp,
+			Condition:     MapWeatherCondition(condition.Main),
+			Icon:          condition.Icon,
+			WindSpeed:     entry.Wind.Speed,
+			Humidity:      entry.Main.Humidity,
+			Precipitation: precipitation,
+		})
+
+		// 白天中午的天气作为全天代表
+		if at.Hour() >= 11 && at.Hour() <= 14 {
+			day.Condition = MapWeatherCondition(condition.Main)
+			day.Description = condition.Description
+			day.Icon = condition.Icon
+		}
+	}
+
+	// 湿度和风速取当天平均值
+	for i := range days {
+		if len(days[i].Hours) == 0 {
+			continue
+		}
+		humidity, windSpeed := 0, 0.0
+		for _, hour := range days[i].Hours {
+			humidity += hour.Humidity
+			windSpeed += hour.WindSpeed
+		}
+		days[i].Humidity = humidity / len(days[i].Hours)
+		days[i].WindSpeed = roundTo(windSpeed/float64(len(days[i].Hours)), 1)
+		days[i].Precipitation = roundTo(days[i].Precipitation, 1)
+	}
+
+	return days
+}
+
+// fetch 请求 OpenWeatherMap 接口，统一使用公制单位
+func (p *openWeatherProvider) fetch(ctx context.Context, path string, req *dto.WeatherRequest, out interface{}) error {
+	query := url.Values{}
+	query.Set("appid", p.apiKey)
+	query.Set("units", WeatherUnitsMetric)
+	query.Set("lang", "zh_cn")
+	if req.City != "" {
+		query.Set("q", req.City)
+	} else {
+		query.Set("lat", strconv.FormatFloat(req.Latitude, 'f', 4, 64))
+		query.Set("lon", strconv.FormatFloat(req.Longitude, 'f', 4, 64))
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("创建天气请求失败: %w", err)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("请求天气数据失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("天气数据源返回错误状态: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("解析天气数据失败: %w", err)
+	}
+	return nil
+}
+
+// firstOpenWeatherCondition 获取第一条天气状况
+func firstOpenWeatherCondition(conditions []openWeatherCondition) openWeatherCondition {
+	if len(conditions) == 0 {
+		return openWeatherCondition{Main: "Clouds"}
+	}
+	return conditions[0]
+}
+
+// formatUTCOffset 将秒数偏移格式化为 UTC+08:00 形式
+func formatUTCOffset(offsetSeconds int) string {
+	sign := "+"
+	if offsetSeconds < 0 {
+		sign = "-"
+		offsetSeconds = -offsetSeconds
+	}
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, offsetSeconds/3600, (offsetSeconds%3600)/60)
+}
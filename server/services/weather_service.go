@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"what-to-wear/server/api/dto"
+	"what-to-wear/server/api/errors"
+	"what-to-wear/server/config"
+	"what-to-wear/server/logger"
+)
+
+// WeatherService 天气服务接口
+type WeatherService interface {
+	// 获取天气（按城市或经纬度），结果按配置缓存
+	GetWeather(ctx context.Context, req *dto.WeatherRequest) (*dto.WeatherResponse, error)
+}
+
+// weatherCacheEntry 天气缓存项
+type weatherCacheEntry struct {
+	weather   *dto.WeatherResponse
+	expiresAt time.Time
+}
+
+// weatherService 天气服务实现
+type weatherService struct {
+	providers []WeatherProvider
+	cacheTTL  time.Duration
+
+	mu    sync.RWMutex
+	cache map[string]weatherCacheEntry
+}
+
+// NewWeatherService 创建天气服务实例
+func NewWeatherService(cfg *config.Config) (WeatherService, error) {
+	log := logger.GetLogger()
+
+	providers := make([]WeatherProvider, 0, len(cfg.Weather.Providers))
+	for _, name := range cfg.Weather.Providers {
+		provider, err := NewWeatherProvider(name, &cfg.Weather)
+		if err != nil {
+			// 单个数据源不可用时跳过，继续尝试其他数据源
+			log.WarnWithErr(err, "Skip weather provider", logger.Fields{
+				"provider": name,
+			})
+			continue
+		}
+		providers = append(providers, provider)
+	}
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("没有可用的天气数据源: %v", cfg.Weather.Providers)
+	}
+
+	return NewWeatherServiceWithProviders(providers, time.Duration(cfg.Weather.CacheTTL)*time.Second), nil
+}
+
+// NewWeatherServiceWithProviders 使用指定数据源创建天气服务实例
+func NewWeatherServiceWithProviders(providers []WeatherProvider, cacheTTL time.Duration) WeatherService {
+	return &weatherService{
+		providers: providers,
+		cacheTTL:  cacheTTL,
+		cache:     make(map[string]weatherCacheEntry),
+	}
+}
+
+// GetWeather 获取天气
+func (s *weatherService) GetWeather(ctx context.Context, req *dto.WeatherRequest) (*dto.WeatherResponse, error) {
+	if req.City == "" && req.Latitude == 0 && req.Longitude == 0 {
+		return nil, errors.ErrInvalidRequest("请提供城市或经纬度")
+	}
+	if req.Latitude < -90 || req.Latitude > 90 || req.Longitude < -180 || req.Longitude > 180 {
+		return nil, errors.ErrInvalidRequest("无效的经纬度")
+	}
+	if !IsValidWeatherUnits(req.Units) {
+		return nil, errors.ErrInvalidRequest("无效的单位: " + req.Units)
+	}
+
+	key := weatherCacheKey(req)
+	if weather, ok := s.getCached(key); ok {
+		return convertWeatherUnits(weather, req.Units), nil
+	}
+
+	// 按顺序尝试各数据源
+	log := logger.GetLogger()
+	var lastErr error
+	for _, provider := range s.providers {
+		weather, err := provider.GetWeather(ctx, req)
+		if err != nil {
+			log.WarnWithErr(err, "Weather provider failed", logger.Fields{
+				"provider": provider.Name(),
+				"city":     req.City,
+			})
+			lastErr = err
+			continue
+		}
+
+		s.setCached(key, weather)
+		return convertWeatherUnits(weather, req.Units), nil
+	}
+
+	return nil, fmt.Errorf("获取天气失败: %w", lastErr)
+}
+
+// getCached 读取未过期的缓存
+func (s *weatherService) getCached(key string) (*dto.WeatherResponse, bool) {
+	if s.cacheTTL <= 0 {
+		return nil, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, ok := s.cache[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.weather, true
+}
+
+// setCached 写入缓存，同时清理已过期的项
+func (s *weatherService) setCached(key string, weather *dto.WeatherResponse) {
+	if s.cacheTTL <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range s.cache {
+		if now.After(entry.expiresAt) {
+			delete(s.cache, k)
+		}
+	}
+	s.cache[key] = weatherCacheEntry{
+		weather:   weather,
+		expiresAt: now.Add(s.cacheTTL),
+	}
+}
+
+// weatherCacheKey 生成缓存键，缓存中统一保存公制数据，与单位无关
+func weatherCacheKey(req *dto.WeatherRequest) string {
+	if req.City != "" {
+		return "city:" + strings.ToLower(strings.TrimSpace(req.City))
+	}
+	// 经纬度保留两位小数（约1公里）
+	return fmt.Sprintf("coord:%.2f,%.2f", req.Latitude, req.Longitude)
+}
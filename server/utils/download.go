@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// DownloadPublicFile 下载公网上的文件，超过 maxSize 字节时返回错误
+// 只允许 http 和 https，连接时拒绝回环、内网和链路本地地址，避免被用来访问内部服务
+func DownloadPublicFile(ctx context.Context, rawURL string, maxSize int64, timeout time.Duration) ([]byte, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("无效的文件地址")
+	}
+
+	dialer := &net.Dialer{Timeout: timeout, Control: rejectPrivateAddress}
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext, Proxy: nil},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("无效的文件地址")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求文件失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求文件失败: HTTP %d", resp.StatusCode)
+	}
+	if resp.ContentLength > maxSize {
+		return nil, fmt.Errorf("文件大小不能超过 %dMB", maxSize>>20)
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+	if int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("文件大小不能超过 %dMB", maxSize>>20)
+	}
+	return content, nil
+}
+
+// rejectPrivateAddress 在建立连接前检查解析后的地址，重定向和 DNS 重绑定同样会经过这里
+func rejectPrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("不允许访问的地址: %s", host)
+	}
+	return nil
+}
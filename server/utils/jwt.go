@@ -12,14 +12,16 @@ var jwtSecret = []byte("your-secret-key-change-in-production")
 type Claims struct {
     UserID   uint   `json:"user_id"`
     Username string `json:"username"`
+    Role     string `json:"role"`
     jwt.RegisteredClaims
 }
 
 // GenerateToken 生成JWT token
-func GenerateToken(userID uint, username string) (string, error) {
+func GenerateToken(userID uint, username, role string) (string, error) {
     claims := Claims{
         UserID:   userID,
         Username: username,
+        Role:     role,
         RegisteredClaims: jwt.RegisteredClaims{
             ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
             IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -0,0 +1,188 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// PDF 页面布局（A4，单位为点）
+const (
+	pdfPageWidth    = 595.0
+	pdfPageHeight   = 842.0
+	pdfMargin       = 50.0
+	pdfTitleSize    = 18.0
+	pdfHeadingSize  = 13.0
+	pdfBodySize     = 10.0
+	pdfFooterSize   = 8.0
+	pdfLineSpacing  = 1.6
+	pdfContentWidth = pdfPageWidth - 2*pdfMargin
+)
+
+// pdfLine 页面中的一行文字
+type pdfLine struct {
+	text string
+	size float64
+	y    float64
+}
+
+// PDFDocument 简单的纯文本PDF文档
+// 使用PDF阅读器内置的 STSong-Light 中文字体，不嵌入字体文件
+type PDFDocument struct {
+	pages [][]pdfLine
+	y     float64
+}
+
+// NewPDFDocument 创建PDF文档
+func NewPDFDocument() *PDFDocument {
+	d := &PDFDocument{}
+	d.newPage()
+	return d
+}
+
+// AddTitle 添加文档标题
+func (d *PDFDocument) AddTitle(text string) {
+	d.addText(text, pdfTitleSize)
+	d.AddBlankLine()
+}
+
+// AddHeading 添加章节标题
+func (d *PDFDocument) AddHeading(text string) {
+	// 标题不单独留在页尾
+	if d.y-pdfHeadingSize*pdfLineSpacing*3 < pdfMargin {
+		d.newPage()
+	}
+	d.addText(text, pdfHeadingSize)
+}
+
+// AddLine 添加正文，超出页面宽度时自动换行
+func (d *PDFDocument) AddLine(text string) {
+	d.addText(text, pdfBodySize)
+}
+
+// AddBlankLine 添加空行
+func (d *PDFDocument) AddBlankLine() {
+	d.y -= pdfBodySize * pdfLineSpacing
+}
+
+// Bytes 输出PDF文件内容
+func (d *PDFDocument) Bytes() []byte {
+	var buf bytes.Buffer
+	offsets := []int{0}
+	writeObject := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets)-1, body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 目录，2 页面树，3-5 字体；之后每页占用页面和内容两个对象
+	const firstPageObject = 6
+	pageRefs := make([]string, len(d.pages))
+	for i := range d.pages {
+		pageRefs[i] = fmt.Sprintf("%d 0 R", firstPageObject+i*2)
+	}
+
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(pageRefs, " "), len(d.pages)))
+	writeObject("<< /Type /Font /Subtype /Type0 /BaseFont /STSong-Light /Encoding /UniGB-UCS2-H /DescendantFonts [4 0 R] >>")
+	writeObject("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /STSong-Light " +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (GB1) /Supplement 2 >> " +
+		"/FontDescriptor 5 0 R /DW 1000 /W [1 95 500] >>")
+	writeObject("<< /Type /FontDescriptor /FontName /STSong-Light /Flags 6 /FontBBox [-25 -254 1000 880] " +
+		"/ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 880 /StemV 93 >>")
+
+	for i, lines := range d.pages {
+		var content bytes.Buffer
+		for _, line := range lines {
+			writeTextCommand(&content, line.text, line.size, pdfMargin, line.y)
+		}
+		footer := fmt.Sprintf("%d / %d", i+1, len(d.pages))
+		writeTextCommand(&content, footer, pdfFooterSize, pdfPageWidth/2-textWidth(footer, pdfFooterSize)/2, pdfMargin/2)
+
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, firstPageObject+i*2+1))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets))
+	for _, offset := range offsets[1:] {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets), xrefOffset)
+
+	return buf.Bytes()
+}
+
+// newPage 开始新页面
+func (d *PDFDocument) newPage() {
+	d.pages = append(d.pages, nil)
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// addText 按宽度拆分文字并逐行写入，页面写满时换页
+func (d *PDFDocument) addText(text string, size float64) {
+	for _, line := range wrapText(text, size, pdfContentWidth) {
+		lineHeight := size * pdfLineSpacing
+		if d.y-lineHeight < pdfMargin {
+			d.newPage()
+		}
+		d.y -= lineHeight
+		page := len(d.pages) - 1
+		d.pages[page] = append(d.pages[page], pdfLine{text: line, size: size, y: d.y})
+	}
+}
+
+// wrapText 按估算宽度拆分文字
+func wrapText(text string, size, maxWidth float64) []string {
+	if text == "" {
+		return []string{""}
+	}
+
+	var lines []string
+	var current strings.Builder
+	width := 0.0
+	for _, r := range text {
+		w := runeWidth(r, size)
+		if width+w > maxWidth && current.Len() > 0 {
+			lines = append(lines, current.String())
+			current.Reset()
+			width = 0
+		}
+		current.WriteRune(r)
+		width += w
+	}
+	return append(lines, current.String())
+}
+
+// textWidth 估算文字宽度
+func textWidth(text string, size float64) float64 {
+	width := 0.0
+	for _, r := range text {
+		width += runeWidth(r, size)
+	}
+	return width
+}
+
+// runeWidth 估算字符宽度：ASCII 为半角，其余为全角
+func runeWidth(r rune, size float64) float64 {
+	if r < utf8.RuneSelf {
+		return size / 2
+	}
+	return size
+}
+
+// writeTextCommand 写入一行文字的绘制指令，文字以 UCS-2 大端十六进制编码
+func writeTextCommand(buf *bytes.Buffer, text string, size, x, y float64) {
+	fmt.Fprintf(buf, "BT /F1 %.1f Tf %.2f %.2f Td <", size, x, y)
+	for _, r := range text {
+		if r > 0xFFFF || r < 0x20 {
+			r = '?'
+		}
+		fmt.Fprintf(buf, "%04X", r)
+	}
+	buf.WriteString("> Tj ET\n")
+}
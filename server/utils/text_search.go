@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 全文搜索分词：PostgreSQL 内置解析器无法切分中文，这里在应用层预先分词，
+// 数据库使用 simple 配置按空格建立索引。中文按单字和相邻二元组切分，
+// 其他字母数字按连续片段切分并转为小写。
+
+// SearchTokens 生成用于建立全文索引的分词结果，以空格分隔并去重
+func SearchTokens(texts ...string) string {
+	seen := make(map[string]bool)
+	tokens := make([]string, 0)
+	add := func(token string) {
+		if token != "" && !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+
+	for _, text := range texts {
+		for _, segment := range splitSearchSegments(text) {
+			if !segment.han {
+				add(segment.text)
+				continue
+			}
+			runes := []rune(segment.text)
+			for i := range runes {
+				add(string(runes[i]))
+				if i+1 < len(runes) {
+					add(string(runes[i : i+2]))
+				}
+			}
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+// SearchTSQuery 将搜索词转换为 to_tsquery 表达式，各分词之间为且关系；
+// 中文按二元组匹配（单字时按单字），字母数字按前缀匹配。没有有效分词时返回空字符串
+func SearchTSQuery(query string) string {
+	terms := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(term string) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	for _, segment := range splitSearchSegments(query) {
+		if !segment.han {
+			add(segment.text + ":*")
+			continue
+		}
+		runes := []rune(segment.text)
+		if len(runes) == 1 {
+			add(segment.text)
+			continue
+		}
+		for i := 0; i+1 < len(runes); i++ {
+			add(string(runes[i : i+2]))
+		}
+	}
+	return strings.Join(terms, " & ")
+}
+
+// searchSegment 连续的中文或字母数字片段
+type searchSegment struct {
+	text string
+	han  bool
+}
+
+// splitSearchSegments 按中文和字母数字切分文本，其余字符作为分隔符
+func splitSearchSegments(text string) []searchSegment {
+	segments := make([]searchSegment, 0)
+	var current strings.Builder
+	currentHan := false
+	flush := func() {
+		if current.Len() > 0 {
+			segments = append(segments, searchSegment{text: current.String(), han: currentHan})
+			current.Reset()
+		}
+	}
+
+	for _, r := range strings.ToLower(text) {
+		han := unicode.Is(unicode.Han, r)
+		if !han && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if current.Len() > 0 && han != currentHan {
+			flush()
+		}
+		currentHan = han
+		current.WriteRune(r)
+	}
+	flush()
+	return segments
+}
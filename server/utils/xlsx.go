@@ -0,0 +1,309 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// XLSXSheet 工作表，单元格均按文本处理
+type XLSXSheet struct {
+	Name string
+	Rows [][]string
+}
+
+// xlsx 文件中固定的部件
+const (
+	xlsxContentTypesHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+	xlsxSheetRelType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
+)
+
+// xlsx 读取限制，防止压缩炸弹耗尽内存
+const (
+	// 读取的各部件解压后的总大小上限
+	maxXLSXUncompressedSize = 64 << 20
+	// 工作表最大行号和列数，与 Excel 的限制一致
+	maxXLSXRows    = 1048576
+	maxXLSXColumns = 16384
+)
+
+// WriteXLSX 生成包含指定工作表的 xlsx 文件，单元格以内联字符串写入
+func WriteXLSX(sheets []XLSXSheet) ([]byte, error) {
+	var contentTypes, workbook, workbookRels strings.Builder
+	contentTypes.WriteString(xlsxContentTypesHeader)
+	workbook.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	files := make(map[string]string, len(sheets)+4)
+	names := make([]string, 0, len(sheets)+4)
+	for i, sheet := range sheets {
+		id := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`+"\n", id)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(sheet.Name), id, id)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="%s" Target="worksheets/sheet%d.xml"/>`, id, xlsxSheetRelType, id)
+
+		name := fmt.Sprintf("xl/worksheets/sheet%d.xml", id)
+		files[name] = renderXLSXSheet(sheet.Rows)
+		names = append(names, name)
+	}
+	contentTypes.WriteString("</Types>")
+	workbook.WriteString("</sheets></workbook>")
+	workbookRels.WriteString("</Relationships>")
+
+	files["[Content_Types].xml"] = contentTypes.String()
+	files["_rels/.rels"] = xlsxRootRels
+	files["xl/workbook.xml"] = workbook.String()
+	files["xl/_rels/workbook.xml.rels"] = workbookRels.String()
+	names = append([]string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"}, names...)
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, name := range names {
+		file, err := writer.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(file, files[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderXLSXSheet 生成工作表 XML
+func renderXLSXSheet(rows [][]string) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for r, row := range rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, value := range row {
+			if value == "" {
+				continue
+			}
+			fmt.Fprintf(&b, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, xlsxColumnName(c), r+1, xmlEscape(value))
+		}
+		b.WriteString("</row>")
+	}
+	b.WriteString("</sheetData></worksheet>")
+	return b.String()
+}
+
+// xlsx 读取时使用的 XML 结构
+type (
+	xlsxWorkbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	xlsxText struct {
+		T    string `xml:"t"`
+		Runs []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	}
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+	xlsxWorksheet struct {
+		Rows []struct {
+			Index int `xml:"r,attr"`
+			Cells []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+// text 合并富文本中的各段文字
+func (t xlsxText) text() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+// ReadXLSX 读取 xlsx 文件中的所有工作表，单元格统一返回文本，空行保留为空切片
+// 读取的部件解压后总大小超过 maxXLSXUncompressedSize 时返回错误
+func ReadXLSX(content []byte) ([]XLSXSheet, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("不是有效的xlsx文件: %w", err)
+	}
+	files := make(map[string]*zip.File, len(reader.File))
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+	decoder := &zipXMLDecoder{files: files, remaining: maxXLSXUncompressedSize}
+
+	var workbook xlsxWorkbook
+	if err := decoder.decode("xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	var rels xlsxRelationships
+	if err := decoder.decode("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	targets := make(map[string]string, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		target := strings.TrimPrefix(rel.Target, "/")
+		if !strings.HasPrefix(target, "xl/") {
+			target = path.Join("xl", target)
+		}
+		targets[rel.ID] = target
+	}
+
+	var shared xlsxSharedStrings
+	if _, exists := files["xl/sharedStrings.xml"]; exists {
+		if err := decoder.decode("xl/sharedStrings.xml", &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	sheets := make([]XLSXSheet, 0, len(workbook.Sheets))
+	for _, entry := range workbook.Sheets {
+		var worksheet xlsxWorksheet
+		if err := decoder.decode(targets[entry.RID], &worksheet); err != nil {
+			return nil, err
+		}
+
+		sheet := XLSXSheet{Name: entry.Name}
+		for _, row := range worksheet.Rows {
+			if row.Index > maxXLSXRows {
+				return nil, fmt.Errorf("无效的行号: %d", row.Index)
+			}
+			// 跳过的空行按行号补齐，保证行号与表格一致
+			for row.Index > len(sheet.Rows)+1 {
+				sheet.Rows = append(sheet.Rows, nil)
+			}
+			var values []string
+			for i, cell := range row.Cells {
+				column := i
+				if cell.Ref != "" {
+					column = xlsxColumnIndex(cell.Ref)
+				}
+				if column >= maxXLSXColumns {
+					return nil, fmt.Errorf("无效的单元格引用: %s", cell.Ref)
+				}
+				for len(values) < column {
+					values = append(values, "")
+				}
+
+				value := cell.Value
+				switch cell.Type {
+				case "s":
+					index, err := strconv.Atoi(cell.Value)
+					if err != nil || index < 0 || index >= len(shared.Items) {
+						return nil, fmt.Errorf("无效的共享字符串索引: %s", cell.Value)
+					}
+					value = shared.Items[index].text()
+				case "inlineStr":
+					value = cell.Inline.text()
+				}
+				values = append(values, value)
+			}
+			sheet.Rows = append(sheet.Rows, values)
+		}
+		sheets = append(sheets, sheet)
+	}
+	return sheets, nil
+}
+
+// errXLSXTooLarge xlsx 文件解压后超过大小上限
+var errXLSXTooLarge = fmt.Errorf("xlsx文件解压后超过 %d MB", maxXLSXUncompressedSize>>20)
+
+// zipXMLDecoder 解码压缩包中的 XML 文件，所有文件共用解压大小额度
+type zipXMLDecoder struct {
+	files     map[string]*zip.File
+	remaining int64
+}
+
+// decode 解码压缩包中的 XML 文件
+// 先按文件头声明的大小检查额度，文件头可能被篡改，读取时再用 LimitedReader 限制实际解压的字节数
+func (d *zipXMLDecoder) decode(name string, v interface{}) error {
+	file, exists := d.files[name]
+	if !exists {
+		return fmt.Errorf("xlsx文件缺少 %s", name)
+	}
+	if file.UncompressedSize64 > uint64(d.remaining) {
+		return errXLSXTooLarge
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	limited := &io.LimitedReader{R: rc, N: d.remaining + 1}
+	err = xml.NewDecoder(limited).Decode(v)
+	d.remaining -= d.remaining + 1 - limited.N
+	if d.remaining < 0 {
+		return errXLSXTooLarge
+	}
+	if err != nil {
+		return fmt.Errorf("解析 %s 失败: %w", name, err)
+	}
+	return nil
+}
+
+// xlsxColumnName 将从0开始的列序号转换为列名（A、B、…、AA）
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// xlsxColumnIndex 从单元格引用（如 C12）解析从0开始的列序号
+func xlsxColumnIndex(ref string) int {
+	index := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A'+1)
+	}
+	return index - 1
+}
+
+// xmlEscape 转义 XML 文本
+func xmlEscape(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
@@ -0,0 +1,177 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	stderrors "errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// buildXLSX 按给定的部件内容打包 xlsx 文件
+func buildXLSX(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range parts {
+		part, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := part.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// xlsxWithSheet 只包含一个工作表的 xlsx 文件
+func xlsxWithSheet(t *testing.T, sheetData, sharedStrings string) []byte {
+	t.Helper()
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="衣物" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="` + xlsxSheetRelType + `" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			sheetData + `</sheetData></worksheet>`,
+	}
+	if sharedStrings != "" {
+		parts["xl/sharedStrings.xml"] = `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` + sharedStrings + `</sst>`
+	}
+	return buildXLSX(t, parts)
+}
+
+func TestReadXLSX(t *testing.T) {
+	tests := []struct {
+		name      string
+		sheetData string
+		shared    string
+		want      [][]string
+		wantErr   bool
+	}{
+		{
+			name:      "内联字符串和数字",
+			sheetData: `<row r="1"><c r="A1" t="inlineStr"><is><t>name</t></is></c><c r="B1"><v>12.5</v></c></row>`,
+			want:      [][]string{{"name", "12.5"}},
+		},
+		{
+			name:      "共享字符串和富文本",
+			sheetData: `<row r="1"><c r="A1" t="s"><v>1</v></c><c r="B1" t="s"><v>0</v></c></row>`,
+			shared:    `<si><t>衬衫</t></si><si><r><t>白</t></r><r><t>衬衫</t></r></si>`,
+			want:      [][]string{{"白衬衫", "衬衫"}},
+		},
+		{
+			name:      "跳过的行和列补为空",
+			sheetData: `<row r="1"><c r="A1"><v>1</v></c></row><row r="3"><c r="C3"><v>3</v></c></row>`,
+			want:      [][]string{{"1"}, nil, {"", "", "3"}},
+		},
+		{
+			name:      "共享字符串索引越界",
+			sheetData: `<row r="1"><c r="A1" t="s"><v>5</v></c></row>`,
+			shared:    `<si><t>衬衫</t></si>`,
+			wantErr:   true,
+		},
+		{
+			name:      "行号超过上限",
+			sheetData: `<row r="1048577"><c r="A1048577"><v>1</v></c></row>`,
+			wantErr:   true,
+		},
+		{
+			name:      "列号超过上限",
+			sheetData: `<row r="1"><c r="XFE1"><v>1</v></c></row>`,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sheets, err := ReadXLSX(xlsxWithSheet(t, tt.sheetData, tt.shared))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadXLSX() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(sheets) != 1 || sheets[0].Name != "衣物" {
+				t.Fatalf("unexpected sheets: %+v", sheets)
+			}
+			if !reflect.DeepEqual(sheets[0].Rows, tt.want) {
+				t.Errorf("Rows = %q, want %q", sheets[0].Rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadXLSXInvalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{name: "不是压缩包", content: []byte("name,category\n")},
+		{name: "缺少工作簿", content: buildXLSX(t, map[string]string{"xl/worksheets/sheet1.xml": "<worksheet/>"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadXLSX(tt.content); err == nil {
+				t.Error("ReadXLSX() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestReadXLSXRejectsOversizedContent(t *testing.T) {
+	// 高压缩比的大量空白，解压后超过上限
+	padding := strings.Repeat(" ", maxXLSXUncompressedSize)
+	content := xlsxWithSheet(t, padding, "")
+	if _, err := ReadXLSX(content); !stderrors.Is(err, errXLSXTooLarge) {
+		t.Errorf("ReadXLSX() error = %v, want %v", err, errXLSXTooLarge)
+	}
+}
+
+func TestWriteXLSXRoundTrip(t *testing.T) {
+	sheets := []XLSXSheet{
+		{Name: "衣物", Rows: [][]string{{"name", "tags"}, {"白衬衫 <夏季>", "通勤;\"休闲\""}}},
+		{Name: "穿着记录", Rows: [][]string{{"item_name"}, {"白衬衫"}}},
+	}
+	content, err := WriteXLSX(sheets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadXLSX(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, sheets) {
+		t.Errorf("ReadXLSX(WriteXLSX()) = %q, want %q", got, sheets)
+	}
+}
+
+func TestXLSXColumnIndex(t *testing.T) {
+	tests := []struct {
+		ref    string
+		column string
+		want   int
+	}{
+		{ref: "A1", column: "A", want: 0},
+		{ref: "Z9", column: "Z", want: 25},
+		{ref: "AA10", column: "AA", want: 26},
+		{ref: "XFD1", column: "XFD", want: maxXLSXColumns - 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			if got := xlsxColumnIndex(tt.ref); got != tt.want {
+				t.Errorf("xlsxColumnIndex(%q) = %d, want %d", tt.ref, got, tt.want)
+			}
+			if got := xlsxColumnName(tt.want); got != tt.column {
+				t.Errorf("xlsxColumnName(%d) = %q, want %q", tt.want, got, tt.column)
+			}
+		})
+	}
+}